@@ -448,6 +448,216 @@ var _ interface {
 	ErrorName() string
 } = GetLastResponseValidationError{}
 
+// Validate checks the field values on GetRegisterRetentionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetRegisterRetentionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRegisterRetentionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRegisterRetentionRequestMultiError, or nil if none found.
+func (m *GetRegisterRetentionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRegisterRetentionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetRegisterRetentionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRegisterRetentionRequestMultiError is an error wrapping multiple
+// validation errors returned by GetRegisterRetentionRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetRegisterRetentionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRegisterRetentionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRegisterRetentionRequestMultiError) AllErrors() []error { return m }
+
+// GetRegisterRetentionRequestValidationError is the validation error returned
+// by GetRegisterRetentionRequest.Validate if the designated constraints
+// aren't met.
+type GetRegisterRetentionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRegisterRetentionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRegisterRetentionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRegisterRetentionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRegisterRetentionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRegisterRetentionRequestValidationError) ErrorName() string {
+	return "GetRegisterRetentionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRegisterRetentionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRegisterRetentionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRegisterRetentionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRegisterRetentionRequestValidationError{}
+
+// Validate checks the field values on GetRegisterRetentionResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetRegisterRetentionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRegisterRetentionResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRegisterRetentionResponseMultiError, or nil if none found.
+func (m *GetRegisterRetentionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRegisterRetentionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for Interval
+
+	if len(errors) > 0 {
+		return GetRegisterRetentionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRegisterRetentionResponseMultiError is an error wrapping multiple
+// validation errors returned by GetRegisterRetentionResponse.ValidateAll() if
+// the designated constraints aren't met.
+type GetRegisterRetentionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRegisterRetentionResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRegisterRetentionResponseMultiError) AllErrors() []error { return m }
+
+// GetRegisterRetentionResponseValidationError is the validation error returned
+// by GetRegisterRetentionResponse.Validate if the designated constraints
+// aren't met.
+type GetRegisterRetentionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRegisterRetentionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRegisterRetentionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRegisterRetentionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRegisterRetentionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRegisterRetentionResponseValidationError) ErrorName() string {
+	return "GetRegisterRetentionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRegisterRetentionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRegisterRetentionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRegisterRetentionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRegisterRetentionResponseValidationError{}
+
 // Validate checks the field values on GetHeightForBlockRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -667,32 +877,34 @@ var _ interface {
 	ErrorName() string
 } = GetHeightForBlockResponseValidationError{}
 
-// Validate checks the field values on GetCommitRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetCommitRequest) Validate() error {
+// Validate checks the field values on GetHeightForTimestampRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForTimestampRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetCommitRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on GetHeightForTimestampRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetCommitRequestMultiError, or nil if none found.
-func (m *GetCommitRequest) ValidateAll() error {
+// GetHeightForTimestampRequestMultiError, or nil if none found.
+func (m *GetHeightForTimestampRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetCommitRequest) validate(all bool) error {
+func (m *GetHeightForTimestampRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetHeight() <= 0 {
-		err := GetCommitRequestValidationError{
-			field:  "Height",
-			reason: "value must be greater than 0",
+	// no validation rules for Timestamp
+
+	if _, ok := _GetHeightForTimestampRequest_Mode_InLookup[m.GetMode()]; !ok {
+		err := GetHeightForTimestampRequestValidationError{
+			field:  "Mode",
+			reason: "value must be in list [1 2]",
 		}
 		if !all {
 			return err
@@ -701,19 +913,19 @@ func (m *GetCommitRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetCommitRequestMultiError(errors)
+		return GetHeightForTimestampRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetCommitRequestMultiError is an error wrapping multiple validation errors
-// returned by GetCommitRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetCommitRequestMultiError []error
+// GetHeightForTimestampRequestMultiError is an error wrapping multiple
+// validation errors returned by GetHeightForTimestampRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetHeightForTimestampRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetCommitRequestMultiError) Error() string {
+func (m GetHeightForTimestampRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -722,11 +934,12 @@ func (m GetCommitRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetCommitRequestMultiError) AllErrors() []error { return m }
+func (m GetHeightForTimestampRequestMultiError) AllErrors() []error { return m }
 
-// GetCommitRequestValidationError is the validation error returned by
-// GetCommitRequest.Validate if the designated constraints aren't met.
-type GetCommitRequestValidationError struct {
+// GetHeightForTimestampRequestValidationError is the validation error returned
+// by GetHeightForTimestampRequest.Validate if the designated constraints
+// aren't met.
+type GetHeightForTimestampRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -734,22 +947,24 @@ type GetCommitRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetCommitRequestValidationError) Field() string { return e.field }
+func (e GetHeightForTimestampRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetCommitRequestValidationError) Reason() string { return e.reason }
+func (e GetHeightForTimestampRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetCommitRequestValidationError) Cause() error { return e.cause }
+func (e GetHeightForTimestampRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetCommitRequestValidationError) Key() bool { return e.key }
+func (e GetHeightForTimestampRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetCommitRequestValidationError) ErrorName() string { return "GetCommitRequestValidationError" }
+func (e GetHeightForTimestampRequestValidationError) ErrorName() string {
+	return "GetHeightForTimestampRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetCommitRequestValidationError) Error() string {
+func (e GetHeightForTimestampRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -761,14 +976,14 @@ func (e GetCommitRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetCommitRequest.%s: %s%s",
+		"invalid %sGetHeightForTimestampRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetCommitRequestValidationError{}
+var _ error = GetHeightForTimestampRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -776,48 +991,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetCommitRequestValidationError{}
+} = GetHeightForTimestampRequestValidationError{}
 
-// Validate checks the field values on GetCommitResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetCommitResponse) Validate() error {
+var _GetHeightForTimestampRequest_Mode_InLookup = map[uint32]struct{}{
+	1: {},
+	2: {},
+}
+
+// Validate checks the field values on GetHeightForTimestampResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForTimestampResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetCommitResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetCommitResponseMultiError, or nil if none found.
-func (m *GetCommitResponse) ValidateAll() error {
+// ValidateAll checks the field values on GetHeightForTimestampResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetHeightForTimestampResponseMultiError, or nil if none found.
+func (m *GetHeightForTimestampResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetCommitResponse) validate(all bool) error {
+func (m *GetHeightForTimestampResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Height
+	// no validation rules for Timestamp
 
-	// no validation rules for Commit
+	// no validation rules for Mode
+
+	// no validation rules for Height
 
 	if len(errors) > 0 {
-		return GetCommitResponseMultiError(errors)
+		return GetHeightForTimestampResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetCommitResponseMultiError is an error wrapping multiple validation errors
-// returned by GetCommitResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetCommitResponseMultiError []error
+// GetHeightForTimestampResponseMultiError is an error wrapping multiple
+// validation errors returned by GetHeightForTimestampResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetHeightForTimestampResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetCommitResponseMultiError) Error() string {
+func (m GetHeightForTimestampResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -826,11 +1048,12 @@ func (m GetCommitResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetCommitResponseMultiError) AllErrors() []error { return m }
+func (m GetHeightForTimestampResponseMultiError) AllErrors() []error { return m }
 
-// GetCommitResponseValidationError is the validation error returned by
-// GetCommitResponse.Validate if the designated constraints aren't met.
-type GetCommitResponseValidationError struct {
+// GetHeightForTimestampResponseValidationError is the validation error
+// returned by GetHeightForTimestampResponse.Validate if the designated
+// constraints aren't met.
+type GetHeightForTimestampResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -838,24 +1061,24 @@ type GetCommitResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetCommitResponseValidationError) Field() string { return e.field }
+func (e GetHeightForTimestampResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetCommitResponseValidationError) Reason() string { return e.reason }
+func (e GetHeightForTimestampResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetCommitResponseValidationError) Cause() error { return e.cause }
+func (e GetHeightForTimestampResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetCommitResponseValidationError) Key() bool { return e.key }
+func (e GetHeightForTimestampResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetCommitResponseValidationError) ErrorName() string {
-	return "GetCommitResponseValidationError"
+func (e GetHeightForTimestampResponseValidationError) ErrorName() string {
+	return "GetHeightForTimestampResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetCommitResponseValidationError) Error() string {
+func (e GetHeightForTimestampResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -867,14 +1090,14 @@ func (e GetCommitResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetCommitResponse.%s: %s%s",
+		"invalid %sGetHeightForTimestampResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetCommitResponseValidationError{}
+var _ error = GetHeightForTimestampResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -882,24 +1105,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetCommitResponseValidationError{}
+} = GetHeightForTimestampResponseValidationError{}
 
-// Validate checks the field values on GetHeaderRequest with the rules defined
+// Validate checks the field values on GetCommitRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *GetHeaderRequest) Validate() error {
+func (m *GetCommitRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetHeaderRequest with the rules
+// ValidateAll checks the field values on GetCommitRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetHeaderRequestMultiError, or nil if none found.
-func (m *GetHeaderRequest) ValidateAll() error {
+// GetCommitRequestMultiError, or nil if none found.
+func (m *GetCommitRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetHeaderRequest) validate(all bool) error {
+func (m *GetCommitRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -907,7 +1130,7 @@ func (m *GetHeaderRequest) validate(all bool) error {
 	var errors []error
 
 	if m.GetHeight() <= 0 {
-		err := GetHeaderRequestValidationError{
+		err := GetCommitRequestValidationError{
 			field:  "Height",
 			reason: "value must be greater than 0",
 		}
@@ -918,7 +1141,224 @@ func (m *GetHeaderRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetHeaderRequestMultiError(errors)
+		return GetCommitRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetCommitRequestMultiError is an error wrapping multiple validation errors
+// returned by GetCommitRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetCommitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetCommitRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetCommitRequestMultiError) AllErrors() []error { return m }
+
+// GetCommitRequestValidationError is the validation error returned by
+// GetCommitRequest.Validate if the designated constraints aren't met.
+type GetCommitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetCommitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetCommitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetCommitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetCommitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetCommitRequestValidationError) ErrorName() string { return "GetCommitRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetCommitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetCommitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetCommitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetCommitRequestValidationError{}
+
+// Validate checks the field values on GetCommitResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetCommitResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetCommitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetCommitResponseMultiError, or nil if none found.
+func (m *GetCommitResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetCommitResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for Commit
+
+	if len(errors) > 0 {
+		return GetCommitResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetCommitResponseMultiError is an error wrapping multiple validation errors
+// returned by GetCommitResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetCommitResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetCommitResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetCommitResponseMultiError) AllErrors() []error { return m }
+
+// GetCommitResponseValidationError is the validation error returned by
+// GetCommitResponse.Validate if the designated constraints aren't met.
+type GetCommitResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetCommitResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetCommitResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetCommitResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetCommitResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetCommitResponseValidationError) ErrorName() string {
+	return "GetCommitResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetCommitResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetCommitResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetCommitResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetCommitResponseValidationError{}
+
+// Validate checks the field values on GetHeaderRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetHeaderRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetHeaderRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetHeaderRequestMultiError, or nil if none found.
+func (m *GetHeaderRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetHeaderRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetHeight() <= 0 {
+		err := GetHeaderRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetHeaderRequestMultiError(errors)
 	}
 
 	return nil
@@ -1134,6 +1574,8 @@ func (m *GetEventsRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Decode
+
 	if len(errors) > 0 {
 		return GetEventsRequestMultiError(errors)
 	}
@@ -1318,32 +1760,32 @@ var _ interface {
 	ErrorName() string
 } = GetEventsResponseValidationError{}
 
-// Validate checks the field values on GetRegisterValuesRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on ListHeightsForEventTypesRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetRegisterValuesRequest) Validate() error {
+func (m *ListHeightsForEventTypesRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetRegisterValuesRequest with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetRegisterValuesRequestMultiError, or nil if none found.
-func (m *GetRegisterValuesRequest) ValidateAll() error {
+// ValidateAll checks the field values on ListHeightsForEventTypesRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListHeightsForEventTypesRequestMultiError, or nil if none found.
+func (m *ListHeightsForEventTypesRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetRegisterValuesRequest) validate(all bool) error {
+func (m *ListHeightsForEventTypesRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetHeight() <= 0 {
-		err := GetRegisterValuesRequestValidationError{
-			field:  "Height",
-			reason: "value must be greater than 0",
+	if len(m.GetTypes()) < 1 {
+		err := ListHeightsForEventTypesRequestValidationError{
+			field:  "Types",
+			reason: "value must contain at least 1 item(s)",
 		}
 		if !all {
 			return err
@@ -1351,10 +1793,12 @@ func (m *GetRegisterValuesRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if len(m.GetRegisters()) < 1 {
-		err := GetRegisterValuesRequestValidationError{
-			field:  "Registers",
-			reason: "value must contain at least 1 item(s)",
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := ListHeightsForEventTypesRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
 		}
 		if !all {
 			return err
@@ -1362,20 +1806,22 @@ func (m *GetRegisterValuesRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Limit
+
 	if len(errors) > 0 {
-		return GetRegisterValuesRequestMultiError(errors)
+		return ListHeightsForEventTypesRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetRegisterValuesRequestMultiError is an error wrapping multiple validation
-// errors returned by GetRegisterValuesRequest.ValidateAll() if the designated
-// constraints aren't met.
-type GetRegisterValuesRequestMultiError []error
+// ListHeightsForEventTypesRequestMultiError is an error wrapping multiple
+// validation errors returned by ListHeightsForEventTypesRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListHeightsForEventTypesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetRegisterValuesRequestMultiError) Error() string {
+func (m ListHeightsForEventTypesRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1384,11 +1830,12 @@ func (m GetRegisterValuesRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetRegisterValuesRequestMultiError) AllErrors() []error { return m }
+func (m ListHeightsForEventTypesRequestMultiError) AllErrors() []error { return m }
 
-// GetRegisterValuesRequestValidationError is the validation error returned by
-// GetRegisterValuesRequest.Validate if the designated constraints aren't met.
-type GetRegisterValuesRequestValidationError struct {
+// ListHeightsForEventTypesRequestValidationError is the validation error
+// returned by ListHeightsForEventTypesRequest.Validate if the designated
+// constraints aren't met.
+type ListHeightsForEventTypesRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1396,24 +1843,24 @@ type GetRegisterValuesRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetRegisterValuesRequestValidationError) Field() string { return e.field }
+func (e ListHeightsForEventTypesRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetRegisterValuesRequestValidationError) Reason() string { return e.reason }
+func (e ListHeightsForEventTypesRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetRegisterValuesRequestValidationError) Cause() error { return e.cause }
+func (e ListHeightsForEventTypesRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetRegisterValuesRequestValidationError) Key() bool { return e.key }
+func (e ListHeightsForEventTypesRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetRegisterValuesRequestValidationError) ErrorName() string {
-	return "GetRegisterValuesRequestValidationError"
+func (e ListHeightsForEventTypesRequestValidationError) ErrorName() string {
+	return "ListHeightsForEventTypesRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetRegisterValuesRequestValidationError) Error() string {
+func (e ListHeightsForEventTypesRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1425,14 +1872,14 @@ func (e GetRegisterValuesRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetRegisterValuesRequest.%s: %s%s",
+		"invalid %sListHeightsForEventTypesRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetRegisterValuesRequestValidationError{}
+var _ error = ListHeightsForEventTypesRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1440,44 +1887,177 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetRegisterValuesRequestValidationError{}
+} = ListHeightsForEventTypesRequestValidationError{}
 
-// Validate checks the field values on GetRegisterValuesResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on ListHeightsForEventTypesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListHeightsForEventTypesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListHeightsForEventTypesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListHeightsForEventTypesResponseMultiError, or nil if none found.
+func (m *ListHeightsForEventTypesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListHeightsForEventTypesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return ListHeightsForEventTypesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListHeightsForEventTypesResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// ListHeightsForEventTypesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListHeightsForEventTypesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListHeightsForEventTypesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListHeightsForEventTypesResponseMultiError) AllErrors() []error { return m }
+
+// ListHeightsForEventTypesResponseValidationError is the validation error
+// returned by ListHeightsForEventTypesResponse.Validate if the designated
+// constraints aren't met.
+type ListHeightsForEventTypesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListHeightsForEventTypesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListHeightsForEventTypesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListHeightsForEventTypesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListHeightsForEventTypesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListHeightsForEventTypesResponseValidationError) ErrorName() string {
+	return "ListHeightsForEventTypesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListHeightsForEventTypesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListHeightsForEventTypesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListHeightsForEventTypesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListHeightsForEventTypesResponseValidationError{}
+
+// Validate checks the field values on ListEventsForContractRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetRegisterValuesResponse) Validate() error {
+func (m *ListEventsForContractRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetRegisterValuesResponse with the
+// ValidateAll checks the field values on ListEventsForContractRequest with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetRegisterValuesResponseMultiError, or nil if none found.
-func (m *GetRegisterValuesResponse) ValidateAll() error {
+// ListEventsForContractRequestMultiError, or nil if none found.
+func (m *ListEventsForContractRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetRegisterValuesResponse) validate(all bool) error {
+func (m *ListEventsForContractRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	if len(m.GetAddress()) != 8 {
+		err := ListEventsForContractRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := ListEventsForContractRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Limit
+
 	if len(errors) > 0 {
-		return GetRegisterValuesResponseMultiError(errors)
+		return ListEventsForContractRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetRegisterValuesResponseMultiError is an error wrapping multiple validation
-// errors returned by GetRegisterValuesResponse.ValidateAll() if the
-// designated constraints aren't met.
-type GetRegisterValuesResponseMultiError []error
+// ListEventsForContractRequestMultiError is an error wrapping multiple
+// validation errors returned by ListEventsForContractRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ListEventsForContractRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetRegisterValuesResponseMultiError) Error() string {
+func (m ListEventsForContractRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1486,11 +2066,12 @@ func (m GetRegisterValuesResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetRegisterValuesResponseMultiError) AllErrors() []error { return m }
+func (m ListEventsForContractRequestMultiError) AllErrors() []error { return m }
 
-// GetRegisterValuesResponseValidationError is the validation error returned by
-// GetRegisterValuesResponse.Validate if the designated constraints aren't met.
-type GetRegisterValuesResponseValidationError struct {
+// ListEventsForContractRequestValidationError is the validation error returned
+// by ListEventsForContractRequest.Validate if the designated constraints
+// aren't met.
+type ListEventsForContractRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1498,24 +2079,24 @@ type GetRegisterValuesResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetRegisterValuesResponseValidationError) Field() string { return e.field }
+func (e ListEventsForContractRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetRegisterValuesResponseValidationError) Reason() string { return e.reason }
+func (e ListEventsForContractRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetRegisterValuesResponseValidationError) Cause() error { return e.cause }
+func (e ListEventsForContractRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetRegisterValuesResponseValidationError) Key() bool { return e.key }
+func (e ListEventsForContractRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetRegisterValuesResponseValidationError) ErrorName() string {
-	return "GetRegisterValuesResponseValidationError"
+func (e ListEventsForContractRequestValidationError) ErrorName() string {
+	return "ListEventsForContractRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetRegisterValuesResponseValidationError) Error() string {
+func (e ListEventsForContractRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1527,14 +2108,14 @@ func (e GetRegisterValuesResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetRegisterValuesResponse.%s: %s%s",
+		"invalid %sListEventsForContractRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetRegisterValuesResponseValidationError{}
+var _ error = ListEventsForContractRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1542,33 +2123,5541 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetRegisterValuesResponseValidationError{}
+} = ListEventsForContractRequestValidationError{}
 
-// Validate checks the field values on GetCollectionRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on BlockEvents with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BlockEvents) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockEvents with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BlockEventsMultiError, or
+// nil if none found.
+func (m *BlockEvents) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockEvents) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return BlockEventsMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockEventsMultiError is an error wrapping multiple validation errors
+// returned by BlockEvents.ValidateAll() if the designated constraints aren't met.
+type BlockEventsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockEventsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockEventsMultiError) AllErrors() []error { return m }
+
+// BlockEventsValidationError is the validation error returned by
+// BlockEvents.Validate if the designated constraints aren't met.
+type BlockEventsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockEventsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockEventsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockEventsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockEventsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockEventsValidationError) ErrorName() string { return "BlockEventsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BlockEventsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockEvents.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockEventsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockEventsValidationError{}
+
+// Validate checks the field values on ListEventsForContractResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetCollectionRequest) Validate() error {
+func (m *ListEventsForContractResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetCollectionRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetCollectionRequestMultiError, or nil if none found.
-func (m *GetCollectionRequest) ValidateAll() error {
+// ValidateAll checks the field values on ListEventsForContractResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListEventsForContractResponseMultiError, or nil if none found.
+func (m *ListEventsForContractResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetCollectionRequest) validate(all bool) error {
+func (m *ListEventsForContractResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetCollectionID()) != 32 {
-		err := GetCollectionRequestValidationError{
-			field:  "CollectionID",
+	for idx, item := range m.GetBlocks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListEventsForContractResponseValidationError{
+						field:  fmt.Sprintf("Blocks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListEventsForContractResponseValidationError{
+						field:  fmt.Sprintf("Blocks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListEventsForContractResponseValidationError{
+					field:  fmt.Sprintf("Blocks[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return ListEventsForContractResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListEventsForContractResponseMultiError is an error wrapping multiple
+// validation errors returned by ListEventsForContractResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListEventsForContractResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListEventsForContractResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListEventsForContractResponseMultiError) AllErrors() []error { return m }
+
+// ListEventsForContractResponseValidationError is the validation error
+// returned by ListEventsForContractResponse.Validate if the designated
+// constraints aren't met.
+type ListEventsForContractResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListEventsForContractResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListEventsForContractResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListEventsForContractResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListEventsForContractResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListEventsForContractResponseValidationError) ErrorName() string {
+	return "ListEventsForContractResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListEventsForContractResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListEventsForContractResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListEventsForContractResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListEventsForContractResponseValidationError{}
+
+// Validate checks the field values on GetRegisterValuesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetRegisterValuesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRegisterValuesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRegisterValuesRequestMultiError, or nil if none found.
+func (m *GetRegisterValuesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRegisterValuesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetHeight() <= 0 {
+		err := GetRegisterValuesRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetRegisters()) < 1 {
+		err := GetRegisterValuesRequestValidationError{
+			field:  "Registers",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetRegisterValuesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRegisterValuesRequestMultiError is an error wrapping multiple validation
+// errors returned by GetRegisterValuesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetRegisterValuesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRegisterValuesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRegisterValuesRequestMultiError) AllErrors() []error { return m }
+
+// GetRegisterValuesRequestValidationError is the validation error returned by
+// GetRegisterValuesRequest.Validate if the designated constraints aren't met.
+type GetRegisterValuesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRegisterValuesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRegisterValuesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRegisterValuesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRegisterValuesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRegisterValuesRequestValidationError) ErrorName() string {
+	return "GetRegisterValuesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRegisterValuesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRegisterValuesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRegisterValuesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRegisterValuesRequestValidationError{}
+
+// Validate checks the field values on GetRegisterValuesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetRegisterValuesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRegisterValuesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRegisterValuesResponseMultiError, or nil if none found.
+func (m *GetRegisterValuesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRegisterValuesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetRegisterValuesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRegisterValuesResponseMultiError is an error wrapping multiple validation
+// errors returned by GetRegisterValuesResponse.ValidateAll() if the
+// designated constraints aren't met.
+type GetRegisterValuesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRegisterValuesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRegisterValuesResponseMultiError) AllErrors() []error { return m }
+
+// GetRegisterValuesResponseValidationError is the validation error returned by
+// GetRegisterValuesResponse.Validate if the designated constraints aren't met.
+type GetRegisterValuesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRegisterValuesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRegisterValuesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRegisterValuesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRegisterValuesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRegisterValuesResponseValidationError) ErrorName() string {
+	return "GetRegisterValuesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRegisterValuesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRegisterValuesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRegisterValuesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRegisterValuesResponseValidationError{}
+
+// Validate checks the field values on GetRegisterHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetRegisterHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRegisterHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRegisterHistoryRequestMultiError, or nil if none found.
+func (m *GetRegisterHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRegisterHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetRegister()) < 1 {
+		err := GetRegisterHistoryRequestValidationError{
+			field:  "Register",
+			reason: "value length must be at least 1 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := GetRegisterHistoryRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Limit
+
+	if len(errors) > 0 {
+		return GetRegisterHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRegisterHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by GetRegisterHistoryRequest.ValidateAll() if the
+// designated constraints aren't met.
+type GetRegisterHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRegisterHistoryRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRegisterHistoryRequestMultiError) AllErrors() []error { return m }
+
+// GetRegisterHistoryRequestValidationError is the validation error returned by
+// GetRegisterHistoryRequest.Validate if the designated constraints aren't met.
+type GetRegisterHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRegisterHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRegisterHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRegisterHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRegisterHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRegisterHistoryRequestValidationError) ErrorName() string {
+	return "GetRegisterHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRegisterHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRegisterHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRegisterHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRegisterHistoryRequestValidationError{}
+
+// Validate checks the field values on RegisterVersion with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RegisterVersion) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RegisterVersion with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RegisterVersionMultiError, or nil if none found.
+func (m *RegisterVersion) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RegisterVersion) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for Value
+
+	if len(errors) > 0 {
+		return RegisterVersionMultiError(errors)
+	}
+
+	return nil
+}
+
+// RegisterVersionMultiError is an error wrapping multiple validation errors
+// returned by RegisterVersion.ValidateAll() if the designated constraints
+// aren't met.
+type RegisterVersionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RegisterVersionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RegisterVersionMultiError) AllErrors() []error { return m }
+
+// RegisterVersionValidationError is the validation error returned by
+// RegisterVersion.Validate if the designated constraints aren't met.
+type RegisterVersionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RegisterVersionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RegisterVersionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RegisterVersionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RegisterVersionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RegisterVersionValidationError) ErrorName() string { return "RegisterVersionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RegisterVersionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRegisterVersion.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RegisterVersionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RegisterVersionValidationError{}
+
+// Validate checks the field values on GetRegisterHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetRegisterHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetRegisterHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetRegisterHistoryResponseMultiError, or nil if none found.
+func (m *GetRegisterHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetRegisterHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetRegisterHistoryResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetRegisterHistoryResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetRegisterHistoryResponseValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return GetRegisterHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetRegisterHistoryResponseMultiError is an error wrapping multiple
+// validation errors returned by GetRegisterHistoryResponse.ValidateAll() if
+// the designated constraints aren't met.
+type GetRegisterHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetRegisterHistoryResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetRegisterHistoryResponseMultiError) AllErrors() []error { return m }
+
+// GetRegisterHistoryResponseValidationError is the validation error returned
+// by GetRegisterHistoryResponse.Validate if the designated constraints aren't met.
+type GetRegisterHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetRegisterHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetRegisterHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetRegisterHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetRegisterHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetRegisterHistoryResponseValidationError) ErrorName() string {
+	return "GetRegisterHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetRegisterHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetRegisterHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetRegisterHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetRegisterHistoryResponseValidationError{}
+
+// Validate checks the field values on GetCollectionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetCollectionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetCollectionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetCollectionRequestMultiError, or nil if none found.
+func (m *GetCollectionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetCollectionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetCollectionID()) != 32 {
+		err := GetCollectionRequestValidationError{
+			field:  "CollectionID",
+			reason: "value length must be 32 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetCollectionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetCollectionRequestMultiError is an error wrapping multiple validation
+// errors returned by GetCollectionRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetCollectionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetCollectionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetCollectionRequestMultiError) AllErrors() []error { return m }
+
+// GetCollectionRequestValidationError is the validation error returned by
+// GetCollectionRequest.Validate if the designated constraints aren't met.
+type GetCollectionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetCollectionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetCollectionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetCollectionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetCollectionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetCollectionRequestValidationError) ErrorName() string {
+	return "GetCollectionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetCollectionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetCollectionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetCollectionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetCollectionRequestValidationError{}
+
+// Validate checks the field values on GetCollectionResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetCollectionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetCollectionResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetCollectionResponseMultiError, or nil if none found.
+func (m *GetCollectionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetCollectionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CollectionID
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return GetCollectionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetCollectionResponseMultiError is an error wrapping multiple validation
+// errors returned by GetCollectionResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetCollectionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetCollectionResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetCollectionResponseMultiError) AllErrors() []error { return m }
+
+// GetCollectionResponseValidationError is the validation error returned by
+// GetCollectionResponse.Validate if the designated constraints aren't met.
+type GetCollectionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetCollectionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetCollectionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetCollectionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetCollectionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetCollectionResponseValidationError) ErrorName() string {
+	return "GetCollectionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetCollectionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetCollectionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetCollectionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetCollectionResponseValidationError{}
+
+// Validate checks the field values on ListCollectionsForHeightRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListCollectionsForHeightRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListCollectionsForHeightRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListCollectionsForHeightRequestMultiError, or nil if none found.
+func (m *ListCollectionsForHeightRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListCollectionsForHeightRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetHeight() <= 0 {
+		err := ListCollectionsForHeightRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListCollectionsForHeightRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListCollectionsForHeightRequestMultiError is an error wrapping multiple
+// validation errors returned by ListCollectionsForHeightRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListCollectionsForHeightRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListCollectionsForHeightRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListCollectionsForHeightRequestMultiError) AllErrors() []error { return m }
+
+// ListCollectionsForHeightRequestValidationError is the validation error
+// returned by ListCollectionsForHeightRequest.Validate if the designated
+// constraints aren't met.
+type ListCollectionsForHeightRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListCollectionsForHeightRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListCollectionsForHeightRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListCollectionsForHeightRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListCollectionsForHeightRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListCollectionsForHeightRequestValidationError) ErrorName() string {
+	return "ListCollectionsForHeightRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListCollectionsForHeightRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListCollectionsForHeightRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListCollectionsForHeightRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListCollectionsForHeightRequestValidationError{}
+
+// Validate checks the field values on ListCollectionsForHeightResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListCollectionsForHeightResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListCollectionsForHeightResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListCollectionsForHeightResponseMultiError, or nil if none found.
+func (m *ListCollectionsForHeightResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListCollectionsForHeightResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	if len(errors) > 0 {
+		return ListCollectionsForHeightResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListCollectionsForHeightResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// ListCollectionsForHeightResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListCollectionsForHeightResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListCollectionsForHeightResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListCollectionsForHeightResponseMultiError) AllErrors() []error { return m }
+
+// ListCollectionsForHeightResponseValidationError is the validation error
+// returned by ListCollectionsForHeightResponse.Validate if the designated
+// constraints aren't met.
+type ListCollectionsForHeightResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListCollectionsForHeightResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListCollectionsForHeightResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListCollectionsForHeightResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListCollectionsForHeightResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListCollectionsForHeightResponseValidationError) ErrorName() string {
+	return "ListCollectionsForHeightResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListCollectionsForHeightResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListCollectionsForHeightResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListCollectionsForHeightResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListCollectionsForHeightResponseValidationError{}
+
+// Validate checks the field values on GetHeightForCollectionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForCollectionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetHeightForCollectionRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetHeightForCollectionRequestMultiError, or nil if none found.
+func (m *GetHeightForCollectionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetHeightForCollectionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetCollectionID()) != 32 {
+		err := GetHeightForCollectionRequestValidationError{
+			field:  "CollectionID",
+			reason: "value length must be 32 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetHeightForCollectionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetHeightForCollectionRequestMultiError is an error wrapping multiple
+// validation errors returned by GetHeightForCollectionRequest.ValidateAll()
+// if the designated constraints aren't met.
+type GetHeightForCollectionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetHeightForCollectionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetHeightForCollectionRequestMultiError) AllErrors() []error { return m }
+
+// GetHeightForCollectionRequestValidationError is the validation error
+// returned by GetHeightForCollectionRequest.Validate if the designated
+// constraints aren't met.
+type GetHeightForCollectionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetHeightForCollectionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetHeightForCollectionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetHeightForCollectionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetHeightForCollectionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetHeightForCollectionRequestValidationError) ErrorName() string {
+	return "GetHeightForCollectionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetHeightForCollectionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetHeightForCollectionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetHeightForCollectionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetHeightForCollectionRequestValidationError{}
+
+// Validate checks the field values on GetHeightForCollectionResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForCollectionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetHeightForCollectionResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetHeightForCollectionResponseMultiError, or nil if none found.
+func (m *GetHeightForCollectionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetHeightForCollectionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CollectionID
+
+	// no validation rules for Height
+
+	if len(errors) > 0 {
+		return GetHeightForCollectionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetHeightForCollectionResponseMultiError is an error wrapping multiple
+// validation errors returned by GetHeightForCollectionResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetHeightForCollectionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetHeightForCollectionResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetHeightForCollectionResponseMultiError) AllErrors() []error { return m }
+
+// GetHeightForCollectionResponseValidationError is the validation error
+// returned by GetHeightForCollectionResponse.Validate if the designated
+// constraints aren't met.
+type GetHeightForCollectionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetHeightForCollectionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetHeightForCollectionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetHeightForCollectionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetHeightForCollectionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetHeightForCollectionResponseValidationError) ErrorName() string {
+	return "GetHeightForCollectionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetHeightForCollectionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetHeightForCollectionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetHeightForCollectionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetHeightForCollectionResponseValidationError{}
+
+// Validate checks the field values on GetGuaranteeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetGuaranteeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetGuaranteeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetGuaranteeRequestMultiError, or nil if none found.
+func (m *GetGuaranteeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetGuaranteeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetCollectionID()) != 32 {
+		err := GetGuaranteeRequestValidationError{
+			field:  "CollectionID",
+			reason: "value length must be 32 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetGuaranteeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetGuaranteeRequestMultiError is an error wrapping multiple validation
+// errors returned by GetGuaranteeRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetGuaranteeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetGuaranteeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetGuaranteeRequestMultiError) AllErrors() []error { return m }
+
+// GetGuaranteeRequestValidationError is the validation error returned by
+// GetGuaranteeRequest.Validate if the designated constraints aren't met.
+type GetGuaranteeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetGuaranteeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetGuaranteeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetGuaranteeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetGuaranteeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetGuaranteeRequestValidationError) ErrorName() string {
+	return "GetGuaranteeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetGuaranteeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetGuaranteeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetGuaranteeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetGuaranteeRequestValidationError{}
+
+// Validate checks the field values on GetGuaranteeResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetGuaranteeResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetGuaranteeResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetGuaranteeResponseMultiError, or nil if none found.
+func (m *GetGuaranteeResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetGuaranteeResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CollectionID
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return GetGuaranteeResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetGuaranteeResponseMultiError is an error wrapping multiple validation
+// errors returned by GetGuaranteeResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetGuaranteeResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetGuaranteeResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetGuaranteeResponseMultiError) AllErrors() []error { return m }
+
+// GetGuaranteeResponseValidationError is the validation error returned by
+// GetGuaranteeResponse.Validate if the designated constraints aren't met.
+type GetGuaranteeResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetGuaranteeResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetGuaranteeResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetGuaranteeResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetGuaranteeResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetGuaranteeResponseValidationError) ErrorName() string {
+	return "GetGuaranteeResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetGuaranteeResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetGuaranteeResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetGuaranteeResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetGuaranteeResponseValidationError{}
+
+// Validate checks the field values on GetTransactionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetTransactionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetTransactionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetTransactionRequestMultiError, or nil if none found.
+func (m *GetTransactionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetTransactionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTransactionID()) != 32 {
+		err := GetTransactionRequestValidationError{
+			field:  "TransactionID",
+			reason: "value length must be 32 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetTransactionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetTransactionRequestMultiError is an error wrapping multiple validation
+// errors returned by GetTransactionRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetTransactionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetTransactionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetTransactionRequestMultiError) AllErrors() []error { return m }
+
+// GetTransactionRequestValidationError is the validation error returned by
+// GetTransactionRequest.Validate if the designated constraints aren't met.
+type GetTransactionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetTransactionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetTransactionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetTransactionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetTransactionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetTransactionRequestValidationError) ErrorName() string {
+	return "GetTransactionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetTransactionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetTransactionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetTransactionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetTransactionRequestValidationError{}
+
+// Validate checks the field values on GetTransactionResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetTransactionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetTransactionResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetTransactionResponseMultiError, or nil if none found.
+func (m *GetTransactionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetTransactionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TransactionID
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return GetTransactionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetTransactionResponseMultiError is an error wrapping multiple validation
+// errors returned by GetTransactionResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetTransactionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetTransactionResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetTransactionResponseMultiError) AllErrors() []error { return m }
+
+// GetTransactionResponseValidationError is the validation error returned by
+// GetTransactionResponse.Validate if the designated constraints aren't met.
+type GetTransactionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetTransactionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetTransactionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetTransactionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetTransactionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetTransactionResponseValidationError) ErrorName() string {
+	return "GetTransactionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetTransactionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetTransactionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetTransactionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetTransactionResponseValidationError{}
+
+// Validate checks the field values on GetHeightForTransactionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForTransactionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetHeightForTransactionRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetHeightForTransactionRequestMultiError, or nil if none found.
+func (m *GetHeightForTransactionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetHeightForTransactionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTransactionID()) != 32 {
+		err := GetHeightForTransactionRequestValidationError{
+			field:  "TransactionID",
+			reason: "value length must be 32 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetHeightForTransactionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetHeightForTransactionRequestMultiError is an error wrapping multiple
+// validation errors returned by GetHeightForTransactionRequest.ValidateAll()
+// if the designated constraints aren't met.
+type GetHeightForTransactionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetHeightForTransactionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetHeightForTransactionRequestMultiError) AllErrors() []error { return m }
+
+// GetHeightForTransactionRequestValidationError is the validation error
+// returned by GetHeightForTransactionRequest.Validate if the designated
+// constraints aren't met.
+type GetHeightForTransactionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetHeightForTransactionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetHeightForTransactionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetHeightForTransactionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetHeightForTransactionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetHeightForTransactionRequestValidationError) ErrorName() string {
+	return "GetHeightForTransactionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetHeightForTransactionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetHeightForTransactionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetHeightForTransactionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetHeightForTransactionRequestValidationError{}
+
+// Validate checks the field values on GetHeightForTransactionResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForTransactionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetHeightForTransactionResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetHeightForTransactionResponseMultiError, or nil if none found.
+func (m *GetHeightForTransactionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetHeightForTransactionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TransactionID
+
+	// no validation rules for Height
+
+	if len(errors) > 0 {
+		return GetHeightForTransactionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetHeightForTransactionResponseMultiError is an error wrapping multiple
+// validation errors returned by GetHeightForTransactionResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetHeightForTransactionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetHeightForTransactionResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetHeightForTransactionResponseMultiError) AllErrors() []error { return m }
+
+// GetHeightForTransactionResponseValidationError is the validation error
+// returned by GetHeightForTransactionResponse.Validate if the designated
+// constraints aren't met.
+type GetHeightForTransactionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetHeightForTransactionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetHeightForTransactionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetHeightForTransactionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetHeightForTransactionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetHeightForTransactionResponseValidationError) ErrorName() string {
+	return "GetHeightForTransactionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetHeightForTransactionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetHeightForTransactionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetHeightForTransactionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetHeightForTransactionResponseValidationError{}
+
+// Validate checks the field values on ListTransactionsForHeightRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListTransactionsForHeightRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTransactionsForHeightRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListTransactionsForHeightRequestMultiError, or nil if none found.
+func (m *ListTransactionsForHeightRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTransactionsForHeightRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetHeight() <= 0 {
+		err := ListTransactionsForHeightRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListTransactionsForHeightRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTransactionsForHeightRequestMultiError is an error wrapping multiple
+// validation errors returned by
+// ListTransactionsForHeightRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListTransactionsForHeightRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTransactionsForHeightRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTransactionsForHeightRequestMultiError) AllErrors() []error { return m }
+
+// ListTransactionsForHeightRequestValidationError is the validation error
+// returned by ListTransactionsForHeightRequest.Validate if the designated
+// constraints aren't met.
+type ListTransactionsForHeightRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTransactionsForHeightRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTransactionsForHeightRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTransactionsForHeightRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTransactionsForHeightRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTransactionsForHeightRequestValidationError) ErrorName() string {
+	return "ListTransactionsForHeightRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTransactionsForHeightRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTransactionsForHeightRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTransactionsForHeightRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTransactionsForHeightRequestValidationError{}
+
+// Validate checks the field values on ListTransactionsForHeightResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListTransactionsForHeightResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTransactionsForHeightResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// ListTransactionsForHeightResponseMultiError, or nil if none found.
+func (m *ListTransactionsForHeightResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTransactionsForHeightResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	if len(errors) > 0 {
+		return ListTransactionsForHeightResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTransactionsForHeightResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// ListTransactionsForHeightResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListTransactionsForHeightResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTransactionsForHeightResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTransactionsForHeightResponseMultiError) AllErrors() []error { return m }
+
+// ListTransactionsForHeightResponseValidationError is the validation error
+// returned by ListTransactionsForHeightResponse.Validate if the designated
+// constraints aren't met.
+type ListTransactionsForHeightResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTransactionsForHeightResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTransactionsForHeightResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTransactionsForHeightResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTransactionsForHeightResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTransactionsForHeightResponseValidationError) ErrorName() string {
+	return "ListTransactionsForHeightResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTransactionsForHeightResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTransactionsForHeightResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTransactionsForHeightResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTransactionsForHeightResponseValidationError{}
+
+// Validate checks the field values on ListTransactionsForAddressRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListTransactionsForAddressRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTransactionsForAddressRequest
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// ListTransactionsForAddressRequestMultiError, or nil if none found.
+func (m *ListTransactionsForAddressRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTransactionsForAddressRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := ListTransactionsForAddressRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := ListTransactionsForAddressRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRoles() > 7 {
+		err := ListTransactionsForAddressRequestValidationError{
+			field:  "Roles",
+			reason: "value must be less than or equal to 7",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Limit
+
+	if len(errors) > 0 {
+		return ListTransactionsForAddressRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTransactionsForAddressRequestMultiError is an error wrapping multiple
+// validation errors returned by
+// ListTransactionsForAddressRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListTransactionsForAddressRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTransactionsForAddressRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTransactionsForAddressRequestMultiError) AllErrors() []error { return m }
+
+// ListTransactionsForAddressRequestValidationError is the validation error
+// returned by ListTransactionsForAddressRequest.Validate if the designated
+// constraints aren't met.
+type ListTransactionsForAddressRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTransactionsForAddressRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTransactionsForAddressRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTransactionsForAddressRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTransactionsForAddressRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTransactionsForAddressRequestValidationError) ErrorName() string {
+	return "ListTransactionsForAddressRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTransactionsForAddressRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTransactionsForAddressRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTransactionsForAddressRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTransactionsForAddressRequestValidationError{}
+
+// Validate checks the field values on AddressTransaction with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AddressTransaction) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AddressTransaction with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AddressTransactionMultiError, or nil if none found.
+func (m *AddressTransaction) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AddressTransaction) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for TransactionID
+
+	// no validation rules for Roles
+
+	if len(errors) > 0 {
+		return AddressTransactionMultiError(errors)
+	}
+
+	return nil
+}
+
+// AddressTransactionMultiError is an error wrapping multiple validation errors
+// returned by AddressTransaction.ValidateAll() if the designated constraints
+// aren't met.
+type AddressTransactionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AddressTransactionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AddressTransactionMultiError) AllErrors() []error { return m }
+
+// AddressTransactionValidationError is the validation error returned by
+// AddressTransaction.Validate if the designated constraints aren't met.
+type AddressTransactionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AddressTransactionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AddressTransactionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AddressTransactionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AddressTransactionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AddressTransactionValidationError) ErrorName() string {
+	return "AddressTransactionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AddressTransactionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAddressTransaction.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AddressTransactionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AddressTransactionValidationError{}
+
+// Validate checks the field values on ListTransactionsForAddressResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListTransactionsForAddressResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTransactionsForAddressResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// ListTransactionsForAddressResponseMultiError, or nil if none found.
+func (m *ListTransactionsForAddressResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTransactionsForAddressResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTransactions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListTransactionsForAddressResponseValidationError{
+						field:  fmt.Sprintf("Transactions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListTransactionsForAddressResponseValidationError{
+						field:  fmt.Sprintf("Transactions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListTransactionsForAddressResponseValidationError{
+					field:  fmt.Sprintf("Transactions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return ListTransactionsForAddressResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTransactionsForAddressResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// ListTransactionsForAddressResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListTransactionsForAddressResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTransactionsForAddressResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTransactionsForAddressResponseMultiError) AllErrors() []error { return m }
+
+// ListTransactionsForAddressResponseValidationError is the validation error
+// returned by ListTransactionsForAddressResponse.Validate if the designated
+// constraints aren't met.
+type ListTransactionsForAddressResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTransactionsForAddressResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTransactionsForAddressResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTransactionsForAddressResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTransactionsForAddressResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTransactionsForAddressResponseValidationError) ErrorName() string {
+	return "ListTransactionsForAddressResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTransactionsForAddressResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTransactionsForAddressResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTransactionsForAddressResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTransactionsForAddressResponseValidationError{}
+
+// Validate checks the field values on ListTransfersForAddressRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListTransfersForAddressRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTransfersForAddressRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListTransfersForAddressRequestMultiError, or nil if none found.
+func (m *ListTransfersForAddressRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTransfersForAddressRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := ListTransfersForAddressRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := ListTransfersForAddressRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Limit
+
+	if len(errors) > 0 {
+		return ListTransfersForAddressRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTransfersForAddressRequestMultiError is an error wrapping multiple
+// validation errors returned by ListTransfersForAddressRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListTransfersForAddressRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTransfersForAddressRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTransfersForAddressRequestMultiError) AllErrors() []error { return m }
+
+// ListTransfersForAddressRequestValidationError is the validation error
+// returned by ListTransfersForAddressRequest.Validate if the designated
+// constraints aren't met.
+type ListTransfersForAddressRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTransfersForAddressRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTransfersForAddressRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTransfersForAddressRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTransfersForAddressRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTransfersForAddressRequestValidationError) ErrorName() string {
+	return "ListTransfersForAddressRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTransfersForAddressRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTransfersForAddressRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTransfersForAddressRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTransfersForAddressRequestValidationError{}
+
+// Validate checks the field values on Transfer with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Transfer) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Transfer with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TransferMultiError, or nil
+// if none found.
+func (m *Transfer) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Transfer) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for TransactionID
+
+	// no validation rules for EventIndex
+
+	// no validation rules for Token
+
+	// no validation rules for From
+
+	// no validation rules for To
+
+	// no validation rules for Amount
+
+	if len(errors) > 0 {
+		return TransferMultiError(errors)
+	}
+
+	return nil
+}
+
+// TransferMultiError is an error wrapping multiple validation errors returned
+// by Transfer.ValidateAll() if the designated constraints aren't met.
+type TransferMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TransferMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TransferMultiError) AllErrors() []error { return m }
+
+// TransferValidationError is the validation error returned by
+// Transfer.Validate if the designated constraints aren't met.
+type TransferValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TransferValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TransferValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TransferValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TransferValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TransferValidationError) ErrorName() string { return "TransferValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TransferValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTransfer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TransferValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TransferValidationError{}
+
+// Validate checks the field values on ListTransfersForAddressResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListTransfersForAddressResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTransfersForAddressResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListTransfersForAddressResponseMultiError, or nil if none found.
+func (m *ListTransfersForAddressResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTransfersForAddressResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTransfers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListTransfersForAddressResponseValidationError{
+						field:  fmt.Sprintf("Transfers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListTransfersForAddressResponseValidationError{
+						field:  fmt.Sprintf("Transfers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListTransfersForAddressResponseValidationError{
+					field:  fmt.Sprintf("Transfers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return ListTransfersForAddressResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTransfersForAddressResponseMultiError is an error wrapping multiple
+// validation errors returned by ListTransfersForAddressResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListTransfersForAddressResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTransfersForAddressResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTransfersForAddressResponseMultiError) AllErrors() []error { return m }
+
+// ListTransfersForAddressResponseValidationError is the validation error
+// returned by ListTransfersForAddressResponse.Validate if the designated
+// constraints aren't met.
+type ListTransfersForAddressResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTransfersForAddressResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTransfersForAddressResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTransfersForAddressResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTransfersForAddressResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTransfersForAddressResponseValidationError) ErrorName() string {
+	return "ListTransfersForAddressResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTransfersForAddressResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTransfersForAddressResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTransfersForAddressResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTransfersForAddressResponseValidationError{}
+
+// Validate checks the field values on ListBalanceHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListBalanceHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListBalanceHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListBalanceHistoryRequestMultiError, or nil if none found.
+func (m *ListBalanceHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListBalanceHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := ListBalanceHistoryRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := ListBalanceHistoryRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := ListBalanceHistoryRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Limit
+
+	if len(errors) > 0 {
+		return ListBalanceHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListBalanceHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by ListBalanceHistoryRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ListBalanceHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListBalanceHistoryRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListBalanceHistoryRequestMultiError) AllErrors() []error { return m }
+
+// ListBalanceHistoryRequestValidationError is the validation error returned by
+// ListBalanceHistoryRequest.Validate if the designated constraints aren't met.
+type ListBalanceHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListBalanceHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListBalanceHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListBalanceHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListBalanceHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListBalanceHistoryRequestValidationError) ErrorName() string {
+	return "ListBalanceHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListBalanceHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListBalanceHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListBalanceHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListBalanceHistoryRequestValidationError{}
+
+// Validate checks the field values on BalanceChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BalanceChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BalanceChange with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BalanceChangeMultiError, or
+// nil if none found.
+func (m *BalanceChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BalanceChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for Deposited
+
+	// no validation rules for Withdrawn
+
+	if len(errors) > 0 {
+		return BalanceChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// BalanceChangeMultiError is an error wrapping multiple validation errors
+// returned by BalanceChange.ValidateAll() if the designated constraints
+// aren't met.
+type BalanceChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BalanceChangeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BalanceChangeMultiError) AllErrors() []error { return m }
+
+// BalanceChangeValidationError is the validation error returned by
+// BalanceChange.Validate if the designated constraints aren't met.
+type BalanceChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BalanceChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BalanceChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BalanceChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BalanceChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BalanceChangeValidationError) ErrorName() string { return "BalanceChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BalanceChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBalanceChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BalanceChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BalanceChangeValidationError{}
+
+// Validate checks the field values on ListBalanceHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListBalanceHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListBalanceHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListBalanceHistoryResponseMultiError, or nil if none found.
+func (m *ListBalanceHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListBalanceHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListBalanceHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListBalanceHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListBalanceHistoryResponseValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return ListBalanceHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListBalanceHistoryResponseMultiError is an error wrapping multiple
+// validation errors returned by ListBalanceHistoryResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListBalanceHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListBalanceHistoryResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListBalanceHistoryResponseMultiError) AllErrors() []error { return m }
+
+// ListBalanceHistoryResponseValidationError is the validation error returned
+// by ListBalanceHistoryResponse.Validate if the designated constraints aren't met.
+type ListBalanceHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListBalanceHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListBalanceHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListBalanceHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListBalanceHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListBalanceHistoryResponseValidationError) ErrorName() string {
+	return "ListBalanceHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListBalanceHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListBalanceHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListBalanceHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListBalanceHistoryResponseValidationError{}
+
+// Validate checks the field values on ListContractsForAddressRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListContractsForAddressRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListContractsForAddressRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListContractsForAddressRequestMultiError, or nil if none found.
+func (m *ListContractsForAddressRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListContractsForAddressRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := ListContractsForAddressRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetHeight() <= 0 {
+		err := ListContractsForAddressRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListContractsForAddressRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListContractsForAddressRequestMultiError is an error wrapping multiple
+// validation errors returned by ListContractsForAddressRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListContractsForAddressRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListContractsForAddressRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListContractsForAddressRequestMultiError) AllErrors() []error { return m }
+
+// ListContractsForAddressRequestValidationError is the validation error
+// returned by ListContractsForAddressRequest.Validate if the designated
+// constraints aren't met.
+type ListContractsForAddressRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListContractsForAddressRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListContractsForAddressRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListContractsForAddressRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListContractsForAddressRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListContractsForAddressRequestValidationError) ErrorName() string {
+	return "ListContractsForAddressRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListContractsForAddressRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListContractsForAddressRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListContractsForAddressRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListContractsForAddressRequestValidationError{}
+
+// Validate checks the field values on Contract with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Contract) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Contract with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ContractMultiError, or nil
+// if none found.
+func (m *Contract) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Contract) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Height
+
+	if len(errors) > 0 {
+		return ContractMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContractMultiError is an error wrapping multiple validation errors returned
+// by Contract.ValidateAll() if the designated constraints aren't met.
+type ContractMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContractMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContractMultiError) AllErrors() []error { return m }
+
+// ContractValidationError is the validation error returned by
+// Contract.Validate if the designated constraints aren't met.
+type ContractValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContractValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContractValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContractValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContractValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContractValidationError) ErrorName() string { return "ContractValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ContractValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContract.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContractValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContractValidationError{}
+
+// Validate checks the field values on ListContractsForAddressResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListContractsForAddressResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListContractsForAddressResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListContractsForAddressResponseMultiError, or nil if none found.
+func (m *ListContractsForAddressResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListContractsForAddressResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	// no validation rules for Height
+
+	for idx, item := range m.GetContracts() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListContractsForAddressResponseValidationError{
+						field:  fmt.Sprintf("Contracts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListContractsForAddressResponseValidationError{
+						field:  fmt.Sprintf("Contracts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListContractsForAddressResponseValidationError{
+					field:  fmt.Sprintf("Contracts[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListContractsForAddressResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListContractsForAddressResponseMultiError is an error wrapping multiple
+// validation errors returned by ListContractsForAddressResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListContractsForAddressResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListContractsForAddressResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListContractsForAddressResponseMultiError) AllErrors() []error { return m }
+
+// ListContractsForAddressResponseValidationError is the validation error
+// returned by ListContractsForAddressResponse.Validate if the designated
+// constraints aren't met.
+type ListContractsForAddressResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListContractsForAddressResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListContractsForAddressResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListContractsForAddressResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListContractsForAddressResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListContractsForAddressResponseValidationError) ErrorName() string {
+	return "ListContractsForAddressResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListContractsForAddressResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListContractsForAddressResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListContractsForAddressResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListContractsForAddressResponseValidationError{}
+
+// Validate checks the field values on ListContractUpdatesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListContractUpdatesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListContractUpdatesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListContractUpdatesRequestMultiError, or nil if none found.
+func (m *ListContractUpdatesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListContractUpdatesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := ListContractUpdatesRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Name
+
+	// no validation rules for StartHeight
+
+	if m.GetEndHeight() <= 0 {
+		err := ListContractUpdatesRequestValidationError{
+			field:  "EndHeight",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Limit
+
+	if len(errors) > 0 {
+		return ListContractUpdatesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListContractUpdatesRequestMultiError is an error wrapping multiple
+// validation errors returned by ListContractUpdatesRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ListContractUpdatesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListContractUpdatesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListContractUpdatesRequestMultiError) AllErrors() []error { return m }
+
+// ListContractUpdatesRequestValidationError is the validation error returned
+// by ListContractUpdatesRequest.Validate if the designated constraints aren't met.
+type ListContractUpdatesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListContractUpdatesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListContractUpdatesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListContractUpdatesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListContractUpdatesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListContractUpdatesRequestValidationError) ErrorName() string {
+	return "ListContractUpdatesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListContractUpdatesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListContractUpdatesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListContractUpdatesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListContractUpdatesRequestValidationError{}
+
+// Validate checks the field values on ContractUpdate with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ContractUpdate) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ContractUpdate with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ContractUpdateMultiError,
+// or nil if none found.
+func (m *ContractUpdate) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ContractUpdate) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	// no validation rules for TransactionID
+
+	// no validation rules for Name
+
+	// no validation rules for Action
+
+	// no validation rules for CodeHash
+
+	if len(errors) > 0 {
+		return ContractUpdateMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContractUpdateMultiError is an error wrapping multiple validation errors
+// returned by ContractUpdate.ValidateAll() if the designated constraints
+// aren't met.
+type ContractUpdateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContractUpdateMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContractUpdateMultiError) AllErrors() []error { return m }
+
+// ContractUpdateValidationError is the validation error returned by
+// ContractUpdate.Validate if the designated constraints aren't met.
+type ContractUpdateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContractUpdateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContractUpdateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContractUpdateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContractUpdateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContractUpdateValidationError) ErrorName() string { return "ContractUpdateValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ContractUpdateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContractUpdate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContractUpdateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContractUpdateValidationError{}
+
+// Validate checks the field values on ListContractUpdatesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListContractUpdatesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListContractUpdatesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListContractUpdatesResponseMultiError, or nil if none found.
+func (m *ListContractUpdatesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListContractUpdatesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUpdates() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListContractUpdatesResponseValidationError{
+						field:  fmt.Sprintf("Updates[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListContractUpdatesResponseValidationError{
+						field:  fmt.Sprintf("Updates[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListContractUpdatesResponseValidationError{
+					field:  fmt.Sprintf("Updates[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextHeight
+
+	if len(errors) > 0 {
+		return ListContractUpdatesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListContractUpdatesResponseMultiError is an error wrapping multiple
+// validation errors returned by ListContractUpdatesResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListContractUpdatesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListContractUpdatesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListContractUpdatesResponseMultiError) AllErrors() []error { return m }
+
+// ListContractUpdatesResponseValidationError is the validation error returned
+// by ListContractUpdatesResponse.Validate if the designated constraints
+// aren't met.
+type ListContractUpdatesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListContractUpdatesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListContractUpdatesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListContractUpdatesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListContractUpdatesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListContractUpdatesResponseValidationError) ErrorName() string {
+	return "ListContractUpdatesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListContractUpdatesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListContractUpdatesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListContractUpdatesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListContractUpdatesResponseValidationError{}
+
+// Validate checks the field values on GetContractCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetContractCodeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetContractCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetContractCodeRequestMultiError, or nil if none found.
+func (m *GetContractCodeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetContractCodeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := GetContractCodeRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetName()) < 1 {
+		err := GetContractCodeRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetHeight() <= 0 {
+		err := GetContractCodeRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetContractCodeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetContractCodeRequestMultiError is an error wrapping multiple validation
+// errors returned by GetContractCodeRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetContractCodeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetContractCodeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetContractCodeRequestMultiError) AllErrors() []error { return m }
+
+// GetContractCodeRequestValidationError is the validation error returned by
+// GetContractCodeRequest.Validate if the designated constraints aren't met.
+type GetContractCodeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetContractCodeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetContractCodeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetContractCodeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetContractCodeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetContractCodeRequestValidationError) ErrorName() string {
+	return "GetContractCodeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetContractCodeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetContractCodeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetContractCodeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetContractCodeRequestValidationError{}
+
+// Validate checks the field values on GetContractCodeResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetContractCodeResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetContractCodeResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetContractCodeResponseMultiError, or nil if none found.
+func (m *GetContractCodeResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetContractCodeResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	// no validation rules for Name
+
+	// no validation rules for Height
+
+	// no validation rules for Code
+
+	if len(errors) > 0 {
+		return GetContractCodeResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetContractCodeResponseMultiError is an error wrapping multiple validation
+// errors returned by GetContractCodeResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetContractCodeResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetContractCodeResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetContractCodeResponseMultiError) AllErrors() []error { return m }
+
+// GetContractCodeResponseValidationError is the validation error returned by
+// GetContractCodeResponse.Validate if the designated constraints aren't met.
+type GetContractCodeResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetContractCodeResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetContractCodeResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetContractCodeResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetContractCodeResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetContractCodeResponseValidationError) ErrorName() string {
+	return "GetContractCodeResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetContractCodeResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetContractCodeResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetContractCodeResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetContractCodeResponseValidationError{}
+
+// Validate checks the field values on GetAccountKeysRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAccountKeysRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAccountKeysRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAccountKeysRequestMultiError, or nil if none found.
+func (m *GetAccountKeysRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAccountKeysRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := GetAccountKeysRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetHeight() <= 0 {
+		err := GetAccountKeysRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetAccountKeysRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAccountKeysRequestMultiError is an error wrapping multiple validation
+// errors returned by GetAccountKeysRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetAccountKeysRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAccountKeysRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAccountKeysRequestMultiError) AllErrors() []error { return m }
+
+// GetAccountKeysRequestValidationError is the validation error returned by
+// GetAccountKeysRequest.Validate if the designated constraints aren't met.
+type GetAccountKeysRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAccountKeysRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAccountKeysRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAccountKeysRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAccountKeysRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAccountKeysRequestValidationError) ErrorName() string {
+	return "GetAccountKeysRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetAccountKeysRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAccountKeysRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAccountKeysRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAccountKeysRequestValidationError{}
+
+// Validate checks the field values on AccountKey with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AccountKey) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccountKey with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AccountKeyMultiError, or
+// nil if none found.
+func (m *AccountKey) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccountKey) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	// no validation rules for PublicKey
+
+	// no validation rules for Weight
+
+	// no validation rules for Revoked
+
+	// no validation rules for SeqNumber
+
+	if len(errors) > 0 {
+		return AccountKeyMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccountKeyMultiError is an error wrapping multiple validation errors
+// returned by AccountKey.ValidateAll() if the designated constraints aren't met.
+type AccountKeyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccountKeyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccountKeyMultiError) AllErrors() []error { return m }
+
+// AccountKeyValidationError is the validation error returned by
+// AccountKey.Validate if the designated constraints aren't met.
+type AccountKeyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccountKeyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccountKeyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccountKeyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccountKeyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccountKeyValidationError) ErrorName() string { return "AccountKeyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AccountKeyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccountKey.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccountKeyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccountKeyValidationError{}
+
+// Validate checks the field values on GetAccountKeysResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetAccountKeysResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAccountKeysResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAccountKeysResponseMultiError, or nil if none found.
+func (m *GetAccountKeysResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAccountKeysResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	// no validation rules for Height
+
+	for idx, item := range m.GetKeys() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetAccountKeysResponseValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetAccountKeysResponseValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetAccountKeysResponseValidationError{
+					field:  fmt.Sprintf("Keys[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetAccountKeysResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAccountKeysResponseMultiError is an error wrapping multiple validation
+// errors returned by GetAccountKeysResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetAccountKeysResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAccountKeysResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAccountKeysResponseMultiError) AllErrors() []error { return m }
+
+// GetAccountKeysResponseValidationError is the validation error returned by
+// GetAccountKeysResponse.Validate if the designated constraints aren't met.
+type GetAccountKeysResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAccountKeysResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAccountKeysResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAccountKeysResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAccountKeysResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAccountKeysResponseValidationError) ErrorName() string {
+	return "GetAccountKeysResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetAccountKeysResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAccountKeysResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAccountKeysResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAccountKeysResponseValidationError{}
+
+// Validate checks the field values on ListAccountKeyHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAccountKeyHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccountKeyHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAccountKeyHistoryRequestMultiError, or nil if none found.
+func (m *ListAccountKeyHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccountKeyHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddress()) != 8 {
+		err := ListAccountKeyHistoryRequestValidationError{
+			field:  "Address",
+			reason: "value length must be 8 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListAccountKeyHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAccountKeyHistoryRequestMultiError is an error wrapping multiple
+// validation errors returned by ListAccountKeyHistoryRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ListAccountKeyHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccountKeyHistoryRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccountKeyHistoryRequestMultiError) AllErrors() []error { return m }
+
+// ListAccountKeyHistoryRequestValidationError is the validation error returned
+// by ListAccountKeyHistoryRequest.Validate if the designated constraints
+// aren't met.
+type ListAccountKeyHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccountKeyHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccountKeyHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccountKeyHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccountKeyHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccountKeyHistoryRequestValidationError) ErrorName() string {
+	return "ListAccountKeyHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccountKeyHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccountKeyHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccountKeyHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccountKeyHistoryRequestValidationError{}
+
+// Validate checks the field values on AccountKeyChange with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AccountKeyChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccountKeyChange with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AccountKeyChangeMultiError, or nil if none found.
+func (m *AccountKeyChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccountKeyChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Height
+
+	for idx, item := range m.GetKeys() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AccountKeyChangeValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AccountKeyChangeValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AccountKeyChangeValidationError{
+					field:  fmt.Sprintf("Keys[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return AccountKeyChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccountKeyChangeMultiError is an error wrapping multiple validation errors
+// returned by AccountKeyChange.ValidateAll() if the designated constraints
+// aren't met.
+type AccountKeyChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccountKeyChangeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccountKeyChangeMultiError) AllErrors() []error { return m }
+
+// AccountKeyChangeValidationError is the validation error returned by
+// AccountKeyChange.Validate if the designated constraints aren't met.
+type AccountKeyChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccountKeyChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccountKeyChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccountKeyChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccountKeyChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccountKeyChangeValidationError) ErrorName() string { return "AccountKeyChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AccountKeyChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccountKeyChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccountKeyChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccountKeyChangeValidationError{}
+
+// Validate checks the field values on ListAccountKeyHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAccountKeyHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAccountKeyHistoryResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListAccountKeyHistoryResponseMultiError, or nil if none found.
+func (m *ListAccountKeyHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAccountKeyHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAccountKeyHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAccountKeyHistoryResponseValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAccountKeyHistoryResponseValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAccountKeyHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAccountKeyHistoryResponseMultiError is an error wrapping multiple
+// validation errors returned by ListAccountKeyHistoryResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListAccountKeyHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAccountKeyHistoryResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAccountKeyHistoryResponseMultiError) AllErrors() []error { return m }
+
+// ListAccountKeyHistoryResponseValidationError is the validation error
+// returned by ListAccountKeyHistoryResponse.Validate if the designated
+// constraints aren't met.
+type ListAccountKeyHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAccountKeyHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAccountKeyHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAccountKeyHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAccountKeyHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAccountKeyHistoryResponseValidationError) ErrorName() string {
+	return "ListAccountKeyHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAccountKeyHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAccountKeyHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAccountKeyHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAccountKeyHistoryResponseValidationError{}
+
+// Validate checks the field values on GetResultRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetResultRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetResultRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetResultRequestMultiError, or nil if none found.
+func (m *GetResultRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetResultRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTransactionID()) != 32 {
+		err := GetResultRequestValidationError{
+			field:  "TransactionID",
+			reason: "value length must be 32 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetResultRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetResultRequestMultiError is an error wrapping multiple validation errors
+// returned by GetResultRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetResultRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetResultRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetResultRequestMultiError) AllErrors() []error { return m }
+
+// GetResultRequestValidationError is the validation error returned by
+// GetResultRequest.Validate if the designated constraints aren't met.
+type GetResultRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetResultRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetResultRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetResultRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetResultRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetResultRequestValidationError) ErrorName() string { return "GetResultRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetResultRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetResultRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetResultRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetResultRequestValidationError{}
+
+// Validate checks the field values on GetResultResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetResultResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetResultResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetResultResponseMultiError, or nil if none found.
+func (m *GetResultResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetResultResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for TransactionID
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return GetResultResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetResultResponseMultiError is an error wrapping multiple validation errors
+// returned by GetResultResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetResultResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetResultResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetResultResponseMultiError) AllErrors() []error { return m }
+
+// GetResultResponseValidationError is the validation error returned by
+// GetResultResponse.Validate if the designated constraints aren't met.
+type GetResultResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetResultResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetResultResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetResultResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetResultResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetResultResponseValidationError) ErrorName() string {
+	return "GetResultResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetResultResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetResultResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetResultResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetResultResponseValidationError{}
+
+// Validate checks the field values on GetSealRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetSealRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSealRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetSealRequestMultiError,
+// or nil if none found.
+func (m *GetSealRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSealRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetSealID()) != 32 {
+		err := GetSealRequestValidationError{
+			field:  "SealID",
 			reason: "value length must be 32 bytes",
 		}
 		if !all {
@@ -1578,19 +7667,19 @@ func (m *GetCollectionRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetCollectionRequestMultiError(errors)
+		return GetSealRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetCollectionRequestMultiError is an error wrapping multiple validation
-// errors returned by GetCollectionRequest.ValidateAll() if the designated
-// constraints aren't met.
-type GetCollectionRequestMultiError []error
+// GetSealRequestMultiError is an error wrapping multiple validation errors
+// returned by GetSealRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetSealRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetCollectionRequestMultiError) Error() string {
+func (m GetSealRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1599,11 +7688,11 @@ func (m GetCollectionRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetCollectionRequestMultiError) AllErrors() []error { return m }
+func (m GetSealRequestMultiError) AllErrors() []error { return m }
 
-// GetCollectionRequestValidationError is the validation error returned by
-// GetCollectionRequest.Validate if the designated constraints aren't met.
-type GetCollectionRequestValidationError struct {
+// GetSealRequestValidationError is the validation error returned by
+// GetSealRequest.Validate if the designated constraints aren't met.
+type GetSealRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1611,24 +7700,22 @@ type GetCollectionRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetCollectionRequestValidationError) Field() string { return e.field }
+func (e GetSealRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetCollectionRequestValidationError) Reason() string { return e.reason }
+func (e GetSealRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetCollectionRequestValidationError) Cause() error { return e.cause }
+func (e GetSealRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetCollectionRequestValidationError) Key() bool { return e.key }
+func (e GetSealRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetCollectionRequestValidationError) ErrorName() string {
-	return "GetCollectionRequestValidationError"
-}
+func (e GetSealRequestValidationError) ErrorName() string { return "GetSealRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetCollectionRequestValidationError) Error() string {
+func (e GetSealRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1640,14 +7727,14 @@ func (e GetCollectionRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetCollectionRequest.%s: %s%s",
+		"invalid %sGetSealRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetCollectionRequestValidationError{}
+var _ error = GetSealRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1655,48 +7742,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetCollectionRequestValidationError{}
+} = GetSealRequestValidationError{}
 
-// Validate checks the field values on GetCollectionResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetCollectionResponse) Validate() error {
+// Validate checks the field values on GetSealResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetSealResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetCollectionResponse with the rules
+// ValidateAll checks the field values on GetSealResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetCollectionResponseMultiError, or nil if none found.
-func (m *GetCollectionResponse) ValidateAll() error {
+// GetSealResponseMultiError, or nil if none found.
+func (m *GetSealResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetCollectionResponse) validate(all bool) error {
+func (m *GetSealResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for CollectionID
+	// no validation rules for SealID
 
 	// no validation rules for Data
 
 	if len(errors) > 0 {
-		return GetCollectionResponseMultiError(errors)
+		return GetSealResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetCollectionResponseMultiError is an error wrapping multiple validation
-// errors returned by GetCollectionResponse.ValidateAll() if the designated
-// constraints aren't met.
-type GetCollectionResponseMultiError []error
+// GetSealResponseMultiError is an error wrapping multiple validation errors
+// returned by GetSealResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetSealResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetCollectionResponseMultiError) Error() string {
+func (m GetSealResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1705,11 +7792,11 @@ func (m GetCollectionResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetCollectionResponseMultiError) AllErrors() []error { return m }
+func (m GetSealResponseMultiError) AllErrors() []error { return m }
 
-// GetCollectionResponseValidationError is the validation error returned by
-// GetCollectionResponse.Validate if the designated constraints aren't met.
-type GetCollectionResponseValidationError struct {
+// GetSealResponseValidationError is the validation error returned by
+// GetSealResponse.Validate if the designated constraints aren't met.
+type GetSealResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1717,24 +7804,22 @@ type GetCollectionResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetCollectionResponseValidationError) Field() string { return e.field }
+func (e GetSealResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetCollectionResponseValidationError) Reason() string { return e.reason }
+func (e GetSealResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetCollectionResponseValidationError) Cause() error { return e.cause }
+func (e GetSealResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetCollectionResponseValidationError) Key() bool { return e.key }
+func (e GetSealResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetCollectionResponseValidationError) ErrorName() string {
-	return "GetCollectionResponseValidationError"
-}
+func (e GetSealResponseValidationError) ErrorName() string { return "GetSealResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetCollectionResponseValidationError) Error() string {
+func (e GetSealResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1746,14 +7831,14 @@ func (e GetCollectionResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetCollectionResponse.%s: %s%s",
+		"invalid %sGetSealResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetCollectionResponseValidationError{}
+var _ error = GetSealResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1761,34 +7846,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetCollectionResponseValidationError{}
+} = GetSealResponseValidationError{}
 
-// Validate checks the field values on ListCollectionsForHeightRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// Validate checks the field values on GetHeightForSealRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListCollectionsForHeightRequest) Validate() error {
+func (m *GetHeightForSealRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListCollectionsForHeightRequest with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the result is a list of violation errors wrapped in
-// ListCollectionsForHeightRequestMultiError, or nil if none found.
-func (m *ListCollectionsForHeightRequest) ValidateAll() error {
+// ValidateAll checks the field values on GetHeightForSealRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetHeightForSealRequestMultiError, or nil if none found.
+func (m *GetHeightForSealRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListCollectionsForHeightRequest) validate(all bool) error {
+func (m *GetHeightForSealRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetHeight() <= 0 {
-		err := ListCollectionsForHeightRequestValidationError{
-			field:  "Height",
-			reason: "value must be greater than 0",
+	if len(m.GetSealID()) != 32 {
+		err := GetHeightForSealRequestValidationError{
+			field:  "SealID",
+			reason: "value length must be 32 bytes",
 		}
 		if !all {
 			return err
@@ -1797,19 +7882,19 @@ func (m *ListCollectionsForHeightRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return ListCollectionsForHeightRequestMultiError(errors)
+		return GetHeightForSealRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListCollectionsForHeightRequestMultiError is an error wrapping multiple
-// validation errors returned by ListCollectionsForHeightRequest.ValidateAll()
-// if the designated constraints aren't met.
-type ListCollectionsForHeightRequestMultiError []error
+// GetHeightForSealRequestMultiError is an error wrapping multiple validation
+// errors returned by GetHeightForSealRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetHeightForSealRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListCollectionsForHeightRequestMultiError) Error() string {
+func (m GetHeightForSealRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1818,12 +7903,11 @@ func (m ListCollectionsForHeightRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListCollectionsForHeightRequestMultiError) AllErrors() []error { return m }
+func (m GetHeightForSealRequestMultiError) AllErrors() []error { return m }
 
-// ListCollectionsForHeightRequestValidationError is the validation error
-// returned by ListCollectionsForHeightRequest.Validate if the designated
-// constraints aren't met.
-type ListCollectionsForHeightRequestValidationError struct {
+// GetHeightForSealRequestValidationError is the validation error returned by
+// GetHeightForSealRequest.Validate if the designated constraints aren't met.
+type GetHeightForSealRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1831,24 +7915,24 @@ type ListCollectionsForHeightRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListCollectionsForHeightRequestValidationError) Field() string { return e.field }
+func (e GetHeightForSealRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListCollectionsForHeightRequestValidationError) Reason() string { return e.reason }
+func (e GetHeightForSealRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListCollectionsForHeightRequestValidationError) Cause() error { return e.cause }
+func (e GetHeightForSealRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListCollectionsForHeightRequestValidationError) Key() bool { return e.key }
+func (e GetHeightForSealRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListCollectionsForHeightRequestValidationError) ErrorName() string {
-	return "ListCollectionsForHeightRequestValidationError"
+func (e GetHeightForSealRequestValidationError) ErrorName() string {
+	return "GetHeightForSealRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListCollectionsForHeightRequestValidationError) Error() string {
+func (e GetHeightForSealRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1860,14 +7944,14 @@ func (e ListCollectionsForHeightRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListCollectionsForHeightRequest.%s: %s%s",
+		"invalid %sGetHeightForSealRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListCollectionsForHeightRequestValidationError{}
+var _ error = GetHeightForSealRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1875,48 +7959,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListCollectionsForHeightRequestValidationError{}
+} = GetHeightForSealRequestValidationError{}
 
-// Validate checks the field values on ListCollectionsForHeightResponse with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the first error encountered is returned, or nil if there are
-// no violations.
-func (m *ListCollectionsForHeightResponse) Validate() error {
+// Validate checks the field values on GetHeightForSealResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetHeightForSealResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListCollectionsForHeightResponse with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the result is a list of violation errors wrapped in
-// ListCollectionsForHeightResponseMultiError, or nil if none found.
-func (m *ListCollectionsForHeightResponse) ValidateAll() error {
+// ValidateAll checks the field values on GetHeightForSealResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetHeightForSealResponseMultiError, or nil if none found.
+func (m *GetHeightForSealResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListCollectionsForHeightResponse) validate(all bool) error {
+func (m *GetHeightForSealResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for SealID
+
 	// no validation rules for Height
 
 	if len(errors) > 0 {
-		return ListCollectionsForHeightResponseMultiError(errors)
+		return GetHeightForSealResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListCollectionsForHeightResponseMultiError is an error wrapping multiple
-// validation errors returned by
-// ListCollectionsForHeightResponse.ValidateAll() if the designated
+// GetHeightForSealResponseMultiError is an error wrapping multiple validation
+// errors returned by GetHeightForSealResponse.ValidateAll() if the designated
 // constraints aren't met.
-type ListCollectionsForHeightResponseMultiError []error
+type GetHeightForSealResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListCollectionsForHeightResponseMultiError) Error() string {
+func (m GetHeightForSealResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1925,12 +8009,11 @@ func (m ListCollectionsForHeightResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListCollectionsForHeightResponseMultiError) AllErrors() []error { return m }
+func (m GetHeightForSealResponseMultiError) AllErrors() []error { return m }
 
-// ListCollectionsForHeightResponseValidationError is the validation error
-// returned by ListCollectionsForHeightResponse.Validate if the designated
-// constraints aren't met.
-type ListCollectionsForHeightResponseValidationError struct {
+// GetHeightForSealResponseValidationError is the validation error returned by
+// GetHeightForSealResponse.Validate if the designated constraints aren't met.
+type GetHeightForSealResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1938,24 +8021,24 @@ type ListCollectionsForHeightResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListCollectionsForHeightResponseValidationError) Field() string { return e.field }
+func (e GetHeightForSealResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListCollectionsForHeightResponseValidationError) Reason() string { return e.reason }
+func (e GetHeightForSealResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListCollectionsForHeightResponseValidationError) Cause() error { return e.cause }
+func (e GetHeightForSealResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListCollectionsForHeightResponseValidationError) Key() bool { return e.key }
+func (e GetHeightForSealResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListCollectionsForHeightResponseValidationError) ErrorName() string {
-	return "ListCollectionsForHeightResponseValidationError"
+func (e GetHeightForSealResponseValidationError) ErrorName() string {
+	return "GetHeightForSealResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListCollectionsForHeightResponseValidationError) Error() string {
+func (e GetHeightForSealResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1967,14 +8050,14 @@ func (e ListCollectionsForHeightResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListCollectionsForHeightResponse.%s: %s%s",
+		"invalid %sGetHeightForSealResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListCollectionsForHeightResponseValidationError{}
+var _ error = GetHeightForSealResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1982,33 +8065,33 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListCollectionsForHeightResponseValidationError{}
+} = GetHeightForSealResponseValidationError{}
 
-// Validate checks the field values on GetGuaranteeRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on GetSealedHeightForBlockRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetGuaranteeRequest) Validate() error {
+func (m *GetSealedHeightForBlockRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetGuaranteeRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetGuaranteeRequestMultiError, or nil if none found.
-func (m *GetGuaranteeRequest) ValidateAll() error {
+// ValidateAll checks the field values on GetSealedHeightForBlockRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetSealedHeightForBlockRequestMultiError, or nil if none found.
+func (m *GetSealedHeightForBlockRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetGuaranteeRequest) validate(all bool) error {
+func (m *GetSealedHeightForBlockRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetCollectionID()) != 32 {
-		err := GetGuaranteeRequestValidationError{
-			field:  "CollectionID",
+	if len(m.GetBlockID()) != 32 {
+		err := GetSealedHeightForBlockRequestValidationError{
+			field:  "BlockID",
 			reason: "value length must be 32 bytes",
 		}
 		if !all {
@@ -2018,19 +8101,19 @@ func (m *GetGuaranteeRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetGuaranteeRequestMultiError(errors)
+		return GetSealedHeightForBlockRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetGuaranteeRequestMultiError is an error wrapping multiple validation
-// errors returned by GetGuaranteeRequest.ValidateAll() if the designated
-// constraints aren't met.
-type GetGuaranteeRequestMultiError []error
+// GetSealedHeightForBlockRequestMultiError is an error wrapping multiple
+// validation errors returned by GetSealedHeightForBlockRequest.ValidateAll()
+// if the designated constraints aren't met.
+type GetSealedHeightForBlockRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetGuaranteeRequestMultiError) Error() string {
+func (m GetSealedHeightForBlockRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2039,11 +8122,12 @@ func (m GetGuaranteeRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetGuaranteeRequestMultiError) AllErrors() []error { return m }
+func (m GetSealedHeightForBlockRequestMultiError) AllErrors() []error { return m }
 
-// GetGuaranteeRequestValidationError is the validation error returned by
-// GetGuaranteeRequest.Validate if the designated constraints aren't met.
-type GetGuaranteeRequestValidationError struct {
+// GetSealedHeightForBlockRequestValidationError is the validation error
+// returned by GetSealedHeightForBlockRequest.Validate if the designated
+// constraints aren't met.
+type GetSealedHeightForBlockRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2051,24 +8135,24 @@ type GetGuaranteeRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetGuaranteeRequestValidationError) Field() string { return e.field }
+func (e GetSealedHeightForBlockRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetGuaranteeRequestValidationError) Reason() string { return e.reason }
+func (e GetSealedHeightForBlockRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetGuaranteeRequestValidationError) Cause() error { return e.cause }
+func (e GetSealedHeightForBlockRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetGuaranteeRequestValidationError) Key() bool { return e.key }
+func (e GetSealedHeightForBlockRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetGuaranteeRequestValidationError) ErrorName() string {
-	return "GetGuaranteeRequestValidationError"
+func (e GetSealedHeightForBlockRequestValidationError) ErrorName() string {
+	return "GetSealedHeightForBlockRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetGuaranteeRequestValidationError) Error() string {
+func (e GetSealedHeightForBlockRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2080,14 +8164,14 @@ func (e GetGuaranteeRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetGuaranteeRequest.%s: %s%s",
+		"invalid %sGetSealedHeightForBlockRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetGuaranteeRequestValidationError{}
+var _ error = GetSealedHeightForBlockRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2095,48 +8179,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetGuaranteeRequestValidationError{}
+} = GetSealedHeightForBlockRequestValidationError{}
 
-// Validate checks the field values on GetGuaranteeResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on GetSealedHeightForBlockResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetGuaranteeResponse) Validate() error {
+func (m *GetSealedHeightForBlockResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetGuaranteeResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetGuaranteeResponseMultiError, or nil if none found.
-func (m *GetGuaranteeResponse) ValidateAll() error {
+// ValidateAll checks the field values on GetSealedHeightForBlockResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetSealedHeightForBlockResponseMultiError, or nil if none found.
+func (m *GetSealedHeightForBlockResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetGuaranteeResponse) validate(all bool) error {
+func (m *GetSealedHeightForBlockResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for CollectionID
+	// no validation rules for BlockID
 
-	// no validation rules for Data
+	// no validation rules for Height
 
 	if len(errors) > 0 {
-		return GetGuaranteeResponseMultiError(errors)
+		return GetSealedHeightForBlockResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetGuaranteeResponseMultiError is an error wrapping multiple validation
-// errors returned by GetGuaranteeResponse.ValidateAll() if the designated
-// constraints aren't met.
-type GetGuaranteeResponseMultiError []error
+// GetSealedHeightForBlockResponseMultiError is an error wrapping multiple
+// validation errors returned by GetSealedHeightForBlockResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetSealedHeightForBlockResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetGuaranteeResponseMultiError) Error() string {
+func (m GetSealedHeightForBlockResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2145,11 +8229,12 @@ func (m GetGuaranteeResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetGuaranteeResponseMultiError) AllErrors() []error { return m }
+func (m GetSealedHeightForBlockResponseMultiError) AllErrors() []error { return m }
 
-// GetGuaranteeResponseValidationError is the validation error returned by
-// GetGuaranteeResponse.Validate if the designated constraints aren't met.
-type GetGuaranteeResponseValidationError struct {
+// GetSealedHeightForBlockResponseValidationError is the validation error
+// returned by GetSealedHeightForBlockResponse.Validate if the designated
+// constraints aren't met.
+type GetSealedHeightForBlockResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2157,24 +8242,24 @@ type GetGuaranteeResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetGuaranteeResponseValidationError) Field() string { return e.field }
+func (e GetSealedHeightForBlockResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetGuaranteeResponseValidationError) Reason() string { return e.reason }
+func (e GetSealedHeightForBlockResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetGuaranteeResponseValidationError) Cause() error { return e.cause }
+func (e GetSealedHeightForBlockResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetGuaranteeResponseValidationError) Key() bool { return e.key }
+func (e GetSealedHeightForBlockResponseValidationError) Key() bool { return e.key }
 
-// ErrorName returns error name.
-func (e GetGuaranteeResponseValidationError) ErrorName() string {
-	return "GetGuaranteeResponseValidationError"
+// ErrorName returns error name.
+func (e GetSealedHeightForBlockResponseValidationError) ErrorName() string {
+	return "GetSealedHeightForBlockResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetGuaranteeResponseValidationError) Error() string {
+func (e GetSealedHeightForBlockResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2186,14 +8271,14 @@ func (e GetGuaranteeResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetGuaranteeResponse.%s: %s%s",
+		"invalid %sGetSealedHeightForBlockResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetGuaranteeResponseValidationError{}
+var _ error = GetSealedHeightForBlockResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2201,34 +8286,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetGuaranteeResponseValidationError{}
+} = GetSealedHeightForBlockResponseValidationError{}
 
-// Validate checks the field values on GetTransactionRequest with the rules
+// Validate checks the field values on ListSealsForHeightRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetTransactionRequest) Validate() error {
+func (m *ListSealsForHeightRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetTransactionRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on ListSealsForHeightRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetTransactionRequestMultiError, or nil if none found.
-func (m *GetTransactionRequest) ValidateAll() error {
+// ListSealsForHeightRequestMultiError, or nil if none found.
+func (m *ListSealsForHeightRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetTransactionRequest) validate(all bool) error {
+func (m *ListSealsForHeightRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetTransactionID()) != 32 {
-		err := GetTransactionRequestValidationError{
-			field:  "TransactionID",
-			reason: "value length must be 32 bytes",
+	if m.GetHeight() <= 0 {
+		err := ListSealsForHeightRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
 		}
 		if !all {
 			return err
@@ -2237,19 +8322,19 @@ func (m *GetTransactionRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetTransactionRequestMultiError(errors)
+		return ListSealsForHeightRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetTransactionRequestMultiError is an error wrapping multiple validation
-// errors returned by GetTransactionRequest.ValidateAll() if the designated
-// constraints aren't met.
-type GetTransactionRequestMultiError []error
+// ListSealsForHeightRequestMultiError is an error wrapping multiple validation
+// errors returned by ListSealsForHeightRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ListSealsForHeightRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetTransactionRequestMultiError) Error() string {
+func (m ListSealsForHeightRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2258,11 +8343,11 @@ func (m GetTransactionRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetTransactionRequestMultiError) AllErrors() []error { return m }
+func (m ListSealsForHeightRequestMultiError) AllErrors() []error { return m }
 
-// GetTransactionRequestValidationError is the validation error returned by
-// GetTransactionRequest.Validate if the designated constraints aren't met.
-type GetTransactionRequestValidationError struct {
+// ListSealsForHeightRequestValidationError is the validation error returned by
+// ListSealsForHeightRequest.Validate if the designated constraints aren't met.
+type ListSealsForHeightRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2270,24 +8355,24 @@ type GetTransactionRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetTransactionRequestValidationError) Field() string { return e.field }
+func (e ListSealsForHeightRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetTransactionRequestValidationError) Reason() string { return e.reason }
+func (e ListSealsForHeightRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetTransactionRequestValidationError) Cause() error { return e.cause }
+func (e ListSealsForHeightRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetTransactionRequestValidationError) Key() bool { return e.key }
+func (e ListSealsForHeightRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetTransactionRequestValidationError) ErrorName() string {
-	return "GetTransactionRequestValidationError"
+func (e ListSealsForHeightRequestValidationError) ErrorName() string {
+	return "ListSealsForHeightRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetTransactionRequestValidationError) Error() string {
+func (e ListSealsForHeightRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2299,14 +8384,14 @@ func (e GetTransactionRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetTransactionRequest.%s: %s%s",
+		"invalid %sListSealsForHeightRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetTransactionRequestValidationError{}
+var _ error = ListSealsForHeightRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2314,48 +8399,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetTransactionRequestValidationError{}
+} = ListSealsForHeightRequestValidationError{}
 
-// Validate checks the field values on GetTransactionResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on ListSealsForHeightResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetTransactionResponse) Validate() error {
+func (m *ListSealsForHeightResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetTransactionResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on ListSealsForHeightResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetTransactionResponseMultiError, or nil if none found.
-func (m *GetTransactionResponse) ValidateAll() error {
+// ListSealsForHeightResponseMultiError, or nil if none found.
+func (m *ListSealsForHeightResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetTransactionResponse) validate(all bool) error {
+func (m *ListSealsForHeightResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for TransactionID
-
-	// no validation rules for Data
+	// no validation rules for Height
 
 	if len(errors) > 0 {
-		return GetTransactionResponseMultiError(errors)
+		return ListSealsForHeightResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetTransactionResponseMultiError is an error wrapping multiple validation
-// errors returned by GetTransactionResponse.ValidateAll() if the designated
-// constraints aren't met.
-type GetTransactionResponseMultiError []error
+// ListSealsForHeightResponseMultiError is an error wrapping multiple
+// validation errors returned by ListSealsForHeightResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListSealsForHeightResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetTransactionResponseMultiError) Error() string {
+func (m ListSealsForHeightResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2364,11 +8447,11 @@ func (m GetTransactionResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetTransactionResponseMultiError) AllErrors() []error { return m }
+func (m ListSealsForHeightResponseMultiError) AllErrors() []error { return m }
 
-// GetTransactionResponseValidationError is the validation error returned by
-// GetTransactionResponse.Validate if the designated constraints aren't met.
-type GetTransactionResponseValidationError struct {
+// ListSealsForHeightResponseValidationError is the validation error returned
+// by ListSealsForHeightResponse.Validate if the designated constraints aren't met.
+type ListSealsForHeightResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2376,24 +8459,24 @@ type GetTransactionResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetTransactionResponseValidationError) Field() string { return e.field }
+func (e ListSealsForHeightResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetTransactionResponseValidationError) Reason() string { return e.reason }
+func (e ListSealsForHeightResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetTransactionResponseValidationError) Cause() error { return e.cause }
+func (e ListSealsForHeightResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetTransactionResponseValidationError) Key() bool { return e.key }
+func (e ListSealsForHeightResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetTransactionResponseValidationError) ErrorName() string {
-	return "GetTransactionResponseValidationError"
+func (e ListSealsForHeightResponseValidationError) ErrorName() string {
+	return "ListSealsForHeightResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetTransactionResponseValidationError) Error() string {
+func (e ListSealsForHeightResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2405,14 +8488,14 @@ func (e GetTransactionResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetTransactionResponse.%s: %s%s",
+		"invalid %sListSealsForHeightResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetTransactionResponseValidationError{}
+var _ error = ListSealsForHeightResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2420,34 +8503,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetTransactionResponseValidationError{}
+} = ListSealsForHeightResponseValidationError{}
 
-// Validate checks the field values on GetHeightForTransactionRequest with the
+// Validate checks the field values on ListRegistersForHeightRequest with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetHeightForTransactionRequest) Validate() error {
+func (m *ListRegistersForHeightRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetHeightForTransactionRequest with
+// ValidateAll checks the field values on ListRegistersForHeightRequest with
 // the rules defined in the proto definition for this message. If any rules
 // are violated, the result is a list of violation errors wrapped in
-// GetHeightForTransactionRequestMultiError, or nil if none found.
-func (m *GetHeightForTransactionRequest) ValidateAll() error {
+// ListRegistersForHeightRequestMultiError, or nil if none found.
+func (m *ListRegistersForHeightRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetHeightForTransactionRequest) validate(all bool) error {
+func (m *ListRegistersForHeightRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetTransactionID()) != 32 {
-		err := GetHeightForTransactionRequestValidationError{
-			field:  "TransactionID",
-			reason: "value length must be 32 bytes",
+	if m.GetHeight() <= 0 {
+		err := ListRegistersForHeightRequestValidationError{
+			field:  "Height",
+			reason: "value must be greater than 0",
 		}
 		if !all {
 			return err
@@ -2455,20 +8538,26 @@ func (m *GetHeightForTransactionRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for IncludeValues
+
+	// no validation rules for StartIndex
+
+	// no validation rules for Limit
+
 	if len(errors) > 0 {
-		return GetHeightForTransactionRequestMultiError(errors)
+		return ListRegistersForHeightRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetHeightForTransactionRequestMultiError is an error wrapping multiple
-// validation errors returned by GetHeightForTransactionRequest.ValidateAll()
+// ListRegistersForHeightRequestMultiError is an error wrapping multiple
+// validation errors returned by ListRegistersForHeightRequest.ValidateAll()
 // if the designated constraints aren't met.
-type GetHeightForTransactionRequestMultiError []error
+type ListRegistersForHeightRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetHeightForTransactionRequestMultiError) Error() string {
+func (m ListRegistersForHeightRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2477,12 +8566,12 @@ func (m GetHeightForTransactionRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetHeightForTransactionRequestMultiError) AllErrors() []error { return m }
+func (m ListRegistersForHeightRequestMultiError) AllErrors() []error { return m }
 
-// GetHeightForTransactionRequestValidationError is the validation error
-// returned by GetHeightForTransactionRequest.Validate if the designated
+// ListRegistersForHeightRequestValidationError is the validation error
+// returned by ListRegistersForHeightRequest.Validate if the designated
 // constraints aren't met.
-type GetHeightForTransactionRequestValidationError struct {
+type ListRegistersForHeightRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2490,24 +8579,24 @@ type GetHeightForTransactionRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetHeightForTransactionRequestValidationError) Field() string { return e.field }
+func (e ListRegistersForHeightRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetHeightForTransactionRequestValidationError) Reason() string { return e.reason }
+func (e ListRegistersForHeightRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetHeightForTransactionRequestValidationError) Cause() error { return e.cause }
+func (e ListRegistersForHeightRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetHeightForTransactionRequestValidationError) Key() bool { return e.key }
+func (e ListRegistersForHeightRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetHeightForTransactionRequestValidationError) ErrorName() string {
-	return "GetHeightForTransactionRequestValidationError"
+func (e ListRegistersForHeightRequestValidationError) ErrorName() string {
+	return "ListRegistersForHeightRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetHeightForTransactionRequestValidationError) Error() string {
+func (e ListRegistersForHeightRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2519,14 +8608,14 @@ func (e GetHeightForTransactionRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetHeightForTransactionRequest.%s: %s%s",
+		"invalid %sListRegistersForHeightRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetHeightForTransactionRequestValidationError{}
+var _ error = ListRegistersForHeightRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2534,48 +8623,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetHeightForTransactionRequestValidationError{}
+} = ListRegistersForHeightRequestValidationError{}
 
-// Validate checks the field values on GetHeightForTransactionResponse with the
+// Validate checks the field values on ListRegistersForHeightResponse with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetHeightForTransactionResponse) Validate() error {
+func (m *ListRegistersForHeightResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetHeightForTransactionResponse with
+// ValidateAll checks the field values on ListRegistersForHeightResponse with
 // the rules defined in the proto definition for this message. If any rules
 // are violated, the result is a list of violation errors wrapped in
-// GetHeightForTransactionResponseMultiError, or nil if none found.
-func (m *GetHeightForTransactionResponse) ValidateAll() error {
+// ListRegistersForHeightResponseMultiError, or nil if none found.
+func (m *ListRegistersForHeightResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetHeightForTransactionResponse) validate(all bool) error {
+func (m *ListRegistersForHeightResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for TransactionID
-
 	// no validation rules for Height
 
+	// no validation rules for NextIndex
+
 	if len(errors) > 0 {
-		return GetHeightForTransactionResponseMultiError(errors)
+		return ListRegistersForHeightResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetHeightForTransactionResponseMultiError is an error wrapping multiple
-// validation errors returned by GetHeightForTransactionResponse.ValidateAll()
+// ListRegistersForHeightResponseMultiError is an error wrapping multiple
+// validation errors returned by ListRegistersForHeightResponse.ValidateAll()
 // if the designated constraints aren't met.
-type GetHeightForTransactionResponseMultiError []error
+type ListRegistersForHeightResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetHeightForTransactionResponseMultiError) Error() string {
+func (m ListRegistersForHeightResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2584,12 +8673,12 @@ func (m GetHeightForTransactionResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetHeightForTransactionResponseMultiError) AllErrors() []error { return m }
+func (m ListRegistersForHeightResponseMultiError) AllErrors() []error { return m }
 
-// GetHeightForTransactionResponseValidationError is the validation error
-// returned by GetHeightForTransactionResponse.Validate if the designated
+// ListRegistersForHeightResponseValidationError is the validation error
+// returned by ListRegistersForHeightResponse.Validate if the designated
 // constraints aren't met.
-type GetHeightForTransactionResponseValidationError struct {
+type ListRegistersForHeightResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2597,24 +8686,24 @@ type GetHeightForTransactionResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetHeightForTransactionResponseValidationError) Field() string { return e.field }
+func (e ListRegistersForHeightResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetHeightForTransactionResponseValidationError) Reason() string { return e.reason }
+func (e ListRegistersForHeightResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetHeightForTransactionResponseValidationError) Cause() error { return e.cause }
+func (e ListRegistersForHeightResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetHeightForTransactionResponseValidationError) Key() bool { return e.key }
+func (e ListRegistersForHeightResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetHeightForTransactionResponseValidationError) ErrorName() string {
-	return "GetHeightForTransactionResponseValidationError"
+func (e ListRegistersForHeightResponseValidationError) ErrorName() string {
+	return "ListRegistersForHeightResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetHeightForTransactionResponseValidationError) Error() string {
+func (e ListRegistersForHeightResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2626,14 +8715,14 @@ func (e GetHeightForTransactionResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetHeightForTransactionResponse.%s: %s%s",
+		"invalid %sListRegistersForHeightResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetHeightForTransactionResponseValidationError{}
+var _ error = ListRegistersForHeightResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2641,25 +8730,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetHeightForTransactionResponseValidationError{}
+} = ListRegistersForHeightResponseValidationError{}
 
-// Validate checks the field values on ListTransactionsForHeightRequest with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the first error encountered is returned, or nil if there are
-// no violations.
-func (m *ListTransactionsForHeightRequest) Validate() error {
+// Validate checks the field values on ListRegistersForOwnerRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListRegistersForOwnerRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListTransactionsForHeightRequest with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the result is a list of violation errors wrapped in
-// ListTransactionsForHeightRequestMultiError, or nil if none found.
-func (m *ListTransactionsForHeightRequest) ValidateAll() error {
+// ValidateAll checks the field values on ListRegistersForOwnerRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRegistersForOwnerRequestMultiError, or nil if none found.
+func (m *ListRegistersForOwnerRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListTransactionsForHeightRequest) validate(all bool) error {
+func (m *ListRegistersForOwnerRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -2667,7 +8755,7 @@ func (m *ListTransactionsForHeightRequest) validate(all bool) error {
 	var errors []error
 
 	if m.GetHeight() <= 0 {
-		err := ListTransactionsForHeightRequestValidationError{
+		err := ListRegistersForOwnerRequestValidationError{
 			field:  "Height",
 			reason: "value must be greater than 0",
 		}
@@ -2677,21 +8765,22 @@ func (m *ListTransactionsForHeightRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Owner
+
 	if len(errors) > 0 {
-		return ListTransactionsForHeightRequestMultiError(errors)
+		return ListRegistersForOwnerRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListTransactionsForHeightRequestMultiError is an error wrapping multiple
-// validation errors returned by
-// ListTransactionsForHeightRequest.ValidateAll() if the designated
-// constraints aren't met.
-type ListTransactionsForHeightRequestMultiError []error
+// ListRegistersForOwnerRequestMultiError is an error wrapping multiple
+// validation errors returned by ListRegistersForOwnerRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ListRegistersForOwnerRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListTransactionsForHeightRequestMultiError) Error() string {
+func (m ListRegistersForOwnerRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2700,12 +8789,12 @@ func (m ListTransactionsForHeightRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListTransactionsForHeightRequestMultiError) AllErrors() []error { return m }
+func (m ListRegistersForOwnerRequestMultiError) AllErrors() []error { return m }
 
-// ListTransactionsForHeightRequestValidationError is the validation error
-// returned by ListTransactionsForHeightRequest.Validate if the designated
-// constraints aren't met.
-type ListTransactionsForHeightRequestValidationError struct {
+// ListRegistersForOwnerRequestValidationError is the validation error returned
+// by ListRegistersForOwnerRequest.Validate if the designated constraints
+// aren't met.
+type ListRegistersForOwnerRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2713,24 +8802,24 @@ type ListTransactionsForHeightRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListTransactionsForHeightRequestValidationError) Field() string { return e.field }
+func (e ListRegistersForOwnerRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListTransactionsForHeightRequestValidationError) Reason() string { return e.reason }
+func (e ListRegistersForOwnerRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListTransactionsForHeightRequestValidationError) Cause() error { return e.cause }
+func (e ListRegistersForOwnerRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListTransactionsForHeightRequestValidationError) Key() bool { return e.key }
+func (e ListRegistersForOwnerRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListTransactionsForHeightRequestValidationError) ErrorName() string {
-	return "ListTransactionsForHeightRequestValidationError"
+func (e ListRegistersForOwnerRequestValidationError) ErrorName() string {
+	return "ListRegistersForOwnerRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListTransactionsForHeightRequestValidationError) Error() string {
+func (e ListRegistersForOwnerRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2742,14 +8831,14 @@ func (e ListTransactionsForHeightRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListTransactionsForHeightRequest.%s: %s%s",
+		"invalid %sListRegistersForOwnerRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListTransactionsForHeightRequestValidationError{}
+var _ error = ListRegistersForOwnerRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2757,25 +8846,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListTransactionsForHeightRequestValidationError{}
+} = ListRegistersForOwnerRequestValidationError{}
 
-// Validate checks the field values on ListTransactionsForHeightResponse with
-// the rules defined in the proto definition for this message. If any rules
-// are violated, the first error encountered is returned, or nil if there are
-// no violations.
-func (m *ListTransactionsForHeightResponse) Validate() error {
+// Validate checks the field values on ListRegistersForOwnerResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListRegistersForOwnerResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListTransactionsForHeightResponse
-// with the rules defined in the proto definition for this message. If any
-// rules are violated, the result is a list of violation errors wrapped in
-// ListTransactionsForHeightResponseMultiError, or nil if none found.
-func (m *ListTransactionsForHeightResponse) ValidateAll() error {
+// ValidateAll checks the field values on ListRegistersForOwnerResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListRegistersForOwnerResponseMultiError, or nil if none found.
+func (m *ListRegistersForOwnerResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListTransactionsForHeightResponse) validate(all bool) error {
+func (m *ListRegistersForOwnerResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -2785,20 +8873,19 @@ func (m *ListTransactionsForHeightResponse) validate(all bool) error {
 	// no validation rules for Height
 
 	if len(errors) > 0 {
-		return ListTransactionsForHeightResponseMultiError(errors)
+		return ListRegistersForOwnerResponseMultiError(errors)
 	}
 
-	return nil
-}
-
-// ListTransactionsForHeightResponseMultiError is an error wrapping multiple
-// validation errors returned by
-// ListTransactionsForHeightResponse.ValidateAll() if the designated
-// constraints aren't met.
-type ListTransactionsForHeightResponseMultiError []error
-
+	return nil
+}
+
+// ListRegistersForOwnerResponseMultiError is an error wrapping multiple
+// validation errors returned by ListRegistersForOwnerResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListRegistersForOwnerResponseMultiError []error
+
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListTransactionsForHeightResponseMultiError) Error() string {
+func (m ListRegistersForOwnerResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2807,12 +8894,12 @@ func (m ListTransactionsForHeightResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListTransactionsForHeightResponseMultiError) AllErrors() []error { return m }
+func (m ListRegistersForOwnerResponseMultiError) AllErrors() []error { return m }
 
-// ListTransactionsForHeightResponseValidationError is the validation error
-// returned by ListTransactionsForHeightResponse.Validate if the designated
+// ListRegistersForOwnerResponseValidationError is the validation error
+// returned by ListRegistersForOwnerResponse.Validate if the designated
 // constraints aren't met.
-type ListTransactionsForHeightResponseValidationError struct {
+type ListRegistersForOwnerResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2820,24 +8907,24 @@ type ListTransactionsForHeightResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListTransactionsForHeightResponseValidationError) Field() string { return e.field }
+func (e ListRegistersForOwnerResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListTransactionsForHeightResponseValidationError) Reason() string { return e.reason }
+func (e ListRegistersForOwnerResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListTransactionsForHeightResponseValidationError) Cause() error { return e.cause }
+func (e ListRegistersForOwnerResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListTransactionsForHeightResponseValidationError) Key() bool { return e.key }
+func (e ListRegistersForOwnerResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListTransactionsForHeightResponseValidationError) ErrorName() string {
-	return "ListTransactionsForHeightResponseValidationError"
+func (e ListRegistersForOwnerResponseValidationError) ErrorName() string {
+	return "ListRegistersForOwnerResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListTransactionsForHeightResponseValidationError) Error() string {
+func (e ListRegistersForOwnerResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2849,14 +8936,14 @@ func (e ListTransactionsForHeightResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListTransactionsForHeightResponse.%s: %s%s",
+		"invalid %sListRegistersForOwnerResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListTransactionsForHeightResponseValidationError{}
+var _ error = ListRegistersForOwnerResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2864,34 +8951,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListTransactionsForHeightResponseValidationError{}
+} = ListRegistersForOwnerResponseValidationError{}
 
-// Validate checks the field values on GetResultRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetResultRequest) Validate() error {
+// Validate checks the field values on SubscribeHeadersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SubscribeHeadersRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetResultRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on SubscribeHeadersRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetResultRequestMultiError, or nil if none found.
-func (m *GetResultRequest) ValidateAll() error {
+// SubscribeHeadersRequestMultiError, or nil if none found.
+func (m *SubscribeHeadersRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetResultRequest) validate(all bool) error {
+func (m *SubscribeHeadersRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetTransactionID()) != 32 {
-		err := GetResultRequestValidationError{
-			field:  "TransactionID",
-			reason: "value length must be 32 bytes",
+	if m.GetStartHeight() <= 0 {
+		err := SubscribeHeadersRequestValidationError{
+			field:  "StartHeight",
+			reason: "value must be greater than 0",
 		}
 		if !all {
 			return err
@@ -2900,19 +8987,19 @@ func (m *GetResultRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetResultRequestMultiError(errors)
+		return SubscribeHeadersRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetResultRequestMultiError is an error wrapping multiple validation errors
-// returned by GetResultRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetResultRequestMultiError []error
+// SubscribeHeadersRequestMultiError is an error wrapping multiple validation
+// errors returned by SubscribeHeadersRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SubscribeHeadersRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetResultRequestMultiError) Error() string {
+func (m SubscribeHeadersRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2921,11 +9008,11 @@ func (m GetResultRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetResultRequestMultiError) AllErrors() []error { return m }
+func (m SubscribeHeadersRequestMultiError) AllErrors() []error { return m }
 
-// GetResultRequestValidationError is the validation error returned by
-// GetResultRequest.Validate if the designated constraints aren't met.
-type GetResultRequestValidationError struct {
+// SubscribeHeadersRequestValidationError is the validation error returned by
+// SubscribeHeadersRequest.Validate if the designated constraints aren't met.
+type SubscribeHeadersRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2933,22 +9020,24 @@ type GetResultRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetResultRequestValidationError) Field() string { return e.field }
+func (e SubscribeHeadersRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetResultRequestValidationError) Reason() string { return e.reason }
+func (e SubscribeHeadersRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetResultRequestValidationError) Cause() error { return e.cause }
+func (e SubscribeHeadersRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetResultRequestValidationError) Key() bool { return e.key }
+func (e SubscribeHeadersRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetResultRequestValidationError) ErrorName() string { return "GetResultRequestValidationError" }
+func (e SubscribeHeadersRequestValidationError) ErrorName() string {
+	return "SubscribeHeadersRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetResultRequestValidationError) Error() string {
+func (e SubscribeHeadersRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2960,14 +9049,14 @@ func (e GetResultRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetResultRequest.%s: %s%s",
+		"invalid %sSubscribeHeadersRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetResultRequestValidationError{}
+var _ error = SubscribeHeadersRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2975,48 +9064,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetResultRequestValidationError{}
+} = SubscribeHeadersRequestValidationError{}
 
-// Validate checks the field values on GetResultResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetResultResponse) Validate() error {
+// Validate checks the field values on SubscribeHeadersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SubscribeHeadersResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetResultResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on SubscribeHeadersResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetResultResponseMultiError, or nil if none found.
-func (m *GetResultResponse) ValidateAll() error {
+// SubscribeHeadersResponseMultiError, or nil if none found.
+func (m *SubscribeHeadersResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetResultResponse) validate(all bool) error {
+func (m *SubscribeHeadersResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for TransactionID
+	// no validation rules for Height
 
 	// no validation rules for Data
 
 	if len(errors) > 0 {
-		return GetResultResponseMultiError(errors)
+		return SubscribeHeadersResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetResultResponseMultiError is an error wrapping multiple validation errors
-// returned by GetResultResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetResultResponseMultiError []error
+// SubscribeHeadersResponseMultiError is an error wrapping multiple validation
+// errors returned by SubscribeHeadersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SubscribeHeadersResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetResultResponseMultiError) Error() string {
+func (m SubscribeHeadersResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3025,11 +9114,11 @@ func (m GetResultResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetResultResponseMultiError) AllErrors() []error { return m }
+func (m SubscribeHeadersResponseMultiError) AllErrors() []error { return m }
 
-// GetResultResponseValidationError is the validation error returned by
-// GetResultResponse.Validate if the designated constraints aren't met.
-type GetResultResponseValidationError struct {
+// SubscribeHeadersResponseValidationError is the validation error returned by
+// SubscribeHeadersResponse.Validate if the designated constraints aren't met.
+type SubscribeHeadersResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3037,24 +9126,24 @@ type GetResultResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetResultResponseValidationError) Field() string { return e.field }
+func (e SubscribeHeadersResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetResultResponseValidationError) Reason() string { return e.reason }
+func (e SubscribeHeadersResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetResultResponseValidationError) Cause() error { return e.cause }
+func (e SubscribeHeadersResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetResultResponseValidationError) Key() bool { return e.key }
+func (e SubscribeHeadersResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetResultResponseValidationError) ErrorName() string {
-	return "GetResultResponseValidationError"
+func (e SubscribeHeadersResponseValidationError) ErrorName() string {
+	return "SubscribeHeadersResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetResultResponseValidationError) Error() string {
+func (e SubscribeHeadersResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3066,14 +9155,14 @@ func (e GetResultResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetResultResponse.%s: %s%s",
+		"invalid %sSubscribeHeadersResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetResultResponseValidationError{}
+var _ error = SubscribeHeadersResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3081,34 +9170,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetResultResponseValidationError{}
+} = SubscribeHeadersResponseValidationError{}
 
-// Validate checks the field values on GetSealRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *GetSealRequest) Validate() error {
+// Validate checks the field values on SubscribeEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SubscribeEventsRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetSealRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in GetSealRequestMultiError,
-// or nil if none found.
-func (m *GetSealRequest) ValidateAll() error {
+// ValidateAll checks the field values on SubscribeEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SubscribeEventsRequestMultiError, or nil if none found.
+func (m *SubscribeEventsRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetSealRequest) validate(all bool) error {
+func (m *SubscribeEventsRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if len(m.GetSealID()) != 32 {
-		err := GetSealRequestValidationError{
-			field:  "SealID",
-			reason: "value length must be 32 bytes",
+	if m.GetStartHeight() <= 0 {
+		err := SubscribeEventsRequestValidationError{
+			field:  "StartHeight",
+			reason: "value must be greater than 0",
 		}
 		if !all {
 			return err
@@ -3117,19 +9206,19 @@ func (m *GetSealRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetSealRequestMultiError(errors)
+		return SubscribeEventsRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetSealRequestMultiError is an error wrapping multiple validation errors
-// returned by GetSealRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetSealRequestMultiError []error
+// SubscribeEventsRequestMultiError is an error wrapping multiple validation
+// errors returned by SubscribeEventsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SubscribeEventsRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetSealRequestMultiError) Error() string {
+func (m SubscribeEventsRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3138,11 +9227,11 @@ func (m GetSealRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetSealRequestMultiError) AllErrors() []error { return m }
+func (m SubscribeEventsRequestMultiError) AllErrors() []error { return m }
 
-// GetSealRequestValidationError is the validation error returned by
-// GetSealRequest.Validate if the designated constraints aren't met.
-type GetSealRequestValidationError struct {
+// SubscribeEventsRequestValidationError is the validation error returned by
+// SubscribeEventsRequest.Validate if the designated constraints aren't met.
+type SubscribeEventsRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3150,22 +9239,24 @@ type GetSealRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetSealRequestValidationError) Field() string { return e.field }
+func (e SubscribeEventsRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetSealRequestValidationError) Reason() string { return e.reason }
+func (e SubscribeEventsRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetSealRequestValidationError) Cause() error { return e.cause }
+func (e SubscribeEventsRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetSealRequestValidationError) Key() bool { return e.key }
+func (e SubscribeEventsRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetSealRequestValidationError) ErrorName() string { return "GetSealRequestValidationError" }
+func (e SubscribeEventsRequestValidationError) ErrorName() string {
+	return "SubscribeEventsRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetSealRequestValidationError) Error() string {
+func (e SubscribeEventsRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3177,14 +9268,14 @@ func (e GetSealRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetSealRequest.%s: %s%s",
+		"invalid %sSubscribeEventsRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetSealRequestValidationError{}
+var _ error = SubscribeEventsRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3192,48 +9283,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetSealRequestValidationError{}
+} = SubscribeEventsRequestValidationError{}
 
-// Validate checks the field values on GetSealResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetSealResponse) Validate() error {
+// Validate checks the field values on SubscribeEventsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SubscribeEventsResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetSealResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on SubscribeEventsResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetSealResponseMultiError, or nil if none found.
-func (m *GetSealResponse) ValidateAll() error {
+// SubscribeEventsResponseMultiError, or nil if none found.
+func (m *SubscribeEventsResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetSealResponse) validate(all bool) error {
+func (m *SubscribeEventsResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for SealID
+	// no validation rules for Height
 
 	// no validation rules for Data
 
 	if len(errors) > 0 {
-		return GetSealResponseMultiError(errors)
+		return SubscribeEventsResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetSealResponseMultiError is an error wrapping multiple validation errors
-// returned by GetSealResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetSealResponseMultiError []error
+// SubscribeEventsResponseMultiError is an error wrapping multiple validation
+// errors returned by SubscribeEventsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SubscribeEventsResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetSealResponseMultiError) Error() string {
+func (m SubscribeEventsResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3242,11 +9333,11 @@ func (m GetSealResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetSealResponseMultiError) AllErrors() []error { return m }
+func (m SubscribeEventsResponseMultiError) AllErrors() []error { return m }
 
-// GetSealResponseValidationError is the validation error returned by
-// GetSealResponse.Validate if the designated constraints aren't met.
-type GetSealResponseValidationError struct {
+// SubscribeEventsResponseValidationError is the validation error returned by
+// SubscribeEventsResponse.Validate if the designated constraints aren't met.
+type SubscribeEventsResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3254,22 +9345,24 @@ type GetSealResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetSealResponseValidationError) Field() string { return e.field }
+func (e SubscribeEventsResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetSealResponseValidationError) Reason() string { return e.reason }
+func (e SubscribeEventsResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetSealResponseValidationError) Cause() error { return e.cause }
+func (e SubscribeEventsResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetSealResponseValidationError) Key() bool { return e.key }
+func (e SubscribeEventsResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetSealResponseValidationError) ErrorName() string { return "GetSealResponseValidationError" }
+func (e SubscribeEventsResponseValidationError) ErrorName() string {
+	return "SubscribeEventsResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetSealResponseValidationError) Error() string {
+func (e SubscribeEventsResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3281,14 +9374,14 @@ func (e GetSealResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetSealResponse.%s: %s%s",
+		"invalid %sSubscribeEventsResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetSealResponseValidationError{}
+var _ error = SubscribeEventsResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3296,33 +9389,33 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetSealResponseValidationError{}
+} = SubscribeEventsResponseValidationError{}
 
-// Validate checks the field values on ListSealsForHeightRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on SubscribeRegisterChangesRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListSealsForHeightRequest) Validate() error {
+func (m *SubscribeRegisterChangesRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListSealsForHeightRequest with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// ListSealsForHeightRequestMultiError, or nil if none found.
-func (m *ListSealsForHeightRequest) ValidateAll() error {
+// ValidateAll checks the field values on SubscribeRegisterChangesRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// SubscribeRegisterChangesRequestMultiError, or nil if none found.
+func (m *SubscribeRegisterChangesRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListSealsForHeightRequest) validate(all bool) error {
+func (m *SubscribeRegisterChangesRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetHeight() <= 0 {
-		err := ListSealsForHeightRequestValidationError{
-			field:  "Height",
+	if m.GetStartHeight() <= 0 {
+		err := SubscribeRegisterChangesRequestValidationError{
+			field:  "StartHeight",
 			reason: "value must be greater than 0",
 		}
 		if !all {
@@ -3331,20 +9424,47 @@ func (m *ListSealsForHeightRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if len(m.GetOwners()) < 1 {
+		err := SubscribeRegisterChangesRequestValidationError{
+			field:  "Owners",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetOwners() {
+		_, _ = idx, item
+
+		if len(item) != 8 {
+			err := SubscribeRegisterChangesRequestValidationError{
+				field:  fmt.Sprintf("Owners[%v]", idx),
+				reason: "value length must be 8 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
 	if len(errors) > 0 {
-		return ListSealsForHeightRequestMultiError(errors)
+		return SubscribeRegisterChangesRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListSealsForHeightRequestMultiError is an error wrapping multiple validation
-// errors returned by ListSealsForHeightRequest.ValidateAll() if the
-// designated constraints aren't met.
-type ListSealsForHeightRequestMultiError []error
+// SubscribeRegisterChangesRequestMultiError is an error wrapping multiple
+// validation errors returned by SubscribeRegisterChangesRequest.ValidateAll()
+// if the designated constraints aren't met.
+type SubscribeRegisterChangesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListSealsForHeightRequestMultiError) Error() string {
+func (m SubscribeRegisterChangesRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3353,11 +9473,12 @@ func (m ListSealsForHeightRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListSealsForHeightRequestMultiError) AllErrors() []error { return m }
+func (m SubscribeRegisterChangesRequestMultiError) AllErrors() []error { return m }
 
-// ListSealsForHeightRequestValidationError is the validation error returned by
-// ListSealsForHeightRequest.Validate if the designated constraints aren't met.
-type ListSealsForHeightRequestValidationError struct {
+// SubscribeRegisterChangesRequestValidationError is the validation error
+// returned by SubscribeRegisterChangesRequest.Validate if the designated
+// constraints aren't met.
+type SubscribeRegisterChangesRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3365,24 +9486,24 @@ type ListSealsForHeightRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListSealsForHeightRequestValidationError) Field() string { return e.field }
+func (e SubscribeRegisterChangesRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListSealsForHeightRequestValidationError) Reason() string { return e.reason }
+func (e SubscribeRegisterChangesRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListSealsForHeightRequestValidationError) Cause() error { return e.cause }
+func (e SubscribeRegisterChangesRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListSealsForHeightRequestValidationError) Key() bool { return e.key }
+func (e SubscribeRegisterChangesRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListSealsForHeightRequestValidationError) ErrorName() string {
-	return "ListSealsForHeightRequestValidationError"
+func (e SubscribeRegisterChangesRequestValidationError) ErrorName() string {
+	return "SubscribeRegisterChangesRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListSealsForHeightRequestValidationError) Error() string {
+func (e SubscribeRegisterChangesRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3394,14 +9515,14 @@ func (e ListSealsForHeightRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListSealsForHeightRequest.%s: %s%s",
+		"invalid %sSubscribeRegisterChangesRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListSealsForHeightRequestValidationError{}
+var _ error = SubscribeRegisterChangesRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3409,24 +9530,25 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListSealsForHeightRequestValidationError{}
+} = SubscribeRegisterChangesRequestValidationError{}
 
-// Validate checks the field values on ListSealsForHeightResponse with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListSealsForHeightResponse) Validate() error {
+// Validate checks the field values on SubscribeRegisterChangesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *SubscribeRegisterChangesResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListSealsForHeightResponse with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// ListSealsForHeightResponseMultiError, or nil if none found.
-func (m *ListSealsForHeightResponse) ValidateAll() error {
+// ValidateAll checks the field values on SubscribeRegisterChangesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// SubscribeRegisterChangesResponseMultiError, or nil if none found.
+func (m *SubscribeRegisterChangesResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListSealsForHeightResponse) validate(all bool) error {
+func (m *SubscribeRegisterChangesResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -3436,19 +9558,20 @@ func (m *ListSealsForHeightResponse) validate(all bool) error {
 	// no validation rules for Height
 
 	if len(errors) > 0 {
-		return ListSealsForHeightResponseMultiError(errors)
+		return SubscribeRegisterChangesResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListSealsForHeightResponseMultiError is an error wrapping multiple
-// validation errors returned by ListSealsForHeightResponse.ValidateAll() if
-// the designated constraints aren't met.
-type ListSealsForHeightResponseMultiError []error
+// SubscribeRegisterChangesResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// SubscribeRegisterChangesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SubscribeRegisterChangesResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListSealsForHeightResponseMultiError) Error() string {
+func (m SubscribeRegisterChangesResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3457,11 +9580,12 @@ func (m ListSealsForHeightResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListSealsForHeightResponseMultiError) AllErrors() []error { return m }
+func (m SubscribeRegisterChangesResponseMultiError) AllErrors() []error { return m }
 
-// ListSealsForHeightResponseValidationError is the validation error returned
-// by ListSealsForHeightResponse.Validate if the designated constraints aren't met.
-type ListSealsForHeightResponseValidationError struct {
+// SubscribeRegisterChangesResponseValidationError is the validation error
+// returned by SubscribeRegisterChangesResponse.Validate if the designated
+// constraints aren't met.
+type SubscribeRegisterChangesResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3469,24 +9593,24 @@ type ListSealsForHeightResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListSealsForHeightResponseValidationError) Field() string { return e.field }
+func (e SubscribeRegisterChangesResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListSealsForHeightResponseValidationError) Reason() string { return e.reason }
+func (e SubscribeRegisterChangesResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListSealsForHeightResponseValidationError) Cause() error { return e.cause }
+func (e SubscribeRegisterChangesResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListSealsForHeightResponseValidationError) Key() bool { return e.key }
+func (e SubscribeRegisterChangesResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListSealsForHeightResponseValidationError) ErrorName() string {
-	return "ListSealsForHeightResponseValidationError"
+func (e SubscribeRegisterChangesResponseValidationError) ErrorName() string {
+	return "SubscribeRegisterChangesResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListSealsForHeightResponseValidationError) Error() string {
+func (e SubscribeRegisterChangesResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3498,14 +9622,14 @@ func (e ListSealsForHeightResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListSealsForHeightResponse.%s: %s%s",
+		"invalid %sSubscribeRegisterChangesResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListSealsForHeightResponseValidationError{}
+var _ error = SubscribeRegisterChangesResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3513,4 +9637,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListSealsForHeightResponseValidationError{}
+} = SubscribeRegisterChangesResponseValidationError{}
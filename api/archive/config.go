@@ -1,15 +1,21 @@
 package archive
 
 import (
+	"time"
+
 	"github.com/onflow/flow-archive/service/trace"
 )
 
 var DefaultConfig = Config{
-	tracer: trace.NewNoopTracer(),
+	tracer:       trace.NewNoopTracer(),
+	notifier:     nil,
+	pollInterval: time.Second,
 }
 
 type Config struct {
-	tracer trace.Tracer
+	tracer       trace.Tracer
+	notifier     Notifier
+	pollInterval time.Duration
 }
 
 type Option func(*Config)
@@ -19,3 +25,20 @@ func WithTracer(tracer trace.Tracer) Option {
 		cfg.tracer = tracer
 	}
 }
+
+// WithNotifier makes subscriptions wait on the given notifier for new heights
+// to be indexed, so that they are streamed as soon as they are available. If
+// not set, subscriptions only poll for new heights.
+func WithNotifier(notifier Notifier) Option {
+	return func(cfg *Config) {
+		cfg.notifier = notifier
+	}
+}
+
+// WithPollInterval sets how often subscriptions check for new heights when
+// they are not notified of them.
+func WithPollInterval(interval time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.pollInterval = interval
+	}
+}
@@ -2,9 +2,14 @@ package archive
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/onflow/flow-go/model/flow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/models/convert"
@@ -65,6 +70,22 @@ func (i *Index) LatestRegisterHeight() (uint64, error) {
 	return res.Height, nil
 }
 
+// RegisterRetention returns the retention that register data was pruned with.
+func (i *Index) RegisterRetention() (archive.RegisterRetention, error) {
+	req := GetRegisterRetentionRequest{}
+	res, err := i.client.GetRegisterRetention(context.Background(), &req)
+	if err != nil {
+		return archive.RegisterRetention{}, fmt.Errorf("could not get register retention: %w", err)
+	}
+
+	retention := archive.RegisterRetention{
+		Height:   res.Height,
+		Interval: res.Interval,
+	}
+
+	return retention, nil
+}
+
 // HeightForBlock returns the height of the given blockID.
 func (i *Index) HeightForBlock(blockID flow.Identifier) (uint64, error) {
 
@@ -79,6 +100,23 @@ func (i *Index) HeightForBlock(blockID flow.Identifier) (uint64, error) {
 	return res.Height, nil
 }
 
+// HeightForTimestamp returns the height of the finalized block at the given
+// timestamp. As there is rarely a block at the exact time, the mode selects
+// either the last block at or before it, or the first block at or after it.
+func (i *Index) HeightForTimestamp(timestamp time.Time, mode archive.TimestampMode) (uint64, error) {
+
+	req := GetHeightForTimestampRequest{
+		Timestamp: timestamp.UnixNano(),
+		Mode:      uint32(mode),
+	}
+	res, err := i.client.GetHeightForTimestamp(context.Background(), &req)
+	if err != nil {
+		return 0, fmt.Errorf("could not get height: %w", err)
+	}
+
+	return res.Height, nil
+}
+
 // Commit returns the commitment of the execution state as it was after the
 // execution of the finalized block at the given height.
 func (i *Index) Commit(height uint64) (flow.StateCommitment, error) {
@@ -139,6 +177,42 @@ func (i *Index) Values(height uint64, regs flow.RegisterIDs) ([]flow.RegisterVal
 	return values, nil
 }
 
+// RegisterHistory returns the values written to the given register at heights
+// within the given range, ordered by ascending height. If limit is greater than
+// zero, at most limit versions are returned, starting with the oldest one.
+// Pages returned by the API are followed until the range or the limit is
+// exhausted.
+func (i *Index) RegisterHistory(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]archive.RegisterVersion, error) {
+
+	var versions []archive.RegisterVersion
+	for {
+		req := GetRegisterHistoryRequest{
+			Register:    reg.Bytes(),
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(versions))
+		}
+		res, err := i.client.GetRegisterHistory(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not get register history: %w", err)
+		}
+
+		for _, version := range res.Versions {
+			versions = append(versions, archive.RegisterVersion{
+				Height: version.Height,
+				Value:  version.Value,
+			})
+		}
+
+		if res.NextHeight == 0 || (limit > 0 && len(versions) >= limit) {
+			return versions, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
 // Collection returns the collection with the given ID.
 func (i *Index) Collection(collID flow.Identifier) (*flow.LightCollection, error) {
 
@@ -178,6 +252,20 @@ func (i *Index) CollectionsByHeight(height uint64) ([]flow.Identifier, error) {
 	return collIDs, nil
 }
 
+// HeightForCollection returns the height of the given collection ID.
+func (i *Index) HeightForCollection(collID flow.Identifier) (uint64, error) {
+
+	req := GetHeightForCollectionRequest{
+		CollectionID: collID[:],
+	}
+	res, err := i.client.GetHeightForCollection(context.Background(), &req)
+	if err != nil {
+		return 0, fmt.Errorf("could not get height: %w", err)
+	}
+
+	return res.Height, nil
+}
+
 // Guarantee returns the collection guarantee for the given collection ID.
 func (i *Index) Guarantee(collID flow.Identifier) (*flow.CollectionGuarantee, error) {
 
@@ -251,6 +339,293 @@ func (i *Index) TransactionsByHeight(height uint64) ([]flow.Identifier, error) {
 	return txIDs, nil
 }
 
+// TransactionsByAddress returns the transactions of finalized blocks within the
+// given range in which the account with the given address has any of the given
+// roles, ordered by ascending height. If limit is greater than zero, at most
+// limit transactions are returned, starting with the lowest height. Pages
+// returned by the API are followed until the range or the limit is exhausted.
+func (i *Index) TransactionsByAddress(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+
+	var txs []archive.AddressTransaction
+	for {
+		req := ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+			Roles:       uint32(roles),
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(txs))
+		}
+		res, err := i.client.ListTransactionsForAddress(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list transactions for address: %w", err)
+		}
+
+		for _, tx := range res.Transactions {
+			txs = append(txs, archive.AddressTransaction{
+				Height:        tx.Height,
+				TransactionID: flow.HashToID(tx.TransactionID),
+				Roles:         archive.TransactionRole(tx.Roles),
+			})
+		}
+
+		// A page can hold more transactions than requested when they are all
+		// part of the same block.
+		if limit > 0 && len(txs) > limit {
+			txs = txs[:limit]
+		}
+		if res.NextHeight == 0 || (limit > 0 && len(txs) >= limit) {
+			return txs, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
+// TransfersByAddress returns the token transfers of finalized blocks within the
+// given range that were sent or received by the account with the given address,
+// ordered by ascending height. If limit is greater than zero, at most limit
+// transfers are returned, starting with the lowest height. Pages returned by
+// the API are followed until the range or the limit is exhausted.
+func (i *Index) TransfersByAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+
+	var transfers []archive.Transfer
+	for {
+		req := ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(transfers))
+		}
+		res, err := i.client.ListTransfersForAddress(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list transfers for address: %w", err)
+		}
+
+		for _, transfer := range res.Transfers {
+			transfers = append(transfers, archive.Transfer{
+				Height:        transfer.Height,
+				TransactionID: flow.HashToID(transfer.TransactionID),
+				EventIndex:    transfer.EventIndex,
+				Token:         transfer.Token,
+				From:          flow.BytesToAddress(transfer.From),
+				To:            flow.BytesToAddress(transfer.To),
+				Amount:        transfer.Amount,
+			})
+		}
+
+		// A page can hold more transfers than requested when they are all part
+		// of the same block.
+		if limit > 0 && len(transfers) > limit {
+			transfers = transfers[:limit]
+		}
+		if res.NextHeight == 0 || (limit > 0 && len(transfers) >= limit) {
+			return transfers, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
+// BalanceHistory returns the changes to the balance of the given token of the
+// account with the given address, for the finalized blocks within the given
+// range, ordered by ascending height. If limit is greater than zero, at most
+// limit changes are returned, starting with the lowest height. Pages returned
+// by the API are followed until the range or the limit is exhausted.
+func (i *Index) BalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+
+	var changes []archive.BalanceChange
+	for {
+		req := ListBalanceHistoryRequest{
+			Address:     address.Bytes(),
+			Token:       token,
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(changes))
+		}
+		res, err := i.client.ListBalanceHistory(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list balance history: %w", err)
+		}
+
+		for _, change := range res.Changes {
+			changes = append(changes, archive.BalanceChange{
+				Height:    change.Height,
+				Deposited: change.Deposited,
+				Withdrawn: change.Withdrawn,
+			})
+		}
+
+		if res.NextHeight == 0 || (limit > 0 && len(changes) >= limit) {
+			return changes, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
+// ContractsByAddress returns the contracts deployed on the account with the
+// given address after the finalized block at the given height, ordered by name,
+// along with the height of the latest change made to each of them.
+func (i *Index) ContractsByAddress(address flow.Address, height uint64) ([]archive.Contract, error) {
+
+	req := ListContractsForAddressRequest{
+		Address: address.Bytes(),
+		Height:  height,
+	}
+	res, err := i.client.ListContractsForAddress(context.Background(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("could not list contracts for address: %w", err)
+	}
+
+	contracts := make([]archive.Contract, 0, len(res.Contracts))
+	for _, contract := range res.Contracts {
+		contracts = append(contracts, archive.Contract{
+			Address: address,
+			Name:    contract.Name,
+			Height:  contract.Height,
+		})
+	}
+
+	return contracts, nil
+}
+
+// ContractHistory returns the changes made to the contracts of the account
+// with the given address in finalized blocks within the given range, ordered
+// by ascending height. If name is not empty, only the changes to the contract
+// with that name are returned. If limit is greater than zero, at most limit
+// changes are returned, starting with the lowest height. Pages returned by the
+// API are followed until the range or the limit is exhausted.
+func (i *Index) ContractHistory(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+
+	var updates []archive.ContractUpdate
+	for {
+		req := ListContractUpdatesRequest{
+			Address:     address.Bytes(),
+			Name:        name,
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(updates))
+		}
+		res, err := i.client.ListContractUpdates(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list contract updates: %w", err)
+		}
+
+		for _, update := range res.Updates {
+			updates = append(updates, archive.ContractUpdate{
+				Height:        update.Height,
+				TransactionID: flow.HashToID(update.TransactionID),
+				Address:       address,
+				Name:          update.Name,
+				Action:        archive.ContractAction(update.Action),
+				CodeHash:      update.CodeHash,
+			})
+		}
+
+		// A page can hold more updates than requested when they are all part of
+		// the same block.
+		if limit > 0 && len(updates) > limit {
+			updates = updates[:limit]
+		}
+		if res.NextHeight == 0 || (limit > 0 && len(updates) >= limit) {
+			return updates, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
+// ContractCode returns the Cadence source of the contract with the given name
+// deployed on the account with the given address, as it was after the
+// finalized block at the given height. If no such contract was deployed at that
+// height, it returns an error wrapping archive.ErrNotFound.
+func (i *Index) ContractCode(address flow.Address, name string, height uint64) ([]byte, error) {
+
+	req := GetContractCodeRequest{
+		Address: address.Bytes(),
+		Name:    name,
+		Height:  height,
+	}
+	res, err := i.client.GetContractCode(context.Background(), &req)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("could not get contract code: %w", archive.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get contract code: %w", err)
+	}
+
+	return res.Code, nil
+}
+
+// AccountKeysAt returns the keys of the account with the given address as they
+// were after the latest indexed change to them at or below the given height. If
+// no such change is indexed, it returns an error wrapping archive.ErrNotFound.
+func (i *Index) AccountKeysAt(address flow.Address, height uint64) ([]archive.AccountKey, error) {
+
+	req := GetAccountKeysRequest{
+		Address: address.Bytes(),
+		Height:  height,
+	}
+	res, err := i.client.GetAccountKeys(context.Background(), &req)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("could not get account keys: %w", archive.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get account keys: %w", err)
+	}
+
+	keys := make([]archive.AccountKey, 0, len(res.Keys))
+	for _, key := range res.Keys {
+		keys = append(keys, archive.AccountKey{
+			Index:     key.Index,
+			PublicKey: key.PublicKey,
+			Weight:    key.Weight,
+			Revoked:   key.Revoked,
+			SeqNumber: key.SeqNumber,
+		})
+	}
+
+	return keys, nil
+}
+
+// AccountKeyHistory returns the keys of the account with the given address
+// after each indexed height at which they changed, ordered by ascending height.
+func (i *Index) AccountKeyHistory(address flow.Address) ([]archive.AccountKeys, error) {
+
+	req := ListAccountKeyHistoryRequest{
+		Address: address.Bytes(),
+	}
+	res, err := i.client.ListAccountKeyHistory(context.Background(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("could not list account key history: %w", err)
+	}
+
+	history := make([]archive.AccountKeys, 0, len(res.Changes))
+	for _, change := range res.Changes {
+		keys := make([]archive.AccountKey, 0, len(change.Keys))
+		for _, key := range change.Keys {
+			keys = append(keys, archive.AccountKey{
+				Index:     key.Index,
+				PublicKey: key.PublicKey,
+				Weight:    key.Weight,
+				Revoked:   key.Revoked,
+				SeqNumber: key.SeqNumber,
+			})
+		}
+		history = append(history, archive.AccountKeys{
+			Height:  change.Height,
+			Address: address,
+			Keys:    keys,
+		})
+	}
+
+	return history, nil
+}
+
 // Result returns the result for a given transaction ID.
 func (i *Index) Result(txID flow.Identifier) (*flow.TransactionResult, error) {
 
@@ -295,6 +670,79 @@ func (i *Index) Events(height uint64, types ...flow.EventType) ([]flow.Event, er
 	return events, nil
 }
 
+// EventHeights returns the heights of the finalized blocks within the given
+// range whose transactions emitted events of any of the given types, by
+// ascending height. If limit is greater than zero, at most limit heights are
+// returned, starting with the lowest one. Pages returned by the API are
+// followed until the range or the limit is exhausted.
+func (i *Index) EventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+
+	var heights []uint64
+	for {
+		req := ListHeightsForEventTypesRequest{
+			Types:       convert.TypesToStrings(types),
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(heights))
+		}
+		res, err := i.client.ListHeightsForEventTypes(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list heights for event types: %w", err)
+		}
+
+		heights = append(heights, res.Heights...)
+
+		if res.NextHeight == 0 || (limit > 0 && len(heights) >= limit) {
+			return heights, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
+// EventsByContract returns the events of the finalized blocks within the given
+// range that were emitted by any of the contracts deployed at the given
+// address, grouped by ascending height. If limit is greater than zero, the
+// events of at most limit heights are returned, starting with the lowest one.
+// Pages returned by the API are followed until the range or the limit is
+// exhausted.
+func (i *Index) EventsByContract(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+
+	var blocks []archive.BlockEvents
+	for {
+		req := ListEventsForContractRequest{
+			Address:     address.Bytes(),
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		}
+		if limit > 0 {
+			req.Limit = uint32(limit - len(blocks))
+		}
+		res, err := i.client.ListEventsForContract(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list events for contract: %w", err)
+		}
+
+		for _, block := range res.Blocks {
+			var events []flow.Event
+			err = i.codec.Unmarshal(block.Data, &events)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode events (height: %d): %w", block.Height, err)
+			}
+			blocks = append(blocks, archive.BlockEvents{
+				Height: block.Height,
+				Events: events,
+			})
+		}
+
+		if res.NextHeight == 0 || (limit > 0 && len(blocks) >= limit) {
+			return blocks, nil
+		}
+		startHeight = res.NextHeight
+	}
+}
+
 // Seal returns the seal with the given ID.
 func (i *Index) Seal(sealID flow.Identifier) (*flow.Seal, error) {
 
@@ -315,6 +763,39 @@ func (i *Index) Seal(sealID flow.Identifier) (*flow.Seal, error) {
 	return &seal, nil
 }
 
+// HeightForSeal returns the height of the given seal ID.
+func (i *Index) HeightForSeal(sealID flow.Identifier) (uint64, error) {
+
+	req := GetHeightForSealRequest{
+		SealID: sealID[:],
+	}
+	res, err := i.client.GetHeightForSeal(context.Background(), &req)
+	if err != nil {
+		return 0, fmt.Errorf("could not get height: %w", err)
+	}
+
+	return res.Height, nil
+}
+
+// SealedAtHeight returns the height of the block that contains the seal for
+// the given block ID. If the block has not been sealed yet, the returned error
+// wraps archive.ErrNotFound.
+func (i *Index) SealedAtHeight(blockID flow.Identifier) (uint64, error) {
+
+	req := GetSealedHeightForBlockRequest{
+		BlockID: blockID[:],
+	}
+	res, err := i.client.GetSealedHeightForBlock(context.Background(), &req)
+	if status.Code(err) == codes.NotFound {
+		return 0, fmt.Errorf("could not get sealed height: %w", archive.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not get sealed height: %w", err)
+	}
+
+	return res.Height, nil
+}
+
 // SealsByHeight returns the seal IDs at the given height.
 func (i *Index) SealsByHeight(height uint64) ([]flow.Identifier, error) {
 
@@ -333,3 +814,72 @@ func (i *Index) SealsByHeight(height uint64) ([]flow.Identifier, error) {
 
 	return sealIDs, nil
 }
+
+// RegistersByHeight returns the IDs of the registers changed at the given height.
+// Pages returned by the API are followed until all registers are retrieved.
+func (i *Index) RegistersByHeight(height uint64) (flow.RegisterIDs, error) {
+
+	var regs flow.RegisterIDs
+	req := ListRegistersForHeightRequest{
+		Height: height,
+	}
+	for {
+		res, err := i.client.ListRegistersForHeight(context.Background(), &req)
+		if err != nil {
+			return nil, fmt.Errorf("could not get registers: %w", err)
+		}
+
+		page, err := convert.BytesToRegisters(res.Registers)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert registers: %w", err)
+		}
+		regs = append(regs, page...)
+
+		if res.NextIndex == 0 {
+			return regs, nil
+		}
+		req.StartIndex = res.NextIndex
+	}
+}
+
+// RegistersByOwner calls fn for every register of the given owner, with its
+// value at the given height. Removed registers are not included.
+func (i *Index) RegistersByOwner(height uint64, owner string, fn func(flow.RegisterEntry) error) error {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := ListRegistersForOwnerRequest{
+		Height: height,
+		Owner:  []byte(owner),
+	}
+	stream, err := i.client.ListRegistersForOwner(ctx, &req)
+	if err != nil {
+		return fmt.Errorf("could not list registers: %w", err)
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not receive registers: %w", err)
+		}
+
+		if len(res.Registers) != len(res.Values) {
+			return fmt.Errorf("mismatched number of registers and values (%d != %d)", len(res.Registers), len(res.Values))
+		}
+		regs, err := convert.BytesToRegisters(res.Registers)
+		if err != nil {
+			return fmt.Errorf("could not convert registers: %w", err)
+		}
+
+		for j, reg := range regs {
+			err = fn(flow.RegisterEntry{Key: reg, Value: res.Values[j]})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
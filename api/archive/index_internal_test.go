@@ -2,13 +2,20 @@ package archive
 
 import (
 	"context"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/models/convert"
 	"github.com/onflow/flow-archive/testing/mocks"
 )
@@ -100,6 +107,45 @@ func TestIndex_Last(t *testing.T) {
 	})
 }
 
+func TestIndex_RegisterRetention(t *testing.T) {
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetRegisterRetentionFunc: func(_ context.Context, in *GetRegisterRetentionRequest, _ ...grpc.CallOption) (*GetRegisterRetentionResponse, error) {
+					assert.NotNil(t, in)
+
+					return &GetRegisterRetentionResponse{
+						Height:   mocks.GenericHeight,
+						Interval: 100,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.RegisterRetention()
+
+		require.NoError(t, err)
+		assert.Equal(t, archive.RegisterRetention{Height: mocks.GenericHeight, Interval: 100}, got)
+	})
+
+	t.Run("handles index failure", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetRegisterRetentionFunc: func(context.Context, *GetRegisterRetentionRequest, ...grpc.CallOption) (*GetRegisterRetentionResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.RegisterRetention()
+		assert.Error(t, err)
+	})
+}
+
 func TestIndex_Header(t *testing.T) {
 	// We need to use the proper encoding to support nanoseconds
 	// and timezones in timestamps.
@@ -299,6 +345,88 @@ func TestIndex_Values(t *testing.T) {
 	})
 }
 
+func TestIndex_RegisterHistory(t *testing.T) {
+	reg := mocks.GenericRegister(0)
+	versions := mocks.GenericRegisterVersions(4)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetRegisterHistoryFunc: func(_ context.Context, in *GetRegisterHistoryRequest, _ ...grpc.CallOption) (*GetRegisterHistoryResponse, error) {
+					assert.Equal(t, reg.Bytes(), in.Register)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Zero(t, in.Limit)
+
+					res := GetRegisterHistoryResponse{}
+					for _, version := range versions {
+						res.Versions = append(res.Versions, &RegisterVersion{
+							Height: version.Height,
+							Value:  version.Value,
+						})
+					}
+
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.RegisterHistory(reg, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, versions, got)
+	})
+
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		index := Index{
+			client: &apiMock{
+				GetRegisterHistoryFunc: func(_ context.Context, in *GetRegisterHistoryRequest, _ ...grpc.CallOption) (*GetRegisterHistoryResponse, error) {
+					// Each page only contains a single version.
+					version := versions[calls]
+					calls++
+
+					assert.Equal(t, version.Height, in.StartHeight)
+					assert.Equal(t, uint32(3-calls+1), in.Limit)
+
+					return &GetRegisterHistoryResponse{
+						Versions: []*RegisterVersion{
+							{Height: version.Height, Value: version.Value},
+						},
+						NextHeight: version.Height + 1,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.RegisterHistory(reg, mocks.GenericHeight, mocks.GenericHeight+10, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, versions[:3], got)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetRegisterHistoryFunc: func(context.Context, *GetRegisterHistoryRequest, ...grpc.CallOption) (*GetRegisterHistoryResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.RegisterHistory(reg, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestIndex_Height(t *testing.T) {
 	header := mocks.GenericHeader
 	blockID := header.ID()
@@ -348,6 +476,50 @@ func TestIndex_Height(t *testing.T) {
 	})
 }
 
+func TestIndex_HeightForTimestamp(t *testing.T) {
+	timestamp := time.Date(2023, time.March, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetHeightForTimestampFunc: func(_ context.Context, in *GetHeightForTimestampRequest, _ ...grpc.CallOption) (*GetHeightForTimestampResponse, error) {
+					assert.Equal(t, timestamp.UnixNano(), in.Timestamp)
+					assert.Equal(t, uint32(archive.AtOrBefore), in.Mode)
+
+					return &GetHeightForTimestampResponse{
+						Timestamp: in.Timestamp,
+						Mode:      in.Mode,
+						Height:    mocks.GenericHeight,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.HeightForTimestamp(timestamp, archive.AtOrBefore)
+
+		require.NoError(t, err)
+		assert.Equal(t, mocks.GenericHeight, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetHeightForTimestampFunc: func(context.Context, *GetHeightForTimestampRequest, ...grpc.CallOption) (*GetHeightForTimestampResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.HeightForTimestamp(timestamp, archive.AtOrAfter)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestIndex_Collection(t *testing.T) {
 	collection := mocks.GenericCollection(0)
 	collID := collection.ID()
@@ -557,37 +729,96 @@ func TestIndex_Transaction(t *testing.T) {
 	})
 }
 
-func TestIndex_Result(t *testing.T) {
-	result := mocks.GenericResult(0)
-	txID := result.TransactionID
-
-	data, err := cbor.Marshal(result)
-	require.NoError(t, err)
+func TestIndex_TransactionsByAddress(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	txs := mocks.GenericAddressTransactions(4)
 
 	t.Run("nominal case", func(t *testing.T) {
 		t.Parallel()
 
-		codec := mocks.BaselineCodec(t)
-		codec.UnmarshalFunc = cbor.Unmarshal
-
 		index := Index{
-			codec: codec,
 			client: &apiMock{
-				GetResultFunc: func(_ context.Context, in *GetResultRequest, _ ...grpc.CallOption) (*GetResultResponse, error) {
-					assert.Equal(t, txID[:], in.TransactionID)
+				ListTransactionsForAddressFunc: func(_ context.Context, in *ListTransactionsForAddressRequest, _ ...grpc.CallOption) (*ListTransactionsForAddressResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Equal(t, uint32(archive.RolePayer|archive.RoleAuthorizer), in.Roles)
+					assert.Zero(t, in.Limit)
+
+					res := ListTransactionsForAddressResponse{}
+					for _, tx := range txs {
+						res.Transactions = append(res.Transactions, &AddressTransaction{
+							Height:        tx.Height,
+							TransactionID: convert.IDToHash(tx.TransactionID),
+							Roles:         uint32(tx.Roles),
+						})
+					}
+
+					return &res, nil
+				},
+			},
+		}
 
-					return &GetResultResponse{
-						TransactionID: txID[:],
-						Data:          data,
+		got, err := index.TransactionsByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, archive.RolePayer|archive.RoleAuthorizer, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, txs, got)
+	})
+
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		index := Index{
+			client: &apiMock{
+				ListTransactionsForAddressFunc: func(_ context.Context, in *ListTransactionsForAddressRequest, _ ...grpc.CallOption) (*ListTransactionsForAddressResponse, error) {
+					// Each page only contains a single transaction.
+					tx := txs[calls]
+					calls++
+
+					assert.Equal(t, tx.Height, in.StartHeight)
+					assert.Equal(t, uint32(3-calls+1), in.Limit)
+
+					return &ListTransactionsForAddressResponse{
+						Transactions: []*AddressTransaction{
+							{Height: tx.Height, TransactionID: convert.IDToHash(tx.TransactionID), Roles: uint32(tx.Roles)},
+						},
+						NextHeight: tx.Height + 1,
 					}, nil
 				},
 			},
 		}
 
-		got, err := index.Result(txID)
+		got, err := index.TransactionsByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, archive.RoleAny, 3)
 
 		require.NoError(t, err)
-		assert.Equal(t, result, got)
+		assert.Equal(t, txs[:3], got)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("truncates pages beyond limit", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListTransactionsForAddressFunc: func(context.Context, *ListTransactionsForAddressRequest, ...grpc.CallOption) (*ListTransactionsForAddressResponse, error) {
+					res := ListTransactionsForAddressResponse{NextHeight: mocks.GenericHeight + 1}
+					for _, tx := range txs {
+						res.Transactions = append(res.Transactions, &AddressTransaction{
+							Height:        mocks.GenericHeight,
+							TransactionID: convert.IDToHash(tx.TransactionID),
+							Roles:         uint32(tx.Roles),
+						})
+					}
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.TransactionsByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, archive.RoleAny, 2)
+
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
 	})
 
 	t.Run("handles index failures", func(t *testing.T) {
@@ -595,229 +826,1266 @@ func TestIndex_Result(t *testing.T) {
 
 		index := Index{
 			client: &apiMock{
-				GetResultFunc: func(context.Context, *GetResultRequest, ...grpc.CallOption) (*GetResultResponse, error) {
+				ListTransactionsForAddressFunc: func(context.Context, *ListTransactionsForAddressRequest, ...grpc.CallOption) (*ListTransactionsForAddressResponse, error) {
 					return nil, mocks.GenericError
 				},
 			},
 		}
 
-		_, err := index.Result(result.ID())
+		_, err := index.TransactionsByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, archive.RoleAny, 0)
 
 		assert.Error(t, err)
 	})
 }
 
-func TestIndex_Events(t *testing.T) {
-	events := mocks.GenericEvents(4)
-	types := mocks.GenericEventTypes(2)
-
-	data, err := cbor.Marshal(events)
-	require.NoError(t, err)
+func TestIndex_TransfersByAddress(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	transfers := mocks.GenericTransfers(4)
+
+	// toAPI converts the given transfer to its API representation.
+	toAPI := func(transfer archive.Transfer) *Transfer {
+		return &Transfer{
+			Height:        transfer.Height,
+			TransactionID: convert.IDToHash(transfer.TransactionID),
+			EventIndex:    transfer.EventIndex,
+			Token:         transfer.Token,
+			From:          transfer.From.Bytes(),
+			To:            transfer.To.Bytes(),
+			Amount:        transfer.Amount,
+		}
+	}
 
 	t.Run("nominal case", func(t *testing.T) {
 		t.Parallel()
 
-		codec := mocks.BaselineCodec(t)
-		codec.UnmarshalFunc = cbor.Unmarshal
+		index := Index{
+			client: &apiMock{
+				ListTransfersForAddressFunc: func(_ context.Context, in *ListTransfersForAddressRequest, _ ...grpc.CallOption) (*ListTransfersForAddressResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Zero(t, in.Limit)
+
+					res := ListTransfersForAddressResponse{}
+					for _, transfer := range transfers {
+						res.Transfers = append(res.Transfers, toAPI(transfer))
+					}
+
+					return &res, nil
+				},
+			},
+		}
 
+		got, err := index.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, transfers, got)
+	})
+
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
 		index := Index{
-			codec: codec,
 			client: &apiMock{
-				GetEventsFunc: func(_ context.Context, in *GetEventsRequest, _ ...grpc.CallOption) (*GetEventsResponse, error) {
-					assert.Equal(t, mocks.GenericHeight, in.Height)
-					assert.Equal(t, convert.TypesToStrings(types), in.Types)
+				ListTransfersForAddressFunc: func(_ context.Context, in *ListTransfersForAddressRequest, _ ...grpc.CallOption) (*ListTransfersForAddressResponse, error) {
+					// Each page only contains a single transfer.
+					transfer := transfers[calls]
+					calls++
 
-					return &GetEventsResponse{
-						Height: mocks.GenericHeight,
-						Types:  convert.TypesToStrings(types),
-						Data:   data,
+					assert.Equal(t, transfer.Height, in.StartHeight)
+					assert.Equal(t, uint32(3-calls+1), in.Limit)
+
+					return &ListTransfersForAddressResponse{
+						Transfers:  []*Transfer{toAPI(transfer)},
+						NextHeight: transfer.Height + 1,
 					}, nil
 				},
 			},
 		}
 
-		got, err := index.Events(mocks.GenericHeight, types...)
+		got, err := index.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, 3)
 
 		require.NoError(t, err)
-		assert.Equal(t, events, got)
+		assert.Equal(t, transfers[:3], got)
+		assert.Equal(t, 3, calls)
 	})
 
-	t.Run("handles index failures", func(t *testing.T) {
+	t.Run("truncates pages beyond limit", func(t *testing.T) {
 		t.Parallel()
 
-		codec := mocks.BaselineCodec(t)
-		codec.UnmarshalFunc = cbor.Unmarshal
-
 		index := Index{
-			codec: codec,
 			client: &apiMock{
-				GetEventsFunc: func(context.Context, *GetEventsRequest, ...grpc.CallOption) (*GetEventsResponse, error) {
-					return nil, mocks.GenericError
+				ListTransfersForAddressFunc: func(context.Context, *ListTransfersForAddressRequest, ...grpc.CallOption) (*ListTransfersForAddressResponse, error) {
+					res := ListTransfersForAddressResponse{NextHeight: mocks.GenericHeight + 1}
+					for _, transfer := range transfers {
+						res.Transfers = append(res.Transfers, toAPI(transfer))
+					}
+					return &res, nil
 				},
 			},
 		}
 
-		_, err := index.Events(mocks.GenericHeight, types...)
+		got, err := index.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, 2)
 
-		assert.Error(t, err)
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
 	})
 
-	t.Run("handles invalid indexed data", func(t *testing.T) {
+	t.Run("handles index failures", func(t *testing.T) {
 		t.Parallel()
 
-		codec := mocks.BaselineCodec(t)
-		codec.UnmarshalFunc = cbor.Unmarshal
-
 		index := Index{
-			codec: codec,
 			client: &apiMock{
-				GetEventsFunc: func(context.Context, *GetEventsRequest, ...grpc.CallOption) (*GetEventsResponse, error) {
-					return &GetEventsResponse{
-						Height: mocks.GenericHeight,
-						Types:  convert.TypesToStrings(types),
-						Data:   []byte(`invalid data`),
-					}, nil
+				ListTransfersForAddressFunc: func(context.Context, *ListTransfersForAddressRequest, ...grpc.CallOption) (*ListTransfersForAddressResponse, error) {
+					return nil, mocks.GenericError
 				},
 			},
 		}
 
-		_, err := index.Events(mocks.GenericHeight, types...)
+		_, err := index.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight+10, 0)
 
 		assert.Error(t, err)
 	})
 }
 
-func TestIndex_Seals(t *testing.T) {
-	seal := mocks.GenericSeal(0)
-	sealID := seal.ID()
+func TestIndex_BalanceHistory(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	changes := mocks.GenericBalanceChanges(4)
 
 	t.Run("nominal case", func(t *testing.T) {
 		t.Parallel()
 
-		data, err := cbor.Marshal(seal)
+		index := Index{
+			client: &apiMock{
+				ListBalanceHistoryFunc: func(_ context.Context, in *ListBalanceHistoryRequest, _ ...grpc.CallOption) (*ListBalanceHistoryResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, archive.FlowSymbol, in.Token)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Zero(t, in.Limit)
+
+					res := ListBalanceHistoryResponse{}
+					for _, change := range changes {
+						res.Changes = append(res.Changes, &BalanceChange{
+							Height:    change.Height,
+							Deposited: change.Deposited,
+							Withdrawn: change.Withdrawn,
+						})
+					}
+
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.BalanceHistory(address, archive.FlowSymbol, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
 		require.NoError(t, err)
+		assert.Equal(t, changes, got)
+	})
 
-		codec := mocks.BaselineCodec(t)
-		codec.UnmarshalFunc = cbor.Unmarshal
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
 
+		var calls int
 		index := Index{
-			codec: codec,
 			client: &apiMock{
-				GetSealFunc: func(_ context.Context, in *GetSealRequest, _ ...grpc.CallOption) (*GetSealResponse, error) {
-					assert.Equal(t, sealID[:], in.SealID)
-
-					return &GetSealResponse{
-						SealID: sealID[:],
-						Data:   data,
+				ListBalanceHistoryFunc: func(_ context.Context, in *ListBalanceHistoryRequest, _ ...grpc.CallOption) (*ListBalanceHistoryResponse, error) {
+					// Each page only contains a single change.
+					change := changes[calls]
+					calls++
+
+					assert.Equal(t, change.Height, in.StartHeight)
+					assert.Equal(t, uint32(3-calls+1), in.Limit)
+
+					return &ListBalanceHistoryResponse{
+						Changes: []*BalanceChange{
+							{Height: change.Height, Deposited: change.Deposited, Withdrawn: change.Withdrawn},
+						},
+						NextHeight: change.Height + 1,
 					}, nil
 				},
 			},
 		}
 
-		got, err := index.Seal(sealID)
+		got, err := index.BalanceHistory(address, archive.FlowSymbol, mocks.GenericHeight, mocks.GenericHeight+10, 3)
 
 		require.NoError(t, err)
-		assert.Equal(t, seal, got)
+		assert.Equal(t, changes[:3], got)
+		assert.Equal(t, 3, calls)
 	})
 
 	t.Run("handles index failures", func(t *testing.T) {
 		t.Parallel()
 
 		index := Index{
-			codec: mocks.BaselineCodec(t),
 			client: &apiMock{
-				GetSealFunc: func(context.Context, *GetSealRequest, ...grpc.CallOption) (*GetSealResponse, error) {
+				ListBalanceHistoryFunc: func(context.Context, *ListBalanceHistoryRequest, ...grpc.CallOption) (*ListBalanceHistoryResponse, error) {
 					return nil, mocks.GenericError
 				},
 			},
 		}
 
-		_, err := index.Seal(sealID)
+		_, err := index.BalanceHistory(address, archive.FlowSymbol, mocks.GenericHeight, mocks.GenericHeight+10, 0)
 
 		assert.Error(t, err)
 	})
 }
 
-func TestIndex_ListSealsForHeight(t *testing.T) {
-	sealIDs := mocks.GenericSealIDs(4)
+func TestIndex_ContractsByAddress(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	contracts := mocks.GenericContracts(4)
 
 	t.Run("nominal case", func(t *testing.T) {
 		t.Parallel()
 
-		data := make([][]byte, 0, len(sealIDs))
-		for _, sealID := range sealIDs {
-			data = append(data, mocks.ByteSlice(sealID))
-		}
-
 		index := Index{
-			codec: mocks.BaselineCodec(t),
 			client: &apiMock{
-				ListSealsForHeightFunc: func(_ context.Context, in *ListSealsForHeightRequest, _ ...grpc.CallOption) (*ListSealsForHeightResponse, error) {
+				ListContractsForAddressFunc: func(_ context.Context, in *ListContractsForAddressRequest, _ ...grpc.CallOption) (*ListContractsForAddressResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
 					assert.Equal(t, mocks.GenericHeight, in.Height)
 
-					return &ListSealsForHeightResponse{
+					res := ListContractsForAddressResponse{
+						Address: in.Address,
 						Height:  in.Height,
-						SealIDs: data,
-					}, nil
+					}
+					for _, contract := range contracts {
+						res.Contracts = append(res.Contracts, &Contract{
+							Name:   contract.Name,
+							Height: contract.Height,
+						})
+					}
+
+					return &res, nil
 				},
 			},
 		}
 
-		got, err := index.SealsByHeight(mocks.GenericHeight)
+		got, err := index.ContractsByAddress(address, mocks.GenericHeight)
 
 		require.NoError(t, err)
-		assert.Equal(t, sealIDs, got)
+		assert.Equal(t, contracts, got)
 	})
 
 	t.Run("handles index failures", func(t *testing.T) {
 		t.Parallel()
 
 		index := Index{
-			codec: mocks.BaselineCodec(t),
 			client: &apiMock{
-				ListSealsForHeightFunc: func(context.Context, *ListSealsForHeightRequest, ...grpc.CallOption) (*ListSealsForHeightResponse, error) {
+				ListContractsForAddressFunc: func(context.Context, *ListContractsForAddressRequest, ...grpc.CallOption) (*ListContractsForAddressResponse, error) {
 					return nil, mocks.GenericError
 				},
 			},
 		}
 
-		_, err := index.SealsByHeight(mocks.GenericHeight)
+		_, err := index.ContractsByAddress(address, mocks.GenericHeight)
 
 		assert.Error(t, err)
 	})
 }
 
-type apiMock struct {
-	GetFirstFunc                  func(ctx context.Context, in *GetFirstRequest, opts ...grpc.CallOption) (*GetFirstResponse, error)
-	GetLastFunc                   func(ctx context.Context, in *GetLastRequest, opts ...grpc.CallOption) (*GetLastResponse, error)
-	GetHeightForBlockFunc         func(ctx context.Context, in *GetHeightForBlockRequest, opts ...grpc.CallOption) (*GetHeightForBlockResponse, error)
-	GetCommitFunc                 func(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error)
-	GetHeaderFunc                 func(ctx context.Context, in *GetHeaderRequest, opts ...grpc.CallOption) (*GetHeaderResponse, error)
-	GetEventsFunc                 func(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
-	GetRegisterValuesFunc         func(ctx context.Context, in *GetRegisterValuesRequest, opts ...grpc.CallOption) (*GetRegisterValuesResponse, error)
-	GetCollectionFunc             func(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*GetCollectionResponse, error)
-	ListCollectionsForHeightFunc  func(ctx context.Context, in *ListCollectionsForHeightRequest, opts ...grpc.CallOption) (*ListCollectionsForHeightResponse, error)
-	GetGuaranteeFunc              func(ctx context.Context, in *GetGuaranteeRequest, opts ...grpc.CallOption) (*GetGuaranteeResponse, error)
-	GetTransactionFunc            func(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
-	GetHeightForTransactionFunc   func(ctx context.Context, in *GetHeightForTransactionRequest, opts ...grpc.CallOption) (*GetHeightForTransactionResponse, error)
-	ListTransactionsForHeightFunc func(ctx context.Context, in *ListTransactionsForHeightRequest, opts ...grpc.CallOption) (*ListTransactionsForHeightResponse, error)
-	GetResultFunc                 func(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error)
-	GetSealFunc                   func(ctx context.Context, in *GetSealRequest, opts ...grpc.CallOption) (*GetSealResponse, error)
-	ListSealsForHeightFunc        func(ctx context.Context, in *ListSealsForHeightRequest, opts ...grpc.CallOption) (*ListSealsForHeightResponse, error)
-}
+func TestIndex_ContractHistory(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	updates := mocks.GenericContractUpdates(4)
+
+	toAPI := func(update archive.ContractUpdate) *ContractUpdate {
+		return &ContractUpdate{
+			Height:        update.Height,
+			TransactionID: update.TransactionID[:],
+			Name:          update.Name,
+			Action:        uint32(update.Action),
+			CodeHash:      update.CodeHash,
+		}
+	}
 
-func (a *apiMock) GetFirst(ctx context.Context, in *GetFirstRequest, opts ...grpc.CallOption) (*GetFirstResponse, error) {
-	return a.GetFirstFunc(ctx, in, opts...)
-}
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
 
-func (a *apiMock) GetLast(ctx context.Context, in *GetLastRequest, opts ...grpc.CallOption) (*GetLastResponse, error) {
-	return a.GetLastFunc(ctx, in, opts...)
-}
+		index := Index{
+			client: &apiMock{
+				ListContractUpdatesFunc: func(_ context.Context, in *ListContractUpdatesRequest, _ ...grpc.CallOption) (*ListContractUpdatesResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, "Contract0", in.Name)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Zero(t, in.Limit)
+
+					res := ListContractUpdatesResponse{}
+					for _, update := range updates {
+						res.Updates = append(res.Updates, toAPI(update))
+					}
+
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.ContractHistory(address, "Contract0", mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, updates, got)
+	})
+
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		index := Index{
+			client: &apiMock{
+				ListContractUpdatesFunc: func(_ context.Context, in *ListContractUpdatesRequest, _ ...grpc.CallOption) (*ListContractUpdatesResponse, error) {
+					// Each page only contains a single update.
+					update := updates[calls]
+					calls++
+
+					assert.Equal(t, update.Height, in.StartHeight)
+					assert.Equal(t, uint32(3-calls+1), in.Limit)
+
+					return &ListContractUpdatesResponse{
+						Updates:    []*ContractUpdate{toAPI(update)},
+						NextHeight: update.Height + 1,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.ContractHistory(address, "", mocks.GenericHeight, mocks.GenericHeight+10, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, updates[:3], got)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListContractUpdatesFunc: func(context.Context, *ListContractUpdatesRequest, ...grpc.CallOption) (*ListContractUpdatesResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.ContractHistory(address, "", mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_ContractCode(t *testing.T) {
+	address := mocks.GenericAddress(0)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetContractCodeFunc: func(_ context.Context, in *GetContractCodeRequest, _ ...grpc.CallOption) (*GetContractCodeResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, "Contract0", in.Name)
+					assert.Equal(t, mocks.GenericHeight, in.Height)
+
+					return &GetContractCodeResponse{
+						Address: in.Address,
+						Name:    in.Name,
+						Height:  in.Height,
+						Code:    mocks.GenericContractCode,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.ContractCode(address, "Contract0", mocks.GenericHeight)
+
+		require.NoError(t, err)
+		assert.Equal(t, mocks.GenericContractCode, got)
+	})
+
+	t.Run("handles unknown contracts", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetContractCodeFunc: func(context.Context, *GetContractCodeRequest, ...grpc.CallOption) (*GetContractCodeResponse, error) {
+					return nil, status.Error(codes.NotFound, "contract not found")
+				},
+			},
+		}
+
+		_, err := index.ContractCode(address, "Contract0", mocks.GenericHeight)
+
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetContractCodeFunc: func(context.Context, *GetContractCodeRequest, ...grpc.CallOption) (*GetContractCodeResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.ContractCode(address, "Contract0", mocks.GenericHeight)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, archive.ErrNotFound)
+	})
+}
+
+func TestIndex_AccountKeysAt(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	keys := mocks.GenericAccountKeys(2)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetAccountKeysFunc: func(_ context.Context, in *GetAccountKeysRequest, _ ...grpc.CallOption) (*GetAccountKeysResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, mocks.GenericHeight, in.Height)
+
+					res := GetAccountKeysResponse{
+						Address: in.Address,
+						Height:  in.Height,
+					}
+					for _, key := range keys {
+						res.Keys = append(res.Keys, &AccountKey{
+							Index:     key.Index,
+							PublicKey: key.PublicKey,
+							Weight:    key.Weight,
+							Revoked:   key.Revoked,
+							SeqNumber: key.SeqNumber,
+						})
+					}
+
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.AccountKeysAt(address, mocks.GenericHeight)
+
+		require.NoError(t, err)
+		assert.Equal(t, keys, got)
+	})
+
+	t.Run("handles unindexed keys", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetAccountKeysFunc: func(context.Context, *GetAccountKeysRequest, ...grpc.CallOption) (*GetAccountKeysResponse, error) {
+					return nil, status.Error(codes.NotFound, "account keys not found")
+				},
+			},
+		}
+
+		_, err := index.AccountKeysAt(address, mocks.GenericHeight)
+
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetAccountKeysFunc: func(context.Context, *GetAccountKeysRequest, ...grpc.CallOption) (*GetAccountKeysResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.AccountKeysAt(address, mocks.GenericHeight)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, archive.ErrNotFound)
+	})
+}
+
+func TestIndex_AccountKeyHistory(t *testing.T) {
+	history := mocks.GenericAccountKeyHistory(4)
+	address := history[0].Address
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListAccountKeyHistoryFunc: func(_ context.Context, in *ListAccountKeyHistoryRequest, _ ...grpc.CallOption) (*ListAccountKeyHistoryResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+
+					res := ListAccountKeyHistoryResponse{
+						Address: in.Address,
+					}
+					for _, change := range history {
+						var keys []*AccountKey
+						for _, key := range change.Keys {
+							keys = append(keys, &AccountKey{
+								Index:     key.Index,
+								PublicKey: key.PublicKey,
+								Weight:    key.Weight,
+								Revoked:   key.Revoked,
+								SeqNumber: key.SeqNumber,
+							})
+						}
+						res.Changes = append(res.Changes, &AccountKeyChange{
+							Height: change.Height,
+							Keys:   keys,
+						})
+					}
+
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.AccountKeyHistory(address)
+
+		require.NoError(t, err)
+		assert.Equal(t, history, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListAccountKeyHistoryFunc: func(context.Context, *ListAccountKeyHistoryRequest, ...grpc.CallOption) (*ListAccountKeyHistoryResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.AccountKeyHistory(address)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_Result(t *testing.T) {
+	result := mocks.GenericResult(0)
+	txID := result.TransactionID
+
+	data, err := cbor.Marshal(result)
+	require.NoError(t, err)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				GetResultFunc: func(_ context.Context, in *GetResultRequest, _ ...grpc.CallOption) (*GetResultResponse, error) {
+					assert.Equal(t, txID[:], in.TransactionID)
+
+					return &GetResultResponse{
+						TransactionID: txID[:],
+						Data:          data,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.Result(txID)
+
+		require.NoError(t, err)
+		assert.Equal(t, result, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetResultFunc: func(context.Context, *GetResultRequest, ...grpc.CallOption) (*GetResultResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.Result(result.ID())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_Events(t *testing.T) {
+	events := mocks.GenericEvents(4)
+	types := mocks.GenericEventTypes(2)
+
+	data, err := cbor.Marshal(events)
+	require.NoError(t, err)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				GetEventsFunc: func(_ context.Context, in *GetEventsRequest, _ ...grpc.CallOption) (*GetEventsResponse, error) {
+					assert.Equal(t, mocks.GenericHeight, in.Height)
+					assert.Equal(t, convert.TypesToStrings(types), in.Types)
+
+					return &GetEventsResponse{
+						Height: mocks.GenericHeight,
+						Types:  convert.TypesToStrings(types),
+						Data:   data,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.Events(mocks.GenericHeight, types...)
+
+		require.NoError(t, err)
+		assert.Equal(t, events, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				GetEventsFunc: func(context.Context, *GetEventsRequest, ...grpc.CallOption) (*GetEventsResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.Events(mocks.GenericHeight, types...)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles invalid indexed data", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				GetEventsFunc: func(context.Context, *GetEventsRequest, ...grpc.CallOption) (*GetEventsResponse, error) {
+					return &GetEventsResponse{
+						Height: mocks.GenericHeight,
+						Types:  convert.TypesToStrings(types),
+						Data:   []byte(`invalid data`),
+					}, nil
+				},
+			},
+		}
+
+		_, err := index.Events(mocks.GenericHeight, types...)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_EventHeights(t *testing.T) {
+	types := mocks.GenericEventTypes(2)
+	heights := []uint64{mocks.GenericHeight, mocks.GenericHeight + 3, mocks.GenericHeight + 7}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListHeightsForEventTypesFunc: func(_ context.Context, in *ListHeightsForEventTypesRequest, _ ...grpc.CallOption) (*ListHeightsForEventTypesResponse, error) {
+					assert.Equal(t, convert.TypesToStrings(types), in.Types)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Zero(t, in.Limit)
+
+					return &ListHeightsForEventTypesResponse{Heights: heights}, nil
+				},
+			},
+		}
+
+		got, err := index.EventHeights(types, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, heights, got)
+	})
+
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		index := Index{
+			client: &apiMock{
+				ListHeightsForEventTypesFunc: func(_ context.Context, in *ListHeightsForEventTypesRequest, _ ...grpc.CallOption) (*ListHeightsForEventTypesResponse, error) {
+					// Each page only contains a single height.
+					height := heights[calls]
+					calls++
+
+					assert.Equal(t, uint32(2-calls+1), in.Limit)
+
+					return &ListHeightsForEventTypesResponse{
+						Heights:    []uint64{height},
+						NextHeight: heights[calls],
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.EventHeights(types, mocks.GenericHeight, mocks.GenericHeight+10, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, heights[:2], got)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListHeightsForEventTypesFunc: func(context.Context, *ListHeightsForEventTypesRequest, ...grpc.CallOption) (*ListHeightsForEventTypesResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.EventHeights(types, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_EventsByContract(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	events := mocks.GenericEvents(4)
+	heights := []uint64{mocks.GenericHeight, mocks.GenericHeight + 3, mocks.GenericHeight + 7}
+
+	data, err := cbor.Marshal(events)
+	require.NoError(t, err)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				ListEventsForContractFunc: func(_ context.Context, in *ListEventsForContractRequest, _ ...grpc.CallOption) (*ListEventsForContractResponse, error) {
+					assert.Equal(t, address.Bytes(), in.Address)
+					assert.Equal(t, mocks.GenericHeight, in.StartHeight)
+					assert.Equal(t, mocks.GenericHeight+10, in.EndHeight)
+					assert.Zero(t, in.Limit)
+
+					res := ListEventsForContractResponse{}
+					for _, height := range heights {
+						res.Blocks = append(res.Blocks, &BlockEvents{Height: height, Data: data})
+					}
+
+					return &res, nil
+				},
+			},
+		}
+
+		got, err := index.EventsByContract(address, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		require.NoError(t, err)
+		require.Len(t, got, len(heights))
+		for i, block := range got {
+			assert.Equal(t, heights[i], block.Height)
+			assert.Equal(t, events, block.Events)
+		}
+	})
+
+	t.Run("follows pages until limit is reached", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		var calls int
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				ListEventsForContractFunc: func(_ context.Context, in *ListEventsForContractRequest, _ ...grpc.CallOption) (*ListEventsForContractResponse, error) {
+					// Each page only contains the events of a single height.
+					height := heights[calls]
+					calls++
+
+					assert.Equal(t, height, in.StartHeight)
+					assert.Equal(t, uint32(2-calls+1), in.Limit)
+
+					return &ListEventsForContractResponse{
+						Blocks:     []*BlockEvents{{Height: height, Data: data}},
+						NextHeight: heights[calls],
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.EventsByContract(address, mocks.GenericHeight, mocks.GenericHeight+10, 2)
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, heights[1], got[1].Height)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			codec: mocks.BaselineCodec(t),
+			client: &apiMock{
+				ListEventsForContractFunc: func(context.Context, *ListEventsForContractRequest, ...grpc.CallOption) (*ListEventsForContractResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.EventsByContract(address, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles invalid indexed data", func(t *testing.T) {
+		t.Parallel()
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				ListEventsForContractFunc: func(context.Context, *ListEventsForContractRequest, ...grpc.CallOption) (*ListEventsForContractResponse, error) {
+					return &ListEventsForContractResponse{
+						Blocks: []*BlockEvents{{Height: mocks.GenericHeight, Data: []byte(`invalid data`)}},
+					}, nil
+				},
+			},
+		}
+
+		_, err := index.EventsByContract(address, mocks.GenericHeight, mocks.GenericHeight+10, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_Seals(t *testing.T) {
+	seal := mocks.GenericSeal(0)
+	sealID := seal.ID()
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := cbor.Marshal(seal)
+		require.NoError(t, err)
+
+		codec := mocks.BaselineCodec(t)
+		codec.UnmarshalFunc = cbor.Unmarshal
+
+		index := Index{
+			codec: codec,
+			client: &apiMock{
+				GetSealFunc: func(_ context.Context, in *GetSealRequest, _ ...grpc.CallOption) (*GetSealResponse, error) {
+					assert.Equal(t, sealID[:], in.SealID)
+
+					return &GetSealResponse{
+						SealID: sealID[:],
+						Data:   data,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.Seal(sealID)
+
+		require.NoError(t, err)
+		assert.Equal(t, seal, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			codec: mocks.BaselineCodec(t),
+			client: &apiMock{
+				GetSealFunc: func(context.Context, *GetSealRequest, ...grpc.CallOption) (*GetSealResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.Seal(sealID)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_SealedAtHeight(t *testing.T) {
+	blockID := mocks.GenericHeader.ID()
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetSealedHeightForBlockFunc: func(_ context.Context, in *GetSealedHeightForBlockRequest, _ ...grpc.CallOption) (*GetSealedHeightForBlockResponse, error) {
+					assert.Equal(t, blockID[:], in.BlockID)
+
+					return &GetSealedHeightForBlockResponse{
+						BlockID: in.BlockID,
+						Height:  mocks.GenericHeight,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.SealedAtHeight(blockID)
+
+		require.NoError(t, err)
+		assert.Equal(t, mocks.GenericHeight, got)
+	})
+
+	t.Run("handles unsealed blocks", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetSealedHeightForBlockFunc: func(context.Context, *GetSealedHeightForBlockRequest, ...grpc.CallOption) (*GetSealedHeightForBlockResponse, error) {
+					return nil, status.Error(codes.NotFound, "block not sealed")
+				},
+			},
+		}
+
+		_, err := index.SealedAtHeight(blockID)
+
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				GetSealedHeightForBlockFunc: func(context.Context, *GetSealedHeightForBlockRequest, ...grpc.CallOption) (*GetSealedHeightForBlockResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.SealedAtHeight(blockID)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, archive.ErrNotFound)
+	})
+}
+
+func TestIndex_ListSealsForHeight(t *testing.T) {
+	sealIDs := mocks.GenericSealIDs(4)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		data := make([][]byte, 0, len(sealIDs))
+		for _, sealID := range sealIDs {
+			data = append(data, mocks.ByteSlice(sealID))
+		}
+
+		index := Index{
+			codec: mocks.BaselineCodec(t),
+			client: &apiMock{
+				ListSealsForHeightFunc: func(_ context.Context, in *ListSealsForHeightRequest, _ ...grpc.CallOption) (*ListSealsForHeightResponse, error) {
+					assert.Equal(t, mocks.GenericHeight, in.Height)
+
+					return &ListSealsForHeightResponse{
+						Height:  in.Height,
+						SealIDs: data,
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.SealsByHeight(mocks.GenericHeight)
+
+		require.NoError(t, err)
+		assert.Equal(t, sealIDs, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			codec: mocks.BaselineCodec(t),
+			client: &apiMock{
+				ListSealsForHeightFunc: func(context.Context, *ListSealsForHeightRequest, ...grpc.CallOption) (*ListSealsForHeightResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.SealsByHeight(mocks.GenericHeight)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_ListRegistersForHeight(t *testing.T) {
+	regs := mocks.GenericRegisters(4)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForHeightFunc: func(_ context.Context, in *ListRegistersForHeightRequest, _ ...grpc.CallOption) (*ListRegistersForHeightResponse, error) {
+					assert.Equal(t, mocks.GenericHeight, in.Height)
+					assert.False(t, in.IncludeValues)
+
+					return &ListRegistersForHeightResponse{
+						Height:    in.Height,
+						Registers: convert.RegistersToBytes(regs),
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.RegistersByHeight(mocks.GenericHeight)
+
+		require.NoError(t, err)
+		assert.Equal(t, regs, got)
+	})
+
+	t.Run("follows pages", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForHeightFunc: func(_ context.Context, in *ListRegistersForHeightRequest, _ ...grpc.CallOption) (*ListRegistersForHeightResponse, error) {
+					if in.StartIndex == 0 {
+						return &ListRegistersForHeightResponse{
+							Height:    in.Height,
+							Registers: convert.RegistersToBytes(regs[:3]),
+							NextIndex: 3,
+						}, nil
+					}
+
+					assert.Equal(t, uint32(3), in.StartIndex)
+					return &ListRegistersForHeightResponse{
+						Height:    in.Height,
+						Registers: convert.RegistersToBytes(regs[3:]),
+					}, nil
+				},
+			},
+		}
+
+		got, err := index.RegistersByHeight(mocks.GenericHeight)
+
+		require.NoError(t, err)
+		assert.Equal(t, regs, got)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForHeightFunc: func(context.Context, *ListRegistersForHeightRequest, ...grpc.CallOption) (*ListRegistersForHeightResponse, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		_, err := index.RegistersByHeight(mocks.GenericHeight)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_RegistersByOwner(t *testing.T) {
+	regs := mocks.GenericRegisters(4)
+	values := mocks.GenericRegisterValues(4)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForOwnerFunc: func(_ context.Context, in *ListRegistersForOwnerRequest, _ ...grpc.CallOption) (API_ListRegistersForOwnerClient, error) {
+					assert.Equal(t, mocks.GenericHeight, in.Height)
+					assert.Equal(t, []byte(regs[0].Owner), in.Owner)
+
+					stream := ownerStreamMock{
+						responses: []*ListRegistersForOwnerResponse{
+							{
+								Height:    in.Height,
+								Registers: convert.RegistersToBytes(regs[:3]),
+								Values:    convert.ValuesToBytes(values[:3]),
+							},
+							{
+								Height:    in.Height,
+								Registers: convert.RegistersToBytes(regs[3:]),
+								Values:    convert.ValuesToBytes(values[3:]),
+							},
+						},
+					}
+					return &stream, nil
+				},
+			},
+		}
+
+		var got flow.RegisterEntries
+		err := index.RegistersByOwner(mocks.GenericHeight, regs[0].Owner, func(entry flow.RegisterEntry) error {
+			got = append(got, entry)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, got, len(regs))
+		for i, entry := range got {
+			assert.Equal(t, regs[i], entry.Key)
+			assert.Equal(t, values[i], entry.Value)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForOwnerFunc: func(context.Context, *ListRegistersForOwnerRequest, ...grpc.CallOption) (API_ListRegistersForOwnerClient, error) {
+					return nil, mocks.GenericError
+				},
+			},
+		}
+
+		err := index.RegistersByOwner(mocks.GenericHeight, regs[0].Owner, func(flow.RegisterEntry) error {
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles stream failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForOwnerFunc: func(context.Context, *ListRegistersForOwnerRequest, ...grpc.CallOption) (API_ListRegistersForOwnerClient, error) {
+					return &ownerStreamMock{err: mocks.GenericError}, nil
+				},
+			},
+		}
+
+		err := index.RegistersByOwner(mocks.GenericHeight, regs[0].Owner, func(flow.RegisterEntry) error {
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles callback failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := Index{
+			client: &apiMock{
+				ListRegistersForOwnerFunc: func(context.Context, *ListRegistersForOwnerRequest, ...grpc.CallOption) (API_ListRegistersForOwnerClient, error) {
+					stream := ownerStreamMock{
+						responses: []*ListRegistersForOwnerResponse{
+							{
+								Registers: convert.RegistersToBytes(regs),
+								Values:    convert.ValuesToBytes(values),
+							},
+						},
+					}
+					return &stream, nil
+				},
+			},
+		}
+
+		err := index.RegistersByOwner(mocks.GenericHeight, regs[0].Owner, func(flow.RegisterEntry) error {
+			return mocks.GenericError
+		})
+
+		assert.ErrorIs(t, err, mocks.GenericError)
+	})
+}
+
+type apiMock struct {
+	GetFirstFunc                   func(ctx context.Context, in *GetFirstRequest, opts ...grpc.CallOption) (*GetFirstResponse, error)
+	GetLastFunc                    func(ctx context.Context, in *GetLastRequest, opts ...grpc.CallOption) (*GetLastResponse, error)
+	GetRegisterRetentionFunc       func(ctx context.Context, in *GetRegisterRetentionRequest, opts ...grpc.CallOption) (*GetRegisterRetentionResponse, error)
+	GetHeightForBlockFunc          func(ctx context.Context, in *GetHeightForBlockRequest, opts ...grpc.CallOption) (*GetHeightForBlockResponse, error)
+	GetHeightForTimestampFunc      func(ctx context.Context, in *GetHeightForTimestampRequest, opts ...grpc.CallOption) (*GetHeightForTimestampResponse, error)
+	GetCommitFunc                  func(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error)
+	GetHeaderFunc                  func(ctx context.Context, in *GetHeaderRequest, opts ...grpc.CallOption) (*GetHeaderResponse, error)
+	GetEventsFunc                  func(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
+	ListHeightsForEventTypesFunc   func(ctx context.Context, in *ListHeightsForEventTypesRequest, opts ...grpc.CallOption) (*ListHeightsForEventTypesResponse, error)
+	ListEventsForContractFunc      func(ctx context.Context, in *ListEventsForContractRequest, opts ...grpc.CallOption) (*ListEventsForContractResponse, error)
+	GetRegisterValuesFunc          func(ctx context.Context, in *GetRegisterValuesRequest, opts ...grpc.CallOption) (*GetRegisterValuesResponse, error)
+	GetRegisterHistoryFunc         func(ctx context.Context, in *GetRegisterHistoryRequest, opts ...grpc.CallOption) (*GetRegisterHistoryResponse, error)
+	GetCollectionFunc              func(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*GetCollectionResponse, error)
+	ListCollectionsForHeightFunc   func(ctx context.Context, in *ListCollectionsForHeightRequest, opts ...grpc.CallOption) (*ListCollectionsForHeightResponse, error)
+	GetHeightForCollectionFunc     func(ctx context.Context, in *GetHeightForCollectionRequest, opts ...grpc.CallOption) (*GetHeightForCollectionResponse, error)
+	GetGuaranteeFunc               func(ctx context.Context, in *GetGuaranteeRequest, opts ...grpc.CallOption) (*GetGuaranteeResponse, error)
+	GetTransactionFunc             func(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	GetHeightForTransactionFunc    func(ctx context.Context, in *GetHeightForTransactionRequest, opts ...grpc.CallOption) (*GetHeightForTransactionResponse, error)
+	ListTransactionsForHeightFunc  func(ctx context.Context, in *ListTransactionsForHeightRequest, opts ...grpc.CallOption) (*ListTransactionsForHeightResponse, error)
+	ListTransactionsForAddressFunc func(ctx context.Context, in *ListTransactionsForAddressRequest, opts ...grpc.CallOption) (*ListTransactionsForAddressResponse, error)
+	ListTransfersForAddressFunc    func(ctx context.Context, in *ListTransfersForAddressRequest, opts ...grpc.CallOption) (*ListTransfersForAddressResponse, error)
+	ListBalanceHistoryFunc         func(ctx context.Context, in *ListBalanceHistoryRequest, opts ...grpc.CallOption) (*ListBalanceHistoryResponse, error)
+	ListContractsForAddressFunc    func(ctx context.Context, in *ListContractsForAddressRequest, opts ...grpc.CallOption) (*ListContractsForAddressResponse, error)
+	ListContractUpdatesFunc        func(ctx context.Context, in *ListContractUpdatesRequest, opts ...grpc.CallOption) (*ListContractUpdatesResponse, error)
+	GetContractCodeFunc            func(ctx context.Context, in *GetContractCodeRequest, opts ...grpc.CallOption) (*GetContractCodeResponse, error)
+	GetAccountKeysFunc             func(ctx context.Context, in *GetAccountKeysRequest, opts ...grpc.CallOption) (*GetAccountKeysResponse, error)
+	ListAccountKeyHistoryFunc      func(ctx context.Context, in *ListAccountKeyHistoryRequest, opts ...grpc.CallOption) (*ListAccountKeyHistoryResponse, error)
+	GetResultFunc                  func(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error)
+	GetSealFunc                    func(ctx context.Context, in *GetSealRequest, opts ...grpc.CallOption) (*GetSealResponse, error)
+	GetHeightForSealFunc           func(ctx context.Context, in *GetHeightForSealRequest, opts ...grpc.CallOption) (*GetHeightForSealResponse, error)
+	GetSealedHeightForBlockFunc    func(ctx context.Context, in *GetSealedHeightForBlockRequest, opts ...grpc.CallOption) (*GetSealedHeightForBlockResponse, error)
+	ListSealsForHeightFunc         func(ctx context.Context, in *ListSealsForHeightRequest, opts ...grpc.CallOption) (*ListSealsForHeightResponse, error)
+	ListRegistersForHeightFunc     func(ctx context.Context, in *ListRegistersForHeightRequest, opts ...grpc.CallOption) (*ListRegistersForHeightResponse, error)
+	ListRegistersForOwnerFunc      func(ctx context.Context, in *ListRegistersForOwnerRequest, opts ...grpc.CallOption) (API_ListRegistersForOwnerClient, error)
+	SubscribeHeadersFunc           func(ctx context.Context, in *SubscribeHeadersRequest, opts ...grpc.CallOption) (API_SubscribeHeadersClient, error)
+	SubscribeEventsFunc            func(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (API_SubscribeEventsClient, error)
+	SubscribeRegisterChangesFunc   func(ctx context.Context, in *SubscribeRegisterChangesRequest, opts ...grpc.CallOption) (API_SubscribeRegisterChangesClient, error)
+}
+
+func (a *apiMock) GetFirst(ctx context.Context, in *GetFirstRequest, opts ...grpc.CallOption) (*GetFirstResponse, error) {
+	return a.GetFirstFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) GetLast(ctx context.Context, in *GetLastRequest, opts ...grpc.CallOption) (*GetLastResponse, error) {
+	return a.GetLastFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) GetRegisterRetention(ctx context.Context, in *GetRegisterRetentionRequest, opts ...grpc.CallOption) (*GetRegisterRetentionResponse, error) {
+	return a.GetRegisterRetentionFunc(ctx, in, opts...)
+}
 
 func (a *apiMock) GetHeightForBlock(ctx context.Context, in *GetHeightForBlockRequest, opts ...grpc.CallOption) (*GetHeightForBlockResponse, error) {
 	return a.GetHeightForBlockFunc(ctx, in, opts...)
 }
 
+func (a *apiMock) GetHeightForTimestamp(ctx context.Context, in *GetHeightForTimestampRequest, opts ...grpc.CallOption) (*GetHeightForTimestampResponse, error) {
+	return a.GetHeightForTimestampFunc(ctx, in, opts...)
+}
+
 func (a *apiMock) GetCommit(ctx context.Context, in *GetCommitRequest, opts ...grpc.CallOption) (*GetCommitResponse, error) {
 	return a.GetCommitFunc(ctx, in, opts...)
 }
@@ -830,10 +2098,22 @@ func (a *apiMock) GetEvents(ctx context.Context, in *GetEventsRequest, opts ...g
 	return a.GetEventsFunc(ctx, in, opts...)
 }
 
+func (a *apiMock) ListHeightsForEventTypes(ctx context.Context, in *ListHeightsForEventTypesRequest, opts ...grpc.CallOption) (*ListHeightsForEventTypesResponse, error) {
+	return a.ListHeightsForEventTypesFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListEventsForContract(ctx context.Context, in *ListEventsForContractRequest, opts ...grpc.CallOption) (*ListEventsForContractResponse, error) {
+	return a.ListEventsForContractFunc(ctx, in, opts...)
+}
+
 func (a *apiMock) GetRegisterValues(ctx context.Context, in *GetRegisterValuesRequest, opts ...grpc.CallOption) (*GetRegisterValuesResponse, error) {
 	return a.GetRegisterValuesFunc(ctx, in, opts...)
 }
 
+func (a *apiMock) GetRegisterHistory(ctx context.Context, in *GetRegisterHistoryRequest, opts ...grpc.CallOption) (*GetRegisterHistoryResponse, error) {
+	return a.GetRegisterHistoryFunc(ctx, in, opts...)
+}
+
 func (a *apiMock) GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*GetCollectionResponse, error) {
 	return a.GetCollectionFunc(ctx, in, opts...)
 }
@@ -842,6 +2122,10 @@ func (a *apiMock) ListCollectionsForHeight(ctx context.Context, in *ListCollecti
 	return a.ListCollectionsForHeightFunc(ctx, in, opts...)
 }
 
+func (a *apiMock) GetHeightForCollection(ctx context.Context, in *GetHeightForCollectionRequest, opts ...grpc.CallOption) (*GetHeightForCollectionResponse, error) {
+	return a.GetHeightForCollectionFunc(ctx, in, opts...)
+}
+
 func (a *apiMock) GetGuarantee(ctx context.Context, in *GetGuaranteeRequest, opts ...grpc.CallOption) (*GetGuaranteeResponse, error) {
 	return a.GetGuaranteeFunc(ctx, in, opts...)
 }
@@ -858,6 +2142,38 @@ func (a *apiMock) ListTransactionsForHeight(ctx context.Context, in *ListTransac
 	return a.ListTransactionsForHeightFunc(ctx, in, opts...)
 }
 
+func (a *apiMock) ListTransactionsForAddress(ctx context.Context, in *ListTransactionsForAddressRequest, opts ...grpc.CallOption) (*ListTransactionsForAddressResponse, error) {
+	return a.ListTransactionsForAddressFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListTransfersForAddress(ctx context.Context, in *ListTransfersForAddressRequest, opts ...grpc.CallOption) (*ListTransfersForAddressResponse, error) {
+	return a.ListTransfersForAddressFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListBalanceHistory(ctx context.Context, in *ListBalanceHistoryRequest, opts ...grpc.CallOption) (*ListBalanceHistoryResponse, error) {
+	return a.ListBalanceHistoryFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListContractsForAddress(ctx context.Context, in *ListContractsForAddressRequest, opts ...grpc.CallOption) (*ListContractsForAddressResponse, error) {
+	return a.ListContractsForAddressFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListContractUpdates(ctx context.Context, in *ListContractUpdatesRequest, opts ...grpc.CallOption) (*ListContractUpdatesResponse, error) {
+	return a.ListContractUpdatesFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) GetContractCode(ctx context.Context, in *GetContractCodeRequest, opts ...grpc.CallOption) (*GetContractCodeResponse, error) {
+	return a.GetContractCodeFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) GetAccountKeys(ctx context.Context, in *GetAccountKeysRequest, opts ...grpc.CallOption) (*GetAccountKeysResponse, error) {
+	return a.GetAccountKeysFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListAccountKeyHistory(ctx context.Context, in *ListAccountKeyHistoryRequest, opts ...grpc.CallOption) (*ListAccountKeyHistoryResponse, error) {
+	return a.ListAccountKeyHistoryFunc(ctx, in, opts...)
+}
+
 func (a *apiMock) GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error) {
 	return a.GetResultFunc(ctx, in, opts...)
 }
@@ -866,6 +2182,56 @@ func (a *apiMock) GetSeal(ctx context.Context, in *GetSealRequest, opts ...grpc.
 	return a.GetSealFunc(ctx, in, opts...)
 }
 
+func (a *apiMock) GetHeightForSeal(ctx context.Context, in *GetHeightForSealRequest, opts ...grpc.CallOption) (*GetHeightForSealResponse, error) {
+	return a.GetHeightForSealFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) GetSealedHeightForBlock(ctx context.Context, in *GetSealedHeightForBlockRequest, opts ...grpc.CallOption) (*GetSealedHeightForBlockResponse, error) {
+	return a.GetSealedHeightForBlockFunc(ctx, in, opts...)
+}
+
 func (a *apiMock) ListSealsForHeight(ctx context.Context, in *ListSealsForHeightRequest, opts ...grpc.CallOption) (*ListSealsForHeightResponse, error) {
 	return a.ListSealsForHeightFunc(ctx, in, opts...)
 }
+
+func (a *apiMock) ListRegistersForHeight(ctx context.Context, in *ListRegistersForHeightRequest, opts ...grpc.CallOption) (*ListRegistersForHeightResponse, error) {
+	return a.ListRegistersForHeightFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) ListRegistersForOwner(ctx context.Context, in *ListRegistersForOwnerRequest, opts ...grpc.CallOption) (API_ListRegistersForOwnerClient, error) {
+	return a.ListRegistersForOwnerFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) SubscribeHeaders(ctx context.Context, in *SubscribeHeadersRequest, opts ...grpc.CallOption) (API_SubscribeHeadersClient, error) {
+	return a.SubscribeHeadersFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (API_SubscribeEventsClient, error) {
+	return a.SubscribeEventsFunc(ctx, in, opts...)
+}
+
+func (a *apiMock) SubscribeRegisterChanges(ctx context.Context, in *SubscribeRegisterChangesRequest, opts ...grpc.CallOption) (API_SubscribeRegisterChangesClient, error) {
+	return a.SubscribeRegisterChangesFunc(ctx, in, opts...)
+}
+
+// ownerStreamMock returns the given responses one by one, followed by the
+// given error, or `io.EOF` if it is nil.
+type ownerStreamMock struct {
+	grpc.ClientStream
+
+	responses []*ListRegistersForOwnerResponse
+	err       error
+}
+
+func (o *ownerStreamMock) Recv() (*ListRegistersForOwnerResponse, error) {
+	if len(o.responses) == 0 {
+		if o.err != nil {
+			return nil, o.err
+		}
+		return nil, io.EOF
+	}
+
+	res := o.responses[0]
+	o.responses = o.responses[1:]
+	return res, nil
+}
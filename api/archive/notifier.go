@@ -0,0 +1,7 @@
+package archive
+
+// Notifier represents something that signals when a new height was indexed.
+// The channel it returns is closed on the next signal.
+type Notifier interface {
+	Wait() <-chan struct{}
+}
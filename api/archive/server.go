@@ -2,17 +2,84 @@ package archive
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/onflow/flow-archive/util"
 
 	"github.com/onflow/flow-go/model/flow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/models/convert"
+	"github.com/onflow/flow-archive/service/filter"
 	"github.com/onflow/flow-archive/service/trace"
 )
 
+const (
+	// DefaultRegisterHistoryLimit is the number of register versions returned
+	// by a single `GetRegisterHistory` call that does not specify a limit.
+	DefaultRegisterHistoryLimit = 1000
+	// MaxRegisterHistoryLimit is the maximum number of register versions
+	// returned by a single `GetRegisterHistory` call.
+	MaxRegisterHistoryLimit = 10000
+	// DefaultEventHeightsLimit is the number of heights returned by a single
+	// `ListHeightsForEventTypes` call that does not specify a limit.
+	DefaultEventHeightsLimit = 1000
+	// MaxEventHeightsLimit is the maximum number of heights returned by a
+	// single `ListHeightsForEventTypes` call.
+	MaxEventHeightsLimit = 10000
+	// DefaultContractEventsLimit is the number of heights whose events are
+	// returned by a single `ListEventsForContract` call that does not specify a
+	// limit.
+	DefaultContractEventsLimit = 100
+	// MaxContractEventsLimit is the maximum number of heights whose events are
+	// returned by a single `ListEventsForContract` call.
+	MaxContractEventsLimit = 1000
+	// DefaultAddressTransactionsLimit is the number of transactions returned by
+	// a single `ListTransactionsForAddress` call that does not specify a limit.
+	DefaultAddressTransactionsLimit = 1000
+	// MaxAddressTransactionsLimit is the maximum number of transactions
+	// returned by a single `ListTransactionsForAddress` call, unless the
+	// transactions of a single height exceed it.
+	MaxAddressTransactionsLimit = 10000
+	// DefaultAddressTransfersLimit is the number of token transfers returned by
+	// a single `ListTransfersForAddress` call that does not specify a limit.
+	DefaultAddressTransfersLimit = 1000
+	// MaxAddressTransfersLimit is the maximum number of token transfers
+	// returned by a single `ListTransfersForAddress` call, unless the
+	// transfers of a single height exceed it.
+	MaxAddressTransfersLimit = 10000
+	// DefaultBalanceHistoryLimit is the number of balance changes returned by a
+	// single `ListBalanceHistory` call that does not specify a limit.
+	DefaultBalanceHistoryLimit = 1000
+	// MaxBalanceHistoryLimit is the maximum number of balance changes returned
+	// by a single `ListBalanceHistory` call.
+	MaxBalanceHistoryLimit = 10000
+	// DefaultContractUpdatesLimit is the number of contract updates returned by
+	// a single `ListContractUpdates` call that does not specify a limit.
+	DefaultContractUpdatesLimit = 100
+	// MaxContractUpdatesLimit is the maximum number of contract updates
+	// returned by a single `ListContractUpdates` call, unless the updates of a
+	// single height exceed it.
+	MaxContractUpdatesLimit = 1000
+	// DefaultHeightRegistersLimit is the number of registers returned by a
+	// single `ListRegistersForHeight` call that does not specify a limit.
+	DefaultHeightRegistersLimit = 1000
+	// MaxHeightRegistersLimit is the maximum number of registers returned by a
+	// single `ListRegistersForHeight` call.
+	MaxHeightRegistersLimit = 10000
+	// OwnerRegistersBatchSize is the number of registers sent in each message
+	// of a `ListRegistersForOwner` stream.
+	OwnerRegistersBatchSize = 1000
+	// SubscriptionBatchSize is the maximum number of heights that a
+	// subscription processes before it checks whether it should end.
+	SubscriptionBatchSize = 1000
+)
+
 // Server is a simple implementation of the generated APIServer interface. It
 // uses an index reader interface as the backend to retrieve the desired data.
 // This is generally an on-disk interface, but could be a GRPC-based index as
@@ -21,6 +88,8 @@ type Server struct {
 	index archive.Reader
 	codec archive.Codec
 	cfg   Config
+	done  chan struct{}
+	stop  sync.Once
 	UnimplementedAPIServer
 }
 
@@ -36,11 +105,19 @@ func NewServer(index archive.Reader, codec archive.Codec, options ...Option) *Se
 		index: index,
 		codec: codec,
 		cfg:   cfg,
+		done:  make(chan struct{}),
 	}
 
 	return &s
 }
 
+// Stop ends all subscriptions that are being streamed. As subscriptions
+// otherwise never end, it needs to be called before the GRPC server can be
+// stopped gracefully.
+func (s *Server) Stop() {
+	s.stop.Do(func() { close(s.done) })
+}
+
 // GetFirst implements the `GetFirst` method of the generated GRPC server.
 func (s *Server) GetFirst(ctx context.Context, _ *GetFirstRequest) (*GetFirstResponse, error) {
 	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetFirst)
@@ -73,6 +150,24 @@ func (s *Server) GetLast(ctx context.Context, _ *GetLastRequest) (*GetLastRespon
 	return &res, nil
 }
 
+// GetRegisterRetention implements the `GetRegisterRetention` method of the
+// generated GRPC server.
+func (s *Server) GetRegisterRetention(ctx context.Context, _ *GetRegisterRetentionRequest) (*GetRegisterRetentionResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetRegisterRetention)
+	defer tracer.End()
+	retention, err := s.index.RegisterRetention()
+	if err != nil {
+		return nil, fmt.Errorf("could not get register retention: %w", err)
+	}
+
+	res := GetRegisterRetentionResponse{
+		Height:   retention.Height,
+		Interval: retention.Interval,
+	}
+
+	return &res, nil
+}
+
 // GetHeightForBlock implements the `GetHeightForBlock` method of the generated GRPC
 // server.
 func (s *Server) GetHeightForBlock(ctx context.Context, req *GetHeightForBlockRequest) (*GetHeightForBlockResponse, error) {
@@ -97,6 +192,31 @@ func (s *Server) GetHeightForBlock(ctx context.Context, req *GetHeightForBlockRe
 	return &res, nil
 }
 
+// GetHeightForTimestamp implements the `GetHeightForTimestamp` method of the
+// generated GRPC server.
+func (s *Server) GetHeightForTimestamp(ctx context.Context, req *GetHeightForTimestampRequest) (*GetHeightForTimestampResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetHeightForTimestamp)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	timestamp := time.Unix(0, req.Timestamp).UTC()
+	height, err := s.index.HeightForTimestamp(timestamp, archive.TimestampMode(req.Mode))
+	if err != nil {
+		return nil, fmt.Errorf("could not get height for timestamp: %w", err)
+	}
+
+	res := GetHeightForTimestampResponse{
+		Timestamp: req.Timestamp,
+		Mode:      req.Mode,
+		Height:    height,
+	}
+
+	return &res, nil
+}
+
 // GetCommit implements the `GetCommit` method of the generated GRPC server.
 func (s *Server) GetCommit(ctx context.Context, req *GetCommitRequest) (*GetCommitResponse, error) {
 	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetCommit)
@@ -147,14 +267,26 @@ func (s *Server) GetHeader(ctx context.Context, req *GetHeaderRequest) (*GetHead
 }
 
 // GetEvents implements the `GetEvents` method of the generated GRPC server.
+// Only the events whose payload fields satisfy all of the requested filters
+// are returned and, if requested, the response also contains the decoded
+// payload of each of them as plain JSON.
 func (s *Server) GetEvents(ctx context.Context, req *GetEventsRequest) (*GetEventsResponse, error) {
 	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetEvents)
 	defer tracer.End()
+	eventFilter, err := filter.New(req.Filters...)
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
 	types := convert.StringsToTypes(req.Types)
 	events, err := s.index.Events(req.Height, types...)
 	if err != nil {
 		return nil, fmt.Errorf("could not get events: %w", err)
 	}
+	events, err = eventFilter.Apply(events)
+	if err != nil {
+		return nil, fmt.Errorf("could not filter events: %w", err)
+	}
 
 	data, err := s.codec.Marshal(events)
 	if err != nil {
@@ -166,6 +298,134 @@ func (s *Server) GetEvents(ctx context.Context, req *GetEventsRequest) (*GetEven
 		Types:  req.Types,
 		Data:   data,
 	}
+	if req.Decode {
+		res.Decoded = make([][]byte, 0, len(events))
+		for _, event := range events {
+			decoded, err := filter.Decode(event)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode event: %w", err)
+			}
+			res.Decoded = append(res.Decoded, decoded)
+		}
+	}
+
+	return &res, nil
+}
+
+// ListHeightsForEventTypes implements the `ListHeightsForEventTypes` method of
+// the generated GRPC server. Heights at which events of any of the requested
+// types were emitted are returned by ascending height; when more heights are
+// available in the requested range, the response contains the height to use as
+// start height of the next request.
+func (s *Server) ListHeightsForEventTypes(ctx context.Context, req *ListHeightsForEventTypesRequest) (*ListHeightsForEventTypesResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListHeightsForEventTypes)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultEventHeightsLimit
+	}
+	if limit > MaxEventHeightsLimit {
+		limit = MaxEventHeightsLimit
+	}
+
+	// We ask for one more height than the limit, so we know whether there is
+	// another page without having to issue a second lookup.
+	types := convert.StringsToTypes(req.Types)
+	heights, err := s.index.EventHeights(types, req.StartHeight, req.EndHeight, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("could not list heights for event types: %w", err)
+	}
+
+	var nextHeight uint64
+	if len(heights) > limit {
+		nextHeight = heights[limit]
+		heights = heights[:limit]
+	}
+
+	res := ListHeightsForEventTypesResponse{
+		Heights:    heights,
+		NextHeight: nextHeight,
+	}
+
+	return &res, nil
+}
+
+// ListEventsForContract implements the `ListEventsForContract` method of the
+// generated GRPC server. Events emitted by the contracts deployed at the
+// requested address are returned grouped by ascending height; when more heights
+// are available in the requested range, the response contains the height to use
+// as start height of the next request.
+func (s *Server) ListEventsForContract(ctx context.Context, req *ListEventsForContractRequest) (*ListEventsForContractResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListEventsForContract)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	// We cap the range at the last indexed height, so that the next height we
+	// return can always be requested.
+	last, err := s.index.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not get last height: %w", err)
+	}
+	endHeight := req.EndHeight
+	if endHeight > last {
+		endHeight = last
+	}
+	if req.StartHeight > endHeight {
+		return nil, fmt.Errorf("the requested start height (%d) is beyond the last indexed height (%d)", req.StartHeight, last)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultContractEventsLimit
+	}
+	if limit > MaxContractEventsLimit {
+		limit = MaxContractEventsLimit
+	}
+
+	// We ask for the events of one more height than the limit, so we know
+	// whether there is another page without having to issue a second lookup.
+	address := flow.BytesToAddress(req.Address)
+	blocks, err := s.index.EventsByContract(address, req.StartHeight, endHeight, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("could not list events for contract: %w", err)
+	}
+
+	var nextHeight uint64
+	if len(blocks) > limit {
+		nextHeight = blocks[limit].Height
+		blocks = blocks[:limit]
+	}
+
+	events := make([]*BlockEvents, 0, len(blocks))
+	for _, block := range blocks {
+		data, err := s.codec.Marshal(block.Events)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode events (height: %d): %w", block.Height, err)
+		}
+		events = append(events, &BlockEvents{
+			Height: block.Height,
+			Data:   data,
+		})
+	}
+
+	res := ListEventsForContractResponse{
+		Blocks:     events,
+		NextHeight: nextHeight,
+	}
 
 	return &res, nil
 }
@@ -200,6 +460,77 @@ func (s *Server) GetRegisterValues(ctx context.Context, req *GetRegisterValuesRe
 	return &res, nil
 }
 
+// GetRegisterHistory implements the `GetRegisterHistory` method of the
+// generated GRPC server. Versions are returned by ascending height; when more
+// versions are available in the requested range, the response contains the
+// height at which the next page starts.
+func (s *Server) GetRegisterHistory(ctx context.Context, req *GetRegisterHistoryRequest) (*GetRegisterHistoryResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetRegisterHistory)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	// Registers are not available beyond the latest indexed register height,
+	// so we cap the range there instead of returning an incomplete history.
+	latest, err := s.index.LatestRegisterHeight()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest register height: %w", err)
+	}
+	endHeight := req.EndHeight
+	if endHeight > latest {
+		endHeight = latest
+	}
+	if req.StartHeight > endHeight {
+		return nil, fmt.Errorf("the requested start height (%d) is beyond the highest indexed height(%d) for registers", req.StartHeight, latest)
+	}
+
+	registers, err := convert.BytesToRegisters([][]byte{req.Register})
+	if err != nil {
+		return nil, fmt.Errorf("could not convert register: %w", err)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultRegisterHistoryLimit
+	}
+	if limit > MaxRegisterHistoryLimit {
+		limit = MaxRegisterHistoryLimit
+	}
+
+	// We ask for one more version than the limit, so we know whether there is
+	// another page without having to issue a second lookup.
+	versions, err := s.index.RegisterHistory(registers[0], req.StartHeight, endHeight, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve register history: %w", err)
+	}
+
+	var nextHeight uint64
+	if len(versions) > limit {
+		nextHeight = versions[limit].Height
+		versions = versions[:limit]
+	}
+
+	messages := make([]*RegisterVersion, 0, len(versions))
+	for _, version := range versions {
+		messages = append(messages, &RegisterVersion{
+			Height: version.Height,
+			Value:  version.Value,
+		})
+	}
+
+	res := GetRegisterHistoryResponse{
+		Versions:   messages,
+		NextHeight: nextHeight,
+	}
+
+	return &res, nil
+}
+
 // GetCollection implements the `GetCollection` method of the generated GRPC
 // server.
 func (s *Server) GetCollection(ctx context.Context, req *GetCollectionRequest) (*GetCollectionResponse, error) {
@@ -256,6 +587,30 @@ func (s *Server) ListCollectionsForHeight(ctx context.Context, req *ListCollecti
 	return &res, nil
 }
 
+// GetHeightForCollection implements the `GetHeightForCollection` method of the generated GRPC
+// server.
+func (s *Server) GetHeightForCollection(ctx context.Context, req *GetHeightForCollectionRequest) (*GetHeightForCollectionResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetHeightForCollection)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	collID := flow.HashToID(req.CollectionID)
+	height, err := s.index.HeightForCollection(collID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get height for collection: %w", err)
+	}
+
+	res := GetHeightForCollectionResponse{
+		CollectionID: req.CollectionID,
+		Height:       height,
+	}
+
+	return &res, nil
+}
+
 // GetGuarantee implements the `GetGuarantee` method of the generated GRPC
 // server.
 func (s *Server) GetGuarantee(ctx context.Context, req *GetGuaranteeRequest) (*GetGuaranteeResponse, error) {
@@ -366,6 +721,506 @@ func (s *Server) ListTransactionsForHeight(ctx context.Context, req *ListTransac
 	return &res, nil
 }
 
+// ListTransactionsForAddress implements the `ListTransactionsForAddress` method
+// of the generated GRPC server. Transactions in which the account has any of
+// the requested roles are returned by ascending height; when more transactions
+// are available in the requested range, the response contains the height to
+// use as start height of the next request.
+func (s *Server) ListTransactionsForAddress(ctx context.Context, req *ListTransactionsForAddressRequest) (*ListTransactionsForAddressResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListTransactionsForAddress)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	// We cap the range at the last indexed height, so that the next height we
+	// return can always be requested.
+	last, err := s.index.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not get last height: %w", err)
+	}
+	endHeight := req.EndHeight
+	if endHeight > last {
+		endHeight = last
+	}
+	if req.StartHeight > endHeight {
+		return nil, fmt.Errorf("the requested start height (%d) is beyond the last indexed height (%d)", req.StartHeight, last)
+	}
+
+	roles := archive.TransactionRole(req.Roles)
+	if roles == 0 {
+		roles = archive.RoleAny
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultAddressTransactionsLimit
+	}
+	if limit > MaxAddressTransactionsLimit {
+		limit = MaxAddressTransactionsLimit
+	}
+
+	// We ask for one more transaction than the limit, so we know whether there
+	// is another page without having to issue a second lookup.
+	address := flow.BytesToAddress(req.Address)
+	txs, err := s.index.TransactionsByAddress(address, req.StartHeight, endHeight, roles, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("could not list transactions by address: %w", err)
+	}
+
+	// Pages never end in the middle of a height, as the next page starts at the
+	// beginning of a height. If a single height holds more transactions than
+	// the limit, that height is returned as a whole.
+	var nextHeight uint64
+	if len(txs) > limit {
+		nextHeight = txs[limit].Height
+		cut := limit
+		for cut > 0 && txs[cut-1].Height == nextHeight {
+			cut--
+		}
+		txs = txs[:cut]
+	}
+	if nextHeight != 0 && len(txs) == 0 {
+		txs, err = s.index.TransactionsByAddress(address, nextHeight, nextHeight, roles, 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not list transactions by address: %w", err)
+		}
+		nextHeight++
+		if nextHeight > endHeight {
+			nextHeight = 0
+		}
+	}
+
+	transactions := make([]*AddressTransaction, 0, len(txs))
+	for _, tx := range txs {
+		transactions = append(transactions, &AddressTransaction{
+			Height:        tx.Height,
+			TransactionID: convert.IDToHash(tx.TransactionID),
+			Roles:         uint32(tx.Roles),
+		})
+	}
+
+	res := ListTransactionsForAddressResponse{
+		Transactions: transactions,
+		NextHeight:   nextHeight,
+	}
+
+	return &res, nil
+}
+
+// ListTransfersForAddress implements the `ListTransfersForAddress` method of
+// the generated GRPC server. Token transfers sent or received by the account
+// are returned by ascending height; when more transfers are available in the
+// requested range, the response contains the height to use as start height of
+// the next request. Heights at which token transfers were not indexed result
+// in a FailedPrecondition error.
+func (s *Server) ListTransfersForAddress(ctx context.Context, req *ListTransfersForAddressRequest) (*ListTransfersForAddressResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListTransfersForAddress)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	// We cap the range at the last indexed height, so that the next height we
+	// return can always be requested.
+	last, err := s.index.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not get last height: %w", err)
+	}
+	endHeight := req.EndHeight
+	if endHeight > last {
+		endHeight = last
+	}
+	if req.StartHeight > endHeight {
+		return nil, fmt.Errorf("the requested start height (%d) is beyond the last indexed height (%d)", req.StartHeight, last)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultAddressTransfersLimit
+	}
+	if limit > MaxAddressTransfersLimit {
+		limit = MaxAddressTransfersLimit
+	}
+
+	// We ask for one more transfer than the limit, so we know whether there is
+	// another page without having to issue a second lookup.
+	address := flow.BytesToAddress(req.Address)
+	transfers, err := s.index.TransfersByAddress(address, req.StartHeight, endHeight, limit+1)
+	if errors.Is(err, archive.ErrNotIndexed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "transfers not indexed: %v", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list transfers by address: %w", err)
+	}
+
+	// Pages never end in the middle of a height, as the next page starts at the
+	// beginning of a height. If a single height holds more transfers than the
+	// limit, that height is returned as a whole.
+	var nextHeight uint64
+	if len(transfers) > limit {
+		nextHeight = transfers[limit].Height
+		cut := limit
+		for cut > 0 && transfers[cut-1].Height == nextHeight {
+			cut--
+		}
+		transfers = transfers[:cut]
+	}
+	if nextHeight != 0 && len(transfers) == 0 {
+		transfers, err = s.index.TransfersByAddress(address, nextHeight, nextHeight, 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not list transfers by address: %w", err)
+		}
+		nextHeight++
+		if nextHeight > endHeight {
+			nextHeight = 0
+		}
+	}
+
+	results := make([]*Transfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		results = append(results, &Transfer{
+			Height:        transfer.Height,
+			TransactionID: convert.IDToHash(transfer.TransactionID),
+			EventIndex:    transfer.EventIndex,
+			Token:         transfer.Token,
+			From:          transfer.From.Bytes(),
+			To:            transfer.To.Bytes(),
+			Amount:        transfer.Amount,
+		})
+	}
+
+	res := ListTransfersForAddressResponse{
+		Transfers:  results,
+		NextHeight: nextHeight,
+	}
+
+	return &res, nil
+}
+
+// ListBalanceHistory implements the `ListBalanceHistory` method of the
+// generated GRPC server. The changes to the balance of the token for the
+// account are returned by ascending height; when more changes are available
+// in the requested range, the response contains the height to use as start
+// height of the next request. Heights at which token transfers were not indexed
+// result in a FailedPrecondition error.
+func (s *Server) ListBalanceHistory(ctx context.Context, req *ListBalanceHistoryRequest) (*ListBalanceHistoryResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListBalanceHistory)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	// We cap the range at the last indexed height, so that the next height we
+	// return can always be requested.
+	last, err := s.index.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not get last height: %w", err)
+	}
+	endHeight := req.EndHeight
+	if endHeight > last {
+		endHeight = last
+	}
+	if req.StartHeight > endHeight {
+		return nil, fmt.Errorf("the requested start height (%d) is beyond the last indexed height (%d)", req.StartHeight, last)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultBalanceHistoryLimit
+	}
+	if limit > MaxBalanceHistoryLimit {
+		limit = MaxBalanceHistoryLimit
+	}
+
+	// There is at most one change per height, so we ask for one more change
+	// than the limit to know where the next page starts.
+	address := flow.BytesToAddress(req.Address)
+	changes, err := s.index.BalanceHistory(address, req.Token, req.StartHeight, endHeight, limit+1)
+	if errors.Is(err, archive.ErrNotIndexed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "balance history not indexed: %v", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list balance history: %w", err)
+	}
+
+	var nextHeight uint64
+	if len(changes) > limit {
+		nextHeight = changes[limit].Height
+		changes = changes[:limit]
+	}
+
+	results := make([]*BalanceChange, 0, len(changes))
+	for _, change := range changes {
+		results = append(results, &BalanceChange{
+			Height:    change.Height,
+			Deposited: change.Deposited,
+			Withdrawn: change.Withdrawn,
+		})
+	}
+
+	res := ListBalanceHistoryResponse{
+		Changes:    results,
+		NextHeight: nextHeight,
+	}
+
+	return &res, nil
+}
+
+// ListContractsForAddress implements the `ListContractsForAddress` method of
+// the generated GRPC server. It returns the contracts deployed on the account
+// at the requested height, along with the height of the latest change made to
+// each of them.
+func (s *Server) ListContractsForAddress(ctx context.Context, req *ListContractsForAddressRequest) (*ListContractsForAddressResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListContractsForAddress)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	err = util.ValidateRegisterHeightIndexed(s.index, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	address := flow.BytesToAddress(req.Address)
+	contracts, err := s.index.ContractsByAddress(address, req.Height)
+	if err != nil {
+		return nil, fmt.Errorf("could not list contracts for address: %w", err)
+	}
+
+	results := make([]*Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		results = append(results, &Contract{
+			Name:   contract.Name,
+			Height: contract.Height,
+		})
+	}
+
+	res := ListContractsForAddressResponse{
+		Address:   req.Address,
+		Height:    req.Height,
+		Contracts: results,
+	}
+
+	return &res, nil
+}
+
+// ListContractUpdates implements the `ListContractUpdates` method of the
+// generated GRPC server. Changes made to the contracts of the account, or only
+// to the contract with the requested name, are returned by ascending height;
+// when more changes are available in the requested range, the response
+// contains the height to use as start height of the next request.
+func (s *Server) ListContractUpdates(ctx context.Context, req *ListContractUpdatesRequest) (*ListContractUpdatesResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListContractUpdates)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	if req.StartHeight > req.EndHeight {
+		return nil, fmt.Errorf("bad request: start height (%d) is above end height (%d)", req.StartHeight, req.EndHeight)
+	}
+
+	// We cap the range at the last indexed height, so that the next height we
+	// return can always be requested.
+	last, err := s.index.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not get last height: %w", err)
+	}
+	endHeight := req.EndHeight
+	if endHeight > last {
+		endHeight = last
+	}
+	if req.StartHeight > endHeight {
+		return nil, fmt.Errorf("the requested start height (%d) is beyond the last indexed height (%d)", req.StartHeight, last)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultContractUpdatesLimit
+	}
+	if limit > MaxContractUpdatesLimit {
+		limit = MaxContractUpdatesLimit
+	}
+
+	// We ask for one more update than the limit, so we know whether there is
+	// another page without having to issue a second lookup.
+	address := flow.BytesToAddress(req.Address)
+	updates, err := s.index.ContractHistory(address, req.Name, req.StartHeight, endHeight, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("could not list contract updates: %w", err)
+	}
+
+	// Pages never end in the middle of a height, as the next page starts at the
+	// beginning of a height. If a single height holds more updates than the
+	// limit, that height is returned as a whole.
+	var nextHeight uint64
+	if len(updates) > limit {
+		nextHeight = updates[limit].Height
+		cut := limit
+		for cut > 0 && updates[cut-1].Height == nextHeight {
+			cut--
+		}
+		updates = updates[:cut]
+	}
+	if nextHeight != 0 && len(updates) == 0 {
+		updates, err = s.index.ContractHistory(address, req.Name, nextHeight, nextHeight, 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not list contract updates: %w", err)
+		}
+		nextHeight++
+		if nextHeight > endHeight {
+			nextHeight = 0
+		}
+	}
+
+	results := make([]*ContractUpdate, 0, len(updates))
+	for _, update := range updates {
+		results = append(results, &ContractUpdate{
+			Height:        update.Height,
+			TransactionID: convert.IDToHash(update.TransactionID),
+			Name:          update.Name,
+			Action:        uint32(update.Action),
+			CodeHash:      update.CodeHash,
+		})
+	}
+
+	res := ListContractUpdatesResponse{
+		Updates:    results,
+		NextHeight: nextHeight,
+	}
+
+	return &res, nil
+}
+
+// GetContractCode implements the `GetContractCode` method of the generated
+// GRPC server. It returns the Cadence source of the contract as it was after
+// the block at the requested height, or a NotFound error if no contract with
+// that name was deployed on the account at that height.
+func (s *Server) GetContractCode(ctx context.Context, req *GetContractCodeRequest) (*GetContractCodeResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetContractCode)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	err = util.ValidateRegisterHeightIndexed(s.index, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	address := flow.BytesToAddress(req.Address)
+	code, err := s.index.ContractCode(address, req.Name, req.Height)
+	if errors.Is(err, archive.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "contract not deployed: %v", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get contract code: %w", err)
+	}
+
+	res := GetContractCodeResponse{
+		Address: req.Address,
+		Name:    req.Name,
+		Height:  req.Height,
+		Code:    code,
+	}
+
+	return &res, nil
+}
+
+// GetAccountKeys implements the `GetAccountKeys` method of the generated GRPC
+// server. It returns the keys of the account as they were after the latest
+// indexed change to them at or below the requested height, or a NotFound error
+// if no change to the keys of the account is indexed at or below that height.
+func (s *Server) GetAccountKeys(ctx context.Context, req *GetAccountKeysRequest) (*GetAccountKeysResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetAccountKeys)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	address := flow.BytesToAddress(req.Address)
+	keys, err := s.index.AccountKeysAt(address, req.Height)
+	if errors.Is(err, archive.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "account keys not indexed: %v", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get account keys: %w", err)
+	}
+
+	res := GetAccountKeysResponse{
+		Address: req.Address,
+		Height:  req.Height,
+		Keys:    make([]*AccountKey, 0, len(keys)),
+	}
+	for _, key := range keys {
+		res.Keys = append(res.Keys, &AccountKey{
+			Index:     key.Index,
+			PublicKey: key.PublicKey,
+			Weight:    key.Weight,
+			Revoked:   key.Revoked,
+			SeqNumber: key.SeqNumber,
+		})
+	}
+
+	return &res, nil
+}
+
+// ListAccountKeyHistory implements the `ListAccountKeyHistory` method of the
+// generated GRPC server. It returns the keys of the account after each
+// indexed height at which they changed, ordered by ascending height.
+func (s *Server) ListAccountKeyHistory(ctx context.Context, req *ListAccountKeyHistoryRequest) (*ListAccountKeyHistoryResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListAccountKeyHistory)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	address := flow.BytesToAddress(req.Address)
+	history, err := s.index.AccountKeyHistory(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get account key history: %w", err)
+	}
+
+	res := ListAccountKeyHistoryResponse{
+		Address: req.Address,
+		Changes: make([]*AccountKeyChange, 0, len(history)),
+	}
+	for _, change := range history {
+		keys := make([]*AccountKey, 0, len(change.Keys))
+		for _, key := range change.Keys {
+			keys = append(keys, &AccountKey{
+				Index:     key.Index,
+				PublicKey: key.PublicKey,
+				Weight:    key.Weight,
+				Revoked:   key.Revoked,
+				SeqNumber: key.SeqNumber,
+			})
+		}
+		res.Changes = append(res.Changes, &AccountKeyChange{
+			Height: change.Height,
+			Keys:   keys,
+		})
+	}
+
+	return &res, nil
+}
+
 // GetResult implements the `GetResult` method of the generated GRPC
 // server.
 func (s *Server) GetResult(ctx context.Context, req *GetResultRequest) (*GetResultResponse, error) {
@@ -425,6 +1280,58 @@ func (s *Server) GetSeal(ctx context.Context, req *GetSealRequest) (*GetSealResp
 	return &res, nil
 }
 
+// GetHeightForSeal implements the `GetHeightForSeal` method of the generated GRPC
+// server.
+func (s *Server) GetHeightForSeal(ctx context.Context, req *GetHeightForSealRequest) (*GetHeightForSealResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetHeightForSeal)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	sealID := flow.HashToID(req.SealID)
+	height, err := s.index.HeightForSeal(sealID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get height for seal: %w", err)
+	}
+
+	res := GetHeightForSealResponse{
+		SealID: req.SealID,
+		Height: height,
+	}
+
+	return &res, nil
+}
+
+// GetSealedHeightForBlock implements the `GetSealedHeightForBlock` method of the generated GRPC
+// server. If the block has not been sealed yet, it returns an error with the
+// `NotFound` code, so that clients can tell it apart from other failures.
+func (s *Server) GetSealedHeightForBlock(ctx context.Context, req *GetSealedHeightForBlockRequest) (*GetSealedHeightForBlockResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.GetSealedHeightForBlock)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	blockID := flow.HashToID(req.BlockID)
+	height, err := s.index.SealedAtHeight(blockID)
+	if errors.Is(err, archive.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "block not sealed: %v", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealed height for block: %w", err)
+	}
+
+	res := GetSealedHeightForBlockResponse{
+		BlockID: req.BlockID,
+		Height:  height,
+	}
+
+	return &res, nil
+}
+
 // ListSealsForHeight implements the `ListSealsForHeight` method of the generated GRPC
 // server.
 func (s *Server) ListSealsForHeight(ctx context.Context, req *ListSealsForHeightRequest) (*ListSealsForHeightResponse, error) {
@@ -457,3 +1364,417 @@ func (s *Server) ListSealsForHeight(ctx context.Context, req *ListSealsForHeight
 
 	return &res, nil
 }
+
+// ListRegistersForHeight implements the `ListRegistersForHeight` method of the
+// generated GRPC server. Registers are returned in pages, starting at the given
+// index; when more registers remain, the response contains the index of the
+// next page. When values are requested, the response also contains the value of
+// each register before and after the block at the given height was executed.
+// Old values are left out when the previous height was pruned.
+func (s *Server) ListRegistersForHeight(ctx context.Context, req *ListRegistersForHeightRequest) (*ListRegistersForHeightResponse, error) {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(ctx, trace.ListRegistersForHeight)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+
+	err = util.ValidateRegisterHeightIndexed(s.index, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	regs, err := s.index.RegistersByHeight(req.Height)
+	if err != nil {
+		return nil, fmt.Errorf("could not list registers by height: %w", err)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultHeightRegistersLimit
+	}
+	if limit > MaxHeightRegistersLimit {
+		limit = MaxHeightRegistersLimit
+	}
+
+	// Changed registers are stored in key order, so the index of a register
+	// within the height is stable across calls.
+	start := int(req.StartIndex)
+	if start > len(regs) {
+		start = len(regs)
+	}
+	end := start + limit
+	var nextIndex uint32
+	if end < len(regs) {
+		nextIndex = uint32(end)
+	} else {
+		end = len(regs)
+	}
+	regs = regs[start:end]
+
+	res := ListRegistersForHeightResponse{
+		Height:    req.Height,
+		Registers: convert.RegistersToBytes(regs),
+		NextIndex: nextIndex,
+	}
+
+	if !req.IncludeValues || len(regs) == 0 {
+		return &res, nil
+	}
+
+	newValues, err := s.index.Values(req.Height, regs)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve new values: %w", err)
+	}
+	res.NewValues = convert.ValuesToBytes(newValues)
+
+	// The first indexed height is the bootstrapped state, so there are no
+	// previous values to compare against.
+	first, err := s.index.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not get first height: %w", err)
+	}
+	if req.Height == first {
+		res.OldValues = convert.ValuesToBytes(make([]flow.RegisterValue, len(regs)))
+		return &res, nil
+	}
+
+	// The previous height might not be kept by the register retention, in
+	// which case its values can no longer be read.
+	retention, err := s.index.RegisterRetention()
+	if err != nil {
+		return nil, fmt.Errorf("could not get register retention: %w", err)
+	}
+	if !retention.Retains(req.Height - 1) {
+		return &res, nil
+	}
+
+	oldValues, err := s.index.Values(req.Height-1, regs)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve old values: %w", err)
+	}
+	res.OldValues = convert.ValuesToBytes(oldValues)
+
+	return &res, nil
+}
+
+// ListRegistersForOwner implements the `ListRegistersForOwner` method of the
+// generated GRPC server. It streams every register of the owner with its value
+// at the given height, in batches of up to `OwnerRegistersBatchSize` registers.
+func (s *Server) ListRegistersForOwner(req *ListRegistersForOwnerRequest, stream API_ListRegistersForOwnerServer) error {
+	_, tracer := s.cfg.tracer.StartSpanFromContext(stream.Context(), trace.ListRegistersForOwner)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return fmt.Errorf("bad request: %w", err)
+	}
+
+	err = util.ValidateRegisterHeightIndexed(s.index, req.Height)
+	if err != nil {
+		return err
+	}
+
+	res := &ListRegistersForOwnerResponse{
+		Height: req.Height,
+	}
+	err = s.index.RegistersByOwner(req.Height, string(req.Owner), func(entry flow.RegisterEntry) error {
+		res.Registers = append(res.Registers, entry.Key.Bytes())
+		res.Values = append(res.Values, entry.Value)
+		if len(res.Registers) < OwnerRegistersBatchSize {
+			return nil
+		}
+
+		err := stream.Send(res)
+		if err != nil {
+			return fmt.Errorf("could not send registers: %w", err)
+		}
+		res = &ListRegistersForOwnerResponse{
+			Height: req.Height,
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list registers for owner: %w", err)
+	}
+
+	if len(res.Registers) == 0 {
+		return nil
+	}
+	err = stream.Send(res)
+	if err != nil {
+		return fmt.Errorf("could not send registers: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeHeaders implements the `SubscribeHeaders` method of the generated
+// GRPC server. It streams the header of every height from the start height on,
+// first for the heights that are already indexed and then for new heights as
+// soon as they are indexed, until the client closes the stream.
+func (s *Server) SubscribeHeaders(req *SubscribeHeadersRequest, stream API_SubscribeHeadersServer) error {
+	ctx, tracer := s.cfg.tracer.StartSpanFromContext(stream.Context(), trace.SubscribeHeaders)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return fmt.Errorf("bad request: %w", err)
+	}
+
+	err = s.validateSubscriptionStart(req.StartHeight)
+	if err != nil {
+		return err
+	}
+
+	return s.follow(ctx, req.StartHeight, s.index.Last, func(startHeight uint64, endHeight uint64) error {
+		for height := startHeight; height <= endHeight; height++ {
+			header, err := s.index.Header(height)
+			if err != nil {
+				return fmt.Errorf("could not get header: %w", err)
+			}
+			data, err := s.codec.Marshal(header)
+			if err != nil {
+				return fmt.Errorf("could not encode header: %w", err)
+			}
+			res := SubscribeHeadersResponse{
+				Height: height,
+				Data:   data,
+			}
+			err = stream.Send(&res)
+			if err != nil {
+				return fmt.Errorf("could not send header: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeEvents implements the `SubscribeEvents` method of the generated
+// GRPC server. It streams the events of the requested types for every height
+// from the start height on, first for the heights that are already indexed and
+// then for new heights as soon as they are indexed, until the client closes the
+// stream. Heights without any matching events are skipped.
+func (s *Server) SubscribeEvents(req *SubscribeEventsRequest, stream API_SubscribeEventsServer) error {
+	ctx, tracer := s.cfg.tracer.StartSpanFromContext(stream.Context(), trace.SubscribeEvents)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return fmt.Errorf("bad request: %w", err)
+	}
+
+	err = s.validateSubscriptionStart(req.StartHeight)
+	if err != nil {
+		return err
+	}
+
+	types := convert.StringsToTypes(req.Types)
+	send := func(height uint64) error {
+		events, err := s.index.Events(height, types...)
+		if err != nil {
+			return fmt.Errorf("could not get events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		data, err := s.codec.Marshal(events)
+		if err != nil {
+			return fmt.Errorf("could not encode events: %w", err)
+		}
+		res := SubscribeEventsResponse{
+			Height: height,
+			Types:  req.Types,
+			Data:   data,
+		}
+		err = stream.Send(&res)
+		if err != nil {
+			return fmt.Errorf("could not send events: %w", err)
+		}
+		return nil
+	}
+
+	return s.follow(ctx, req.StartHeight, s.index.Last, func(startHeight uint64, endHeight uint64) error {
+
+		// Without types, every height has to be checked for events. With
+		// types, we can look up the heights that have matching events instead.
+		// As batches never exceed the size of the range, all of its heights
+		// are returned by a single lookup.
+		heights := make([]uint64, 0, endHeight-startHeight+1)
+		if len(types) == 0 {
+			for height := startHeight; height <= endHeight; height++ {
+				heights = append(heights, height)
+			}
+		} else {
+			var err error
+			heights, err = s.index.EventHeights(types, startHeight, endHeight, int(endHeight-startHeight+1))
+			if err != nil {
+				return fmt.Errorf("could not list heights for event types: %w", err)
+			}
+		}
+
+		for _, height := range heights {
+			err := send(height)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SubscribeRegisterChanges implements the `SubscribeRegisterChanges` method of
+// the generated GRPC server. It streams the registers of the requested owners
+// that changed at every height from the start height on, with their new values,
+// first for the heights that are already indexed and then for new heights as
+// soon as they are indexed, until the client closes the stream. Heights without
+// any changes to registers of the owners are skipped.
+func (s *Server) SubscribeRegisterChanges(req *SubscribeRegisterChangesRequest, stream API_SubscribeRegisterChangesServer) error {
+	ctx, tracer := s.cfg.tracer.StartSpanFromContext(stream.Context(), trace.SubscribeRegisterChanges)
+	defer tracer.End()
+	err := req.Validate()
+	if err != nil {
+		return fmt.Errorf("bad request: %w", err)
+	}
+
+	err = s.validateSubscriptionStart(req.StartHeight)
+	if err != nil {
+		return err
+	}
+	err = s.validateRegisterRetention(req.StartHeight)
+	if err != nil {
+		return err
+	}
+
+	owners := make(map[string]struct{}, len(req.Owners))
+	for _, owner := range req.Owners {
+		owners[string(owner)] = struct{}{}
+	}
+
+	return s.follow(ctx, req.StartHeight, s.index.LatestRegisterHeight, func(startHeight uint64, endHeight uint64) error {
+
+		// The pruner might have caught up with a subscription that lags
+		// behind, in which case the values of its next heights are gone.
+		err := s.validateRegisterRetention(startHeight)
+		if err != nil {
+			return err
+		}
+
+		for height := startHeight; height <= endHeight; height++ {
+			regs, err := s.index.RegistersByHeight(height)
+			if err != nil {
+				return fmt.Errorf("could not list registers by height: %w", err)
+			}
+			changes := make(flow.RegisterIDs, 0, len(regs))
+			for _, reg := range regs {
+				_, ok := owners[reg.Owner]
+				if ok {
+					changes = append(changes, reg)
+				}
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			values, err := s.index.Values(height, changes)
+			if err != nil {
+				return fmt.Errorf("could not retrieve values: %w", err)
+			}
+			res := SubscribeRegisterChangesResponse{
+				Height:    height,
+				Registers: convert.RegistersToBytes(changes),
+				Values:    convert.ValuesToBytes(values),
+			}
+			err = stream.Send(&res)
+			if err != nil {
+				return fmt.Errorf("could not send register changes: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// validateSubscriptionStart checks that a subscription does not start below the
+// first indexed height. It can start above the last indexed height, in which
+// case it waits for that height to be indexed.
+func (s *Server) validateSubscriptionStart(height uint64) error {
+	first, err := s.index.First()
+	if err != nil {
+		return fmt.Errorf("could not get first height: %w", err)
+	}
+	if height < first {
+		return fmt.Errorf("bad request: start height (%d) is below first indexed height (%d)", height, first)
+	}
+	return nil
+}
+
+// validateRegisterRetention checks that the registers of every height from the
+// given height on can still be read. Heights that are only retained because
+// they are a multiple of the pruning interval do not suffice, as the changes of
+// the heights in between are gone.
+func (s *Server) validateRegisterRetention(height uint64) error {
+	retention, err := s.index.RegisterRetention()
+	if err != nil {
+		return fmt.Errorf("could not get register retention: %w", err)
+	}
+	if height < retention.Height {
+		return fmt.Errorf("the requested height (%d) has been pruned for registers (retained from height %d)", height, retention.Height)
+	}
+	return nil
+}
+
+// follow calls the given function for every range of heights from the start
+// height on that is indexed according to the given function returning the
+// latest indexed height, in ascending order and in batches of up to
+// `SubscriptionBatchSize` heights. Once it is up to date, it waits for new
+// heights to be indexed. It only returns once the client closes the stream,
+// the server is stopped, or the given function fails.
+func (s *Server) follow(ctx context.Context, next uint64, latest func() (uint64, error), process func(startHeight uint64, endHeight uint64) error) error {
+
+	poll := time.NewTicker(s.cfg.pollInterval)
+	defer poll.Stop()
+
+	for {
+
+		// We get the channel of the next notification before looking up the
+		// latest height, so that a height indexed right after the lookup
+		// still wakes us up.
+		var notified <-chan struct{}
+		if s.cfg.notifier != nil {
+			notified = s.cfg.notifier.Wait()
+		}
+
+		last, err := latest()
+		if err != nil {
+			return fmt.Errorf("could not get latest height: %w", err)
+		}
+
+		if next > last {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-s.done:
+				return status.Error(codes.Unavailable, "server is stopping")
+			case <-notified:
+			case <-poll.C:
+			}
+			continue
+		}
+
+		end := next + SubscriptionBatchSize - 1
+		if end > last {
+			end = last
+		}
+		err = process(next, end)
+		if err != nil {
+			return err
+		}
+		next = end + 1
+
+		// Between batches, we only check whether the subscription should end
+		// before continuing with the next one.
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is stopping")
+		default:
+		}
+	}
+}
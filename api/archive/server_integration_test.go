@@ -18,9 +18,7 @@ import (
 	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/convert"
 	"github.com/onflow/flow-archive/service/index"
-	"github.com/onflow/flow-archive/service/storage"
 	"github.com/onflow/flow-archive/service/storage2"
-	"github.com/onflow/flow-archive/testing/helpers"
 	"github.com/onflow/flow-archive/testing/mocks"
 )
 
@@ -33,16 +31,13 @@ func getFakeDatabase(t *testing.T) (
 	log := zerolog.Nop()
 	codec := zbor.NewCodec()
 
-	db := helpers.InMemoryDB(t)
-
 	lib2, err := storage2.NewLibrary2(t.TempDir(), 1<<20)
 	require.NoError(t, err)
 
-	disk := storage.New(codec)
-	reader := index.NewReader(log, db, disk, lib2)
-	writer := index.NewWriter(db, disk, lib2)
+	reader := index.NewReader(log, lib2)
+	writer := index.NewWriter(lib2)
 
-	return reader, writer, codec, db
+	return reader, writer, codec, lib2
 }
 
 func TestIntegrationServer_GetFirst(t *testing.T) {
@@ -568,9 +563,12 @@ func TestIntegrationServer_GetTransaction(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, txID[:], resp.TransactionID)
 
-		wantData, err := codec.Marshal(transactions[0])
-		require.NoError(t, err)
-		assert.Equal(t, wantData, resp.Data)
+		// The index uses its own codec instance, and the first frame that a
+		// zstd encoder compresses with a dictionary differs from later ones,
+		// so we compare the decoded transaction rather than the encoded bytes.
+		var got flow.TransactionBody
+		require.NoError(t, codec.Unmarshal(resp.Data, &got))
+		assert.Equal(t, transactions[0], &got)
 	})
 
 	t.Run("handles indexer failure on Transaction", func(t *testing.T) {
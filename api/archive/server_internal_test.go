@@ -2,14 +2,21 @@ package archive
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/model/flow"
 
+	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/models/convert"
+	"github.com/onflow/flow-archive/service/filter"
 	"github.com/onflow/flow-archive/testing/mocks"
 )
 
@@ -144,6 +151,68 @@ func TestServer_GetLast(t *testing.T) {
 	}
 }
 
+func TestServer_GetRegisterRetention(t *testing.T) {
+
+	tests := []struct {
+		name string
+
+		mockRetention archive.RegisterRetention
+		mockErr       error
+
+		wantRes *GetRegisterRetentionResponse
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			mockRetention: archive.RegisterRetention{Height: mocks.GenericHeight, Interval: 100},
+
+			wantRes: &GetRegisterRetentionResponse{
+				Height:   mocks.GenericHeight,
+				Interval: 100,
+			},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "error case",
+
+			mockErr: mocks.GenericError,
+
+			wantRes: nil,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.RegisterRetentionFunc = func() (archive.RegisterRetention, error) {
+				return test.mockRetention, test.mockErr
+			}
+
+			s := Server{
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			req := &GetRegisterRetentionRequest{}
+
+			gotRes, gotErr := s.GetRegisterRetention(context.Background(), req)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				assert.Equal(t, test.wantRes, gotRes)
+			}
+		})
+	}
+}
+
 func TestServer_GetHeightForBlock(t *testing.T) {
 	blockID := mocks.GenericHeader.ID()
 	tests := []struct {
@@ -216,6 +285,97 @@ func TestServer_GetHeightForBlock(t *testing.T) {
 	}
 }
 
+func TestServer_GetHeightForTimestamp(t *testing.T) {
+	timestamp := time.Date(2023, time.March, 31, 23, 59, 59, 0, time.UTC)
+	tests := []struct {
+		name string
+
+		req *GetHeightForTimestampRequest
+
+		mockErr error
+
+		wantMode archive.TimestampMode
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "at or before",
+
+			req: &GetHeightForTimestampRequest{
+				Timestamp: timestamp.UnixNano(),
+				Mode:      uint32(archive.AtOrBefore),
+			},
+
+			wantMode: archive.AtOrBefore,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "at or after",
+
+			req: &GetHeightForTimestampRequest{
+				Timestamp: timestamp.UnixNano(),
+				Mode:      uint32(archive.AtOrAfter),
+			},
+
+			wantMode: archive.AtOrAfter,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles invalid mode",
+
+			req: &GetHeightForTimestampRequest{
+				Timestamp: timestamp.UnixNano(),
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "error handling",
+
+			req: &GetHeightForTimestampRequest{
+				Timestamp: timestamp.UnixNano(),
+				Mode:      uint32(archive.AtOrBefore),
+			},
+
+			mockErr: mocks.GenericError,
+
+			wantMode: archive.AtOrBefore,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.HeightForTimestampFunc = func(gotTimestamp time.Time, mode archive.TimestampMode) (uint64, error) {
+				assert.True(t, timestamp.Equal(gotTimestamp))
+				assert.Equal(t, test.wantMode, mode)
+				return mocks.GenericHeight, test.mockErr
+			}
+
+			s := Server{
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			gotRes, gotErr := s.GetHeightForTimestamp(context.Background(), test.req)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				assert.Equal(t, mocks.GenericHeight, gotRes.Height)
+				assert.Equal(t, test.req.Timestamp, gotRes.Timestamp)
+				assert.Equal(t, test.req.Mode, gotRes.Mode)
+			}
+		})
+	}
+}
+
 func TestServer_GetCommit(t *testing.T) {
 	tests := []struct {
 		name string
@@ -373,17 +533,25 @@ func TestServer_GetHeader(t *testing.T) {
 }
 
 func TestServer_GetEvents(t *testing.T) {
+	events := mocks.GenericEvents(4)
+	filters := []string{fmt.Sprintf("address == %s", mocks.GenericAddress(1).HexWithPrefix())}
+	decoded, err := filter.Decode(events[1])
+	require.NoError(t, err)
+
 	tests := []struct {
 		name string
 
-		reqHeight uint64
-		reqTypes  []flow.EventType
+		reqHeight  uint64
+		reqTypes   []flow.EventType
+		reqFilters []string
+		reqDecode  bool
 
 		mockEvents []flow.Event
 		mockErr    error
 
 		wantHeight uint64
 		wantTypes  []flow.EventType
+		wantEvents []flow.Event
 		wantRes    *GetEventsResponse
 
 		checkErr require.ErrorAssertionFunc
@@ -394,11 +562,32 @@ func TestServer_GetEvents(t *testing.T) {
 			reqHeight: mocks.GenericHeight,
 			reqTypes:  mocks.GenericEventTypes(2),
 
-			mockEvents: mocks.GenericEvents(4),
+			mockEvents: events,
 			mockErr:    nil,
 
 			wantHeight: mocks.GenericHeight,
 			wantTypes:  mocks.GenericEventTypes(2),
+			wantEvents: events,
+			wantRes: &GetEventsResponse{
+				Height: mocks.GenericHeight,
+				Types:  convert.TypesToStrings(mocks.GenericEventTypes(2)),
+				Data:   mocks.GenericBytes,
+			},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "filtered events",
+
+			reqHeight:  mocks.GenericHeight,
+			reqTypes:   mocks.GenericEventTypes(2),
+			reqFilters: filters,
+
+			mockEvents: events,
+
+			wantHeight: mocks.GenericHeight,
+			wantTypes:  mocks.GenericEventTypes(2),
+			wantEvents: events[1:2],
 			wantRes: &GetEventsResponse{
 				Height: mocks.GenericHeight,
 				Types:  convert.TypesToStrings(mocks.GenericEventTypes(2)),
@@ -407,19 +596,56 @@ func TestServer_GetEvents(t *testing.T) {
 
 			checkErr: require.NoError,
 		},
+		{
+			name: "decoded events",
+
+			reqHeight:  mocks.GenericHeight,
+			reqTypes:   mocks.GenericEventTypes(2),
+			reqFilters: filters,
+			reqDecode:  true,
+
+			mockEvents: events,
+
+			wantHeight: mocks.GenericHeight,
+			wantTypes:  mocks.GenericEventTypes(2),
+			wantEvents: events[1:2],
+			wantRes: &GetEventsResponse{
+				Height:  mocks.GenericHeight,
+				Types:   convert.TypesToStrings(mocks.GenericEventTypes(2)),
+				Data:    mocks.GenericBytes,
+				Decoded: [][]byte{decoded},
+			},
+
+			checkErr: require.NoError,
+		},
 		{
 			name: "error case",
 
 			reqHeight: mocks.GenericHeight,
 			reqTypes:  mocks.GenericEventTypes(2),
 
-			mockEvents: mocks.GenericEvents(4),
+			mockEvents: events,
 			mockErr:    mocks.GenericError,
 
 			wantHeight: mocks.GenericHeight,
 			wantTypes:  mocks.GenericEventTypes(2),
 			wantRes:    nil,
 
+			checkErr: require.Error,
+		},
+		{
+			name: "filter evaluation error",
+
+			reqHeight:  mocks.GenericHeight,
+			reqTypes:   mocks.GenericEventTypes(2),
+			reqFilters: []string{"address > 0x1"},
+
+			mockEvents: events,
+
+			wantHeight: mocks.GenericHeight,
+			wantTypes:  mocks.GenericEventTypes(2),
+			wantRes:    nil,
+
 			checkErr: require.Error,
 		},
 	}
@@ -429,9 +655,11 @@ func TestServer_GetEvents(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
+			var gotEvents []flow.Event
 			codec := mocks.BaselineCodec(t)
 			codec.MarshalFunc = func(v interface{}) ([]byte, error) {
 				assert.IsType(t, []flow.Event{}, v)
+				gotEvents = v.([]flow.Event)
 				return mocks.GenericBytes, nil
 			}
 
@@ -451,82 +679,143 @@ func TestServer_GetEvents(t *testing.T) {
 			}
 
 			req := &GetEventsRequest{
-				Height: test.reqHeight,
-				Types:  convert.TypesToStrings(test.reqTypes),
+				Height:  test.reqHeight,
+				Types:   convert.TypesToStrings(test.reqTypes),
+				Filters: test.reqFilters,
+				Decode:  test.reqDecode,
 			}
 			gotRes, gotErr := s.GetEvents(context.Background(), req)
 
 			test.checkErr(t, gotErr)
 			assert.Equal(t, mocks.GenericHeight, gotHeight)
 			assert.Equal(t, test.wantTypes, gotTypes)
+			assert.Equal(t, test.wantEvents, gotEvents)
 			if gotErr == nil {
 				assert.Equal(t, test.wantRes, gotRes)
 			}
 		})
 	}
+
+	t.Run("handles invalid filters", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventsFunc = func(uint64, ...flow.EventType) ([]flow.Event, error) {
+			t.Fatal("index should not be called for invalid filters")
+			return nil, nil
+		}
+
+		s := Server{
+			codec: mocks.BaselineCodec(t),
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := &GetEventsRequest{
+			Height:  mocks.GenericHeight,
+			Filters: []string{"amount = 1"},
+		}
+		_, err := s.GetEvents(context.Background(), req)
+
+		assert.Error(t, err)
+	})
 }
 
-func TestServer_GetRegisterValues(t *testing.T) {
+func TestServer_ListHeightsForEventTypes(t *testing.T) {
+	types := mocks.GenericEventTypes(2)
+	heights := []uint64{mocks.GenericHeight, mocks.GenericHeight + 3, mocks.GenericHeight + 7, mocks.GenericHeight + 8}
+
 	tests := []struct {
 		name string
 
-		req *GetRegisterValuesRequest
+		req *ListHeightsForEventTypesRequest
 
 		mockErr error
 
-		want *GetRegisterValuesResponse
+		wantLimit int
+
+		want     []uint64
+		wantNext uint64
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetRegisterValuesRequest{
-				Height:    mocks.GenericHeight,
-				Registers: convert.RegistersToBytes(mocks.GenericRegisters(6)),
+			req: &ListHeightsForEventTypesRequest{
+				Types:       convert.TypesToStrings(types),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight + 10,
 			},
 
-			mockErr: nil,
+			wantLimit: DefaultEventHeightsLimit + 1,
 
-			want: &GetRegisterValuesResponse{
-				Values: convert.ValuesToBytes(mocks.GenericRegisterValues(6)),
+			want:     heights,
+			checkErr: require.NoError,
+		},
+		{
+			name: "returns next height when there are more heights",
+
+			req: &ListHeightsForEventTypesRequest{
+				Types:       convert.TypesToStrings(types),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight + 10,
+				Limit:       3,
 			},
 
+			wantLimit: 4,
+
+			want:     heights[:3],
+			wantNext: heights[3],
 			checkErr: require.NoError,
 		},
 		{
-			name: "handles missing paths",
+			name: "caps limit",
 
-			req: &GetRegisterValuesRequest{
-				Height: mocks.GenericHeight,
+			req: &ListHeightsForEventTypesRequest{
+				Types:       convert.TypesToStrings(types),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight + 10,
+				Limit:       MaxEventHeightsLimit + 1,
 			},
 
-			want: nil,
+			wantLimit: MaxEventHeightsLimit + 1,
 
-			checkErr: require.Error,
+			want:     heights,
+			checkErr: require.NoError,
 		},
 		{
-			name: "handles paths with invalid lengths",
+			name: "handles missing types",
 
-			req: &GetRegisterValuesRequest{
-				Height:    mocks.GenericHeight,
-				Registers: [][]byte{mocks.GenericBytes},
+			req: &ListHeightsForEventTypesRequest{
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight + 10,
 			},
 
-			want: nil,
+			checkErr: require.Error,
+		},
+		{
+			name: "handles inverted range",
+
+			req: &ListHeightsForEventTypesRequest{
+				Types:       convert.TypesToStrings(types),
+				StartHeight: mocks.GenericHeight + 1,
+				EndHeight:   mocks.GenericHeight,
+			},
 
 			checkErr: require.Error,
 		},
 		{
 			name: "error case",
 
-			req: &GetRegisterValuesRequest{
-				Height:    mocks.GenericHeight,
-				Registers: convert.RegistersToBytes(mocks.GenericRegisters(6)),
+			req: &ListHeightsForEventTypesRequest{
+				Types:       convert.TypesToStrings(types),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight + 10,
 			},
 			mockErr: mocks.GenericError,
 
-			want: nil,
+			wantLimit: DefaultEventHeightsLimit + 1,
 
 			checkErr: require.Error,
 		},
@@ -538,8 +827,13 @@ func TestServer_GetRegisterValues(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.ValuesFunc = func(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error) {
-				return mocks.GenericRegisterValues(6), test.mockErr
+			index.EventHeightsFunc = func(gotTypes []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+				assert.Equal(t, types, gotTypes)
+				assert.Equal(t, test.req.StartHeight, startHeight)
+				assert.Equal(t, test.req.EndHeight, endHeight)
+				assert.Equal(t, test.wantLimit, limit)
+
+				return heights, test.mockErr
 			}
 
 			s := Server{
@@ -547,61 +841,237 @@ func TestServer_GetRegisterValues(t *testing.T) {
 				cfg:   DefaultConfig,
 			}
 
-			gotRes, gotErr := s.GetRegisterValues(context.Background(), test.req)
+			gotRes, gotErr := s.ListHeightsForEventTypes(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
-			if test.want != nil {
+			if gotErr == nil {
 				require.NotNil(t, gotRes)
-				assert.ElementsMatch(t, test.want.Values, gotRes.Values)
+				assert.Equal(t, test.want, gotRes.Heights)
+				assert.Equal(t, test.wantNext, gotRes.NextHeight)
 			}
 		})
 	}
 }
 
-func TestServer_GetCollection(t *testing.T) {
-	collection := mocks.GenericCollection(0)
+func TestServer_ListEventsForContract(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	events := mocks.GenericEvents(4)
+
+	// atHeights returns the events of blocks at the given heights.
+	atHeights := func(heights ...uint64) []archive.BlockEvents {
+		blocks := make([]archive.BlockEvents, 0, len(heights))
+		for _, height := range heights {
+			blocks = append(blocks, archive.BlockEvents{Height: height, Events: events})
+		}
+		return blocks
+	}
+
+	newServer := func(t *testing.T, fn func(startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.LastFunc = func() (uint64, error) {
+			return mocks.GenericHeight + 10, nil
+		}
+		index.EventsByContractFunc = func(gotAddress flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+			assert.Equal(t, address, gotAddress)
+			return fn(startHeight, endHeight, limit)
+		}
+
+		codec := mocks.BaselineCodec(t)
+		codec.MarshalFunc = func(v interface{}) ([]byte, error) {
+			assert.Equal(t, events, v)
+			return mocks.GenericBytes, nil
+		}
+
+		return &Server{
+			index: index,
+			codec: codec,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+3)
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight+5, endHeight)
+			assert.Equal(t, DefaultContractEventsLimit+1, limit)
+			return blocks, nil
+		})
+
+		req := &ListEventsForContractRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		got, err := s.ListEventsForContract(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Blocks, len(blocks))
+		for i, block := range got.Blocks {
+			assert.Equal(t, blocks[i].Height, block.Height)
+			assert.Equal(t, mocks.GenericBytes, block.Data)
+		}
+		assert.Zero(t, got.NextHeight)
+	})
+
+	t.Run("caps end height to last height", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+			assert.Equal(t, mocks.GenericHeight+10, endHeight)
+			return nil, nil
+		})
+
+		req := &ListEventsForContractRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 100,
+		}
+		_, err := s.ListEventsForContract(context.Background(), req)
+
+		require.NoError(t, err)
+	})
 
+	t.Run("returns next height when there are more heights", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+			assert.Equal(t, 3, limit)
+			return atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+4), nil
+		})
+
+		req := &ListEventsForContractRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListEventsForContract(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Blocks, 2)
+		assert.Equal(t, mocks.GenericHeight+4, got.NextHeight)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+			assert.Equal(t, MaxContractEventsLimit+1, limit)
+			return nil, nil
+		})
+
+		req := &ListEventsForContractRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       MaxContractEventsLimit + 1,
+		}
+		_, err := s.ListEventsForContract(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.BlockEvents, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*ListEventsForContractRequest{
+			{Address: []byte{1, 2, 3}, StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight + 1, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight + 11, EndHeight: mocks.GenericHeight + 12},
+		}
+		for _, req := range reqs {
+			_, err := s.ListEventsForContract(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.BlockEvents, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &ListEventsForContractRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListEventsForContract(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_GetRegisterValues(t *testing.T) {
 	tests := []struct {
 		name string
 
-		req *GetCollectionRequest
+		req *GetRegisterValuesRequest
 
-		mockCollection *flow.LightCollection
-		mockErr        error
+		mockErr error
+
+		want *GetRegisterValuesResponse
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetCollectionRequest{
-				CollectionID: mocks.ByteSlice(collection.ID()),
+			req: &GetRegisterValuesRequest{
+				Height:    mocks.GenericHeight,
+				Registers: convert.RegistersToBytes(mocks.GenericRegisters(6)),
 			},
 
-			mockCollection: collection,
+			mockErr: nil,
+
+			want: &GetRegisterValuesResponse{
+				Values: convert.ValuesToBytes(mocks.GenericRegisterValues(6)),
+			},
 
 			checkErr: require.NoError,
 		},
 		{
-			name: "handles invalid collection ID",
+			name: "handles missing paths",
 
-			req: &GetCollectionRequest{
-				CollectionID: mocks.GenericBytes,
+			req: &GetRegisterValuesRequest{
+				Height: mocks.GenericHeight,
 			},
 
-			mockCollection: collection,
+			want: nil,
 
 			checkErr: require.Error,
 		},
 		{
-			name: "handles index failure",
-
-			req: &GetCollectionRequest{
-				CollectionID: mocks.ByteSlice(collection.ID()),
-			},
+			name: "handles paths with invalid lengths",
 
-			mockCollection: collection,
-			mockErr:        mocks.GenericError,
+			req: &GetRegisterValuesRequest{
+				Height:    mocks.GenericHeight,
+				Registers: [][]byte{mocks.GenericBytes},
+			},
+
+			want: nil,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "error case",
+
+			req: &GetRegisterValuesRequest{
+				Height:    mocks.GenericHeight,
+				Registers: convert.RegistersToBytes(mocks.GenericRegisters(6)),
+			},
+			mockErr: mocks.GenericError,
+
+			want: nil,
 
 			checkErr: require.Error,
 		},
@@ -613,56 +1083,153 @@ func TestServer_GetCollection(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.CollectionFunc = func(id flow.Identifier) (*flow.LightCollection, error) {
-				return test.mockCollection, test.mockErr
+			index.ValuesFunc = func(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error) {
+				return mocks.GenericRegisterValues(6), test.mockErr
 			}
 
 			s := Server{
-				codec: mocks.BaselineCodec(t),
 				index: index,
 				cfg:   DefaultConfig,
 			}
 
-			wantID := test.mockCollection.ID()
-			gotRes, gotErr := s.GetCollection(context.Background(), test.req)
+			gotRes, gotErr := s.GetRegisterValues(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
-
-			if gotRes != nil {
-				assert.Equal(t, gotRes.CollectionID, wantID[:])
-				assert.NotEmpty(t, gotRes.Data)
+			if test.want != nil {
+				require.NotNil(t, gotRes)
+				assert.ElementsMatch(t, test.want.Values, gotRes.Values)
 			}
 		})
 	}
 }
 
-func TestServer_ListCollectionsForHeight(t *testing.T) {
+func TestServer_GetRegisterHistory(t *testing.T) {
+	reg := mocks.GenericRegister(0)
+	versions := mocks.GenericRegisterVersions(4)
+
 	tests := []struct {
 		name string
 
-		reqHeight uint64
+		req *GetRegisterHistoryRequest
 
-		mockCollections []flow.Identifier
-		mockErr         error
+		mockErr error
+
+		wantEnd   uint64
+		wantLimit int
+
+		want     int
+		wantNext uint64
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			reqHeight: mocks.GenericHeight,
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight,
+			},
 
-			mockCollections: mocks.GenericCollectionIDs(5),
+			wantEnd:   mocks.GenericHeight,
+			wantLimit: DefaultRegisterHistoryLimit + 1,
 
+			want:     4,
 			checkErr: require.NoError,
 		},
 		{
-			name: "handles index failure",
+			name: "returns next height when there are more versions",
 
-			reqHeight: mocks.GenericHeight,
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight,
+				Limit:       3,
+			},
+
+			wantEnd:   mocks.GenericHeight,
+			wantLimit: 4,
+
+			want:     3,
+			wantNext: versions[3].Height,
+			checkErr: require.NoError,
+		},
+		{
+			name: "caps end height to latest register height",
+
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight - 10,
+				EndHeight:   mocks.GenericHeight + 10,
+			},
+
+			wantEnd:   mocks.GenericHeight,
+			wantLimit: DefaultRegisterHistoryLimit + 1,
+
+			want:     4,
+			checkErr: require.NoError,
+		},
+		{
+			name: "caps limit",
+
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight,
+				Limit:       MaxRegisterHistoryLimit + 1,
+			},
 
+			wantEnd:   mocks.GenericHeight,
+			wantLimit: MaxRegisterHistoryLimit + 1,
+
+			want:     4,
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles missing register",
+
+			req: &GetRegisterHistoryRequest{
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight,
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles inverted range",
+
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight + 1,
+				EndHeight:   mocks.GenericHeight,
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles start height above latest register height",
+
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight + 1,
+				EndHeight:   mocks.GenericHeight + 2,
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "error case",
+
+			req: &GetRegisterHistoryRequest{
+				Register:    reg.Bytes(),
+				StartHeight: mocks.GenericHeight,
+				EndHeight:   mocks.GenericHeight,
+			},
 			mockErr: mocks.GenericError,
 
+			wantEnd:   mocks.GenericHeight,
+			wantLimit: DefaultRegisterHistoryLimit + 1,
+
 			checkErr: require.Error,
 		},
 	}
@@ -673,8 +1240,13 @@ func TestServer_ListCollectionsForHeight(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.CollectionsByHeightFunc = func(height uint64) ([]flow.Identifier, error) {
-				return test.mockCollections, test.mockErr
+			index.RegisterHistoryFunc = func(gotReg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]archive.RegisterVersion, error) {
+				assert.Equal(t, reg, gotReg)
+				assert.Equal(t, test.req.StartHeight, startHeight)
+				assert.Equal(t, test.wantEnd, endHeight)
+				assert.Equal(t, test.wantLimit, limit)
+
+				return versions, test.mockErr
 			}
 
 			s := Server{
@@ -682,64 +1254,160 @@ func TestServer_ListCollectionsForHeight(t *testing.T) {
 				cfg:   DefaultConfig,
 			}
 
-			req := &ListCollectionsForHeightRequest{
-				Height: mocks.GenericHeight,
-			}
-			gotRes, gotErr := s.ListCollectionsForHeight(context.Background(), req)
+			gotRes, gotErr := s.GetRegisterHistory(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
 			if gotErr == nil {
-				assert.Equal(t, gotRes.Height, mocks.GenericHeight)
-				assert.Len(t, gotRes.CollectionIDs, len(test.mockCollections))
-				for _, want := range test.mockCollections {
-					assert.Contains(t, gotRes.CollectionIDs, want[:])
+				require.NotNil(t, gotRes)
+				require.Len(t, gotRes.Versions, test.want)
+				for i, version := range gotRes.Versions {
+					assert.Equal(t, versions[i].Height, version.Height)
+					assert.Equal(t, []byte(versions[i].Value), version.Value)
 				}
+				assert.Equal(t, test.wantNext, gotRes.NextHeight)
 			}
 		})
 	}
 }
 
-func TestServer_GetGuarantee(t *testing.T) {
-	guarantee := mocks.GenericGuarantee(0)
+func TestServer_ListRegistersForHeight(t *testing.T) {
+	regs := mocks.GenericRegisters(4)
+	values := mocks.GenericRegisterValues(4)
+
 	tests := []struct {
 		name string
 
-		req *GetGuaranteeRequest
+		reqHeight     uint64
+		reqStart      uint32
+		reqLimit      uint32
+		includeValues bool
 
-		mockErr error
+		mockRegs      flow.RegisterIDs
+		mockErr       error
+		mockValErr    error
+		mockRetention archive.RegisterRetention
 
-		wantGuarantee *flow.CollectionGuarantee
+		wantRegs flow.RegisterIDs
+		wantNext uint32
+		wantOld  int
+		wantNew  int
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetGuaranteeRequest{
-				CollectionID: mocks.ByteSlice(guarantee.CollectionID),
-			},
+			reqHeight: mocks.GenericHeight,
 
-			wantGuarantee: guarantee,
-			checkErr:      require.NoError,
+			mockRegs: regs,
+
+			wantRegs: regs,
+
+			checkErr: require.NoError,
 		},
 		{
-			name: "handles invalid collection ID",
+			name: "includes values",
 
-			req: &GetGuaranteeRequest{
-				CollectionID: mocks.GenericBytes,
-			},
+			reqHeight:     mocks.GenericHeight,
+			includeValues: true,
 
-			checkErr: require.Error,
+			mockRegs: regs,
+
+			wantRegs: regs,
+			wantOld:  len(regs),
+			wantNew:  len(regs),
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "returns first page",
+
+			reqHeight:     mocks.GenericHeight,
+			reqLimit:      3,
+			includeValues: true,
+
+			mockRegs: regs,
+
+			wantRegs: regs[:3],
+			wantNext: 3,
+			wantOld:  3,
+			wantNew:  3,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "returns last page",
+
+			reqHeight: mocks.GenericHeight,
+			reqStart:  3,
+			reqLimit:  3,
+
+			mockRegs: regs,
+
+			wantRegs: regs[3:],
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles start index beyond registers",
+
+			reqHeight: mocks.GenericHeight,
+			reqStart:  uint32(len(regs) + 1),
+
+			mockRegs: regs,
+
+			wantRegs: flow.RegisterIDs{},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "leaves out old values of pruned height",
+
+			reqHeight:     mocks.GenericHeight,
+			includeValues: true,
+
+			mockRegs:      regs,
+			mockRetention: archive.RegisterRetention{Height: mocks.GenericHeight},
+
+			wantRegs: regs,
+			wantNew:  len(regs),
+
+			checkErr: require.NoError,
 		},
 		{
 			name: "handles index failure",
 
-			req: &GetGuaranteeRequest{
-				CollectionID: mocks.ByteSlice(guarantee.CollectionID),
-			},
+			reqHeight: mocks.GenericHeight,
 
 			mockErr: mocks.GenericError,
 
+			checkErr: require.Error,
+		},
+		{
+			name: "handles values failure",
+
+			reqHeight:     mocks.GenericHeight,
+			includeValues: true,
+
+			mockRegs:   regs,
+			mockValErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles height above latest register height",
+
+			reqHeight: mocks.GenericHeight + 1,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles pruned height",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockRetention: archive.RegisterRetention{Height: mocks.GenericHeight + 1},
+
 			checkErr: require.Error,
 		},
 	}
@@ -750,67 +1418,91 @@ func TestServer_GetGuarantee(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.GuaranteeFunc = func(id flow.Identifier) (*flow.CollectionGuarantee, error) {
-				return test.wantGuarantee, test.mockErr
+			index.FirstFunc = func() (uint64, error) {
+				return mocks.GenericHeight - 1, nil
+			}
+			index.RegisterRetentionFunc = func() (archive.RegisterRetention, error) {
+				return test.mockRetention, nil
+			}
+			index.RegistersByHeightFunc = func(height uint64) (flow.RegisterIDs, error) {
+				assert.Equal(t, test.reqHeight, height)
+				return test.mockRegs, test.mockErr
+			}
+			index.ValuesFunc = func(height uint64, gotRegs flow.RegisterIDs) ([]flow.RegisterValue, error) {
+				assert.Equal(t, test.wantRegs, gotRegs)
+				return values[:len(gotRegs)], test.mockValErr
 			}
 
 			s := Server{
-				codec: mocks.BaselineCodec(t),
 				index: index,
 				cfg:   DefaultConfig,
 			}
 
-			gotRes, gotErr := s.GetGuarantee(context.Background(), test.req)
+			req := ListRegistersForHeightRequest{
+				Height:        test.reqHeight,
+				StartIndex:    test.reqStart,
+				Limit:         test.reqLimit,
+				IncludeValues: test.includeValues,
+			}
+
+			gotRes, gotErr := s.ListRegistersForHeight(context.Background(), &req)
 
 			test.checkErr(t, gotErr)
 			if gotErr == nil {
-				assert.Equal(t, gotRes.CollectionID, test.req.CollectionID)
-				assert.NotEmpty(t, gotRes.Data)
+				assert.Equal(t, test.reqHeight, gotRes.Height)
+				assert.Equal(t, convert.RegistersToBytes(test.wantRegs), gotRes.Registers)
+				assert.Equal(t, test.wantNext, gotRes.NextIndex)
+				assert.Len(t, gotRes.OldValues, test.wantOld)
+				assert.Len(t, gotRes.NewValues, test.wantNew)
 			}
 		})
 	}
 }
 
-func TestServer_GetTransaction(t *testing.T) {
-	tx := mocks.GenericTransaction(0)
+func TestServer_GetCollection(t *testing.T) {
+	collection := mocks.GenericCollection(0)
+
 	tests := []struct {
 		name string
 
-		req *GetTransactionRequest
-
-		mockErr error
+		req *GetCollectionRequest
 
-		wantTransaction *flow.TransactionBody
+		mockCollection *flow.LightCollection
+		mockErr        error
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetTransactionRequest{
-				TransactionID: mocks.ByteSlice(tx.ID()),
+			req: &GetCollectionRequest{
+				CollectionID: mocks.ByteSlice(collection.ID()),
 			},
 
-			wantTransaction: tx,
-			checkErr:        require.NoError,
+			mockCollection: collection,
+
+			checkErr: require.NoError,
 		},
 		{
-			name: "handles invalid transaction ID",
+			name: "handles invalid collection ID",
 
-			req: &GetTransactionRequest{
-				TransactionID: mocks.GenericBytes,
+			req: &GetCollectionRequest{
+				CollectionID: mocks.GenericBytes,
 			},
 
+			mockCollection: collection,
+
 			checkErr: require.Error,
 		},
 		{
 			name: "handles index failure",
 
-			req: &GetTransactionRequest{
-				TransactionID: mocks.ByteSlice(tx.ID()),
+			req: &GetCollectionRequest{
+				CollectionID: mocks.ByteSlice(collection.ID()),
 			},
 
-			mockErr: mocks.GenericError,
+			mockCollection: collection,
+			mockErr:        mocks.GenericError,
 
 			checkErr: require.Error,
 		},
@@ -822,8 +1514,8 @@ func TestServer_GetTransaction(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.TransactionFunc = func(transactionID flow.Identifier) (*flow.TransactionBody, error) {
-				return test.wantTransaction, test.mockErr
+			index.CollectionFunc = func(id flow.Identifier) (*flow.LightCollection, error) {
+				return test.mockCollection, test.mockErr
 			}
 
 			s := Server{
@@ -832,49 +1524,109 @@ func TestServer_GetTransaction(t *testing.T) {
 				cfg:   DefaultConfig,
 			}
 
-			gotRes, gotErr := s.GetTransaction(context.Background(), test.req)
+			wantID := test.mockCollection.ID()
+			gotRes, gotErr := s.GetCollection(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
 
-			if test.wantTransaction != nil {
-				assert.Equal(t, gotRes.TransactionID, mocks.ByteSlice(tx.ID()))
+			if gotRes != nil {
+				assert.Equal(t, gotRes.CollectionID, wantID[:])
 				assert.NotEmpty(t, gotRes.Data)
 			}
 		})
 	}
 }
 
-func TestServer_GetHeightForTransaction(t *testing.T) {
-	blockID := mocks.GenericHeader.ID()
+func TestServer_ListCollectionsForHeight(t *testing.T) {
 	tests := []struct {
 		name string
 
-		req *GetHeightForTransactionRequest
-
-		mockErr error
+		reqHeight uint64
 
-		wantTxID flow.Identifier
+		mockCollections []flow.Identifier
+		mockErr         error
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetHeightForTransactionRequest{
-				TransactionID: mocks.ByteSlice(blockID),
-			},
-
-			mockErr: nil,
+			reqHeight: mocks.GenericHeight,
 
-			wantTxID: blockID,
+			mockCollections: mocks.GenericCollectionIDs(5),
 
 			checkErr: require.NoError,
 		},
 		{
-			name: "handles invalid transaction ID",
+			name: "handles index failure",
 
-			req: &GetHeightForTransactionRequest{
-				TransactionID: mocks.GenericBytes,
+			reqHeight: mocks.GenericHeight,
+
+			mockErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.CollectionsByHeightFunc = func(height uint64) ([]flow.Identifier, error) {
+				return test.mockCollections, test.mockErr
+			}
+
+			s := Server{
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			req := &ListCollectionsForHeightRequest{
+				Height: mocks.GenericHeight,
+			}
+			gotRes, gotErr := s.ListCollectionsForHeight(context.Background(), req)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				assert.Equal(t, gotRes.Height, mocks.GenericHeight)
+				assert.Len(t, gotRes.CollectionIDs, len(test.mockCollections))
+				for _, want := range test.mockCollections {
+					assert.Contains(t, gotRes.CollectionIDs, want[:])
+				}
+			}
+		})
+	}
+}
+
+func TestServer_GetHeightForCollection(t *testing.T) {
+	collID := mocks.GenericCollection(0).ID()
+	tests := []struct {
+		name string
+
+		req *GetHeightForCollectionRequest
+
+		mockErr error
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			req: &GetHeightForCollectionRequest{
+				CollectionID: mocks.ByteSlice(collID),
+			},
+
+			mockErr: nil,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles invalid collection ID",
+
+			req: &GetHeightForCollectionRequest{
+				CollectionID: mocks.GenericBytes,
 			},
 
 			checkErr: require.Error,
@@ -882,8 +1634,8 @@ func TestServer_GetHeightForTransaction(t *testing.T) {
 		{
 			name: "error handling",
 
-			req: &GetHeightForTransactionRequest{
-				TransactionID: mocks.ByteSlice(blockID),
+			req: &GetHeightForCollectionRequest{
+				CollectionID: mocks.ByteSlice(collID),
 			},
 
 			mockErr: mocks.GenericError,
@@ -898,7 +1650,9 @@ func TestServer_GetHeightForTransaction(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.HeightForTransactionFunc = func(blockID flow.Identifier) (uint64, error) {
+			index.HeightForCollectionFunc = func(got flow.Identifier) (uint64, error) {
+				assert.Equal(t, collID, got)
+
 				return mocks.GenericHeight, test.mockErr
 			}
 
@@ -907,41 +1661,55 @@ func TestServer_GetHeightForTransaction(t *testing.T) {
 				cfg:   DefaultConfig,
 			}
 
-			gotRes, gotErr := s.GetHeightForTransaction(context.Background(), test.req)
+			gotRes, gotErr := s.GetHeightForCollection(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
 			if gotErr == nil {
 				assert.Equal(t, mocks.GenericHeight, gotRes.Height)
-				assert.Equal(t, test.wantTxID[:], gotRes.TransactionID)
+				assert.Equal(t, collID[:], gotRes.CollectionID)
 			}
 		})
 	}
 }
 
-func TestServer_ListTransactionsForHeight(t *testing.T) {
+func TestServer_GetGuarantee(t *testing.T) {
+	guarantee := mocks.GenericGuarantee(0)
 	tests := []struct {
 		name string
 
-		reqHeight uint64
+		req *GetGuaranteeRequest
 
-		mockTransactions []flow.Identifier
-		mockErr          error
+		mockErr error
+
+		wantGuarantee *flow.CollectionGuarantee
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			reqHeight: mocks.GenericHeight,
+			req: &GetGuaranteeRequest{
+				CollectionID: mocks.ByteSlice(guarantee.CollectionID),
+			},
 
-			mockTransactions: mocks.GenericTransactionIDs(5),
+			wantGuarantee: guarantee,
+			checkErr:      require.NoError,
+		},
+		{
+			name: "handles invalid collection ID",
 
-			checkErr: require.NoError,
+			req: &GetGuaranteeRequest{
+				CollectionID: mocks.GenericBytes,
+			},
+
+			checkErr: require.Error,
 		},
 		{
 			name: "handles index failure",
 
-			reqHeight: mocks.GenericHeight,
+			req: &GetGuaranteeRequest{
+				CollectionID: mocks.ByteSlice(guarantee.CollectionID),
+			},
 
 			mockErr: mocks.GenericError,
 
@@ -955,59 +1723,54 @@ func TestServer_ListTransactionsForHeight(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.TransactionsByHeightFunc = func(height uint64) ([]flow.Identifier, error) {
-				return test.mockTransactions, test.mockErr
+			index.GuaranteeFunc = func(id flow.Identifier) (*flow.CollectionGuarantee, error) {
+				return test.wantGuarantee, test.mockErr
 			}
 
 			s := Server{
+				codec: mocks.BaselineCodec(t),
 				index: index,
 				cfg:   DefaultConfig,
 			}
 
-			req := &ListTransactionsForHeightRequest{
-				Height: mocks.GenericHeight,
-			}
-			gotRes, gotErr := s.ListTransactionsForHeight(context.Background(), req)
+			gotRes, gotErr := s.GetGuarantee(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
 			if gotErr == nil {
-				assert.Equal(t, gotRes.Height, mocks.GenericHeight)
-				assert.Len(t, gotRes.TransactionIDs, len(test.mockTransactions))
-				for _, want := range test.mockTransactions {
-					assert.Contains(t, gotRes.TransactionIDs, want[:])
-				}
+				assert.Equal(t, gotRes.CollectionID, test.req.CollectionID)
+				assert.NotEmpty(t, gotRes.Data)
 			}
 		})
 	}
 }
 
-func TestServer_GetResult(t *testing.T) {
-	result := mocks.GenericResult(0)
+func TestServer_GetTransaction(t *testing.T) {
+	tx := mocks.GenericTransaction(0)
 	tests := []struct {
 		name string
 
-		req *GetResultRequest
+		req *GetTransactionRequest
 
-		mockResult *flow.TransactionResult
-		mockErr    error
+		mockErr error
+
+		wantTransaction *flow.TransactionBody
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetResultRequest{
-				TransactionID: mocks.ByteSlice(result.TransactionID),
+			req: &GetTransactionRequest{
+				TransactionID: mocks.ByteSlice(tx.ID()),
 			},
 
-			mockResult: result,
-
-			checkErr: require.NoError,
+			wantTransaction: tx,
+			checkErr:        require.NoError,
 		},
 		{
 			name: "handles invalid transaction ID",
 
-			req: &GetResultRequest{
+			req: &GetTransactionRequest{
 				TransactionID: mocks.GenericBytes,
 			},
 
@@ -1016,8 +1779,8 @@ func TestServer_GetResult(t *testing.T) {
 		{
 			name: "handles index failure",
 
-			req: &GetResultRequest{
-				TransactionID: mocks.ByteSlice(result.TransactionID),
+			req: &GetTransactionRequest{
+				TransactionID: mocks.ByteSlice(tx.ID()),
 			},
 
 			mockErr: mocks.GenericError,
@@ -1032,8 +1795,8 @@ func TestServer_GetResult(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.ResultFunc = func(transactionID flow.Identifier) (*flow.TransactionResult, error) {
-				return test.mockResult, test.mockErr
+			index.TransactionFunc = func(transactionID flow.Identifier) (*flow.TransactionBody, error) {
+				return test.wantTransaction, test.mockErr
 			}
 
 			s := Server{
@@ -1042,55 +1805,60 @@ func TestServer_GetResult(t *testing.T) {
 				cfg:   DefaultConfig,
 			}
 
-			gotRes, gotErr := s.GetResult(context.Background(), test.req)
+			gotRes, gotErr := s.GetTransaction(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
-			if gotErr == nil {
-				assert.Equal(t, gotRes.TransactionID, mocks.ByteSlice(result.TransactionID))
+
+			if test.wantTransaction != nil {
+				assert.Equal(t, gotRes.TransactionID, mocks.ByteSlice(tx.ID()))
 				assert.NotEmpty(t, gotRes.Data)
 			}
 		})
 	}
 }
 
-func TestServer_GetSeal(t *testing.T) {
-	seal := mocks.GenericSeal(0)
+func TestServer_GetHeightForTransaction(t *testing.T) {
+	blockID := mocks.GenericHeader.ID()
 	tests := []struct {
 		name string
 
-		req *GetSealRequest
+		req *GetHeightForTransactionRequest
 
-		mockSeal *flow.Seal
-		mockErr  error
+		mockErr error
+
+		wantTxID flow.Identifier
 
 		checkErr require.ErrorAssertionFunc
 	}{
 		{
 			name: "nominal case",
 
-			req: &GetSealRequest{
-				SealID: mocks.ByteSlice(seal.ID()),
+			req: &GetHeightForTransactionRequest{
+				TransactionID: mocks.ByteSlice(blockID),
 			},
 
-			mockSeal: seal,
+			mockErr: nil,
+
+			wantTxID: blockID,
 
 			checkErr: require.NoError,
 		},
 		{
-			name: "handles invalid seal ID",
+			name: "handles invalid transaction ID",
 
-			req: &GetSealRequest{
-				SealID: mocks.GenericBytes,
+			req: &GetHeightForTransactionRequest{
+				TransactionID: mocks.GenericBytes,
 			},
 
 			checkErr: require.Error,
 		},
 		{
-			name: "handles index failure",
+			name: "error handling",
 
-			req: &GetSealRequest{
-				SealID: mocks.ByteSlice(seal.ID()),
+			req: &GetHeightForTransactionRequest{
+				TransactionID: mocks.ByteSlice(blockID),
 			},
+
 			mockErr: mocks.GenericError,
 
 			checkErr: require.Error,
@@ -1103,37 +1871,34 @@ func TestServer_GetSeal(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.SealFunc = func(sealID flow.Identifier) (*flow.Seal, error) {
-				return test.mockSeal, test.mockErr
+			index.HeightForTransactionFunc = func(blockID flow.Identifier) (uint64, error) {
+				return mocks.GenericHeight, test.mockErr
 			}
 
 			s := Server{
-				codec: mocks.BaselineCodec(t),
 				index: index,
 				cfg:   DefaultConfig,
 			}
 
-			gotRes, gotErr := s.GetSeal(context.Background(), test.req)
+			gotRes, gotErr := s.GetHeightForTransaction(context.Background(), test.req)
 
 			test.checkErr(t, gotErr)
-
 			if gotErr == nil {
-				assert.Equal(t, gotRes.SealID, test.req.SealID)
-				assert.NotEmpty(t, gotRes.Data)
+				assert.Equal(t, mocks.GenericHeight, gotRes.Height)
+				assert.Equal(t, test.wantTxID[:], gotRes.TransactionID)
 			}
 		})
 	}
 }
 
-func TestServer_ListSealsForHeight(t *testing.T) {
-	sealIDs := mocks.GenericSealIDs(5)
+func TestServer_ListTransactionsForHeight(t *testing.T) {
 	tests := []struct {
 		name string
 
 		reqHeight uint64
 
-		mockSeals []flow.Identifier
-		mockErr   error
+		mockTransactions []flow.Identifier
+		mockErr          error
 
 		checkErr require.ErrorAssertionFunc
 	}{
@@ -1142,7 +1907,7 @@ func TestServer_ListSealsForHeight(t *testing.T) {
 
 			reqHeight: mocks.GenericHeight,
 
-			mockSeals: sealIDs,
+			mockTransactions: mocks.GenericTransactionIDs(5),
 
 			checkErr: require.NoError,
 		},
@@ -1163,30 +1928,2158 @@ func TestServer_ListSealsForHeight(t *testing.T) {
 			t.Parallel()
 
 			index := mocks.BaselineReader(t)
-			index.SealsByHeightFunc = func(height uint64) ([]flow.Identifier, error) {
-				return test.mockSeals, test.mockErr
+			index.TransactionsByHeightFunc = func(height uint64) ([]flow.Identifier, error) {
+				return test.mockTransactions, test.mockErr
 			}
 
 			s := Server{
-				codec: mocks.BaselineCodec(t),
 				index: index,
 				cfg:   DefaultConfig,
 			}
 
-			req := ListSealsForHeightRequest{
+			req := &ListTransactionsForHeightRequest{
 				Height: mocks.GenericHeight,
 			}
-
-			gotRes, gotErr := s.ListSealsForHeight(context.Background(), &req)
+			gotRes, gotErr := s.ListTransactionsForHeight(context.Background(), req)
 
 			test.checkErr(t, gotErr)
 			if gotErr == nil {
-				assert.Equal(t, gotRes.Height, test.reqHeight)
-				assert.Len(t, gotRes.SealIDs, len(test.mockSeals))
-				for _, want := range test.mockSeals {
-					assert.Contains(t, gotRes.SealIDs, want[:])
+				assert.Equal(t, gotRes.Height, mocks.GenericHeight)
+				assert.Len(t, gotRes.TransactionIDs, len(test.mockTransactions))
+				for _, want := range test.mockTransactions {
+					assert.Contains(t, gotRes.TransactionIDs, want[:])
 				}
 			}
 		})
 	}
 }
+
+func TestServer_ListTransactionsForAddress(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	txIDs := mocks.GenericTransactionIDs(4)
+
+	// atHeights returns transactions at the given heights, in that order.
+	atHeights := func(heights ...uint64) []archive.AddressTransaction {
+		txs := make([]archive.AddressTransaction, 0, len(heights))
+		for i, height := range heights {
+			txs = append(txs, archive.AddressTransaction{
+				Height:        height,
+				TransactionID: txIDs[i],
+				Roles:         archive.RolePayer,
+			})
+		}
+		return txs
+	}
+
+	newServer := func(t *testing.T, fn func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.LastFunc = func() (uint64, error) {
+			return mocks.GenericHeight + 10, nil
+		}
+		index.TransactionsByAddressFunc = func(gotAddress flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+			assert.Equal(t, address, gotAddress)
+			assert.Equal(t, archive.RoleAny, roles)
+			return fn(startHeight, endHeight, limit)
+		}
+
+		return &Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		txs := atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+3)
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error) {
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight+5, endHeight)
+			assert.Equal(t, DefaultAddressTransactionsLimit+1, limit)
+			return txs, nil
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		got, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Transactions, len(txs))
+		for i, tx := range got.Transactions {
+			assert.Equal(t, txs[i].Height, tx.Height)
+			assert.Equal(t, txs[i].TransactionID[:], tx.TransactionID)
+			assert.Equal(t, uint32(archive.RolePayer), tx.Roles)
+		}
+		assert.Zero(t, got.NextHeight)
+	})
+
+	t.Run("caps end height to last height", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error) {
+			assert.Equal(t, mocks.GenericHeight+10, endHeight)
+			return nil, nil
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 100,
+		}
+		_, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("returns next height when there are more transactions", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error) {
+			assert.Equal(t, 3, limit)
+			return atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+2), nil
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Transactions, 2)
+		assert.Equal(t, mocks.GenericHeight+2, got.NextHeight)
+	})
+
+	t.Run("does not end pages in the middle of a height", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error) {
+			return atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+1), nil
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Transactions, 1)
+		assert.Equal(t, mocks.GenericHeight, got.Transactions[0].Height)
+		assert.Equal(t, mocks.GenericHeight+1, got.NextHeight)
+	})
+
+	t.Run("returns whole height when it exceeds limit", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error) {
+			calls++
+			if calls == 1 {
+				return atHeights(mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight), nil
+			}
+
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight, endHeight)
+			assert.Zero(t, limit)
+			return atHeights(mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight), nil
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Transactions, 4)
+		assert.Equal(t, mocks.GenericHeight+1, got.NextHeight)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.AddressTransaction, error) {
+			assert.Equal(t, MaxAddressTransactionsLimit+1, limit)
+			return nil, nil
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       MaxAddressTransactionsLimit + 1,
+		}
+		_, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.AddressTransaction, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*ListTransactionsForAddressRequest{
+			{Address: []byte{1, 2, 3}, StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight + 1, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight + 11, EndHeight: mocks.GenericHeight + 12},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight, Roles: 8},
+		}
+		for _, req := range reqs {
+			_, err := s.ListTransactionsForAddress(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.AddressTransaction, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &ListTransactionsForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListTransactionsForAddress(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_ListTransfersForAddress(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	other := mocks.GenericAddress(1)
+	txIDs := mocks.GenericTransactionIDs(4)
+
+	// atHeights returns transfers at the given heights, in that order.
+	atHeights := func(heights ...uint64) []archive.Transfer {
+		transfers := make([]archive.Transfer, 0, len(heights))
+		for i, height := range heights {
+			transfers = append(transfers, archive.Transfer{
+				Height:        height,
+				TransactionID: txIDs[i],
+				EventIndex:    uint32(i),
+				Token:         archive.FlowSymbol,
+				From:          address,
+				To:            other,
+				Amount:        uint64(i + 1),
+			})
+		}
+		return transfers
+	}
+
+	newServer := func(t *testing.T, fn func(startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.LastFunc = func() (uint64, error) {
+			return mocks.GenericHeight + 10, nil
+		}
+		index.TransfersByAddressFunc = func(gotAddress flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			assert.Equal(t, address, gotAddress)
+			return fn(startHeight, endHeight, limit)
+		}
+
+		return &Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		transfers := atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+3)
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight+5, endHeight)
+			assert.Equal(t, DefaultAddressTransfersLimit+1, limit)
+			return transfers, nil
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		got, err := s.ListTransfersForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Transfers, len(transfers))
+		for i, transfer := range got.Transfers {
+			assert.Equal(t, transfers[i].Height, transfer.Height)
+			assert.Equal(t, transfers[i].TransactionID[:], transfer.TransactionID)
+			assert.Equal(t, transfers[i].EventIndex, transfer.EventIndex)
+			assert.Equal(t, archive.FlowSymbol, transfer.Token)
+			assert.Equal(t, address.Bytes(), transfer.From)
+			assert.Equal(t, other.Bytes(), transfer.To)
+			assert.Equal(t, transfers[i].Amount, transfer.Amount)
+		}
+		assert.Zero(t, got.NextHeight)
+	})
+
+	t.Run("caps end height to last height", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			assert.Equal(t, mocks.GenericHeight+10, endHeight)
+			return nil, nil
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 100,
+		}
+		_, err := s.ListTransfersForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("does not end pages in the middle of a height", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			assert.Equal(t, 3, limit)
+			return atHeights(mocks.GenericHeight, mocks.GenericHeight+1, mocks.GenericHeight+1), nil
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListTransfersForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Transfers, 1)
+		assert.Equal(t, mocks.GenericHeight, got.Transfers[0].Height)
+		assert.Equal(t, mocks.GenericHeight+1, got.NextHeight)
+	})
+
+	t.Run("returns whole height when it exceeds limit", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			calls++
+			if calls == 1 {
+				return atHeights(mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight), nil
+			}
+
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight, endHeight)
+			assert.Zero(t, limit)
+			return atHeights(mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight), nil
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListTransfersForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Transfers, 4)
+		assert.Equal(t, mocks.GenericHeight+1, got.NextHeight)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			assert.Equal(t, MaxAddressTransfersLimit+1, limit)
+			return nil, nil
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       MaxAddressTransfersLimit + 1,
+		}
+		_, err := s.ListTransfersForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.Transfer, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*ListTransfersForAddressRequest{
+			{Address: []byte{1, 2, 3}, StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight + 1, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight + 11, EndHeight: mocks.GenericHeight + 12},
+		}
+		for _, req := range reqs {
+			_, err := s.ListTransfersForAddress(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.Transfer, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListTransfersForAddress(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles transfers not indexed", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.Transfer, error) {
+			return nil, archive.ErrNotIndexed
+		})
+
+		req := &ListTransfersForAddressRequest{
+			Address:     address.Bytes(),
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListTransfersForAddress(context.Background(), req)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestServer_ListBalanceHistory(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	changes := mocks.GenericBalanceChanges(4)
+
+	newServer := func(t *testing.T, fn func(startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.LastFunc = func() (uint64, error) {
+			return mocks.GenericHeight + 10, nil
+		}
+		index.BalanceHistoryFunc = func(gotAddress flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+			assert.Equal(t, address, gotAddress)
+			assert.Equal(t, archive.FlowSymbol, token)
+			return fn(startHeight, endHeight, limit)
+		}
+
+		return &Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight+10, endHeight)
+			assert.Equal(t, DefaultBalanceHistoryLimit+1, limit)
+			return changes, nil
+		})
+
+		req := &ListBalanceHistoryRequest{
+			Address:     address.Bytes(),
+			Token:       archive.FlowSymbol,
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 100,
+		}
+		got, err := s.ListBalanceHistory(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Changes, len(changes))
+		for i, change := range got.Changes {
+			assert.Equal(t, changes[i].Height, change.Height)
+			assert.Equal(t, changes[i].Deposited, change.Deposited)
+			assert.Equal(t, changes[i].Withdrawn, change.Withdrawn)
+		}
+		assert.Zero(t, got.NextHeight)
+	})
+
+	t.Run("returns next height when there are more changes", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+			assert.Equal(t, 3, limit)
+			return changes[:3], nil
+		})
+
+		req := &ListBalanceHistoryRequest{
+			Address:     address.Bytes(),
+			Token:       archive.FlowSymbol,
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListBalanceHistory(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Changes, 2)
+		assert.Equal(t, changes[2].Height, got.NextHeight)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+			assert.Equal(t, MaxBalanceHistoryLimit+1, limit)
+			return nil, nil
+		})
+
+		req := &ListBalanceHistoryRequest{
+			Address:     address.Bytes(),
+			Token:       archive.FlowSymbol,
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       MaxBalanceHistoryLimit + 1,
+		}
+		_, err := s.ListBalanceHistory(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.BalanceChange, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*ListBalanceHistoryRequest{
+			{Address: []byte{1, 2, 3}, Token: archive.FlowSymbol, StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), Token: archive.FlowSymbol, StartHeight: mocks.GenericHeight + 1, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), Token: archive.FlowSymbol, StartHeight: mocks.GenericHeight + 11, EndHeight: mocks.GenericHeight + 12},
+		}
+		for _, req := range reqs {
+			_, err := s.ListBalanceHistory(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.BalanceChange, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &ListBalanceHistoryRequest{
+			Address:     address.Bytes(),
+			Token:       archive.FlowSymbol,
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListBalanceHistory(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles transfers not indexed", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.BalanceChange, error) {
+			return nil, archive.ErrNotIndexed
+		})
+
+		req := &ListBalanceHistoryRequest{
+			Address:     address.Bytes(),
+			Token:       archive.FlowSymbol,
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListBalanceHistory(context.Background(), req)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestServer_ListContractsForAddress(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	contracts := mocks.GenericContracts(4)
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.ContractsByAddressFunc = func(gotAddress flow.Address, height uint64) ([]archive.Contract, error) {
+			assert.Equal(t, address, gotAddress)
+			assert.Equal(t, mocks.GenericHeight, height)
+			return contracts, nil
+		}
+
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := &ListContractsForAddressRequest{
+			Address: address.Bytes(),
+			Height:  mocks.GenericHeight,
+		}
+		got, err := s.ListContractsForAddress(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, req.Address, got.Address)
+		assert.Equal(t, req.Height, got.Height)
+		require.Len(t, got.Contracts, len(contracts))
+		for i, contract := range got.Contracts {
+			assert.Equal(t, contracts[i].Name, contract.Name)
+			assert.Equal(t, contracts[i].Height, contract.Height)
+		}
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.ContractsByAddressFunc = func(flow.Address, uint64) ([]archive.Contract, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		}
+
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		reqs := []*ListContractsForAddressRequest{
+			{Address: []byte{1, 2, 3}, Height: mocks.GenericHeight},
+			{Address: address.Bytes()},
+			{Address: address.Bytes(), Height: mocks.GenericHeight + 1},
+		}
+		for _, req := range reqs {
+			_, err := s.ListContractsForAddress(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.ContractsByAddressFunc = func(flow.Address, uint64) ([]archive.Contract, error) {
+			return nil, mocks.GenericError
+		}
+
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := &ListContractsForAddressRequest{
+			Address: address.Bytes(),
+			Height:  mocks.GenericHeight,
+		}
+		_, err := s.ListContractsForAddress(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_ListContractUpdates(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	updates := mocks.GenericContractUpdates(4)
+
+	newServer := func(t *testing.T, fn func(startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.LastFunc = func() (uint64, error) {
+			return mocks.GenericHeight + 10, nil
+		}
+		index.ContractHistoryFunc = func(gotAddress flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+			assert.Equal(t, address, gotAddress)
+			assert.Equal(t, "Contract0", name)
+			return fn(startHeight, endHeight, limit)
+		}
+
+		return &Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+			assert.Equal(t, mocks.GenericHeight, startHeight)
+			assert.Equal(t, mocks.GenericHeight+10, endHeight)
+			assert.Equal(t, DefaultContractUpdatesLimit+1, limit)
+			return updates, nil
+		})
+
+		req := &ListContractUpdatesRequest{
+			Address:     address.Bytes(),
+			Name:        "Contract0",
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 100,
+		}
+		got, err := s.ListContractUpdates(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, got.Updates, len(updates))
+		for i, update := range got.Updates {
+			assert.Equal(t, updates[i].Height, update.Height)
+			assert.Equal(t, updates[i].TransactionID[:], update.TransactionID)
+			assert.Equal(t, updates[i].Name, update.Name)
+			assert.Equal(t, uint32(updates[i].Action), update.Action)
+			assert.Equal(t, updates[i].CodeHash, update.CodeHash)
+		}
+		assert.Zero(t, got.NextHeight)
+	})
+
+	t.Run("returns next height when there are more updates", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+			assert.Equal(t, 3, limit)
+			return updates[:3], nil
+		})
+
+		req := &ListContractUpdatesRequest{
+			Address:     address.Bytes(),
+			Name:        "Contract0",
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       2,
+		}
+		got, err := s.ListContractUpdates(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, got.Updates, 2)
+		assert.Equal(t, updates[2].Height, got.NextHeight)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+			assert.Equal(t, MaxContractUpdatesLimit+1, limit)
+			return nil, nil
+		})
+
+		req := &ListContractUpdatesRequest{
+			Address:     address.Bytes(),
+			Name:        "Contract0",
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+			Limit:       MaxContractUpdatesLimit + 1,
+		}
+		_, err := s.ListContractUpdates(context.Background(), req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.ContractUpdate, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*ListContractUpdatesRequest{
+			{Address: []byte{1, 2, 3}, Name: "Contract0", StartHeight: mocks.GenericHeight, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), Name: "Contract0", StartHeight: mocks.GenericHeight + 1, EndHeight: mocks.GenericHeight},
+			{Address: address.Bytes(), Name: "Contract0", StartHeight: mocks.GenericHeight + 11, EndHeight: mocks.GenericHeight + 12},
+		}
+		for _, req := range reqs {
+			_, err := s.ListContractUpdates(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func(uint64, uint64, int) ([]archive.ContractUpdate, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &ListContractUpdatesRequest{
+			Address:     address.Bytes(),
+			Name:        "Contract0",
+			StartHeight: mocks.GenericHeight,
+			EndHeight:   mocks.GenericHeight + 5,
+		}
+		_, err := s.ListContractUpdates(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_GetContractCode(t *testing.T) {
+	address := mocks.GenericAddress(0)
+
+	newServer := func(t *testing.T, fn func() ([]byte, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.ContractCodeFunc = func(gotAddress flow.Address, name string, height uint64) ([]byte, error) {
+			assert.Equal(t, address, gotAddress)
+			assert.Equal(t, "Contract0", name)
+			assert.Equal(t, mocks.GenericHeight, height)
+			return fn()
+		}
+
+		return &Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]byte, error) {
+			return mocks.GenericContractCode, nil
+		})
+
+		req := &GetContractCodeRequest{
+			Address: address.Bytes(),
+			Name:    "Contract0",
+			Height:  mocks.GenericHeight,
+		}
+		got, err := s.GetContractCode(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, req.Address, got.Address)
+		assert.Equal(t, req.Name, got.Name)
+		assert.Equal(t, req.Height, got.Height)
+		assert.Equal(t, mocks.GenericContractCode, got.Code)
+	})
+
+	t.Run("handles unknown contracts", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]byte, error) {
+			return nil, archive.ErrNotFound
+		})
+
+		req := &GetContractCodeRequest{
+			Address: address.Bytes(),
+			Name:    "Contract0",
+			Height:  mocks.GenericHeight,
+		}
+		_, err := s.GetContractCode(context.Background(), req)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]byte, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*GetContractCodeRequest{
+			{Address: []byte{1, 2, 3}, Name: "Contract0", Height: mocks.GenericHeight},
+			{Address: address.Bytes(), Height: mocks.GenericHeight},
+			{Address: address.Bytes(), Name: "Contract0"},
+			{Address: address.Bytes(), Name: "Contract0", Height: mocks.GenericHeight + 1},
+		}
+		for _, req := range reqs {
+			_, err := s.GetContractCode(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]byte, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &GetContractCodeRequest{
+			Address: address.Bytes(),
+			Name:    "Contract0",
+			Height:  mocks.GenericHeight,
+		}
+		_, err := s.GetContractCode(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.NotEqual(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_GetAccountKeys(t *testing.T) {
+	address := mocks.GenericAddress(0)
+	keys := mocks.GenericAccountKeys(2)
+
+	newServer := func(t *testing.T, fn func() ([]archive.AccountKey, error)) *Server {
+		index := mocks.BaselineReader(t)
+		index.AccountKeysAtFunc = func(gotAddress flow.Address, height uint64) ([]archive.AccountKey, error) {
+			assert.Equal(t, address, gotAddress)
+			assert.Equal(t, mocks.GenericHeight, height)
+			return fn()
+		}
+
+		return &Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]archive.AccountKey, error) {
+			return keys, nil
+		})
+
+		req := &GetAccountKeysRequest{
+			Address: address.Bytes(),
+			Height:  mocks.GenericHeight,
+		}
+		got, err := s.GetAccountKeys(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, req.Address, got.Address)
+		assert.Equal(t, req.Height, got.Height)
+		require.Len(t, got.Keys, len(keys))
+		for i, key := range keys {
+			assert.Equal(t, key.Index, got.Keys[i].Index)
+			assert.Equal(t, key.PublicKey, got.Keys[i].PublicKey)
+			assert.Equal(t, key.Weight, got.Keys[i].Weight)
+			assert.Equal(t, key.Revoked, got.Keys[i].Revoked)
+			assert.Equal(t, key.SeqNumber, got.Keys[i].SeqNumber)
+		}
+	})
+
+	t.Run("handles unindexed keys", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]archive.AccountKey, error) {
+			return nil, archive.ErrNotFound
+		})
+
+		req := &GetAccountKeysRequest{
+			Address: address.Bytes(),
+			Height:  mocks.GenericHeight,
+		}
+		_, err := s.GetAccountKeys(context.Background(), req)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]archive.AccountKey, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		})
+
+		reqs := []*GetAccountKeysRequest{
+			{Address: []byte{1, 2, 3}, Height: mocks.GenericHeight},
+			{Address: address.Bytes()},
+		}
+		for _, req := range reqs {
+			_, err := s.GetAccountKeys(context.Background(), req)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		s := newServer(t, func() ([]archive.AccountKey, error) {
+			return nil, mocks.GenericError
+		})
+
+		req := &GetAccountKeysRequest{
+			Address: address.Bytes(),
+			Height:  mocks.GenericHeight,
+		}
+		_, err := s.GetAccountKeys(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.NotEqual(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_ListAccountKeyHistory(t *testing.T) {
+	history := mocks.GenericAccountKeyHistory(4)
+	address := history[0].Address
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.AccountKeyHistoryFunc = func(gotAddress flow.Address) ([]archive.AccountKeys, error) {
+			assert.Equal(t, address, gotAddress)
+			return history, nil
+		}
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := &ListAccountKeyHistoryRequest{
+			Address: address.Bytes(),
+		}
+		got, err := s.ListAccountKeyHistory(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, req.Address, got.Address)
+		require.Len(t, got.Changes, len(history))
+		for i, change := range history {
+			assert.Equal(t, change.Height, got.Changes[i].Height)
+			require.Len(t, got.Changes[i].Keys, len(change.Keys))
+			for j, key := range change.Keys {
+				assert.Equal(t, key.Index, got.Changes[i].Keys[j].Index)
+				assert.Equal(t, key.Revoked, got.Changes[i].Keys[j].Revoked)
+			}
+		}
+	})
+
+	t.Run("handles invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.AccountKeyHistoryFunc = func(flow.Address) ([]archive.AccountKeys, error) {
+			t.Fatal("index should not be called for invalid requests")
+			return nil, nil
+		}
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		_, err := s.ListAccountKeyHistory(context.Background(), &ListAccountKeyHistoryRequest{Address: []byte{1, 2, 3}})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.AccountKeyHistoryFunc = func(flow.Address) ([]archive.AccountKeys, error) {
+			return nil, mocks.GenericError
+		}
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		_, err := s.ListAccountKeyHistory(context.Background(), &ListAccountKeyHistoryRequest{Address: address.Bytes()})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_GetResult(t *testing.T) {
+	result := mocks.GenericResult(0)
+	tests := []struct {
+		name string
+
+		req *GetResultRequest
+
+		mockResult *flow.TransactionResult
+		mockErr    error
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			req: &GetResultRequest{
+				TransactionID: mocks.ByteSlice(result.TransactionID),
+			},
+
+			mockResult: result,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles invalid transaction ID",
+
+			req: &GetResultRequest{
+				TransactionID: mocks.GenericBytes,
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles index failure",
+
+			req: &GetResultRequest{
+				TransactionID: mocks.ByteSlice(result.TransactionID),
+			},
+
+			mockErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.ResultFunc = func(transactionID flow.Identifier) (*flow.TransactionResult, error) {
+				return test.mockResult, test.mockErr
+			}
+
+			s := Server{
+				codec: mocks.BaselineCodec(t),
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			gotRes, gotErr := s.GetResult(context.Background(), test.req)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				assert.Equal(t, gotRes.TransactionID, mocks.ByteSlice(result.TransactionID))
+				assert.NotEmpty(t, gotRes.Data)
+			}
+		})
+	}
+}
+
+func TestServer_GetSeal(t *testing.T) {
+	seal := mocks.GenericSeal(0)
+	tests := []struct {
+		name string
+
+		req *GetSealRequest
+
+		mockSeal *flow.Seal
+		mockErr  error
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			req: &GetSealRequest{
+				SealID: mocks.ByteSlice(seal.ID()),
+			},
+
+			mockSeal: seal,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles invalid seal ID",
+
+			req: &GetSealRequest{
+				SealID: mocks.GenericBytes,
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles index failure",
+
+			req: &GetSealRequest{
+				SealID: mocks.ByteSlice(seal.ID()),
+			},
+			mockErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.SealFunc = func(sealID flow.Identifier) (*flow.Seal, error) {
+				return test.mockSeal, test.mockErr
+			}
+
+			s := Server{
+				codec: mocks.BaselineCodec(t),
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			gotRes, gotErr := s.GetSeal(context.Background(), test.req)
+
+			test.checkErr(t, gotErr)
+
+			if gotErr == nil {
+				assert.Equal(t, gotRes.SealID, test.req.SealID)
+				assert.NotEmpty(t, gotRes.Data)
+			}
+		})
+	}
+}
+
+func TestServer_GetHeightForSeal(t *testing.T) {
+	sealID := mocks.GenericSeal(0).ID()
+	tests := []struct {
+		name string
+
+		req *GetHeightForSealRequest
+
+		mockErr error
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			req: &GetHeightForSealRequest{
+				SealID: mocks.ByteSlice(sealID),
+			},
+
+			mockErr: nil,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles invalid seal ID",
+
+			req: &GetHeightForSealRequest{
+				SealID: mocks.GenericBytes,
+			},
+
+			checkErr: require.Error,
+		},
+		{
+			name: "error handling",
+
+			req: &GetHeightForSealRequest{
+				SealID: mocks.ByteSlice(sealID),
+			},
+
+			mockErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.HeightForSealFunc = func(got flow.Identifier) (uint64, error) {
+				assert.Equal(t, sealID, got)
+
+				return mocks.GenericHeight, test.mockErr
+			}
+
+			s := Server{
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			gotRes, gotErr := s.GetHeightForSeal(context.Background(), test.req)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				assert.Equal(t, mocks.GenericHeight, gotRes.Height)
+				assert.Equal(t, sealID[:], gotRes.SealID)
+			}
+		})
+	}
+}
+
+func TestServer_GetSealedHeightForBlock(t *testing.T) {
+	blockID := mocks.GenericHeader.ID()
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.SealedAtHeightFunc = func(got flow.Identifier) (uint64, error) {
+			assert.Equal(t, blockID, got)
+
+			return mocks.GenericHeight, nil
+		}
+
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := GetSealedHeightForBlockRequest{BlockID: mocks.ByteSlice(blockID)}
+		res, err := s.GetSealedHeightForBlock(context.Background(), &req)
+
+		require.NoError(t, err)
+		assert.Equal(t, mocks.GenericHeight, res.Height)
+		assert.Equal(t, blockID[:], res.BlockID)
+	})
+
+	t.Run("handles invalid block ID", func(t *testing.T) {
+		t.Parallel()
+
+		s := Server{
+			index: mocks.BaselineReader(t),
+			cfg:   DefaultConfig,
+		}
+
+		req := GetSealedHeightForBlockRequest{BlockID: mocks.GenericBytes}
+		_, err := s.GetSealedHeightForBlock(context.Background(), &req)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("returns not found for unsealed blocks", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.SealedAtHeightFunc = func(flow.Identifier) (uint64, error) {
+			return 0, archive.ErrNotFound
+		}
+
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := GetSealedHeightForBlockRequest{BlockID: mocks.ByteSlice(blockID)}
+		_, err := s.GetSealedHeightForBlock(context.Background(), &req)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("handles index failures", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.SealedAtHeightFunc = func(flow.Identifier) (uint64, error) {
+			return 0, mocks.GenericError
+		}
+
+		s := Server{
+			index: index,
+			cfg:   DefaultConfig,
+		}
+
+		req := GetSealedHeightForBlockRequest{BlockID: mocks.ByteSlice(blockID)}
+		_, err := s.GetSealedHeightForBlock(context.Background(), &req)
+
+		assert.Error(t, err)
+		assert.NotEqual(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_ListSealsForHeight(t *testing.T) {
+	sealIDs := mocks.GenericSealIDs(5)
+	tests := []struct {
+		name string
+
+		reqHeight uint64
+
+		mockSeals []flow.Identifier
+		mockErr   error
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockSeals: sealIDs,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles index failure",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.SealsByHeightFunc = func(height uint64) ([]flow.Identifier, error) {
+				return test.mockSeals, test.mockErr
+			}
+
+			s := Server{
+				codec: mocks.BaselineCodec(t),
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			req := ListSealsForHeightRequest{
+				Height: mocks.GenericHeight,
+			}
+
+			gotRes, gotErr := s.ListSealsForHeight(context.Background(), &req)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				assert.Equal(t, gotRes.Height, test.reqHeight)
+				assert.Len(t, gotRes.SealIDs, len(test.mockSeals))
+				for _, want := range test.mockSeals {
+					assert.Contains(t, gotRes.SealIDs, want[:])
+				}
+			}
+		})
+	}
+}
+
+func TestServer_ListRegistersForOwner(t *testing.T) {
+	owner := mocks.GenericRegister(0).Owner
+
+	tests := []struct {
+		name string
+
+		reqHeight uint64
+
+		mockCount   int
+		mockErr     error
+		mockSendErr error
+
+		wantBatches []int
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockCount: 3,
+
+			wantBatches: []int{3},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "splits registers into batches",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockCount: OwnerRegistersBatchSize + 1,
+
+			wantBatches: []int{OwnerRegistersBatchSize, 1},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "sends nothing for owner without registers",
+
+			reqHeight: mocks.GenericHeight,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles invalid height",
+
+			reqHeight: 0,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles height above latest register height",
+
+			reqHeight: mocks.GenericHeight + 1,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles index failure",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles send failure",
+
+			reqHeight: mocks.GenericHeight,
+
+			mockCount:   3,
+			mockSendErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := mocks.BaselineReader(t)
+			index.FirstFunc = func() (uint64, error) {
+				return mocks.GenericHeight - 1, nil
+			}
+			index.RegistersByOwnerFunc = func(height uint64, gotOwner string, fn func(flow.RegisterEntry) error) error {
+				assert.Equal(t, test.reqHeight, height)
+				assert.Equal(t, owner, gotOwner)
+				for i := 0; i < test.mockCount; i++ {
+					err := fn(flow.RegisterEntry{
+						Key:   mocks.GenericRegister(i),
+						Value: mocks.GenericRegisterValue(i),
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return test.mockErr
+			}
+
+			s := Server{
+				index: index,
+				cfg:   DefaultConfig,
+			}
+
+			stream := &ownerServerStreamMock{
+				ctx: context.Background(),
+				err: test.mockSendErr,
+			}
+			req := ListRegistersForOwnerRequest{
+				Height: test.reqHeight,
+				Owner:  []byte(owner),
+			}
+
+			gotErr := s.ListRegistersForOwner(&req, stream)
+
+			test.checkErr(t, gotErr)
+			if gotErr == nil {
+				require.Len(t, stream.sent, len(test.wantBatches))
+				count := 0
+				for i, res := range stream.sent {
+					assert.Equal(t, test.reqHeight, res.Height)
+					require.Len(t, res.Registers, test.wantBatches[i])
+					require.Len(t, res.Values, test.wantBatches[i])
+					for j := range res.Registers {
+						reg := mocks.GenericRegister(count)
+						assert.Equal(t, reg.Bytes(), res.Registers[j])
+						assert.Equal(t, []byte(mocks.GenericRegisterValue(count)), res.Values[j])
+						count++
+					}
+				}
+			}
+		})
+	}
+}
+
+// ownerServerStreamMock records the responses sent on it, or fails to send
+// them with the given error.
+type ownerServerStreamMock struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	err  error
+	sent []*ListRegistersForOwnerResponse
+}
+
+func (o *ownerServerStreamMock) Context() context.Context {
+	return o.ctx
+}
+
+func (o *ownerServerStreamMock) Send(res *ListRegistersForOwnerResponse) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.sent = append(o.sent, res)
+	return nil
+}
+
+func TestServer_Stop(t *testing.T) {
+	s := NewServer(mocks.BaselineReader(t), mocks.BaselineCodec(t))
+
+	s.Stop()
+	s.Stop()
+
+	select {
+	case <-s.done:
+	default:
+		t.Error("server not stopped")
+	}
+}
+
+func TestServer_SubscribeHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+
+		reqStart uint64
+
+		mockLatest    []uint64
+		mockLatestErr error
+		mockHeaderErr error
+		mockSendErr   error
+
+		wantHeights []uint64
+		wantCode    codes.Code
+	}{
+		{
+			name: "nominal case",
+
+			reqStart: mocks.GenericHeight,
+
+			mockLatest: []uint64{mocks.GenericHeight + 2},
+
+			wantHeights: []uint64{mocks.GenericHeight, mocks.GenericHeight + 1, mocks.GenericHeight + 2},
+			wantCode:    codes.Canceled,
+		},
+		{
+			name: "follows new heights",
+
+			reqStart: mocks.GenericHeight,
+
+			mockLatest: []uint64{mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight + 1},
+
+			wantHeights: []uint64{mocks.GenericHeight, mocks.GenericHeight + 1},
+			wantCode:    codes.Canceled,
+		},
+		{
+			name: "waits for start height above last height",
+
+			reqStart: mocks.GenericHeight + 2,
+
+			mockLatest: []uint64{mocks.GenericHeight, mocks.GenericHeight + 1, mocks.GenericHeight + 2},
+
+			wantHeights: []uint64{mocks.GenericHeight + 2},
+			wantCode:    codes.Canceled,
+		},
+		{
+			name: "handles invalid start height",
+
+			reqStart: 0,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles start height below first height",
+
+			reqStart: mocks.GenericHeight - 1,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles latest height failure",
+
+			reqStart: mocks.GenericHeight,
+
+			mockLatestErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles index failure",
+
+			reqStart: mocks.GenericHeight,
+
+			mockLatest:    []uint64{mocks.GenericHeight},
+			mockHeaderErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles send failure",
+
+			reqStart: mocks.GenericHeight,
+
+			mockLatest:  []uint64{mocks.GenericHeight},
+			mockSendErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			index := mocks.BaselineReader(t)
+			index.LastFunc = latestHeightsMock(cancel, test.mockLatest, test.mockLatestErr)
+			index.HeaderFunc = func(height uint64) (*flow.Header, error) {
+				return mocks.GenericHeader, test.mockHeaderErr
+			}
+
+			s := baselineSubscriptionServer(t, index)
+
+			stream := &headersServerStreamMock{
+				ctx: ctx,
+				err: test.mockSendErr,
+			}
+			req := SubscribeHeadersRequest{
+				StartHeight: test.reqStart,
+			}
+
+			gotErr := s.SubscribeHeaders(&req, stream)
+
+			assert.Equal(t, test.wantCode, status.Code(gotErr))
+			var gotHeights []uint64
+			for _, res := range stream.sent {
+				assert.Equal(t, mocks.GenericBytes, res.Data)
+				gotHeights = append(gotHeights, res.Height)
+			}
+			assert.Equal(t, test.wantHeights, gotHeights)
+		})
+	}
+
+	t.Run("polls without notifier", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		index := mocks.BaselineReader(t)
+		index.LastFunc = latestHeightsMock(cancel, []uint64{mocks.GenericHeight, mocks.GenericHeight, mocks.GenericHeight + 1}, nil)
+
+		s := baselineSubscriptionServer(t, index)
+		s.cfg.notifier = nil
+		s.cfg.pollInterval = time.Millisecond
+
+		stream := &headersServerStreamMock{ctx: ctx}
+		req := SubscribeHeadersRequest{StartHeight: mocks.GenericHeight}
+
+		gotErr := s.SubscribeHeaders(&req, stream)
+
+		assert.Equal(t, codes.Canceled, status.Code(gotErr))
+		require.Len(t, stream.sent, 2)
+		assert.Equal(t, mocks.GenericHeight+1, stream.sent[1].Height)
+	})
+
+	t.Run("ends when server is stopped", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		s := baselineSubscriptionServer(t, index)
+		index.LastFunc = latestHeightsMock(s.Stop, []uint64{mocks.GenericHeight}, nil)
+
+		stream := &headersServerStreamMock{ctx: context.Background()}
+		req := SubscribeHeadersRequest{StartHeight: mocks.GenericHeight}
+
+		gotErr := s.SubscribeHeaders(&req, stream)
+
+		assert.Equal(t, codes.Unavailable, status.Code(gotErr))
+		assert.Len(t, stream.sent, 1)
+	})
+}
+
+func TestServer_SubscribeEvents(t *testing.T) {
+	events := mocks.GenericEvents(4)
+	types := []flow.EventType{mocks.GenericEventType(0), mocks.GenericEventType(1)}
+
+	tests := []struct {
+		name string
+
+		reqTypes []flow.EventType
+
+		mockLatest     uint64
+		mockEvents     map[uint64][]flow.Event
+		mockHeights    []uint64
+		mockHeightsErr error
+		mockEventsErr  error
+		mockSendErr    error
+
+		wantHeights []uint64
+		wantCode    codes.Code
+	}{
+		{
+			name: "nominal case without types",
+
+			mockLatest: mocks.GenericHeight + 2,
+			mockEvents: map[uint64][]flow.Event{
+				mocks.GenericHeight:     events,
+				mocks.GenericHeight + 2: events,
+			},
+
+			wantHeights: []uint64{mocks.GenericHeight, mocks.GenericHeight + 2},
+			wantCode:    codes.Canceled,
+		},
+		{
+			name: "nominal case with types",
+
+			reqTypes: types,
+
+			mockLatest:  mocks.GenericHeight + 2,
+			mockHeights: []uint64{mocks.GenericHeight + 1},
+			mockEvents: map[uint64][]flow.Event{
+				mocks.GenericHeight + 1: events,
+			},
+
+			wantHeights: []uint64{mocks.GenericHeight + 1},
+			wantCode:    codes.Canceled,
+		},
+		{
+			name: "handles event heights failure",
+
+			reqTypes: types,
+
+			mockLatest:     mocks.GenericHeight,
+			mockHeightsErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles events failure",
+
+			mockLatest:    mocks.GenericHeight,
+			mockEventsErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles send failure",
+
+			mockLatest: mocks.GenericHeight,
+			mockEvents: map[uint64][]flow.Event{
+				mocks.GenericHeight: events,
+			},
+			mockSendErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			index := mocks.BaselineReader(t)
+			index.LastFunc = latestHeightsMock(cancel, []uint64{test.mockLatest}, nil)
+			index.EventHeightsFunc = func(gotTypes []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+				assert.ElementsMatch(t, test.reqTypes, gotTypes)
+				assert.Equal(t, mocks.GenericHeight, startHeight)
+				assert.Equal(t, test.mockLatest, endHeight)
+				assert.Equal(t, int(endHeight-startHeight+1), limit)
+				return test.mockHeights, test.mockHeightsErr
+			}
+			index.EventsFunc = func(height uint64, gotTypes ...flow.EventType) ([]flow.Event, error) {
+				assert.ElementsMatch(t, test.reqTypes, gotTypes)
+				return test.mockEvents[height], test.mockEventsErr
+			}
+
+			s := baselineSubscriptionServer(t, index)
+
+			stream := &eventsServerStreamMock{
+				ctx: ctx,
+				err: test.mockSendErr,
+			}
+			req := SubscribeEventsRequest{
+				StartHeight: mocks.GenericHeight,
+				Types:       convert.TypesToStrings(test.reqTypes),
+			}
+
+			gotErr := s.SubscribeEvents(&req, stream)
+
+			assert.Equal(t, test.wantCode, status.Code(gotErr))
+			var gotHeights []uint64
+			for _, res := range stream.sent {
+				assert.ElementsMatch(t, req.Types, res.Types)
+				assert.Equal(t, mocks.GenericBytes, res.Data)
+				gotHeights = append(gotHeights, res.Height)
+			}
+			assert.Equal(t, test.wantHeights, gotHeights)
+		})
+	}
+}
+
+func TestServer_SubscribeRegisterChanges(t *testing.T) {
+	owner := mocks.GenericAddress(0).Bytes()
+	other := mocks.GenericAddress(1).Bytes()
+	regs := flow.RegisterIDs{
+		{Owner: string(owner), Key: "key-0"},
+		{Owner: string(other), Key: "key-1"},
+		{Owner: string(owner), Key: "key-2"},
+	}
+	values := mocks.GenericRegisterValues(2)
+
+	tests := []struct {
+		name string
+
+		reqStart  uint64
+		reqOwners [][]byte
+
+		mockRetention    archive.RegisterRetention
+		mockRegisters    map[uint64]flow.RegisterIDs
+		mockRegistersErr error
+		mockValuesErr    error
+		mockSendErr      error
+
+		wantHeights []uint64
+		wantCode    codes.Code
+	}{
+		{
+			name: "nominal case",
+
+			reqStart:  mocks.GenericHeight - 1,
+			reqOwners: [][]byte{owner},
+
+			mockRegisters: map[uint64]flow.RegisterIDs{
+				mocks.GenericHeight - 1: regs[1:2],
+				mocks.GenericHeight:     regs,
+			},
+
+			wantHeights: []uint64{mocks.GenericHeight},
+			wantCode:    codes.Canceled,
+		},
+		{
+			name: "handles missing owners",
+
+			reqStart: mocks.GenericHeight,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles invalid owner",
+
+			reqStart:  mocks.GenericHeight,
+			reqOwners: [][]byte{mocks.GenericBytes},
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles pruned start height",
+
+			reqStart:  mocks.GenericHeight - 1,
+			reqOwners: [][]byte{owner},
+
+			mockRetention: archive.RegisterRetention{Height: mocks.GenericHeight, Interval: 1},
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles registers failure",
+
+			reqStart:  mocks.GenericHeight,
+			reqOwners: [][]byte{owner},
+
+			mockRegistersErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles values failure",
+
+			reqStart:  mocks.GenericHeight,
+			reqOwners: [][]byte{owner},
+
+			mockRegisters: map[uint64]flow.RegisterIDs{
+				mocks.GenericHeight: regs,
+			},
+			mockValuesErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+		{
+			name: "handles send failure",
+
+			reqStart:  mocks.GenericHeight,
+			reqOwners: [][]byte{owner},
+
+			mockRegisters: map[uint64]flow.RegisterIDs{
+				mocks.GenericHeight: regs,
+			},
+			mockSendErr: mocks.GenericError,
+
+			wantCode: codes.Unknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			index := mocks.BaselineReader(t)
+			index.FirstFunc = func() (uint64, error) {
+				return mocks.GenericHeight - 1, nil
+			}
+			index.LatestRegisterHeightFunc = latestHeightsMock(cancel, []uint64{mocks.GenericHeight}, nil)
+			index.RegisterRetentionFunc = func() (archive.RegisterRetention, error) {
+				return test.mockRetention, nil
+			}
+			index.RegistersByHeightFunc = func(height uint64) (flow.RegisterIDs, error) {
+				return test.mockRegisters[height], test.mockRegistersErr
+			}
+			index.ValuesFunc = func(height uint64, gotRegs flow.RegisterIDs) ([]flow.RegisterValue, error) {
+				assert.Equal(t, mocks.GenericHeight, height)
+				assert.Equal(t, flow.RegisterIDs{regs[0], regs[2]}, gotRegs)
+				return values, test.mockValuesErr
+			}
+
+			s := baselineSubscriptionServer(t, index)
+
+			stream := &registerChangesServerStreamMock{
+				ctx: ctx,
+				err: test.mockSendErr,
+			}
+			req := SubscribeRegisterChangesRequest{
+				StartHeight: test.reqStart,
+				Owners:      test.reqOwners,
+			}
+
+			gotErr := s.SubscribeRegisterChanges(&req, stream)
+
+			assert.Equal(t, test.wantCode, status.Code(gotErr))
+			var gotHeights []uint64
+			for _, res := range stream.sent {
+				assert.Equal(t, convert.RegistersToBytes(flow.RegisterIDs{regs[0], regs[2]}), res.Registers)
+				assert.Equal(t, convert.ValuesToBytes(values), res.Values)
+				gotHeights = append(gotHeights, res.Height)
+			}
+			assert.Equal(t, test.wantHeights, gotHeights)
+		})
+	}
+}
+
+// baselineSubscriptionServer returns a server whose subscriptions are notified
+// immediately whenever they wait for new heights.
+func baselineSubscriptionServer(t *testing.T, index archive.Reader) *Server {
+	t.Helper()
+
+	notified := make(chan struct{})
+	close(notified)
+	notifier := mocks.BaselineNotifier(t)
+	notifier.WaitFunc = func() <-chan struct{} {
+		return notified
+	}
+
+	s := Server{
+		index: index,
+		codec: mocks.BaselineCodec(t),
+		cfg:   DefaultConfig,
+		done:  make(chan struct{}),
+	}
+	s.cfg.notifier = notifier
+
+	return &s
+}
+
+// latestHeightsMock returns the given heights one by one on each call, and the
+// last one again on the call after, which also ends the subscription by calling
+// the given function. If the given error is not nil, it is returned instead.
+func latestHeightsMock(end func(), heights []uint64, err error) func() (uint64, error) {
+	return func() (uint64, error) {
+		if err != nil {
+			return 0, err
+		}
+		if len(heights) > 1 {
+			height := heights[0]
+			heights = heights[1:]
+			return height, nil
+		}
+		end()
+		return heights[0], nil
+	}
+}
+
+// headersServerStreamMock records the responses sent on it, or fails to send
+// them with the given error.
+type headersServerStreamMock struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	err  error
+	sent []*SubscribeHeadersResponse
+}
+
+func (h *headersServerStreamMock) Context() context.Context {
+	return h.ctx
+}
+
+func (h *headersServerStreamMock) Send(res *SubscribeHeadersResponse) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.sent = append(h.sent, res)
+	return nil
+}
+
+// eventsServerStreamMock records the responses sent on it, or fails to send
+// them with the given error.
+type eventsServerStreamMock struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	err  error
+	sent []*SubscribeEventsResponse
+}
+
+func (e *eventsServerStreamMock) Context() context.Context {
+	return e.ctx
+}
+
+func (e *eventsServerStreamMock) Send(res *SubscribeEventsResponse) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.sent = append(e.sent, res)
+	return nil
+}
+
+// registerChangesServerStreamMock records the responses sent on it, or fails
+// to send them with the given error.
+type registerChangesServerStreamMock struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	err  error
+	sent []*SubscribeRegisterChangesResponse
+}
+
+func (r *registerChangesServerStreamMock) Context() context.Context {
+	return r.ctx
+}
+
+func (r *registerChangesServerStreamMock) Send(res *SubscribeRegisterChangesResponse) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, res)
+	return nil
+}
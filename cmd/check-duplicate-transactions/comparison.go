@@ -9,9 +9,8 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage/badger/operation"
 
-	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/archive"
-	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/storage2"
 )
 
 func compareDuplicates(log zerolog.Logger, dataDir string, indexDir string, duplicates map[uint64][]flow.Identifier) error {
@@ -24,14 +23,11 @@ func compareDuplicates(log zerolog.Logger, dataDir string, indexDir string, dupl
 		return fmt.Errorf("could not open protocol state (dir: %s): %w", dataDir, err)
 	}
 	defer protocol.Close()
-	index, err := badger.Open(archive.DefaultOptions(indexDir).WithReadOnly(true))
+	lib, err := storage2.NewLibrary2(indexDir, 1<<30)
 	if err != nil {
 		return fmt.Errorf("could not open state index (dir: %s): %w", indexDir, err)
 	}
-	defer index.Close()
-
-	// Initialize the storage library.
-	lib := storage.New(zbor.NewCodec())
+	defer lib.Close()
 
 	// Go through duplicates and compare number and IDs of duplicates beetween databases.
 	for height, duplicateIDs := range duplicates {
@@ -83,8 +79,7 @@ func compareDuplicates(log zerolog.Logger, dataDir string, indexDir string, dupl
 			}
 		}
 
-		var txIDs []flow.Identifier
-		err = index.View(lib.LookupTransactionsForHeight(height, &txIDs))
+		txIDs, err := lib.GetTransactionsForHeight(height)
 		if err != nil {
 			return fmt.Errorf("could not look up transactions (height: %d): %w", height, err)
 		}
@@ -4,14 +4,12 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
 
-	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/archive"
-	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/storage2"
 )
 
 func indexCheck(log zerolog.Logger, dir string) (map[uint64][]flow.Identifier, error) {
@@ -22,18 +20,14 @@ func indexCheck(log zerolog.Logger, dir string) (map[uint64][]flow.Identifier, e
 	log.Info().Str("index", dir).Msg("starting index state duplicate check")
 
 	// Open the index database.
-	index, err := badger.Open(archive.DefaultOptions(dir).WithReadOnly(true))
+	lib, err := storage2.NewLibrary2(dir, 1<<30)
 	if err != nil {
 		return nil, fmt.Errorf("could not open state index (dir: %s): %w", dir, err)
 	}
-	defer index.Close()
-
-	// Initialize the storage library.
-	lib := storage.New(zbor.NewCodec())
+	defer lib.Close()
 
 	// Retrieve the root height as a start height for duplicate check.
-	var first uint64
-	err = index.View(lib.RetrieveFirst(&first))
+	first, err := lib.GetFirst()
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve first: %w", err)
 	}
@@ -45,9 +39,8 @@ func indexCheck(log zerolog.Logger, dir string) (map[uint64][]flow.Identifier, e
 		log := log.With().Uint64("height", height).Logger()
 
 		// height => txIDs
-		var txIDs []flow.Identifier
-		err = index.View(lib.LookupTransactionsForHeight(height, &txIDs))
-		if errors.Is(err, badger.ErrKeyNotFound) {
+		txIDs, err := lib.GetTransactionsForHeight(height)
+		if errors.Is(err, archive.ErrNotFound) {
 			break
 		}
 		if err != nil {
@@ -3,23 +3,39 @@ package main
 import (
 	"fmt"
 
+	"github.com/onflow/flow-archive/service/snapshot"
 	"github.com/onflow/flow-archive/service/storage2"
 	"github.com/rs/zerolog"
 )
 
-// create a checkpoint for the payload storage at the given indexDir,
-// save the checkpoint to the given checkpointDir
+// create a checkpoint for the pebble storage at the given indexDir,
+// save the checkpoint to the given checkpointDir, along with a snapshot
+// manifest describing it
 func createCheckpoint(indexDir string, checkpointDir string, log zerolog.Logger) error {
 	lib2, err := storage2.NewLibrary2(indexDir, 1<<30)
 	if err != nil {
 		return err
 	}
 
-	checkpointPath := storage2.StoragePath(checkpointDir)
-	err = lib2.Checkpoint(checkpointPath)
+	err = lib2.Checkpoint(checkpointDir)
 	if err != nil {
 		return fmt.Errorf("could not create checkpoint at dir (%v): %w", checkpointDir, err)
 	}
 
+	manifest, err := snapshot.NewManifest(lib2, checkpointDir)
+	if err != nil {
+		return fmt.Errorf("could not create snapshot manifest: %w", err)
+	}
+	err = snapshot.WriteManifest(checkpointDir, manifest)
+	if err != nil {
+		return fmt.Errorf("could not write snapshot manifest: %w", err)
+	}
+
+	log.Info().
+		Uint64("first", manifest.First).
+		Uint64("last", manifest.Last).
+		Int("files", len(manifest.Files)).
+		Msg("snapshot manifest written")
+
 	return nil
 }
@@ -4,16 +4,20 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/klauspost/compress/zstd"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
 
+	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/snapshot"
+	"github.com/onflow/flow-archive/service/storage2"
 )
 
 const (
@@ -44,11 +48,18 @@ func run() int {
 		flagCompression string
 		flagEncoding    string
 		flagIndex       string
+		flagBase        string
+		flagManifest    string
+
+		flagBlockCacheSize int64
 	)
 
 	pflag.StringVarP(&flagCompression, "compression", "c", compressionZstd, "compression algorithm (\"none\", \"zstd\" or \"gzip\")")
 	pflag.StringVarP(&flagEncoding, "encoding", "e", encodingNone, "output encoding (\"none\", \"hex\" or \"base64\")")
-	pflag.StringVarP(&flagIndex, "index", "i", "index", "database directory for state index")
+	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to the pebble-based index database directory")
+	pflag.StringVarP(&flagBase, "base", "b", "", "path to the manifest of a base snapshot, to create a delta snapshot on top of it")
+	pflag.StringVarP(&flagManifest, "manifest", "m", "", "path to which the manifest of the snapshot is written, for use as base of a later delta snapshot")
+	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes.")
 
 	pflag.Parse()
 
@@ -56,13 +67,49 @@ func run() int {
 	zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
 	log := zerolog.New(os.Stderr).With().Timestamp().Logger().Level(zerolog.DebugLevel)
 
-	// Open the index database.
-	db, err := badger.Open(archive.DefaultOptions(flagIndex).WithReadOnly(true))
+	// Open the index database and create the snapshot next to it, so that a
+	// checkpoint can hard-link the files of the index.
+	lib2, err := storage2.NewLibrary2(flagIndex, flagBlockCacheSize)
 	if err != nil {
-		log.Error().Str("index", flagIndex).Err(err).Msg("could not open badger db")
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not open storage2")
 		return failure
 	}
-	defer db.Close()
+	defer lib2.Close()
+
+	dir, err := os.MkdirTemp(filepath.Dir(filepath.Clean(flagIndex)), "snapshot")
+	if err != nil {
+		log.Error().Err(err).Msg("could not create snapshot directory")
+		return failure
+	}
+	defer os.RemoveAll(dir)
+
+	// Nothing else can write to the index while we hold it open, so its heights
+	// are the ones of the snapshot.
+	var manifest *snapshot.Manifest
+	snap := filepath.Join(dir, "index")
+	if flagBase == "" {
+		manifest, err = checkpoint(lib2, snap)
+	} else {
+		manifest, err = delta(lib2, flagBase, snap)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("could not create snapshot")
+		return failure
+	}
+	err = snapshot.WriteManifest(snap, manifest)
+	if err != nil {
+		log.Error().Err(err).Msg("could not write snapshot manifest")
+		return failure
+	}
+
+	log.Info().
+		Str("chain", manifest.ChainID.String()).
+		Uint64("first", manifest.First).
+		Uint64("last", manifest.Last).
+		Uint64("latest_register_height", manifest.LatestRegisterHeight).
+		Bool("delta", manifest.IsDelta()).
+		Int("files", len(manifest.Files)).
+		Msg("snapshot manifest created")
 
 	// We want to pipe everything to stdout in the end; if the user wants to
 	// create a file, he can redirect the output.
@@ -100,14 +147,47 @@ func run() int {
 		log.Error().Str("encoding", flagEncoding).Msg("invalid encoding format specified")
 	}
 
-	// Run the DB backup mechanism on top of the writer to create the snapshot.
-	_, err = db.Backup(writer, 0)
+	// Archive the files of the snapshot along with their manifest on top of
+	// the writer.
+	err = snapshot.Write(writer, snap)
 	if err != nil {
 		log.Error().Err(err).Msg("snapshot generation failed")
 		return failure
 	}
 
+	// Only keep the manifest once the snapshot is complete, so that it is never
+	// used as the base of a delta snapshot without the snapshot itself.
+	if flagManifest != "" {
+		err = snapshot.WriteManifestFile(flagManifest, manifest)
+		if err != nil {
+			log.Error().Err(err).Msg("could not write snapshot manifest file")
+			return failure
+		}
+	}
+
 	log.Info().Msg("snapshot generation complete")
 
 	return success
 }
+
+// checkpoint creates a consistent checkpoint of the index in the given
+// directory, which must not exist yet, and returns its manifest.
+func checkpoint(lib2 archive.Library2, dir string) (*snapshot.Manifest, error) {
+	err := lib2.Checkpoint(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not create checkpoint: %w", err)
+	}
+
+	return snapshot.NewManifest(lib2, dir)
+}
+
+// delta exports the index data added since the snapshot with the manifest at
+// the given path to the given directory, and returns its manifest.
+func delta(lib2 archive.Library2, basePath string, dir string) (*snapshot.Manifest, error) {
+	base, err := snapshot.ReadManifestFile(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read base manifest: %w", err)
+	}
+
+	return snapshot.NewDelta(lib2, zbor.NewCodec(), base, dir)
+}
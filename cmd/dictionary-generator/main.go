@@ -6,13 +6,13 @@ import (
 	"runtime"
 	"time"
 
-	"github.com/dgraph-io/badger/v2"
+	"github.com/cockroachdb/pebble"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-archive/codec/generator"
 	"github.com/onflow/flow-archive/codec/zbor"
-	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2"
 )
 
 const (
@@ -40,7 +40,7 @@ func run() int {
 	)
 
 	pflag.StringVar(&flagDictionaryPath, "dictionary-path", "./codec/zbor", "path to the package in which to write dictionaries")
-	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to database directory for state index")
+	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to the pebble-based index database directory")
 	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
 	pflag.StringVar(&flagSamplePath, "sample-path", "", "path to the directory in which to store samples for dictionary training (temporary folder when left empty)")
 	pflag.IntVar(&flagStartSize, "start-size", 512, "minimum dictionary size in bytes to generate (will be doubled on each iteration)")
@@ -62,8 +62,8 @@ func run() int {
 	}
 	log = log.Level(level)
 
-	// Initialize the index core state and open database in read-only mode.
-	db, err := badger.Open(archive.DefaultOptions(flagIndex).WithReadOnly(true))
+	// Open the block storage database of the index in read-only mode.
+	db, err := pebble.Open(storage2.BlocksPath(flagIndex), &pebble.Options{ReadOnly: true})
 	if err != nil {
 		log.Error().Str("index", flagIndex).Err(err).Msg("could not open index DB")
 		return failure
@@ -89,12 +89,6 @@ func run() int {
 		generator.WithStartSize(flagStartSize),
 	)
 
-	err = generate.Dictionary(generator.KindPayloads)
-	if err != nil {
-		log.Error().Err(err).Msg("could not generate payload dictionary")
-		return failure
-	}
-
 	err = generate.Dictionary(generator.KindTransactions)
 	if err != nil {
 		log.Error().Err(err).Msg("could not generate transactions dictionary")
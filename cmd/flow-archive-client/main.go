@@ -14,8 +14,9 @@ import (
 
 	"github.com/onflow/cadence/encoding/json"
 
-	"github.com/onflow/flow-archive/api/archive"
+	api "github.com/onflow/flow-archive/api/archive"
 	"github.com/onflow/flow-archive/codec/zbor"
+	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/models/convert"
 	"github.com/onflow/flow-archive/service/invoker"
 	flowModel "github.com/onflow/flow-go/model/flow"
@@ -44,6 +45,7 @@ func run() int {
 		flagLevel  string
 		flagParams string
 		flagScript string
+		flagTime   string
 	)
 
 	pflag.StringVarP(&flagAPI, "api", "a", "", "host for GRPC API server")
@@ -52,6 +54,7 @@ func run() int {
 	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
 	pflag.StringVarP(&flagParams, "params", "p", "", "comma-separated list of Cadence parameters")
 	pflag.StringVarP(&flagScript, "script", "s", "script.cdc", "path to file with Cadence script")
+	pflag.StringVarP(&flagTime, "time", "t", "", "execute the script as of this RFC3339 time, at the last block before it")
 
 	pflag.Parse()
 
@@ -65,6 +68,25 @@ func run() int {
 	}
 	log = log.Level(level)
 
+	// Parse the time to execute the script at, if given. As the spork can only
+	// be chosen based on height, it requires the API server to be given.
+	var timestamp time.Time
+	if flagTime != "" {
+		if pflag.CommandLine.Changed("height") {
+			log.Error().Msg("height and time can not be given together")
+			return failure
+		}
+		if flagAPI == "" {
+			log.Error().Str("time", flagTime).Msg("API server is required to execute a script as of a time")
+			return failure
+		}
+		timestamp, err = time.Parse(time.RFC3339, flagTime)
+		if err != nil {
+			log.Error().Str("time", flagTime).Err(err).Msg("could not parse time")
+			return failure
+		}
+	}
+
 	// If no API server is given, choose based on height.
 	if flagAPI == "" {
 		for _, spork := range DefaultSporks {
@@ -118,9 +140,9 @@ func run() int {
 	codec := zbor.NewCodec()
 
 	// Execute the script using remote lookup and read.
-	client := archive.NewAPIClient(conn)
+	client := api.NewAPIClient(conn)
 
-	read := archive.IndexFromAPI(client, codec)
+	read := api.IndexFromAPI(client, codec)
 
 	chainID, err := getChainId(read)
 	if err != nil {
@@ -128,6 +150,16 @@ func run() int {
 		return failure
 	}
 
+	// Look up the last block at or before the given time.
+	if flagTime != "" {
+		flagHeight, err = read.HeightForTimestamp(timestamp, archive.AtOrBefore)
+		if err != nil {
+			log.Error().Time("time", timestamp).Err(err).Msg("could not get height for time")
+			return failure
+		}
+		log.Info().Time("time", timestamp).Uint64("height", flagHeight).Msg("height chosen based on time")
+	}
+
 	config := invoker.DefaultConfig
 	config.ChainID = chainID
 	config.CacheSize = flagCache
@@ -153,7 +185,7 @@ func run() int {
 	return success
 }
 
-func getChainId(read *archive.Index) (flowModel.ChainID, error) {
+func getChainId(read *api.Index) (flowModel.ChainID, error) {
 	chainID := flowModel.Emulator
 
 	f, err := read.First()
@@ -11,15 +11,22 @@ import (
 	"github.com/prometheus/tsdb/wal"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
+	api "github.com/onflow/flow-archive/api/archive"
 	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/service/chain"
+	"github.com/onflow/flow-archive/service/contracts"
 	"github.com/onflow/flow-archive/service/index"
 	"github.com/onflow/flow-archive/service/mapper"
-	"github.com/onflow/flow-archive/service/storage"
+	seeder "github.com/onflow/flow-archive/service/seed"
 	"github.com/onflow/flow-archive/service/storage2"
+	storconfig "github.com/onflow/flow-archive/service/storage2/config"
+	"github.com/onflow/flow-archive/service/tokens"
 	"github.com/onflow/flow-archive/service/triereader"
+	"github.com/onflow/flow-archive/service/verifier"
 )
 
 const (
@@ -39,25 +46,40 @@ func run() int {
 
 	// Command line parameter initialization.
 	var (
-		flagCheckpoint string
-		flagData       string
-		flagIndex      string
-		flagLevel      string
-		flagTrie       string
-		flagSkip       bool
-
-		flagIndex2         string
+		flagCheckpoint   string
+		flagData         string
+		flagIndex        string
+		flagLevel        string
+		flagTrie         string
+		flagSkip         bool
+		flagVerify       bool
+		flagWorkers      int
+		flagBatchSize    int
+		flagBulkLoad     bool
+		flagDurability   string
+		flagSyncInterval time.Duration
+		flagSeedIndex    string
+		flagSeedAPI      string
+		flagSeedHeight   uint64
+
 		flagBlockCacheSize int64
 	)
 
 	pflag.StringVarP(&flagCheckpoint, "checkpoint", "c", "", "path to checkpoint root file, ensure the directory contains all partitions of checkpoint files for execution state trie")
 	pflag.StringVarP(&flagData, "data", "d", "data", "path to database directory for protocol data")
-	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to database directory for state index")
+	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to the pebble-based index database directory")
 	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
 	pflag.StringVarP(&flagTrie, "trie", "t", "", "path to data directory for execution state ledger")
 	pflag.BoolVarP(&flagSkip, "skip", "s", false, "skip indexing of execution state ledger registers")
-
-	pflag.StringVarP(&flagIndex2, "index2", "I", "index2", "path to the pebble-based index database directory")
+	pflag.BoolVar(&flagVerify, "verify-bootstrap", false, "verify the registers imported from the root checkpoint against the root state commitment")
+	pflag.IntVar(&flagWorkers, "bootstrap-workers", mapper.DefaultConfig.BootstrapWorkers, "number of workers writing the registers of the root checkpoint concurrently")
+	pflag.IntVar(&flagBatchSize, "bootstrap-batch-size", mapper.DefaultConfig.BootstrapBatchSize, "number of registers of the root checkpoint written per batch")
+	pflag.StringVar(&flagDurability, "durability", storconfig.DurabilityPeriodic.String(), "when index writes are synced to disk: after every batch (batch), once per block (block) or in periodic group commits (periodic)")
+	pflag.DurationVar(&flagSyncInterval, "sync-interval", storage2.DefaultConfig.SyncInterval, "interval between group commits of index writes with periodic durability")
+	pflag.BoolVar(&flagBulkLoad, "bulk-load", false, "bulk load the registers of the root checkpoint by ingesting sorted sstables, instead of writing them in batches")
+	pflag.StringVar(&flagSeedIndex, "seed-index", "", "path to a pebble-based index to bootstrap the registers from, instead of a root checkpoint")
+	pflag.StringVar(&flagSeedAPI, "seed-api", "", "address of an Archive API to bootstrap the registers from, instead of a root checkpoint")
+	pflag.Uint64Var(&flagSeedHeight, "seed-height", 0, "height at which to bootstrap the registers from the seed, and from which to index")
 	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes.")
 
 	pflag.Parse()
@@ -76,18 +98,33 @@ func run() int {
 	}
 	log = log.Level(level)
 
-	// Open the needed databases.
-	indexDB, err := badger.Open(archive.DefaultOptions(flagIndex))
-	if err != nil {
-		log.Error().Str("index", flagIndex).Err(err).Msg("could not open index database")
+	// Bootstrapping from a root checkpoint needs at least one worker, writing
+	// batches of at least one register.
+	if flagWorkers <= 0 {
+		log.Error().Int("bootstrap_workers", flagWorkers).Msg("number of bootstrap workers (--bootstrap-workers) must be positive")
 		return failure
 	}
-	defer func() {
-		err := indexDB.Close()
-		if err != nil {
-			log.Error().Err(err).Msg("could not close index database")
-		}
-	}()
+	if flagBatchSize <= 0 {
+		log.Error().Int("bootstrap_batch_size", flagBatchSize).Msg("bootstrap batch size (--bootstrap-batch-size) must be positive")
+		return failure
+	}
+
+	// Check that at most one seed is given, and that it comes with a height.
+	seeded := flagSeedIndex != "" || flagSeedAPI != ""
+	if flagSeedIndex != "" && flagSeedAPI != "" {
+		log.Error().Msg("only one of seed index (--seed-index) and seed API (--seed-api) can be given")
+		return failure
+	}
+	if seeded && flagSeedHeight == 0 {
+		log.Error().Msg("seed height (--seed-height) is required to bootstrap from a seed")
+		return failure
+	}
+	if seeded && flagBulkLoad {
+		log.Error().Msg("bulk load (--bulk-load) can only be used to bootstrap from a root checkpoint")
+		return failure
+	}
+
+	// Open the needed databases.
 	protocolDB, err := badger.Open(archive.DefaultOptions(flagData))
 	if err != nil {
 		log.Error().Err(err).Msg("could not open protocol state database")
@@ -100,14 +137,50 @@ func run() int {
 		}
 	}()
 
-	// The storage library is initialized with a codec and provides functions to
-	// interact with a Badger database while encoding and compressing
-	// transparently.
-	codec := zbor.NewCodec()
-	storage := storage.New(codec)
-	storage2, err := storage2.NewLibrary2(flagIndex2, flagBlockCacheSize)
+	// The seed provides the registers to bootstrap from when not starting from
+	// a root checkpoint, either from another index on disk or from the Archive
+	// API of another instance.
+	var seed mapper.Seed
+	switch {
+	case flagSeedIndex != "":
+		seedLib, err := storage2.NewLibrary2(flagSeedIndex, flagBlockCacheSize)
+		if err != nil {
+			log.Error().Str("seed_index", flagSeedIndex).Err(err).Msg("could not open seed index")
+			return failure
+		}
+		defer func() {
+			err := seedLib.Close()
+			if err != nil {
+				log.Error().Err(err).Msg("could not close seed index")
+			}
+		}()
+		seed = seeder.FromLibrary(seedLib)
+	case flagSeedAPI != "":
+		conn, err := grpc.Dial(flagSeedAPI, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Error().Str("seed_api", flagSeedAPI).Err(err).Msg("could not dial seed API")
+			return failure
+		}
+		defer conn.Close()
+		seed = seeder.FromReader(api.IndexFromAPI(api.NewAPIClient(conn), zbor.NewCodec()))
+	}
+
+	// The storage library provides functions to interact with the pebble
+	// databases of the index while encoding and compressing transparently.
+	// We default to periodic durability, rather than syncing every write, to
+	// improve throughput when indexing from static on-disk data. Indexing
+	// resumes after the last durable block if it is interrupted.
+	durability, err := storconfig.ParseDurability(flagDurability)
 	if err != nil {
-		log.Error().Str("index2", flagIndex2).Err(err).Msg("could not open storage2")
+		log.Error().Str("durability", flagDurability).Err(err).Msg("could not parse durability mode")
+		return failure
+	}
+	storage2, err := storage2.NewLibrary2(flagIndex, flagBlockCacheSize,
+		storage2.WithDurability(durability),
+		storage2.WithSyncInterval(flagSyncInterval),
+	)
+	if err != nil {
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not open storage2")
 		return failure
 	}
 	defer func() {
@@ -118,19 +191,30 @@ func run() int {
 	}()
 
 	// Check if index already exists.
-	read := index.NewReader(log, indexDB, storage, storage2)
-	empty := errors.Is(err, badger.ErrKeyNotFound)
+	read := index.NewReader(log, storage2)
+	_, err = read.First()
+	empty := errors.Is(err, archive.ErrNotFound)
 	if err != nil && !empty {
 		log.Error().Err(err).Msg("could not get first height from index reader")
 		return failure
 	}
-	if empty && flagCheckpoint == "" {
-		log.Error().Msg("index doesn't exist, please provide root checkpoint (-c, --checkpoint) to bootstrap")
+	if empty && flagCheckpoint == "" && !seeded {
+		log.Error().Msg("index doesn't exist, please provide root checkpoint (-c, --checkpoint) or seed (--seed-index, --seed-api) to bootstrap")
 		return failure
 	}
 
 	// The chain is responsible for reading blockchain data from the protocol state.
 	disk := chain.FromDisk(protocolDB)
+	root, err := disk.Root()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get root height from protocol state")
+		return failure
+	}
+	header, err := disk.Header(root)
+	if err != nil {
+		log.Error().Err(err).Msg("could not get root header from protocol state")
+		return failure
+	}
 
 	// Feeder is responsible for reading the write-ahead log of the execution state.
 	segments, err := wal.NewSegmentsReader(flagTrie)
@@ -141,14 +225,7 @@ func run() int {
 	feed := triereader.FromWAL(wal.NewReader(segments))
 
 	// Writer is responsible for writing the index data to the index database.
-	// We explicitly disable flushing at regular intervals to improve throughput
-	// of badger transactions when indexing from static on-disk data.
-	write := index.NewWriter(
-		indexDB,
-		storage,
-		storage2,
-		index.WithFlushInterval(0),
-	)
+	write := index.NewWriter(storage2)
 	defer func() {
 		err := write.Close()
 		if err != nil {
@@ -174,9 +251,27 @@ func run() int {
 		file.Close()
 	}
 
-	transitions := mapper.NewTransitions(log, disk, feed, read, write,
+	options := []mapper.Option{
+		mapper.WithBootstrapWorkers(flagWorkers),
+		mapper.WithBootstrapBatchSize(flagBatchSize),
+		mapper.WithBootstrapProgress(storage2),
 		mapper.WithSkipRegisters(flagSkip),
-	)
+	}
+	if flagBulkLoad {
+		options = append(options, mapper.WithBulkLoader(storage2))
+	}
+	if flagVerify {
+		options = append(options, mapper.WithVerifier(verifier.New(log, storage2)))
+	}
+	if seeded {
+		options = append(options, mapper.WithSeed(seed, flagSeedHeight))
+	}
+	params, ok := archive.FlowParams[header.ChainID]
+	if ok {
+		options = append(options, mapper.WithTransfers(tokens.NewExtractor(log, params)))
+	}
+	options = append(options, mapper.WithContracts(contracts.NewExtractor(log)))
+	transitions := mapper.NewTransitions(log, disk, feed, read, write, options...)
 	state := mapper.EmptyState(flagCheckpoint)
 	fsm := mapper.NewFSM(state,
 		mapper.WithTransition(mapper.StatusInitialize, transitions.InitializeMapper),
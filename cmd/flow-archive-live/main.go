@@ -38,15 +38,21 @@ import (
 	"github.com/onflow/flow-archive/models/archive"
 	accessSvc "github.com/onflow/flow-archive/service/access"
 	"github.com/onflow/flow-archive/service/cloud"
+	"github.com/onflow/flow-archive/service/contracts"
 	"github.com/onflow/flow-archive/service/index"
 	"github.com/onflow/flow-archive/service/initializer"
 	"github.com/onflow/flow-archive/service/invoker"
+	"github.com/onflow/flow-archive/service/keys"
 	"github.com/onflow/flow-archive/service/mapper"
 	"github.com/onflow/flow-archive/service/metrics"
+	"github.com/onflow/flow-archive/service/notifier"
 	"github.com/onflow/flow-archive/service/profiler"
-	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/pruner"
 	"github.com/onflow/flow-archive/service/storage2"
+	storconfig "github.com/onflow/flow-archive/service/storage2/config"
+	"github.com/onflow/flow-archive/service/tokens"
 	"github.com/onflow/flow-archive/service/tracker"
+	"github.com/onflow/flow-archive/service/verifier"
 )
 
 const (
@@ -80,13 +86,22 @@ func run() int {
 		flagMetricsAddr      string
 		flagProfiling        string
 		flagSkip             bool
+		flagVerify           bool
 		flagWaitInterval     time.Duration
+		flagWorkers          int
+		flagBatchSize        int
+		flagDurability       string
+		flagSyncInterval     time.Duration
 
 		flagCache          uint64
-		flagIndex2         string
 		flagBlockCacheSize int64
 
 		flagFlushInterval     time.Duration
+		flagIndexKeys         bool
+		flagKeysFrequency     time.Duration
+		flagPruneRetain       uint64
+		flagPruneInterval     uint64
+		flagPruneFrequency    time.Duration
 		flagSeedAddress       string
 		flagSeedKey           string
 		flagTracing           bool
@@ -103,20 +118,31 @@ func run() int {
 	pflag.StringVarP(&flagMetricsAddr, "metrics", "m", "", "address on which to expose metrics (no metrics are exposed when left empty)")
 	pflag.StringVarP(&flagProfiling, "profiler-address", "p", "", "address for net/http/pprof profiler (profiler is disabled if left empty)")
 	pflag.BoolVarP(&flagSkip, "skip", "s", mapper.DefaultConfig.SkipRegisters, "skip indexing of execution state ledger registers")
+	pflag.BoolVar(&flagVerify, "verify-bootstrap", false, "verify the registers imported from the root checkpoint against the root state commitment")
+	pflag.IntVar(&flagWorkers, "bootstrap-workers", mapper.DefaultConfig.BootstrapWorkers, "number of workers writing the registers of the root checkpoint concurrently")
+	pflag.IntVar(&flagBatchSize, "bootstrap-batch-size", mapper.DefaultConfig.BootstrapBatchSize, "number of registers of the root checkpoint written per batch")
+	pflag.StringVar(&flagDurability, "durability", storage2.DefaultConfig.Durability.String(), "when index writes are synced to disk: after every batch (batch), once per block (block) or in periodic group commits (periodic)")
+	pflag.DurationVar(&flagSyncInterval, "sync-interval", storage2.DefaultConfig.SyncInterval, "interval between group commits of index writes with periodic durability")
 	pflag.DurationVarP(&flagWaitInterval, "wait-interval", "", mapper.DefaultConfig.WaitInterval, "wait interval for polling execution data for the next block (default: 250ms), useful to set a longer duration after fully synced for historical spork")
 
-	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to database directory for state index")
-	pflag.StringVarP(&flagIndex2, "index2", "I", "index2", "path to the pebble-based index database directory")
+	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to the pebble-based index database directory")
 	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes.")
 	pflag.Uint64Var(&flagCache, "register-cache-size", 1<<30, "maximum cache size for register reads in bytes")
 
-	pflag.DurationVar(&flagFlushInterval, "flush-interval", 1*time.Second, "interval for flushing badger transactions (0s for disabled)")
+	pflag.DurationVar(&flagFlushInterval, "flush-interval", 1*time.Second, "no longer used, as index writes are committed immediately")
+	pflag.Uint64Var(&flagPruneRetain, "prune-retain-blocks", pruner.DefaultConfig.RetainBlocks, "number of most recent blocks for which all register versions are kept (0 disables pruning)")
+	pflag.Uint64Var(&flagPruneInterval, "prune-interval", pruner.DefaultConfig.Interval, "keep the register state of every height that is a multiple of this interval when pruning (0 for none)")
+	pflag.DurationVar(&flagPruneFrequency, "prune-frequency", pruner.DefaultConfig.Frequency, "interval between two pruning runs")
+	pflag.BoolVar(&flagIndexKeys, "index-account-keys", false, "index the key history of accounts in the background")
+	pflag.DurationVar(&flagKeysFrequency, "account-keys-frequency", keys.DefaultConfig.Frequency, "interval between two runs of the account key indexer")
 	pflag.StringVar(&flagSeedAddress, "seed-address", "", "host address of seed node to follow consensus")
 	pflag.StringVar(&flagSeedKey, "seed-key", "", "hex-encoded public network key of seed node to follow consensus")
 	pflag.BoolVarP(&flagTracing, "tracing", "t", false, "enable tracing for this instance")
 	pflag.StringVar(&flagExecAddress, "exec-address", "", "host address of access node to get exec data from")
 	pflag.BoolVarP(&flagValidateRegisters, "validate-registers", "v", false, "validate register data from GCP with exec")
 
+	_ = pflag.CommandLine.MarkDeprecated("flush-interval", "index writes are now committed immediately")
+
 	pflag.Parse()
 
 	// Increase the GOMAXPROCS value in order to use the full IOPS available, see:
@@ -133,21 +159,19 @@ func run() int {
 	}
 	log = log.Level(level)
 
-	// As a first step, we will open the protocol state and the index database.
-	// The protocol state database is what the consensus follower will write to
-	// and the mapper will read from. The index database is what the mapper will
-	// write to and the DPS API will read from.
-	indexDB, err := badger.Open(archive.DefaultOptions(flagIndex))
-	if err != nil {
-		log.Error().Str("index", flagIndex).Err(err).Msg("could not open index database")
+	// Bootstrapping from a root checkpoint needs at least one worker, writing
+	// batches of at least one register.
+	if flagWorkers <= 0 {
+		log.Error().Int("bootstrap_workers", flagWorkers).Msg("number of bootstrap workers (--bootstrap-workers) must be positive")
 		return failure
 	}
-	defer func() {
-		err := indexDB.Close()
-		if err != nil {
-			log.Error().Err(err).Msg("could not close index database")
-		}
-	}()
+	if flagBatchSize <= 0 {
+		log.Error().Int("bootstrap_batch_size", flagBatchSize).Msg("bootstrap batch size (--bootstrap-batch-size) must be positive")
+		return failure
+	}
+
+	// As a first step, we will open the protocol state database. It is what the
+	// consensus follower will write to and the mapper will read from.
 	protocolDB, err := badger.Open(archive.DefaultOptions(flagData))
 	if err != nil {
 		log.Error().Err(err).Msg("could not open protocol state database")
@@ -160,17 +184,26 @@ func run() int {
 		}
 	}()
 
-	// Next, we initialize the index reader and writer. They use a common codec
-	// and storage library to interact with the underlying database. If there
-	// already is an index database, we need the force flag to be set, as we do
-	// not want to start overwriting data in the index silently. We also need
-	// to flush the writer to make sure all data is written correctly when
-	// shutting down.
+	// Next, we initialize the index reader and writer. They use a common
+	// storage library to interact with the underlying pebble databases, which
+	// the mapper will write to and the DPS API will read from. The codec is
+	// used by the DPS API to encode its responses.
 	codec := zbor.NewCodec()
-	storage := storage.New(codec)
-	storage2, err := storage2.NewLibrary2(flagIndex2, flagBlockCacheSize)
+	durability, err := storconfig.ParseDurability(flagDurability)
 	if err != nil {
-		log.Error().Str("index2", flagIndex2).Err(err).Msg("could not open storage2")
+		log.Error().Str("durability", flagDurability).Err(err).Msg("could not parse durability mode")
+		return failure
+	}
+	// The index notifies the API of every persisted last height, so that
+	// subscriptions can stream it right away.
+	notify := notifier.New()
+	storage2, err := storage2.NewLibrary2(flagIndex, flagBlockCacheSize,
+		storage2.WithDurability(durability),
+		storage2.WithSyncInterval(flagSyncInterval),
+		storage2.WithNotifier(notify),
+	)
+	if err != nil {
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not open storage2")
 		return failure
 	}
 	defer func() {
@@ -179,19 +212,8 @@ func run() int {
 			log.Error().Err(err).Msg("could not close storage2")
 		}
 	}()
-	read := index.NewReader(log, indexDB, storage, storage2)
-
-	// We initialize the writer with a flush interval, which will make sure that
-	// Badger transactions are committed to the database, even if they don't
-	// fill up fast enough. This avoids having latency between when we add data
-	// to the transaction and when it becomes available on-disk for serving the
-	// DPS API.
-	write := index.NewWriter(
-		indexDB,
-		storage,
-		storage2,
-		index.WithFlushInterval(flagFlushInterval),
-	)
+	read := index.NewReader(log, storage2)
+	write := index.NewWriter(storage2)
 
 	defer func() {
 		err := write.Close()
@@ -370,10 +392,25 @@ func run() int {
 	// At this point, we can initialize the core business logic of the indexer,
 	// with the mapper's finite state machine and transitions. We also want to
 	// load and inject the root checkpoint if it is given as a parameter.
-	transitions := mapper.NewTransitions(log, consensus, execution, read, writer,
+	options := []mapper.Option{
+		mapper.WithBootstrapWorkers(flagWorkers),
+		mapper.WithBootstrapBatchSize(flagBatchSize),
+		mapper.WithBootstrapProgress(storage2),
 		mapper.WithSkipRegisters(flagSkip),
 		mapper.WithWaitInterval(flagWaitInterval),
-	)
+	}
+	if metricsEnabled {
+		options = append(options, mapper.WithMetrics(metrics.NewBootstrapMetrics()))
+	}
+	if flagVerify {
+		options = append(options, mapper.WithVerifier(verifier.New(log, storage2)))
+	}
+	params, ok := archive.FlowParams[chainID]
+	if ok {
+		options = append(options, mapper.WithTransfers(tokens.NewExtractor(log, params)))
+	}
+	options = append(options, mapper.WithContracts(contracts.NewExtractor(log)))
+	transitions := mapper.NewTransitions(log, consensus, execution, read, writer, options...)
 	state := mapper.EmptyState(flagCheckpoint)
 	fsm := mapper.NewFSM(state,
 		mapper.WithTransition(mapper.StatusInitialize, transitions.InitializeMapper),
@@ -386,6 +423,21 @@ func run() int {
 		mapper.WithTransition(mapper.StatusForward, transitions.ForwardHeight),
 	)
 
+	// If pruning is enabled, a background job regularly deletes the register
+	// versions that are no longer needed to serve the retained heights.
+	var prune *pruner.Pruner
+	if flagPruneRetain > 0 {
+		var pruneMetrics pruner.Metrics
+		if metricsEnabled {
+			pruneMetrics = metrics.NewPruneMetrics()
+		}
+		prune = pruner.New(log, read, storage2, pruneMetrics,
+			pruner.WithRetainBlocks(flagPruneRetain),
+			pruner.WithInterval(flagPruneInterval),
+			pruner.WithFrequency(flagPruneFrequency),
+		)
+	}
+
 	// Next, we initialize the GRPC server that will serve the DPS API on top of
 	// the index database that is generated live by the mapper.
 	logOpts := []logging.Option{
@@ -404,17 +456,18 @@ func run() int {
 	}
 
 	gsvr := grpc.NewServer(options...)
-	var server *api.Server
+	apiOptions := []api.Option{
+		api.WithNotifier(notify),
+	}
 	if flagTracing {
 		tracer, err := metrics.NewTracer(log, "archive")
 		if err != nil {
 			log.Error().Err(err).Msg("could not initialize tracer")
 			return failure
 		}
-		server = api.NewServer(read, codec, api.WithTracer(tracer))
-	} else {
-		server = api.NewServer(read, codec)
+		apiOptions = append(apiOptions, api.WithTracer(tracer))
 	}
+	server := api.NewServer(read, codec, apiOptions...)
 
 	log.Info().Msgf("Creating local invoker with register cache: %d", flagCache)
 	config := invoker.DefaultConfig
@@ -430,6 +483,16 @@ func run() int {
 		return failure
 	}
 	accessServer := accessSvc.NewServer(read, invoke)
+
+	// If enabled, a background job regularly indexes the keys of the accounts
+	// whose keys changed, as read through the invoker at the heights of the
+	// changes.
+	var keyIndex *keys.Indexer
+	if flagIndexKeys {
+		keyIndex = keys.New(log, read, invoke, storage2,
+			keys.WithFrequency(flagKeysFrequency),
+		)
+	}
 	accessGsvr := grpc.NewServer(options...)
 
 	// This section launches the main executing components in their own
@@ -489,6 +552,24 @@ func run() int {
 		}
 		log.Info().Msg("Flow Access API Server stopped")
 	}()
+	go func() {
+		if prune == nil {
+			return
+		}
+
+		log.Info().Msg("register pruner starting")
+		prune.Run(ctx)
+		log.Info().Msg("register pruner stopped")
+	}()
+	go func() {
+		if keyIndex == nil {
+			return
+		}
+
+		log.Info().Msg("account key indexer starting")
+		keyIndex.Run(ctx)
+		log.Info().Msg("account key indexer stopped")
+	}()
 	go func() {
 		if !metricsEnabled {
 			return
@@ -534,9 +615,11 @@ func run() int {
 		os.Exit(1)
 	}()
 
-	// We first stop serving the DPS API by shutting down the GRPC server. Next,
-	// we shut down the consensus follower, so that there is no indexing to be
-	// done anymore. Lastly, we stop the mapper logic itself.
+	// We first stop serving the DPS API by ending its subscriptions and shutting
+	// down the GRPC server. Next, we shut down the consensus follower, so that
+	// there is no indexing to be done anymore. Lastly, we stop the mapper logic
+	// itself.
+	server.Stop()
 	gsvr.GracefulStop()
 	cancel()
 	<-follow.Done()
@@ -11,7 +11,6 @@ import (
 	"github.com/onflow/flow-archive/service/metrics"
 	"github.com/onflow/flow-archive/service/storage2"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
 	"google.golang.org/grpc"
@@ -22,9 +21,7 @@ import (
 
 	api "github.com/onflow/flow-archive/api/archive"
 	"github.com/onflow/flow-archive/codec/zbor"
-	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/service/index"
-	"github.com/onflow/flow-archive/service/storage"
 )
 
 const (
@@ -49,7 +46,6 @@ func run() int {
 		flagTracing bool
 
 		flagIndex          string
-		flagIndex2         string
 		flagBlockCacheSize int64
 	)
 
@@ -57,8 +53,7 @@ func run() int {
 	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
 	pflag.BoolVarP(&flagTracing, "tracing", "t", false, "enable tracing for this instance")
 
-	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to database directory for state index")
-	pflag.StringVarP(&flagIndex2, "index2", "I", "index2", "path to the pebble-based index database directory")
+	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to the pebble-based index database directory")
 	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes.")
 
 	pflag.Parse()
@@ -73,20 +68,11 @@ func run() int {
 	}
 	log = log.Level(level)
 
-	// Initialize the index core state and open database in read-only mode.
-	db, err := badger.Open(archive.DefaultOptions(flagIndex).WithReadOnly(true))
-	if err != nil {
-		log.Error().Str("index", flagIndex).Err(err).Msg("could not open index DB")
-		return failure
-	}
-	defer db.Close()
-
 	// Initialize storage library.
 	codec := zbor.NewCodec()
-	storage := storage.New(codec)
-	storage2, err := storage2.NewLibrary2(flagIndex2, flagBlockCacheSize)
+	storage2, err := storage2.NewLibrary2(flagIndex, flagBlockCacheSize)
 	if err != nil {
-		log.Error().Str("index2", flagIndex2).Err(err).Msg("could not open storage2")
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not open storage2")
 		return failure
 	}
 	defer func() {
@@ -110,7 +96,7 @@ func run() int {
 			logging.StreamServerInterceptor(grpczerolog.InterceptorLogger(log), opts...),
 		),
 	)
-	index := index.NewReader(log, db, storage, storage2)
+	index := index.NewReader(log, storage2)
 	var server *api.Server
 	if flagTracing {
 		tracer, err := metrics.NewTracer(log, "archive")
@@ -161,6 +147,9 @@ func run() int {
 		os.Exit(1)
 	}()
 
+	// Subscriptions never end by themselves, so they have to be ended before
+	// the GRPC server can be shut down gracefully.
+	server.Stop()
 	gsvr.GracefulStop()
 
 	return success
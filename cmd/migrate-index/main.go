@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-archive/codec/zbor"
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/migrator"
+	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/storage2"
+	"github.com/onflow/flow-archive/service/storage2/blocks"
+)
+
+const (
+	success = 0
+	failure = 1
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// migrate-index command copies the block data of a legacy badger index into
+// the pebble-based index, and verifies the result.
+func run() int {
+
+	// Parse the command line arguments.
+	var (
+		flagLegacy         string
+		flagIndex          string
+		flagLevel          string
+		flagBatchSize      int
+		flagSampleInterval uint64
+		flagDropPayloads   bool
+		flagBlockCacheSize int64
+	)
+
+	pflag.StringVarP(&flagLegacy, "legacy", "b", "", "path to the legacy badger index database directory")
+	pflag.StringVarP(&flagIndex, "index", "i", "", "path to the pebble-based index database directory")
+	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
+	pflag.IntVar(&flagBatchSize, "batch-size", migrator.DefaultConfig.BatchSize, "number of records written to the pebble index at once")
+	pflag.Uint64Var(&flagSampleInterval, "sample-interval", migrator.DefaultConfig.SampleInterval, "compare the values of one in this many records during verification (0 to only compare counts)")
+	pflag.BoolVar(&flagDropPayloads, "drop-payloads", false, "delete the obsolete register payloads from the legacy index after a successful verification")
+	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes")
+
+	pflag.Parse()
+
+	// Initialize the logger.
+	zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger().Level(zerolog.DebugLevel)
+	level, err := zerolog.ParseLevel(flagLevel)
+	if err != nil {
+		log.Error().Str("level", flagLevel).Err(err).Msg("could not parse log level")
+		return failure
+	}
+	log = log.Level(level)
+
+	log.Info().
+		Str("legacy", flagLegacy).
+		Str("index", flagIndex).
+		Str("level", flagLevel).
+		Int("batch_size", flagBatchSize).
+		Uint64("sample_interval", flagSampleInterval).
+		Bool("drop_payloads", flagDropPayloads).
+		Msgf("flags loaded")
+
+	if flagLegacy == "" || flagIndex == "" {
+		log.Error().Msg("missing index directory")
+		return failure
+	}
+	if flagLegacy == flagIndex {
+		log.Error().Msg("legacy and pebble index directories must be different")
+		return failure
+	}
+	if flagBatchSize <= 0 {
+		log.Error().Int("batch_size", flagBatchSize).Msg("batch size must be positive")
+		return failure
+	}
+
+	// The legacy index is only written to when dropping its payloads.
+	db, err := badger.Open(archive.DefaultOptions(flagLegacy).WithReadOnly(!flagDropPayloads))
+	if err != nil {
+		log.Error().Str("legacy", flagLegacy).Err(err).Msg("could not open legacy index")
+		return failure
+	}
+	defer db.Close()
+
+	cache := pebble.NewCache(flagBlockCacheSize)
+	defer cache.Unref()
+
+	codec := zbor.NewCodec()
+	store, err := blocks.NewStorage(storage2.BlocksPath(flagIndex), cache, codec)
+	if err != nil {
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not open pebble index")
+		return failure
+	}
+	defer func() {
+		err := store.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("could not close pebble index")
+		}
+	}()
+
+	m := migrator.New(log, db, storage.New(codec), store,
+		migrator.WithBatchSize(flagBatchSize),
+		migrator.WithSampleInterval(flagSampleInterval),
+	)
+
+	start := time.Now()
+	err = m.Migrate()
+	if err != nil {
+		log.Error().Err(err).Msg("could not migrate index")
+		return failure
+	}
+
+	log.Info().Str("duration", time.Since(start).Round(time.Second).String()).Msg("index migrated, verifying")
+
+	err = m.Verify()
+	if err != nil {
+		log.Error().Err(err).Msg("could not verify migrated index")
+		return failure
+	}
+
+	if flagDropPayloads {
+		err = m.DropPayloads()
+		if err != nil {
+			log.Error().Err(err).Msg("could not drop legacy payloads")
+			return failure
+		}
+	}
+
+	log.Info().Str("duration", time.Since(start).Round(time.Second).String()).Msg("successfully migrated index")
+
+	return success
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/service/index"
+	"github.com/onflow/flow-archive/service/storage2"
+)
+
+// Contracts prints the contracts deployed on the given owner at the given
+// height to standard output, one name and the height of its latest change per
+// line. If a contract name is given, it prints the Cadence source of that
+// contract at the height instead, or its update history up to the height, one
+// height, action and transaction ID per line.
+func Contracts(indexDir string, height uint64, owner string, name string, history bool, log zerolog.Logger) error {
+	lib2, err := storage2.NewLibrary2(indexDir, 1<<30)
+	if err != nil {
+		return err
+	}
+	defer lib2.Close()
+
+	read := index.NewReader(log, lib2)
+	address := flow.HexToAddress(owner)
+
+	switch {
+	case name != "" && history:
+		updates, err := read.ContractHistory(address, name, 0, height, 0)
+		if err != nil {
+			return fmt.Errorf("could not get contract history: %w", err)
+		}
+		for _, update := range updates {
+			_, err = fmt.Printf("%d %d %x\n", update.Height, update.Action, update.TransactionID)
+			if err != nil {
+				return err
+			}
+		}
+		log.Info().Msgf("successfully listed %v updates of contract %s at height %v for owner: %s", len(updates), name, height, address)
+
+	case name != "":
+		code, err := read.ContractCode(address, name, height)
+		if err != nil {
+			return fmt.Errorf("could not get contract code: %w", err)
+		}
+		_, err = os.Stdout.Write(code)
+		if err != nil {
+			return err
+		}
+		log.Info().Msgf("successfully printed contract %s at height %v for owner: %s (len: %v)", name, height, address, len(code))
+
+	default:
+		contracts, err := read.ContractsByAddress(address, height)
+		if err != nil {
+			return fmt.Errorf("could not list contracts: %w", err)
+		}
+		for _, contract := range contracts {
+			_, err = fmt.Printf("%s %d\n", contract.Name, contract.Height)
+			if err != nil {
+				return err
+			}
+		}
+		log.Info().Msgf("successfully listed %v contracts at height %v for owner: %s", len(contracts), height, address)
+	}
+
+	return nil
+}
@@ -20,7 +20,9 @@ func main() {
 	os.Exit(run())
 }
 
-// payloads command allows us to query the payload (register value) from the storage by owner, key and height
+// payloads command allows us to query the payload (register value) from the storage by owner, key and height.
+// The `contracts` subcommand lists the contracts deployed on the owner at the height instead, or prints the
+// Cadence source or the update history of a single one of them.
 func run() int {
 
 	// Parse the command line arguments.
@@ -30,6 +32,10 @@ func run() int {
 		flagLevel  string
 		flagOwner  string
 		flagKey    string
+		flagAll    bool
+
+		flagContract string
+		flagHistory  bool
 	)
 
 	pflag.StringVarP(&flagIndex, "index", "i", "/var/flow/data/pebble/index2", "database directory for state index")
@@ -37,6 +43,9 @@ func run() int {
 	pflag.StringVarP(&flagOwner, "owner", "o", "", "owner in hex format")
 	pflag.StringVarP(&flagKey, "key", "k", "", "register key in hex format")
 	pflag.Uint64VarP(&flagHeight, "height", "h", 0, "height for getting register id")
+	pflag.BoolVarP(&flagAll, "all", "a", false, "print every register of the owner with its value at the height, instead of a single register")
+	pflag.StringVar(&flagContract, "contract", "", "name of the contract whose Cadence source is printed by the contracts subcommand")
+	pflag.BoolVar(&flagHistory, "history", false, "print the update history of the contract up to the height instead of its source, with the contracts subcommand")
 
 	pflag.Parse()
 
@@ -56,6 +65,9 @@ func run() int {
 		Uint64("height", flagHeight).
 		Str("owner", flagOwner).
 		Str("key", flagKey).
+		Bool("all", flagAll).
+		Str("contract", flagContract).
+		Bool("history", flagHistory).
 		Msgf("flags loaded")
 
 	if flagIndex == "" {
@@ -70,13 +82,44 @@ func run() int {
 		return failure
 	}
 
-	if flagKey == "" {
-		log.Error().Msgf("--key flag is empty")
+	if flagHeight == 0 {
+		log.Error().Msgf("--height flag is 0")
 		return failure
 	}
 
-	if flagHeight == 0 {
-		log.Error().Msgf("--height flag is 0")
+	switch pflag.Arg(0) {
+	case "":
+	case "contracts":
+		if flagOwner == "" {
+			log.Error().Msgf("--owner flag is empty")
+			return failure
+		}
+		if flagHistory && flagContract == "" {
+			log.Error().Msgf("--history flag requires --contract")
+			return failure
+		}
+		err = Contracts(flagIndex, flagHeight, flagOwner, flagContract, flagHistory, log)
+		if err != nil {
+			log.Error().Err(err).Msg("can not read contracts")
+			return failure
+		}
+		return success
+	default:
+		log.Error().Str("command", pflag.Arg(0)).Msg("unknown subcommand")
+		return failure
+	}
+
+	if flagAll {
+		err = ListPayloads(flagIndex, flagHeight, flagOwner, log)
+		if err != nil {
+			log.Error().Err(err).Msg("can not list payloads")
+			return failure
+		}
+		return success
+	}
+
+	if flagKey == "" {
+		log.Error().Msgf("--key flag is empty")
 		return failure
 	}
 
@@ -112,3 +155,32 @@ func GetPayload(indexDir string, height uint64, owner string, key string, log ze
 	log.Info().Msgf("successfully get register value at height %v for reg id: %v: %x (len: %v)", height, regID, regValue, len(regValue))
 	return nil
 }
+
+// ListPayloads prints every register of the given owner with its value at the
+// given height to standard output, one hex-encoded key and value per line.
+func ListPayloads(indexDir string, height uint64, owner string, log zerolog.Logger) error {
+	lib2, err := storage2.NewLibrary2(indexDir, 1<<30)
+	if err != nil {
+		return err
+	}
+	defer lib2.Close()
+
+	// An empty owner lists the registers that are not owned by any account.
+	var ownerBytes []byte
+	if owner != "" {
+		ownerBytes = flow.HexToAddress(owner).Bytes()
+	}
+
+	count := 0
+	err = lib2.IterateOwnerPayloads(height, string(ownerBytes), func(entry flow.RegisterEntry) error {
+		count++
+		_, err := fmt.Printf("%x %x\n", entry.Key.Key, entry.Value)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not list register values: %w", err)
+	}
+
+	log.Info().Msgf("successfully listed %v registers at height %v for owner: %x", count, height, ownerBytes)
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2"
+)
+
+const (
+	success = 0
+	failure = 1
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// prune-registers command deletes the register versions of the pebble index
+// that are no longer needed to serve the heights kept by a retention.
+func run() int {
+
+	// Parse the command line arguments.
+	var (
+		flagIndex          string
+		flagLevel          string
+		flagHeight         uint64
+		flagInterval       uint64
+		flagBlockCacheSize int64
+	)
+
+	pflag.StringVarP(&flagIndex, "index", "i", "/var/flow/data/pebble/index2", "database directory for state index")
+	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
+	pflag.Uint64VarP(&flagHeight, "height", "h", 0, "height from which all register versions are kept")
+	pflag.Uint64Var(&flagInterval, "interval", 0, "keep the register state of every height below the given height that is a multiple of this interval (0 for none)")
+	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes")
+
+	pflag.Parse()
+
+	// Initialize the logger.
+	zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger().Level(zerolog.DebugLevel)
+	level, err := zerolog.ParseLevel(flagLevel)
+	if err != nil {
+		log.Error().Str("level", flagLevel).Err(err).Msg("could not parse log level")
+		return failure
+	}
+	log = log.Level(level)
+
+	log.Info().
+		Str("index", flagIndex).
+		Str("level", flagLevel).
+		Uint64("height", flagHeight).
+		Uint64("interval", flagInterval).
+		Msgf("flags loaded")
+
+	if flagIndex == "" {
+		log.Error().Msg("missing index directory")
+		return failure
+	}
+
+	storagePath := storage2.StoragePath(flagIndex)
+	// Check if the path exists
+	if _, err := os.Stat(storagePath); os.IsNotExist(err) {
+		log.Error().Msgf("The storagePath '%s' does not exist.\n", storagePath)
+		return failure
+	}
+
+	if flagHeight == 0 {
+		log.Error().Msg("--height flag is 0")
+		return failure
+	}
+
+	lib2, err := storage2.NewLibrary2(flagIndex, flagBlockCacheSize)
+	if err != nil {
+		log.Error().Err(err).Msg("could not open index")
+		return failure
+	}
+	defer func() {
+		err := lib2.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("could not close index")
+		}
+	}()
+
+	// The retention height has to be within the indexed heights, or it would
+	// either prune registers still needed for the latest indexed state, or
+	// keep nothing that was not already kept.
+	first, err := lib2.GetFirst()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get first indexed height")
+		return failure
+	}
+	latest, err := lib2.GetLatestRegisterHeight()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get latest register height")
+		return failure
+	}
+	if flagHeight < first || flagHeight > latest {
+		log.Error().
+			Uint64("height", flagHeight).
+			Uint64("first", first).
+			Uint64("latest", latest).
+			Msg("--height flag is outside of the indexed register heights")
+		return failure
+	}
+
+	retention := archive.RegisterRetention{
+		Height:   flagHeight,
+		Interval: flagInterval,
+	}
+
+	start := time.Now()
+	err = lib2.PruneRegisters(retention, func(progress archive.PruneProgress) {
+		log.Info().
+			Uint64("scanned", progress.Scanned).
+			Uint64("deleted", progress.Deleted).
+			Msg("pruning registers")
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("could not prune registers")
+		return failure
+	}
+
+	log.Info().Str("duration", time.Since(start).Round(time.Second).String()).Msg("successfully pruned registers")
+
+	return success
+}
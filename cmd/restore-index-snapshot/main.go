@@ -4,20 +4,20 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"os"
-	"runtime"
+	"path/filepath"
 	"time"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/klauspost/compress/zstd"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/archive"
-	"github.com/onflow/flow-archive/service/index"
-	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/snapshot"
 	"github.com/onflow/flow-archive/service/storage2"
 )
 
@@ -50,15 +50,15 @@ func run() int {
 		flagEncoding    string
 
 		flagIndex          string
-		flagIndex2         string
+		flagDeltas         []string
 		flagBlockCacheSize int64
 	)
 
 	pflag.StringVarP(&flagCompression, "compression", "c", compressionZstd, "compression algorithm (\"none\", \"zstd\" or \"gzip\")")
 	pflag.StringVarP(&flagEncoding, "encoding", "e", encodingNone, "output encoding (\"none\", \"hex\" or \"base64\")")
 
-	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to database directory for state index")
-	pflag.StringVarP(&flagIndex2, "index2", "I", "index2", "path to the pebble-based index database directory")
+	pflag.StringVarP(&flagIndex, "index", "i", "index", "path to the pebble-based index database directory")
+	pflag.StringSliceVarP(&flagDeltas, "delta", "d", nil, "paths to delta snapshots to apply in order on top of the restored snapshot")
 	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes.")
 
 	pflag.Parse()
@@ -67,58 +67,185 @@ func run() int {
 	zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
 	log := zerolog.New(os.Stderr).With().Timestamp().Logger().Level(zerolog.DebugLevel)
 
-	// Open the index database.
-	db, err := badger.Open(archive.DefaultOptions(flagIndex))
+	// The snapshot is restored atomically by extracting it next to the index
+	// directory first, so the index directory must be empty or not exist yet.
+	entries, err := os.ReadDir(flagIndex)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not check index directory")
+		return failure
+	}
+	if len(entries) != 0 {
+		log.Error().Str("index", flagIndex).Msg("index directory is not empty")
+		return failure
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(filepath.Clean(flagIndex)), "restore")
+	if err != nil {
+		log.Error().Err(err).Msg("could not create restore directory")
+		return failure
+	}
+	defer os.RemoveAll(dir)
+
+	// We will consume from stdin; if the user wants to load from a file, he can
+	// pipe it into the command.
+	reader, closer, err := decode(os.Stdin, flagCompression, flagEncoding)
+	if err != nil {
+		log.Error().Err(err).Msg("could not decode snapshot")
+		return failure
+	}
+	defer closer()
+
+	// Restore the database files from the archive, verifying their checksums.
+	manifest, err := snapshot.Read(reader, dir)
+	if err != nil {
+		log.Error().Err(err).Msg("snapshot restoration failed")
+		return failure
+	}
+
+	if manifest.IsDelta() {
+		log.Error().Msg("snapshot on standard input is a delta snapshot")
+		return failure
+	}
+
+	// The manifest only describes the files of the snapshot, which change as
+	// soon as the index is opened.
+	err = os.Remove(filepath.Join(dir, snapshot.ManifestName))
 	if err != nil {
-		log.Error().Str("index", flagIndex).Err(err).Msg("could not open badger db")
+		log.Error().Err(err).Msg("could not remove snapshot manifest")
 		return failure
 	}
-	defer db.Close()
 
-	storage2, err := storage2.NewLibrary2(flagIndex2, flagBlockCacheSize)
+	// Make sure that the restored index is the one described by the manifest,
+	// and apply the delta snapshots on top of it.
+	manifest, err = apply(log, dir, manifest, flagDeltas, flagCompression, flagEncoding, flagBlockCacheSize)
 	if err != nil {
-		log.Error().Str("index2", flagIndex2).Err(err).Msg("could not open storage2")
+		log.Error().Err(err).Msg("snapshot verification failed")
+		return failure
+	}
+
+	log.Info().
+		Str("chain", manifest.ChainID.String()).
+		Uint64("first", manifest.First).
+		Uint64("last", manifest.Last).
+		Uint64("latest_register_height", manifest.LatestRegisterHeight).
+		Msg("snapshot verified")
+
+	// Move the restored index into place. The index directory is empty, so it
+	// can be replaced as a whole.
+	err = os.Remove(flagIndex)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not remove empty index directory")
 		return failure
 	}
-	defer func() {
-		err := storage2.Close()
+	err = os.Rename(dir, flagIndex)
+	if err != nil {
+		log.Error().Str("index", flagIndex).Err(err).Msg("could not move restored index into place")
+		return failure
+	}
+
+	log.Info().Msg("snapshot restoration complete")
+
+	return success
+}
+
+// apply opens the index restored in the given directory, verifies that its
+// heights and chain are the ones of the given manifest, and applies the delta
+// snapshots in the given files on top of it. It returns the manifest of the
+// last applied snapshot.
+func apply(log zerolog.Logger, dir string, manifest *snapshot.Manifest, deltas []string, compression string, encoding string, cacheSize int64) (*snapshot.Manifest, error) {
+	lib2, err := storage2.NewLibrary2(dir, cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not open restored index: %w", err)
+	}
+	defer lib2.Close()
+
+	err = manifest.Check(lib2)
+	if err != nil {
+		return nil, err
+	}
+
+	codec := zbor.NewCodec()
+	for _, path := range deltas {
+		manifest, err = applyDelta(lib2, codec, path, filepath.Dir(dir), compression, encoding)
 		if err != nil {
-			log.Error().Err(err).Msg("could not close storage2")
+			return nil, fmt.Errorf("could not apply delta snapshot (path: %s): %w", path, err)
 		}
-	}()
 
-	// Check if the database is empty.
-	index := index.NewReader(log, db, storage.New(zbor.NewCodec()), storage2)
-	_, err = index.First()
-	if err == nil {
-		log.Error().Msg("database directory already contains index database")
-		return failure
+		log.Info().
+			Str("path", path).
+			Uint64("last", manifest.Last).
+			Uint64("latest_register_height", manifest.LatestRegisterHeight).
+			Msg("delta snapshot applied")
 	}
 
-	// We will consume from stdin; if the user wants to load from a file, he can
-	// pipe it into the command.
-	var reader io.Reader
-	reader = os.Stdin
-	defer os.Stdin.Close()
+	return manifest, nil
+}
+
+// applyDelta extracts the delta snapshot in the file at the given path into a
+// temporary directory within the given parent directory, and applies it to the
+// given index.
+func applyDelta(lib2 archive.Library2, codec archive.Codec, path string, parent string, compression string, encoding string) (*snapshot.Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+	defer file.Close()
+
+	reader, closer, err := decode(file, compression, encoding)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	dir, err := os.MkdirTemp(parent, "delta")
+	if err != nil {
+		return nil, fmt.Errorf("could not create delta directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, err := snapshot.Read(reader, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = snapshot.ApplyDelta(lib2, codec, manifest, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// decode wraps the given reader to decompress and decode a snapshot created
+// with the given compression and encoding. The returned function releases the
+// resources of the decompressor.
+func decode(reader io.Reader, compression string, encoding string) (io.Reader, func(), error) {
 
 	// When reading, we first need to decompress, so we start with that
-	switch flagCompression {
+	closer := func() {}
+	switch compression {
 	case compressionNone:
 		// nothing to do
 	case compressionZstd:
-		decompressor, _ := zstd.NewReader(reader)
-		defer decompressor.Close()
+		decompressor, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create zstd decompressor: %w", err)
+		}
+		closer = decompressor.Close
 		reader = decompressor
 	case compressionGzip:
-		decompressor, _ := gzip.NewReader(reader)
-		defer decompressor.Close()
+		decompressor, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create gzip decompressor: %w", err)
+		}
+		closer = func() { _ = decompressor.Close() }
 		reader = decompressor
 	default:
-		log.Error().Str("compression", flagCompression).Msg("invalid compression algorithm specified")
+		return nil, nil, fmt.Errorf("invalid compression algorithm specified (compression: %s)", compression)
 	}
 
 	// After decompression, we can decode the encoding.
-	switch flagEncoding {
+	switch encoding {
 	case encodingNone:
 		// nothing to do
 	case encodingHex:
@@ -126,17 +253,9 @@ func run() int {
 	case encodingBase64:
 		reader = base64.NewDecoder(base64.StdEncoding, reader)
 	default:
-		log.Error().Str("encoding", flagEncoding).Msg("invalid encoding format specified")
+		closer()
+		return nil, nil, fmt.Errorf("invalid encoding format specified (encoding: %s)", encoding)
 	}
 
-	// Restore the database
-	err = db.Load(reader, runtime.GOMAXPROCS(0))
-	if err != nil {
-		log.Error().Err(err).Msg("snapshot restoration failed")
-		return failure
-	}
-
-	log.Info().Msg("snapshot restoration complete")
-
-	return success
+	return reader, closer, nil
 }
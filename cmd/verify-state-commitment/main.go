@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-archive/service/storage2"
+	"github.com/onflow/flow-archive/service/verifier"
+)
+
+const (
+	success = 0
+	failure = 1
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// verify-state-commitment command rebuilds the execution state trie from the
+// registers of the pebble index at a height, and checks that its root hash is
+// the state commitment indexed for that height.
+func run() int {
+
+	// Parse the command line arguments.
+	var (
+		flagIndex          string
+		flagLevel          string
+		flagHeight         uint64
+		flagBlockCacheSize int64
+	)
+
+	pflag.StringVarP(&flagIndex, "index", "i", "/var/flow/data/pebble/index2", "database directory for state index")
+	pflag.StringVarP(&flagLevel, "level", "l", "info", "log output level")
+	pflag.Uint64VarP(&flagHeight, "height", "h", 0, "height at which to verify the registers (0 for the latest indexed register height)")
+	pflag.Int64Var(&flagBlockCacheSize, "block-cache-size", 1<<30, "size of the pebble block cache in bytes")
+
+	pflag.Parse()
+
+	// Initialize the logger.
+	zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger().Level(zerolog.DebugLevel)
+	level, err := zerolog.ParseLevel(flagLevel)
+	if err != nil {
+		log.Error().Str("level", flagLevel).Err(err).Msg("could not parse log level")
+		return failure
+	}
+	log = log.Level(level)
+
+	log.Info().
+		Str("index", flagIndex).
+		Str("level", flagLevel).
+		Uint64("height", flagHeight).
+		Msgf("flags loaded")
+
+	if flagIndex == "" {
+		log.Error().Msg("missing index directory")
+		return failure
+	}
+
+	storagePath := storage2.StoragePath(flagIndex)
+	// Check if the path exists
+	if _, err := os.Stat(storagePath); os.IsNotExist(err) {
+		log.Error().Msgf("The storagePath '%s' does not exist.\n", storagePath)
+		return failure
+	}
+
+	lib2, err := storage2.NewLibrary2(flagIndex, flagBlockCacheSize)
+	if err != nil {
+		log.Error().Err(err).Msg("could not open index")
+		return failure
+	}
+	defer func() {
+		err := lib2.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("could not close index")
+		}
+	}()
+
+	height := flagHeight
+	if height == 0 {
+		height, err = lib2.GetLatestRegisterHeight()
+		if err != nil {
+			log.Error().Err(err).Msg("could not get latest register height")
+			return failure
+		}
+	}
+
+	commit, err := lib2.GetCommit(height)
+	if err != nil {
+		log.Error().Uint64("height", height).Err(err).Msg("could not get state commitment")
+		return failure
+	}
+
+	start := time.Now()
+	err = verifier.New(log, lib2).Verify(height, commit)
+	if err != nil {
+		log.Error().Uint64("height", height).Err(err).Msg("could not verify state commitment")
+		return failure
+	}
+
+	log.Info().
+		Uint64("height", height).
+		Hex("commit", commit[:]).
+		Str("duration", time.Since(start).Round(time.Second).String()).
+		Msg("registers match state commitment")
+
+	return success
+}
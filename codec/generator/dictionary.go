@@ -9,7 +9,6 @@ type DictionaryKind string
 
 // Supported dictionary kinds.
 const (
-	KindPayloads     DictionaryKind = "payloads"
 	KindEvents       DictionaryKind = "events"
 	KindTransactions DictionaryKind = "transactions"
 )
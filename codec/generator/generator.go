@@ -6,11 +6,11 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/dgraph-io/badger/v2"
+	"github.com/cockroachdb/pebble"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-archive/models/archive"
-	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/storage2/blocks"
 )
 
 // Generator generates optimized Zstandard dictionaries and turns them into Go files
@@ -18,12 +18,13 @@ import (
 type Generator struct {
 	cfg   Config
 	log   zerolog.Logger
-	db    *badger.DB
+	db    *pebble.DB
 	codec archive.Codec
 }
 
-// New returns a new dictionary generator.
-func New(log zerolog.Logger, db *badger.DB, codec archive.Codec, opts ...Option) *Generator {
+// New returns a new dictionary generator, which samples the records of the
+// given pebble block storage database.
+func New(log zerolog.Logger, db *pebble.DB, codec archive.Codec, opts ...Option) *Generator {
 
 	cfg := DefaultConfig
 	for _, opt := range opts {
@@ -159,74 +160,64 @@ func (g *Generator) getSamples(kind DictionaryKind, size int) ([][]byte, error)
 	// Create an iterator prefix based on the kind of sample we want.
 	var prefix []byte
 	switch kind {
-	case KindPayloads:
-		prefix = storage.EncodeKey(storage.PrefixPayload)
 	case KindTransactions:
-		prefix = storage.EncodeKey(storage.PrefixTransaction)
+		prefix = []byte{blocks.PrefixTransaction}
 	case KindEvents:
 		// TODO: Select an event type in the prefix. See https://github.com/optakt/flow-dps/issues/501
-		prefix = storage.EncodeKey(storage.PrefixEvents)
+		prefix = []byte{blocks.PrefixEvents}
+	default:
+		return nil, fmt.Errorf("unsupported dictionary kind (%s)", kind)
 	}
 
 	key := generateRandomKey(prefix)
 
 	// Go through the entries of the index database until enough samples have been collected.
-	samples := make([][]byte, 0, size)
-	err := g.db.View(func(tx *badger.Txn) error {
-		it := tx.NewIterator(badger.IteratorOptions{
-			Prefix: prefix,
-		})
-		defer it.Close()
-
-		it.Seek(key)
-
-		var totalBytes int
-		for totalBytes <= size {
-			sampleKey := it.Item().Key()
-
-			// If we're out of entries to read from, reset the iterator.
-			// This will result in duplicate entries in the samples, but should not be a big deal.
-			if !it.ValidForPrefix(prefix) {
-				g.log.Info().Msg("reached end of entries in index database, rewinding")
+	it := g.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: []byte{prefix[0] + 1},
+	})
+	defer it.Close()
 
-				it.Rewind()
-				it.Seek(key)
-			}
+	samples := make([][]byte, 0, size)
+	var totalBytes int
+	for valid := it.SeekGE(key); totalBytes <= size; valid = it.Next() {
 
-			// Retrieve the value of the sample.
-			val, err := tx.Get(sampleKey)
+		// If we're out of entries to read from, reset the iterator.
+		// This will result in duplicate entries in the samples, but should not be a big deal.
+		if !valid {
+			err := it.Error()
 			if err != nil {
-				return fmt.Errorf("could not get value from key %x: %w", sampleKey, err)
+				return nil, fmt.Errorf("could not read from index database: %w", err)
 			}
 
-			err = val.Value(func(val []byte) error {
-				value, err := g.codec.Decompress(val)
-				if err != nil {
-					return fmt.Errorf("could not decompress value from key %x: %w", sampleKey, err)
-				}
+			g.log.Info().Msg("reached end of entries in index database, rewinding")
 
-				// If for some reason, an empty value is stored at that key,
-				// no need to add it to the samples.
-				if len(value) == 0 {
-					return nil
-				}
+			valid = it.SeekGE(key)
+			if !valid {
+				return nil, fmt.Errorf("no entries in index database (kind: %s)", kind)
+			}
+		}
 
-				samples = append(samples, value)
-				totalBytes += len(value)
+		// Retrieve the value of the sample.
+		sampleKey := it.Key()
+		val, err := it.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("could not get value from key %x: %w", sampleKey, err)
+		}
 
-				return nil
-			})
-			if err != nil {
-				return fmt.Errorf("could not read value from key %x: %w", sampleKey, err)
-			}
+		value, err := g.codec.Decompress(val)
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress value from key %x: %w", sampleKey, err)
+		}
 
-			it.Next()
+		// If for some reason, an empty value is stored at that key,
+		// no need to add it to the samples.
+		if len(value) == 0 {
+			continue
 		}
 
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("could not read from index database: %w", err)
+		samples = append(samples, value)
+		totalBytes += len(value)
 	}
 
 	return samples, nil
@@ -2,12 +2,16 @@ package access
 
 import (
 	"context"
+
 	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
 )
 
 // Invoker represents something that can retrieve accounts at any given height, and execute scripts to retrieve values
 // from the Flow Virtual Machine.
 type Invoker interface {
 	Account(ctx context.Context, height uint64, address flow.Address) (*flow.Account, error)
+	AccountKeysAt(ctx context.Context, height uint64, address flow.Address) ([]archive.AccountKey, error)
 	Script(ctx context.Context, height uint64, script []byte, parameters [][]byte) ([]byte, error)
 }
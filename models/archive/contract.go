@@ -0,0 +1,47 @@
+package archive
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Types of the protocol events emitted when a contract is deployed on, updated
+// on or removed from an account.
+const (
+	EventAccountContractAdded   flow.EventType = "flow.AccountContractAdded"
+	EventAccountContractUpdated flow.EventType = "flow.AccountContractUpdated"
+	EventAccountContractRemoved flow.EventType = "flow.AccountContractRemoved"
+)
+
+// ContractAction is the kind of change made to a contract of an account.
+type ContractAction uint8
+
+// The kinds of changes made to contracts, one for each contract event type.
+const (
+	ContractAdded ContractAction = iota + 1
+	ContractUpdated
+	ContractRemoved
+)
+
+// ContractUpdate is a change made to a contract of an account, as recorded by
+// the protocol event emitted for it. The transaction and event indices locate
+// that event within its block. The code hash is the SHA3-256 hash of the
+// contract's Cadence source after the change.
+type ContractUpdate struct {
+	Height           uint64
+	TransactionID    flow.Identifier
+	TransactionIndex uint32
+	EventIndex       uint32
+	Address          flow.Address
+	Name             string
+	Action           ContractAction
+	CodeHash         []byte
+}
+
+// Contract is a contract deployed on an account at a given height. Its height
+// is the one of the latest change made to it at or below that height, or zero
+// if that change was made before the first indexed height.
+type Contract struct {
+	Address flow.Address
+	Name    string
+	Height  uint64
+}
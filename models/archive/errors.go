@@ -8,4 +8,6 @@ import (
 var (
 	ErrFinished    = errors.New("finished")
 	ErrUnavailable = errors.New("unavailable")
+	ErrNotFound    = errors.New("not found")
+	ErrNotIndexed  = errors.New("not indexed")
 )
@@ -0,0 +1,12 @@
+package archive
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BlockEvents are events that were emitted by the transactions of the block
+// at a given height.
+type BlockEvents struct {
+	Height uint64
+	Events []flow.Event
+}
@@ -0,0 +1,32 @@
+package archive
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Types of the protocol events emitted when a key is added to or removed from
+// an account.
+const (
+	EventAccountKeyAdded   flow.EventType = "flow.AccountKeyAdded"
+	EventAccountKeyRemoved flow.EventType = "flow.AccountKeyRemoved"
+)
+
+// AccountKey is a key of an account. Keys are never deleted from an account,
+// so a removed key is kept with its revoked flag set. The public key is encoded
+// the way the signature algorithm of the key encodes it.
+type AccountKey struct {
+	Index     uint32
+	PublicKey []byte
+	Weight    uint32
+	Revoked   bool
+	SeqNumber uint64
+}
+
+// AccountKeys are the keys of an account as they were after a finalized block
+// in which keys were added to or removed from it. The sequence numbers of the
+// keys are the ones they had at that height.
+type AccountKeys struct {
+	Height  uint64
+	Address flow.Address
+	Keys    []AccountKey
+}
@@ -1,6 +1,8 @@
 package archive
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -9,14 +11,22 @@ type Reader interface {
 	First() (uint64, error)
 	Last() (uint64, error)
 	LatestRegisterHeight() (uint64, error)
+	RegisterRetention() (RegisterRetention, error)
 
 	HeightForBlock(blockID flow.Identifier) (uint64, error)
 	HeightForTransaction(txID flow.Identifier) (uint64, error)
+	HeightForTimestamp(timestamp time.Time, mode TimestampMode) (uint64, error)
+	HeightForCollection(collID flow.Identifier) (uint64, error)
+	HeightForSeal(sealID flow.Identifier) (uint64, error)
+	SealedAtHeight(blockID flow.Identifier) (uint64, error)
 
 	Commit(height uint64) (flow.StateCommitment, error)
 	Header(height uint64) (*flow.Header, error)
 	Events(height uint64, types ...flow.EventType) ([]flow.Event, error)
+	EventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error)
+	EventsByContract(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]BlockEvents, error)
 	Values(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error)
+	RegisterHistory(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]RegisterVersion, error)
 
 	Collection(collID flow.Identifier) (*flow.LightCollection, error)
 	Guarantee(collID flow.Identifier) (*flow.CollectionGuarantee, error)
@@ -26,5 +36,15 @@ type Reader interface {
 
 	CollectionsByHeight(height uint64) ([]flow.Identifier, error)
 	TransactionsByHeight(height uint64) ([]flow.Identifier, error)
+	TransactionsByAddress(address flow.Address, startHeight uint64, endHeight uint64, roles TransactionRole, limit int) ([]AddressTransaction, error)
+	TransfersByAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]Transfer, error)
+	BalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]BalanceChange, error)
+	ContractsByAddress(address flow.Address, height uint64) ([]Contract, error)
+	ContractHistory(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]ContractUpdate, error)
+	ContractCode(address flow.Address, name string, height uint64) ([]byte, error)
+	AccountKeysAt(address flow.Address, height uint64) ([]AccountKey, error)
+	AccountKeyHistory(address flow.Address) ([]AccountKeys, error)
 	SealsByHeight(height uint64) ([]flow.Identifier, error)
+	RegistersByHeight(height uint64) (flow.RegisterIDs, error)
+	RegistersByOwner(height uint64, owner string, fn func(flow.RegisterEntry) error) error
 }
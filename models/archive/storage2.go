@@ -15,10 +15,129 @@ type Library2 interface {
 }
 
 type ReadLibrary2 interface {
+	GetFirst() (uint64, error)
+	GetLast() (uint64, error)
+	GetLatestRegisterHeight() (uint64, error)
+	GetBootstrapProgress(height uint64) (uint64, error)
+
+	GetHeightForBlock(blockID flow.Identifier) (uint64, error)
+	GetHeightForTransaction(txID flow.Identifier) (uint64, error)
+	GetHeightForCollection(collID flow.Identifier) (uint64, error)
+	GetHeightForSeal(sealID flow.Identifier) (uint64, error)
+	GetSealedHeightForBlock(blockID flow.Identifier) (uint64, error)
+
+	GetCommit(height uint64) (flow.StateCommitment, error)
+	GetHeader(height uint64) (*flow.Header, error)
+	GetEvents(height uint64, types []flow.EventType) ([]flow.Event, error)
+	GetEventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error)
+	GetContractEvents(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]BlockEvents, error)
+
+	GetTransactionsForHeight(height uint64) ([]flow.Identifier, error)
+	GetTransactionsForCollection(collID flow.Identifier) ([]flow.Identifier, error)
+	GetTransactionsForAddress(address flow.Address, startHeight uint64, endHeight uint64, roles TransactionRole, limit int) ([]AddressTransaction, error)
+	GetFirstTransferHeight() (uint64, error)
+	GetTransfersForAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]Transfer, error)
+	GetBalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]BalanceChange, error)
+	GetFirstContractUpdateHeight() (uint64, error)
+	GetContractUpdates(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]ContractUpdate, error)
+	GetLatestAccountKeysHeight() (uint64, error)
+	GetAccountKeys(address flow.Address, height uint64) (AccountKeys, error)
+	GetAccountKeyHistory(address flow.Address) ([]AccountKeys, error)
+	GetCollectionsForHeight(height uint64) ([]flow.Identifier, error)
+	GetSealsForHeight(height uint64) ([]flow.Identifier, error)
+
+	GetCollection(collID flow.Identifier) (*flow.LightCollection, error)
+	GetGuarantee(collID flow.Identifier) (*flow.CollectionGuarantee, error)
+	GetTransaction(txID flow.Identifier) (*flow.TransactionBody, error)
+	GetResult(txID flow.Identifier) (*flow.TransactionResult, error)
+	GetSeal(sealID flow.Identifier) (*flow.Seal, error)
+
 	GetPayload(height uint64, reg flow.RegisterID) ([]byte, error)
+	GetPayloads(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error)
+	IterateOwnerPayloads(height uint64, owner string, fn func(flow.RegisterEntry) error) error
+	IteratePayloads(height uint64, fn func(flow.RegisterEntry) error) error
+	GetPayloadHistory(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]RegisterVersion, error)
+	GetRegistersForHeight(height uint64) (flow.RegisterIDs, error)
+	GetRegisterRetention() (RegisterRetention, error)
 }
 
 type WriteLibrary2 interface {
+	SetFirst(height uint64) error
+	SetLast(height uint64) error
+	SetLatestRegisterHeight(height uint64) error
+	SetBootstrapProgress(height uint64, leaves uint64) error
+
+	SetHeightForBlock(blockID flow.Identifier, height uint64) error
+
+	SetCommit(height uint64, commit flow.StateCommitment) error
+	SetHeader(height uint64, header *flow.Header) error
+	BatchSetEvents(height uint64, events []flow.Event) error
+
+	BatchSetCollections(height uint64, collections []*flow.LightCollection) error
+	BatchSetGuarantees(guarantees []*flow.CollectionGuarantee) error
+	BatchSetTransactions(height uint64, transactions []*flow.TransactionBody) error
+	BatchSetResults(results []*flow.TransactionResult) error
+	BatchSetSeals(height uint64, seals []*flow.Seal) error
+	BatchSetTransfers(height uint64, transfers []Transfer) error
+	BatchSetContractUpdates(height uint64, updates []ContractUpdate) error
+	BatchSetAccountKeys(height uint64, changes []AccountKeys) error
+
 	BatchSetPayload(height uint64, entries flow.RegisterEntries) error
+	BatchSetRegistersForHeight(height uint64, regs flow.RegisterIDs) error
+	NewRegisterLoader(height uint64) (RegisterLoader, error)
+	PruneRegisters(retention RegisterRetention, progress func(PruneProgress)) error
 	Checkpoint(dir string) error
 }
+
+// RegisterLoader bulk loads the registers of a single height into the index.
+// Added registers only become readable once Finish returns, and Close removes
+// any temporary files of the loader.
+type RegisterLoader interface {
+	Add(entries flow.RegisterEntries) error
+	Finish() error
+
+	io.Closer
+}
+
+// RegisterVersion is the value a register was set to at a given height.
+type RegisterVersion struct {
+	Height uint64
+	Value  flow.RegisterValue
+}
+
+// RegisterRetention describes which heights register data is kept for after
+// pruning. Every height at or above Height is retained. Below Height, only
+// heights that are a multiple of a non-zero Interval are retained.
+//
+// The zero value retains every height.
+type RegisterRetention struct {
+	Height   uint64
+	Interval uint64
+}
+
+// Retains returns whether register values can still be read at the given height.
+func (r RegisterRetention) Retains(height uint64) bool {
+	if height >= r.Height {
+		return true
+	}
+	return r.Interval != 0 && height%r.Interval == 0
+}
+
+// Covers returns whether every height retained by the given retention is also
+// retained by this one. Pruning can only ever move towards a retention that
+// is covered by the current one, as pruned data can not be restored.
+func (r RegisterRetention) Covers(other RegisterRetention) bool {
+	if other.Height < r.Height {
+		return false
+	}
+	if r.Height == 0 || other.Interval == 0 {
+		return true
+	}
+	return r.Interval != 0 && other.Interval%r.Interval == 0
+}
+
+// PruneProgress reports how far a pruning run has gotten.
+type PruneProgress struct {
+	Scanned uint64
+	Deleted uint64
+}
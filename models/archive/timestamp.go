@@ -0,0 +1,15 @@
+package archive
+
+// TimestampMode determines which block is selected when looking up the height
+// for a timestamp that falls between the timestamps of two blocks.
+type TimestampMode uint8
+
+// Modes of looking up the height of the block at a given timestamp.
+const (
+	// AtOrBefore selects the last block with a timestamp at or before the given
+	// one, which is the state of the chain as of that time.
+	AtOrBefore TimestampMode = iota + 1
+	// AtOrAfter selects the first block with a timestamp at or after the given
+	// one.
+	AtOrAfter
+)
@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionRole is a set of roles that an account has in a transaction.
+type TransactionRole uint8
+
+// Roles that an account can have in a transaction. They can be combined to
+// look up transactions in which an account has any of several roles.
+const (
+	RolePayer TransactionRole = 1 << iota
+	RoleProposer
+	RoleAuthorizer
+
+	RoleAny = RolePayer | RoleProposer | RoleAuthorizer
+)
+
+// TransactionRoles returns the roles that the account with the given address
+// has in the given transaction. The result is zero if it has none.
+func TransactionRoles(tx *flow.TransactionBody, address flow.Address) TransactionRole {
+	var roles TransactionRole
+	if tx.Payer == address {
+		roles |= RolePayer
+	}
+	if tx.ProposalKey.Address == address {
+		roles |= RoleProposer
+	}
+	for _, authorizer := range tx.Authorizers {
+		if authorizer == address {
+			roles |= RoleAuthorizer
+			break
+		}
+	}
+	return roles
+}
+
+// AddressTransaction is a transaction that an account has a role in, along
+// with the height of the block that contains it.
+type AddressTransaction struct {
+	Height        uint64
+	TransactionID flow.Identifier
+	Roles         TransactionRole
+}
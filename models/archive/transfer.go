@@ -0,0 +1,31 @@
+package archive
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Transfer is a movement of fungible tokens between two accounts, made up of
+// the withdrawal and deposit events emitted by the token contract. Tokens that
+// were minted have no sender, and tokens that were burned have no recipient;
+// the missing address is then flow.EmptyAddress.
+//
+// The amount is given in the smallest unit of the token, which is 10^-8 for
+// tokens that use the UFix64 type.
+type Transfer struct {
+	Height        uint64
+	TransactionID flow.Identifier
+	EventIndex    uint32
+	Token         string
+	From          flow.Address
+	To            flow.Address
+	Amount        uint64
+}
+
+// BalanceChange is the change of the balance of an account in a token within
+// the block at a given height. Amounts are given in the smallest unit of the
+// token.
+type BalanceChange struct {
+	Height    uint64
+	Deposited uint64
+	Withdrawn uint64
+}
@@ -25,4 +25,6 @@ type Writer interface {
 	Transactions(height uint64, transactions []*flow.TransactionBody) error
 	Results(results []*flow.TransactionResult) error
 	Seals(height uint64, seals []*flow.Seal) error
+	Transfers(height uint64, transfers []Transfer) error
+	ContractUpdates(height uint64, updates []ContractUpdate) error
 }
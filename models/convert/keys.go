@@ -0,0 +1,26 @@
+package convert
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// AccountKeys converts the public keys of a Flow account into account keys.
+func AccountKeys(keys []flow.AccountPublicKey) []archive.AccountKey {
+	converted := make([]archive.AccountKey, 0, len(keys))
+	for _, key := range keys {
+		var publicKey []byte
+		if key.PublicKey != nil {
+			publicKey = key.PublicKey.Encode()
+		}
+		converted = append(converted, archive.AccountKey{
+			Index:     uint32(key.Index),
+			PublicKey: publicKey,
+			Weight:    uint32(key.Weight),
+			Revoked:   key.Revoked,
+			SeqNumber: key.SeqNumber,
+		})
+	}
+	return converted
+}
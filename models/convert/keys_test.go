@@ -0,0 +1,38 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/models/convert"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestAccountKeys(t *testing.T) {
+	key := mocks.GenericAccount.Keys[0]
+	revoked := key
+	revoked.Weight = 1000
+	revoked.Revoked = true
+
+	got := convert.AccountKeys([]flow.AccountPublicKey{key, revoked})
+
+	want := []archive.AccountKey{
+		{
+			Index:     0,
+			PublicKey: key.PublicKey.Encode(),
+			SeqNumber: 42,
+		},
+		{
+			Index:     0,
+			PublicKey: key.PublicKey.Encode(),
+			Weight:    1000,
+			Revoked:   true,
+			SeqNumber: 42,
+		},
+	}
+	assert.Equal(t, want, got)
+}
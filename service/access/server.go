@@ -7,12 +7,14 @@ import (
 
 	"github.com/onflow/flow-go/fvm/blueprints"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/onflow/flow-archive/models/archive"
 	conv "github.com/onflow/flow-archive/models/convert"
+	"github.com/onflow/flow-archive/service/filter"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow/protobuf/go/flow/access"
@@ -21,6 +23,20 @@ import (
 	accessModel "github.com/onflow/flow-archive/models/access"
 )
 
+// EventFilterKey is the key of the request metadata entries that hold the
+// predicates used to filter the events returned by the `GetEventsFor*`
+// endpoints, such as `to == 0x1234` or `amount > 100.0`. The request messages
+// of the Flow Access API are fixed, so predicates can not be part of them.
+// When several entries are given, events have to satisfy all of them.
+const EventFilterKey = "x-event-filter"
+
+// EventDecodeKey is the key of the request metadata entry that asks for the
+// decoded payloads of the returned events. The event messages of the Flow
+// Access API only hold the raw JSON-CDC payloads, so decoded payloads are only
+// available from the `GetEvents` method of the Archive API, and requests that
+// ask for them here are rejected instead of silently answered without them.
+const EventDecodeKey = "x-event-decode"
+
 // Server is a simple implementation of the generated AccessAPIServer interface.
 // It uses an index reader interface as the backend to retrieve the desired data.
 // This is generally an on-disk interface, but could be a GRPC-based index as
@@ -230,14 +246,14 @@ func (s *Server) GetTransactionResult(_ context.Context, in *access.GetTransacti
 		statusCode = 1
 	}
 
+	// The transaction is only sealed once a seal for its block has been
+	// indexed; until then, it has only been executed.
 	status := entities.TransactionStatus_SEALED
-	sealedHeight, err := s.index.Last()
-	if err != nil {
-		return nil, fmt.Errorf("could not retrieve last height: %w", err)
-	}
-
-	if height > sealedHeight {
+	_, err = s.index.SealedAtHeight(blockID)
+	if errors.Is(err, archive.ErrNotFound) {
 		status = entities.TransactionStatus_EXECUTED
+	} else if err != nil {
+		return nil, fmt.Errorf("could not retrieve sealed height: %w", err)
 	}
 
 	events, err := s.index.Events(height)
@@ -458,18 +474,42 @@ func (s *Server) ExecuteScriptAtBlockHeight(ctx context.Context, in *access.Exec
 
 // GetEventsForHeightRange implements the GetEventsForHeightRange endpoint from the Flow Access API.
 // See https://docs.onflow.org/access-api/#geteventsforheightrange
-func (s *Server) GetEventsForHeightRange(_ context.Context, in *access.GetEventsForHeightRangeRequest) (*access.EventsResponse, error) {
+func (s *Server) GetEventsForHeightRange(ctx context.Context, in *access.GetEventsForHeightRangeRequest) (*access.EventsResponse, error) {
+	eventFilter, err := eventFilterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var types []flow.EventType
 	if in.Type != "" {
 		types = append(types, flow.EventType(in.Type))
 	}
 
+	// When filtering by type, the event type index tells us at which heights
+	// there are events to retrieve, so that we can skip all other heights
+	// without looking up their events or headers.
+	var heights []uint64
+	if len(types) > 0 && in.StartHeight <= in.EndHeight {
+		heights, err = s.index.EventHeights(types, in.StartHeight, in.EndHeight, 0)
+		if err != nil {
+			return nil, fmt.Errorf("could not get heights with events of type %s: %w", in.Type, err)
+		}
+	} else {
+		for height := in.StartHeight; height <= in.EndHeight; height++ {
+			heights = append(heights, height)
+		}
+	}
+
 	var events []*access.EventsResponse_Result
-	for height := in.StartHeight; height <= in.EndHeight; height++ {
+	for _, height := range heights {
 		ee, err := s.index.Events(height, types...)
 		if err != nil {
 			return nil, fmt.Errorf("could not get events at height %d: %w", height, err)
 		}
+		ee, err = eventFilter.Apply(ee)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "could not filter events at height %d: %v", height, err)
+		}
 
 		header, err := s.index.Header(height)
 		if err != nil {
@@ -503,7 +543,12 @@ func (s *Server) GetEventsForHeightRange(_ context.Context, in *access.GetEvents
 
 // GetEventsForBlockIDs implements the GetEventsForBlockIDs endpoint from the Flow Access API.
 // See https://docs.onflow.org/access-api/#geteventsforblockids
-func (s *Server) GetEventsForBlockIDs(_ context.Context, in *access.GetEventsForBlockIDsRequest) (*access.EventsResponse, error) {
+func (s *Server) GetEventsForBlockIDs(ctx context.Context, in *access.GetEventsForBlockIDsRequest) (*access.EventsResponse, error) {
+	eventFilter, err := eventFilterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var types []flow.EventType
 	if in.Type != "" {
 		types = append(types, flow.EventType(in.Type))
@@ -521,6 +566,10 @@ func (s *Server) GetEventsForBlockIDs(_ context.Context, in *access.GetEventsFor
 		if err != nil {
 			return nil, fmt.Errorf("could not get events at height %d: %w", height, err)
 		}
+		ee, err = eventFilter.Apply(ee)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "could not filter events at height %d: %v", height, err)
+		}
 
 		header, err := s.index.Header(height)
 		if err != nil {
@@ -588,3 +637,19 @@ func (s *Server) SendTransaction(ctx context.Context, in *access.SendTransaction
 func (s *Server) GetLatestProtocolStateSnapshot(ctx context.Context, in *access.GetLatestProtocolStateSnapshotRequest) (*access.ProtocolStateSnapshotResponse, error) {
 	return nil, errors.New("GetLatestProtocolSnapshot is not implemented by the Flow DPS API; please use the Flow Access API on a Flow access node directly")
 }
+
+// eventFilterFromContext creates the event filter from the predicates in the
+// metadata of the given request context. It fails if the metadata asks for
+// decoded payloads, which the Flow Access API can not return.
+func eventFilterFromContext(ctx context.Context) (*filter.Filter, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(EventDecodeKey)) != 0 {
+		return nil, status.Errorf(codes.Unimplemented, "decoded payloads are only available from the GetEvents method of the Archive API")
+	}
+	eventFilter, err := filter.New(md.Get(EventFilterKey)...)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid event filter: %v", err)
+	}
+
+	return eventFilter, nil
+}
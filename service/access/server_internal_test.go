@@ -2,11 +2,13 @@ package access
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/cadence"
@@ -109,6 +111,11 @@ func TestServer_GetTransactionResult(t *testing.T) {
 
 			return header, nil
 		}
+		index.SealedAtHeightFunc = func(gotBlockID flow.Identifier) (uint64, error) {
+			assert.Equal(t, blockID, gotBlockID)
+
+			return header.Height + 1, nil
+		}
 		index.EventsFunc = func(height uint64, types ...flow.EventType) ([]flow.Event, error) {
 			assert.Equal(t, header.Height, height)
 			assert.Empty(t, types)
@@ -134,7 +141,7 @@ func TestServer_GetTransactionResult(t *testing.T) {
 	t.Run("nominal case with status executed and an error message", func(t *testing.T) {
 		t.Parallel()
 
-		height := header.Height + 999
+		height := header.Height
 		failedResult := mocks.GenericResult(0)
 		failedResult.ErrorMessage = "dummy error"
 
@@ -154,6 +161,11 @@ func TestServer_GetTransactionResult(t *testing.T) {
 
 			return header, nil
 		}
+		index.SealedAtHeightFunc = func(gotBlockID flow.Identifier) (uint64, error) {
+			assert.Equal(t, blockID, gotBlockID)
+
+			return 0, archive.ErrNotFound
+		}
 		index.EventsFunc = func(gotHeight uint64, types ...flow.EventType) ([]flow.Event, error) {
 			assert.Equal(t, height, gotHeight)
 			assert.Empty(t, types)
@@ -225,11 +237,11 @@ func TestServer_GetTransactionResult(t *testing.T) {
 		assert.Error(t, err)
 	})
 
-	t.Run("handles indexer error on last", func(t *testing.T) {
+	t.Run("handles indexer error on SealedAtHeight", func(t *testing.T) {
 		t.Parallel()
 
 		index := mocks.BaselineReader(t)
-		index.LastFunc = func() (uint64, error) {
+		index.SealedAtHeightFunc = func(flow.Identifier) (uint64, error) {
 			return 0, mocks.GenericError
 		}
 
@@ -468,10 +480,52 @@ func TestServer_GetEventsForBlockIDs(t *testing.T) {
 		}
 	})
 
+	t.Run("only retrieves events at heights with events of the type", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventHeightsFunc = func([]flow.EventType, uint64, uint64, int) ([]uint64, error) {
+			return []uint64{header.Height + 2}, nil
+		}
+		index.EventsFunc = func(h uint64, gotTypes ...flow.EventType) ([]flow.Event, error) {
+			assert.Equal(t, header.Height+2, h)
+
+			return events, nil
+		}
+		index.HeaderFunc = func(h uint64) (*flow.Header, error) {
+			return header, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height + 3,
+			Type:        string(types[0]),
+		}
+		resp, err := s.GetEventsForHeightRange(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 4)
+		for i, block := range resp.Results {
+			assert.Equal(t, header.Height+uint64(i), block.BlockHeight)
+			if i == 2 {
+				assert.Len(t, block.Events, len(events))
+				continue
+			}
+			assert.Empty(t, block.Events)
+		}
+	})
+
 	t.Run("nominal case without event type", func(t *testing.T) {
 		t.Parallel()
 
 		index := mocks.BaselineReader(t)
+		index.EventHeightsFunc = func([]flow.EventType, uint64, uint64, int) ([]uint64, error) {
+			t.Fatal("event type index should not be used without event type")
+			return nil, nil
+		}
 		index.HeightForBlockFunc = func(blockID flow.Identifier) (uint64, error) {
 			assert.Contains(t, blocks, blockID)
 
@@ -533,6 +587,27 @@ func TestServer_GetEventsForBlockIDs(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("handles indexer error on EventHeights", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventHeightsFunc = func([]flow.EventType, uint64, uint64, int) ([]uint64, error) {
+			return nil, mocks.GenericError
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height + 3,
+			Type:        string(types[0]),
+		}
+		_, err := s.GetEventsForHeightRange(context.Background(), req)
+
+		assert.Error(t, err)
+	})
+
 	t.Run("handles indexer error on Events", func(t *testing.T) {
 		t.Parallel()
 
@@ -580,6 +655,80 @@ func TestServer_GetEventsForBlockIDs(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("filters events with predicates from metadata", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.HeightForBlockFunc = func(blockID flow.Identifier) (uint64, error) {
+			return blocks[blockID], nil
+		}
+		index.EventsFunc = func(uint64, ...flow.EventType) ([]flow.Event, error) {
+			return events, nil
+		}
+		index.HeaderFunc = func(uint64) (*flow.Header, error) {
+			return header, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(EventFilterKey, fmt.Sprintf("address == %s", mocks.GenericAddress(1).HexWithPrefix()))
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForBlockIDsRequest{
+			BlockIds: [][]byte{blockIDs[0][:]},
+		}
+		resp, err := s.GetEventsForBlockIDs(ctx, req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		require.Len(t, resp.Results[0].Events, 1)
+		assert.Equal(t, events[1].Payload, resp.Results[0].Events[0].Payload)
+	})
+
+	t.Run("handles invalid event filter", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.HeightForBlockFunc = func(flow.Identifier) (uint64, error) {
+			t.Fatal("index should not be called for invalid event filters")
+			return 0, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(EventFilterKey, "amount = 1")
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForBlockIDsRequest{
+			BlockIds: [][]byte{blockIDs[0][:]},
+		}
+		_, err := s.GetEventsForBlockIDs(ctx, req)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("rejects requests for decoded payloads", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.HeightForBlockFunc = func(flow.Identifier) (uint64, error) {
+			t.Fatal("index should not be called for decoded payloads")
+			return 0, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(EventDecodeKey, "true")
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForBlockIDsRequest{
+			BlockIds: [][]byte{blockIDs[0][:]},
+		}
+		_, err := s.GetEventsForBlockIDs(ctx, req)
+
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
 }
 
 func TestServer_GetEventsForHeightRange(t *testing.T) {
@@ -591,6 +740,14 @@ func TestServer_GetEventsForHeightRange(t *testing.T) {
 		t.Parallel()
 
 		index := mocks.BaselineReader(t)
+		index.EventHeightsFunc = func(gotTypes []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+			assert.Equal(t, types, gotTypes)
+			assert.Equal(t, header.Height, startHeight)
+			assert.Equal(t, header.Height+3, endHeight)
+			assert.Zero(t, limit)
+
+			return []uint64{header.Height, header.Height + 1, header.Height + 2, header.Height + 3}, nil
+		}
 		index.EventsFunc = func(h uint64, gotTypes ...flow.EventType) ([]flow.Event, error) {
 			// Expect height to be between GenericHeight and GenericHeight + 3 since there are four
 			// given blockIDs.
@@ -662,6 +819,40 @@ func TestServer_GetEventsForHeightRange(t *testing.T) {
 		}
 	})
 
+	t.Run("skips heights without events of the type", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventHeightsFunc = func([]flow.EventType, uint64, uint64, int) ([]uint64, error) {
+			return []uint64{header.Height, header.Height + 2}, nil
+		}
+		index.EventsFunc = func(h uint64, _ ...flow.EventType) ([]flow.Event, error) {
+			assert.Contains(t, []uint64{header.Height, header.Height + 2}, h)
+
+			return events, nil
+		}
+		index.HeaderFunc = func(h uint64) (*flow.Header, error) {
+			assert.Contains(t, []uint64{header.Height, header.Height + 2}, h)
+
+			return header, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height + 3,
+			Type:        string(types[0]),
+		}
+		resp, err := s.GetEventsForHeightRange(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, header.Height, resp.Results[0].BlockHeight)
+		assert.Equal(t, header.Height+2, resp.Results[1].BlockHeight)
+	})
+
 	t.Run("handles indexer error on Header", func(t *testing.T) {
 		t.Parallel()
 
@@ -703,6 +894,114 @@ func TestServer_GetEventsForHeightRange(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("filters events with predicates from metadata", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventsFunc = func(uint64, ...flow.EventType) ([]flow.Event, error) {
+			return events, nil
+		}
+		index.HeaderFunc = func(uint64) (*flow.Header, error) {
+			return header, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(
+			EventFilterKey, fmt.Sprintf("address != %s", mocks.GenericAddress(1).HexWithPrefix()),
+			EventFilterKey, fmt.Sprintf("address != %s", mocks.GenericAddress(2).HexWithPrefix()),
+		)
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height + 1,
+		}
+		resp, err := s.GetEventsForHeightRange(ctx, req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		for _, block := range resp.Results {
+			assert.Len(t, block.Events, len(events)-2)
+		}
+	})
+
+	t.Run("skips events with undecodable payloads", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := make([]flow.Event, len(events))
+		copy(invalid, events)
+		invalid[0].Payload = []byte("invalid")
+
+		index := mocks.BaselineReader(t)
+		index.EventsFunc = func(uint64, ...flow.EventType) ([]flow.Event, error) {
+			return invalid, nil
+		}
+		index.HeaderFunc = func(uint64) (*flow.Header, error) {
+			return header, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(EventFilterKey, fmt.Sprintf("address != %s", mocks.GenericAddress(1).HexWithPrefix()))
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height,
+		}
+		resp, err := s.GetEventsForHeightRange(ctx, req)
+
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		assert.Len(t, resp.Results[0].Events, len(events)-2)
+	})
+
+	t.Run("handles invalid event filter", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventsFunc = func(uint64, ...flow.EventType) ([]flow.Event, error) {
+			return events, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(EventFilterKey, "address > 0x1")
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height,
+		}
+		_, err := s.GetEventsForHeightRange(ctx, req)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("rejects requests for decoded payloads", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.EventsFunc = func(uint64, ...flow.EventType) ([]flow.Event, error) {
+			t.Fatal("index should not be called for decoded payloads")
+			return nil, nil
+		}
+
+		s := baselineServer(t)
+		s.index = index
+
+		md := metadata.Pairs(EventDecodeKey, "true")
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		req := &access.GetEventsForHeightRangeRequest{
+			StartHeight: header.Height,
+			EndHeight:   header.Height,
+		}
+		_, err := s.GetEventsForHeightRange(ctx, req)
+
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
 }
 
 func TestServer_GetNetworkParameters(t *testing.T) {
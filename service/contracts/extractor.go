@@ -0,0 +1,141 @@
+package contracts
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// actions maps the types of the protocol events emitted for changes to the
+// contracts of an account to the kind of change they record.
+var actions = map[flow.EventType]archive.ContractAction{
+	archive.EventAccountContractAdded:   archive.ContractAdded,
+	archive.EventAccountContractUpdated: archive.ContractUpdated,
+	archive.EventAccountContractRemoved: archive.ContractRemoved,
+}
+
+// EventTypes returns the types of the protocol events emitted for changes to
+// the contracts of an account.
+func EventTypes() []flow.EventType {
+	return []flow.EventType{
+		archive.EventAccountContractAdded,
+		archive.EventAccountContractUpdated,
+		archive.EventAccountContractRemoved,
+	}
+}
+
+// Extractor extracts the changes made to the contracts of accounts in a block
+// from the protocol events emitted for them.
+type Extractor struct {
+	log zerolog.Logger
+}
+
+// NewExtractor creates a new extractor of contract updates, which logs the
+// contract events it can not decode with the given logger.
+func NewExtractor(log zerolog.Logger) *Extractor {
+
+	e := Extractor{
+		log: log.With().Str("component", "contract_extractor").Logger(),
+	}
+
+	return &e
+}
+
+// Updates returns the changes to contracts recorded by the given events, which
+// were emitted by the transactions of the block at the given height, in the
+// order of the events.
+//
+// A contract event that can not be decoded is logged and skipped, so that a
+// single malformed event does not prevent the block from being indexed.
+func (e *Extractor) Updates(height uint64, events []flow.Event) ([]archive.ContractUpdate, error) {
+
+	var updates []archive.ContractUpdate
+	for _, event := range events {
+		_, ok := actions[event.Type]
+		if !ok {
+			continue
+		}
+
+		update, err := Decode(height, event)
+		if err != nil {
+			e.log.Warn().
+				Err(err).
+				Uint64("height", height).
+				Hex("transaction", event.TransactionID[:]).
+				Uint32("index", event.EventIndex).
+				Msg("skipping contract event that could not be decoded")
+			continue
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+// Decode decodes the contract update recorded by the given contract event,
+// which was emitted at the given height.
+func Decode(height uint64, event flow.Event) (archive.ContractUpdate, error) {
+	action, ok := actions[event.Type]
+	if !ok {
+		return archive.ContractUpdate{}, fmt.Errorf("invalid contract event type (%s)", event.Type)
+	}
+
+	value, err := json.Decode(nil, event.Payload)
+	if err != nil {
+		return archive.ContractUpdate{}, fmt.Errorf("could not decode payload: %w", err)
+	}
+	ev, ok := value.(cadence.Event)
+	if !ok || ev.EventType == nil || len(ev.Fields) != len(ev.EventType.Fields) {
+		return archive.ContractUpdate{}, fmt.Errorf("invalid payload (%T)", value)
+	}
+
+	update := archive.ContractUpdate{
+		Height:           height,
+		TransactionID:    event.TransactionID,
+		TransactionIndex: event.TransactionIndex,
+		EventIndex:       event.EventIndex,
+		Action:           action,
+	}
+	for i, field := range ev.EventType.Fields {
+		switch field.Identifier {
+		case "address":
+			address, ok := ev.Fields[i].(cadence.Address)
+			if !ok {
+				return archive.ContractUpdate{}, fmt.Errorf("invalid address type (%T)", ev.Fields[i])
+			}
+			update.Address = flow.Address(address)
+		case "codeHash":
+			array, ok := ev.Fields[i].(cadence.Array)
+			if !ok {
+				return archive.ContractUpdate{}, fmt.Errorf("invalid code hash type (%T)", ev.Fields[i])
+			}
+			hash := make([]byte, 0, len(array.Values))
+			for _, v := range array.Values {
+				b, ok := v.(cadence.UInt8)
+				if !ok {
+					return archive.ContractUpdate{}, fmt.Errorf("invalid code hash element type (%T)", v)
+				}
+				hash = append(hash, byte(b))
+			}
+			update.CodeHash = hash
+		case "contract":
+			name, ok := ev.Fields[i].(cadence.String)
+			if !ok {
+				return archive.ContractUpdate{}, fmt.Errorf("invalid contract name type (%T)", ev.Fields[i])
+			}
+			update.Name = string(name)
+		}
+	}
+	if update.Address == flow.EmptyAddress || update.Name == "" {
+		return archive.ContractUpdate{}, fmt.Errorf("missing contract address or name")
+	}
+
+	return update, nil
+}
@@ -0,0 +1,150 @@
+package contracts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+func TestExtractor_Updates(t *testing.T) {
+	extract := NewExtractor(zerolog.Nop())
+
+	alice := flow.HexToAddress("01cf0e2f2f715450")
+	bob := flow.HexToAddress("179b6b1cb6755e31")
+	tx1 := flow.Identifier{1}
+	tx2 := flow.Identifier{2}
+	hash := []byte{1, 2, 3}
+
+	const height = 42
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		events := []flow.Event{
+			genericContractEvent(t, archive.EventAccountContractAdded, tx1, 0, 0, alice, "Token", hash),
+			{Type: "flow.AccountCreated", TransactionID: tx1, EventIndex: 1},
+			genericContractEvent(t, archive.EventAccountContractUpdated, tx2, 1, 0, alice, "Token", hash),
+			genericContractEvent(t, archive.EventAccountContractRemoved, tx2, 1, 1, bob, "Market", hash),
+		}
+
+		got, err := extract.Updates(height, events)
+
+		require.NoError(t, err)
+		want := []archive.ContractUpdate{
+			{Height: height, TransactionID: tx1, TransactionIndex: 0, EventIndex: 0, Address: alice, Name: "Token", Action: archive.ContractAdded, CodeHash: hash},
+			{Height: height, TransactionID: tx2, TransactionIndex: 1, EventIndex: 0, Address: alice, Name: "Token", Action: archive.ContractUpdated, CodeHash: hash},
+			{Height: height, TransactionID: tx2, TransactionIndex: 1, EventIndex: 1, Address: bob, Name: "Market", Action: archive.ContractRemoved, CodeHash: hash},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("skips undecodable events", func(t *testing.T) {
+		t.Parallel()
+
+		events := []flow.Event{
+			{Type: archive.EventAccountContractUpdated, TransactionID: tx1, EventIndex: 0, Payload: []byte("invalid")},
+			genericContractEvent(t, archive.EventAccountContractAdded, tx1, 0, 1, alice, "", hash),
+			genericContractEvent(t, archive.EventAccountContractAdded, tx2, 1, 0, bob, "Market", hash),
+		}
+
+		got, err := extract.Updates(height, events)
+
+		require.NoError(t, err)
+		want := []archive.ContractUpdate{
+			{Height: height, TransactionID: tx2, TransactionIndex: 1, EventIndex: 0, Address: bob, Name: "Market", Action: archive.ContractAdded, CodeHash: hash},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("no contract events", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := extract.Updates(height, []flow.Event{{Type: "flow.AccountCreated"}})
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	alice := flow.HexToAddress("01cf0e2f2f715450")
+	tx := flow.Identifier{1}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		event := genericContractEvent(t, archive.EventAccountContractUpdated, tx, 2, 3, alice, "Token", []byte{4})
+
+		got, err := Decode(42, event)
+
+		require.NoError(t, err)
+		want := archive.ContractUpdate{Height: 42, TransactionID: tx, TransactionIndex: 2, EventIndex: 3, Address: alice, Name: "Token", Action: archive.ContractUpdated, CodeHash: []byte{4}}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("handles invalid event type", func(t *testing.T) {
+		t.Parallel()
+
+		event := genericContractEvent(t, archive.EventAccountContractUpdated, tx, 0, 0, alice, "Token", nil)
+		event.Type = "flow.AccountCreated"
+
+		_, err := Decode(42, event)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles invalid payload", func(t *testing.T) {
+		t.Parallel()
+
+		event := flow.Event{Type: archive.EventAccountContractAdded, TransactionID: tx, Payload: []byte("invalid")}
+
+		_, err := Decode(42, event)
+
+		assert.Error(t, err)
+	})
+}
+
+func genericContractEvent(t *testing.T, typ flow.EventType, txID flow.Identifier, txIndex uint32, eventIndex uint32, address flow.Address, name string, hash []byte) flow.Event {
+	t.Helper()
+
+	hashType := cadence.NewVariableSizedArrayType(cadence.UInt8Type{})
+	values := make([]cadence.Value, 0, len(hash))
+	for _, b := range hash {
+		values = append(values, cadence.UInt8(b))
+	}
+
+	event := cadence.NewEvent([]cadence.Value{
+		cadence.NewAddress(address),
+		cadence.NewArray(values).WithType(hashType),
+		cadence.String(name),
+	}).WithType(&cadence.EventType{
+		Location:            stdlib.FlowLocation{},
+		QualifiedIdentifier: strings.TrimPrefix(string(typ), "flow."),
+		Fields: []cadence.Field{
+			{Identifier: "address", Type: cadence.AddressType{}},
+			{Identifier: "codeHash", Type: hashType},
+			{Identifier: "contract", Type: cadence.StringType{}},
+		},
+	})
+
+	payload, err := json.Encode(event)
+	require.NoError(t, err)
+
+	return flow.Event{
+		Type:             typ,
+		TransactionID:    txID,
+		TransactionIndex: txIndex,
+		EventIndex:       eventIndex,
+		Payload:          payload,
+	}
+}
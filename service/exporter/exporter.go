@@ -0,0 +1,97 @@
+package exporter
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/verifier"
+)
+
+// RootHashSuffix is appended to the name of an exported checkpoint file to get
+// the name of the file holding its hex-encoded root hash.
+const RootHashSuffix = ".root_hash"
+
+// Exporter exports the registers of the index at a height as a checkpoint in
+// the V6 format of flow-go, which can be used as root checkpoint to bootstrap
+// another index or an execution node.
+type Exporter struct {
+	log   zerolog.Logger
+	lib2  archive.ReadLibrary2
+	build *verifier.Verifier
+}
+
+// New creates a new exporter, which reads the registers from the given library.
+func New(log zerolog.Logger, lib2 archive.ReadLibrary2) *Exporter {
+
+	e := Exporter{
+		log:   log.With().Str("component", "checkpoint_exporter").Logger(),
+		lib2:  lib2,
+		build: verifier.New(log, lib2),
+	}
+
+	return &e
+}
+
+// Export rebuilds the execution state trie from the values of all registers at
+// the given height, and writes it as a checkpoint file with the given name to
+// the given directory, along with its part files. The root hash of the trie is
+// written next to it, and returned.
+//
+// The root hash must match the state commitment indexed for the height, so that
+// the exported checkpoint is known to be correct.
+func (e *Exporter) Export(height uint64, dir string, fileName string) (ledger.RootHash, error) {
+
+	commit, err := e.lib2.GetCommit(height)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not get commit: %w", err)
+	}
+
+	tree, err := e.build.Trie(height)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not build trie: %w", err)
+	}
+	root := tree.RootHash()
+	if flow.StateCommitment(root) != commit {
+		return ledger.RootHash{}, fmt.Errorf("state commitment mismatch (height: %d, commit: %x, registers: %x)", height, commit[:], root[:])
+	}
+
+	e.log.Info().
+		Uint64("height", height).
+		Hex("root_hash", root[:]).
+		Uint64("registers", tree.AllocatedRegCount()).
+		Msg("trie rebuilt from registers")
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not create checkpoint directory: %w", err)
+	}
+
+	err = wal.StoreCheckpointV6Concurrently([]*trie.MTrie{tree}, dir, fileName, &e.log)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not store checkpoint: %w", err)
+	}
+
+	path := filepath.Join(dir, fileName+RootHashSuffix)
+	err = os.WriteFile(path, []byte(hex.EncodeToString(root[:])+"\n"), 0644)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not write root hash (path: %s): %w", path, err)
+	}
+
+	e.log.Info().
+		Uint64("height", height).
+		Str("dir", dir).
+		Str("file", fileName).
+		Msg("checkpoint exported")
+
+	return root, nil
+}
@@ -0,0 +1,82 @@
+package exporter
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/verifier"
+	"github.com/onflow/flow-archive/testing/helpers"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestExporter_Export(t *testing.T) {
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+		entries := helpers.RegisterEntries(100)
+		require.NoError(t, lib2.BatchSetPayload(1, entries))
+		require.NoError(t, lib2.BatchSetPayload(2, flow.RegisterEntries{{Key: entries[0].Key, Value: []byte{}}}))
+
+		root, err := verifier.New(mocks.NoopLogger, lib2).RootHash(2)
+		require.NoError(t, err)
+		require.NoError(t, lib2.SetCommit(2, flow.StateCommitment(root)))
+
+		dir := t.TempDir()
+		e := New(mocks.NoopLogger, lib2)
+
+		got, err := e.Export(2, dir, "root.checkpoint")
+		require.NoError(t, err)
+		assert.Equal(t, root, got)
+
+		// The checkpoint must be readable by flow-go, and hold the same trie.
+		tries, err := wal.OpenAndReadCheckpointV6(dir, "root.checkpoint", &mocks.NoopLogger)
+		require.NoError(t, err)
+		require.Len(t, tries, 1)
+		assert.Equal(t, root, tries[0].RootHash())
+		assert.Equal(t, uint64(len(entries)-1), tries[0].AllocatedRegCount())
+
+		data, err := os.ReadFile(filepath.Join(dir, "root.checkpoint"+RootHashSuffix))
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(root[:])+"\n", string(data))
+	})
+
+	t.Run("handles commit mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+		require.NoError(t, lib2.BatchSetPayload(1, helpers.RegisterEntries(10)))
+		require.NoError(t, lib2.SetCommit(1, mocks.GenericCommit(0)))
+
+		dir := t.TempDir()
+		e := New(mocks.NoopLogger, lib2)
+
+		_, err := e.Export(1, dir, "root.checkpoint")
+		assert.Error(t, err)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("handles missing commit", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+		require.NoError(t, lib2.BatchSetPayload(1, helpers.RegisterEntries(10)))
+
+		e := New(mocks.NoopLogger, lib2)
+
+		_, err := e.Export(1, t.TempDir(), "root.checkpoint")
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+}
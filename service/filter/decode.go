@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// composite is implemented by the Cadence values that have named fields, such
+// as structs, resources and events.
+type composite interface {
+	GetFields() []cadence.Field
+	GetFieldValues() []cadence.Value
+}
+
+// Decode returns a plain JSON representation of the payload of the given
+// event, which is an object that maps the name of each field to its value.
+// Unlike JSON-CDC, values are not wrapped in objects that describe their
+// type. Numbers are kept as JSON numbers with their full precision, and
+// values without a JSON equivalent, such as paths, are given as strings.
+func Decode(event flow.Event) ([]byte, error) {
+
+	payload, err := decodePayload(event)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode event (type: %s): %w", event.Type, err)
+	}
+
+	data, err := json.Marshal(plain(payload))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode event (type: %s): %w", event.Type, err)
+	}
+
+	return data, nil
+}
+
+// plain converts the given Cadence value to a value that encodes to its plain
+// JSON representation.
+func plain(value cadence.Value) interface{} {
+	switch v := value.(type) {
+
+	case nil:
+		return nil
+
+	case cadence.Optional:
+		return plain(v.Value)
+
+	case cadence.Void:
+		return nil
+
+	case cadence.Bool:
+		return bool(v)
+
+	case cadence.String:
+		return string(v)
+
+	case cadence.Character:
+		return string(v)
+
+	case cadence.Address:
+		return v.String()
+
+	case cadence.NumberValue:
+		return json.Number(v.String())
+
+	case cadence.Array:
+		values := make([]interface{}, 0, len(v.Values))
+		for _, element := range v.Values {
+			values = append(values, plain(element))
+		}
+		return values
+
+	case cadence.Dictionary:
+		// JSON objects can only have string keys, so keys are given by their
+		// plain representation if they are strings, and by their Cadence
+		// representation otherwise.
+		pairs := make(map[string]interface{}, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			key, ok := pair.Key.(cadence.String)
+			if ok {
+				pairs[string(key)] = plain(pair.Value)
+				continue
+			}
+			pairs[pair.Key.String()] = plain(pair.Value)
+		}
+		return pairs
+
+	case composite:
+		values := v.GetFieldValues()
+		fields := make(map[string]interface{}, len(values))
+		for i, field := range v.GetFields() {
+			if i >= len(values) {
+				break
+			}
+			fields[field.Identifier] = plain(values[i])
+		}
+		return fields
+
+	case cadence.TypeValue:
+		if v.StaticType == nil {
+			return nil
+		}
+		return v.StaticType.ID()
+
+	default:
+		return v.String()
+	}
+}
@@ -0,0 +1,119 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrInvalidPayload is returned when the payload of an event can not be
+// decoded for matching.
+var ErrInvalidPayload = errors.New("invalid event payload")
+
+// Filter selects events by evaluating predicates on the fields of their
+// decoded payloads. An event matches when it satisfies all of the predicates.
+type Filter struct {
+	predicates []Predicate
+}
+
+// New creates a new filter from the given predicate expressions. A filter
+// without predicates matches every event without decoding it.
+func New(expressions ...string) (*Filter, error) {
+
+	predicates := make([]Predicate, 0, len(expressions))
+	for _, expression := range expressions {
+		predicate, err := ParsePredicate(expression)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse predicate: %w", err)
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	f := Filter{
+		predicates: predicates,
+	}
+
+	return &f, nil
+}
+
+// Empty returns whether the filter has no predicates.
+func (f *Filter) Empty() bool {
+	return len(f.predicates) == 0
+}
+
+// Match returns whether the given event satisfies all predicates of the
+// filter. Events without one of the fields used by the predicates never
+// match, so that events of several types can be filtered together.
+func (f *Filter) Match(event flow.Event) (bool, error) {
+	if f.Empty() {
+		return true, nil
+	}
+
+	payload, err := decodePayload(event)
+	if err != nil {
+		return false, fmt.Errorf("could not decode event (type: %s): %w: %s", event.Type, ErrInvalidPayload, err)
+	}
+
+	fields := make(map[string]cadence.Value, len(payload.Fields))
+	for i, field := range payload.EventType.Fields {
+		fields[field.Identifier] = payload.Fields[i]
+	}
+
+	for _, predicate := range f.predicates {
+		value, ok := fields[predicate.Field]
+		if !ok {
+			return false, nil
+		}
+		match, err := predicate.Evaluate(value)
+		if err != nil {
+			return false, fmt.Errorf("could not evaluate predicate (%s): %w", predicate, err)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Apply returns the given events that match the filter, in their original
+// order. Events with payloads that can not be decoded never match.
+func (f *Filter) Apply(events []flow.Event) ([]flow.Event, error) {
+	if f.Empty() {
+		return events, nil
+	}
+
+	matches := make([]flow.Event, 0, len(events))
+	for _, event := range events {
+		match, err := f.Match(event)
+		if errors.Is(err, ErrInvalidPayload) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			matches = append(matches, event)
+		}
+	}
+
+	return matches, nil
+}
+
+// decodePayload decodes the JSON-CDC payload of the given event.
+func decodePayload(event flow.Event) (cadence.Event, error) {
+	value, err := json.Decode(nil, event.Payload)
+	if err != nil {
+		return cadence.Event{}, fmt.Errorf("could not decode payload: %w", err)
+	}
+	payload, ok := value.(cadence.Event)
+	if !ok || payload.EventType == nil || len(payload.Fields) != len(payload.EventType.Fields) {
+		return cadence.Event{}, fmt.Errorf("invalid payload (%T)", value)
+	}
+
+	return payload, nil
+}
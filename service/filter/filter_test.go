@@ -0,0 +1,140 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/service/filter"
+)
+
+func TestFilter_Apply(t *testing.T) {
+	small := depositEvent(t, 0, "10.0", []byte{0x12, 0x34})
+	large := depositEvent(t, 1, "150.5", []byte{0x12, 0x34})
+	other := depositEvent(t, 2, "150.5", []byte{0x56, 0x78})
+	events := []flow.Event{small, large, other}
+
+	tests := []struct {
+		name        string
+		expressions []string
+		want        []flow.Event
+		checkErr    require.ErrorAssertionFunc
+	}{
+		{name: "no predicates", want: events, checkErr: require.NoError},
+		{name: "single predicate", expressions: []string{"amount > 100.0"}, want: []flow.Event{large, other}, checkErr: require.NoError},
+		{name: "all predicates", expressions: []string{"amount > 100.0", "to == 0x1234"}, want: []flow.Event{large}, checkErr: require.NoError},
+		{name: "unknown field", expressions: []string{"from == 0x1234"}, want: []flow.Event{}, checkErr: require.NoError},
+		{name: "invalid literal", expressions: []string{"amount > lots"}, checkErr: require.Error},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := filter.New(test.expressions...)
+			require.NoError(t, err)
+
+			got, err := f.Apply(events)
+
+			test.checkErr(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("handles invalid predicates", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := filter.New("amount = 1")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles invalid payloads", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := filter.New("amount > 1")
+		require.NoError(t, err)
+
+		invalid := small
+		invalid.Payload = []byte("invalid")
+		_, err = f.Match(invalid)
+
+		assert.ErrorIs(t, err, filter.ErrInvalidPayload)
+	})
+
+	t.Run("skips invalid payloads", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := filter.New("amount > 100.0")
+		require.NoError(t, err)
+
+		invalid := large
+		invalid.Payload = []byte("invalid")
+		got, err := f.Apply([]flow.Event{invalid, other})
+
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Event{other}, got)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		event := depositEvent(t, 0, "150.5", []byte{0x12, 0x34})
+
+		got, err := filter.Decode(event)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"amount":150.50000000,"to":"0x0000000000001234","tags":["a","b"]}`, string(got))
+	})
+
+	t.Run("handles invalid payloads", func(t *testing.T) {
+		t.Parallel()
+
+		event := depositEvent(t, 0, "150.5", []byte{0x12, 0x34})
+		event.Payload = []byte("invalid")
+
+		_, err := filter.Decode(event)
+
+		assert.Error(t, err)
+	})
+}
+
+// depositEvent creates a token deposit event with the given amount and an
+// optional recipient address.
+func depositEvent(t *testing.T, index uint32, amount string, to []byte) flow.Event {
+	t.Helper()
+
+	value, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+
+	tagsType := cadence.NewVariableSizedArrayType(cadence.StringType{})
+	payload := cadence.NewEvent([]cadence.Value{
+		value,
+		cadence.NewOptional(cadence.BytesToAddress(to)),
+		cadence.NewArray([]cadence.Value{cadence.String("a"), cadence.String("b")}).WithType(tagsType),
+	}).WithType(&cadence.EventType{
+		Location:            common.AddressLocation{Address: common.MustBytesToAddress([]byte{0x1}), Name: "Token"},
+		QualifiedIdentifier: "Token.TokensDeposited",
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType{})},
+			{Identifier: "tags", Type: tagsType},
+		},
+	})
+
+	event := flow.Event{
+		Type:       "A.0000000000000001.Token.TokensDeposited",
+		EventIndex: index,
+		Payload:    json.MustEncode(payload),
+	}
+
+	return event
+}
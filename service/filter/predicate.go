@@ -0,0 +1,190 @@
+package filter
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+)
+
+// Operator is the comparison operator of a predicate.
+type Operator string
+
+// Supported comparison operators.
+const (
+	Equal          Operator = "=="
+	NotEqual       Operator = "!="
+	Less           Operator = "<"
+	LessOrEqual    Operator = "<="
+	Greater        Operator = ">"
+	GreaterOrEqual Operator = ">="
+)
+
+// nilLiteral is the literal that matches optional fields without a value.
+const nilLiteral = "nil"
+
+// fieldName matches the names of event fields, which are Cadence identifiers.
+var fieldName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Predicate is a comparison between a field of an event and a literal, such
+// as `to == 0x1234` or `amount > 100.0`.
+type Predicate struct {
+	Field    string
+	Operator Operator
+	Literal  string
+}
+
+// ParsePredicate parses a predicate from an expression of the form
+// `<field> <operator> <literal>`. String literals can optionally be quoted.
+func ParsePredicate(expression string) (Predicate, error) {
+
+	// The operator is the first comparison character and, if it forms a
+	// two-character operator, the character that follows it.
+	index := strings.IndexAny(expression, "=!<>")
+	if index < 0 {
+		return Predicate{}, fmt.Errorf("missing operator (%s)", expression)
+	}
+	op := Operator(expression[index : index+1])
+	if index+1 < len(expression) && expression[index+1] == '=' {
+		op = Operator(expression[index : index+2])
+	}
+	switch op {
+	case Equal, NotEqual, Less, LessOrEqual, Greater, GreaterOrEqual:
+	default:
+		return Predicate{}, fmt.Errorf("invalid operator (%s)", op)
+	}
+
+	field := strings.TrimSpace(expression[:index])
+	if !fieldName.MatchString(field) {
+		return Predicate{}, fmt.Errorf("invalid field name (%s)", field)
+	}
+	literal := strings.TrimSpace(expression[index+len(op):])
+	if literal == "" {
+		return Predicate{}, fmt.Errorf("missing literal (%s)", expression)
+	}
+
+	p := Predicate{
+		Field:    field,
+		Operator: op,
+		Literal:  literal,
+	}
+
+	return p, nil
+}
+
+// String returns the expression of the predicate.
+func (p Predicate) String() string {
+	return fmt.Sprintf("%s %s %s", p.Field, p.Operator, p.Literal)
+}
+
+// Evaluate returns whether the given field value satisfies the predicate.
+// Numbers and strings support all operators, while other values can only be
+// compared for equality.
+func (p Predicate) Evaluate(value cadence.Value) (bool, error) {
+
+	// Optional values are compared by their inner value, and only match the
+	// nil literal when they have none. A missing value is neither lower nor
+	// greater than any literal.
+	optional, ok := value.(cadence.Optional)
+	if ok {
+		value = optional.Value
+	}
+	if p.Literal == nilLiteral {
+		return p.equality(value == nil)
+	}
+	if value == nil && p.Operator != Equal && p.Operator != NotEqual {
+		return false, nil
+	}
+	if value == nil {
+		return p.equality(false)
+	}
+
+	switch v := value.(type) {
+
+	case cadence.Address:
+		// Leading zeros of address literals can be omitted, so literals with
+		// an odd number of digits are padded to whole bytes.
+		digits := strings.TrimPrefix(p.Literal, "0x")
+		if len(digits)%2 != 0 {
+			digits = "0" + digits
+		}
+		literal, err := hex.DecodeString(digits)
+		if err != nil || len(literal) > cadence.AddressLength {
+			return false, fmt.Errorf("invalid address literal (%s)", p.Literal)
+		}
+		return p.equality(cadence.BytesToAddress(literal) == v)
+
+	case cadence.Bool:
+		literal, err := strconv.ParseBool(p.Literal)
+		if err != nil {
+			return false, fmt.Errorf("invalid boolean literal (%s)", p.Literal)
+		}
+		return p.equality(bool(v) == literal)
+
+	case cadence.String:
+		return p.order(strings.Compare(string(v), unquote(p.Literal)))
+
+	case cadence.NumberValue:
+		// All number values, including the fixed-point ones, are formatted as
+		// decimals, so they can be compared as rational numbers.
+		number, ok := new(big.Rat).SetString(v.String())
+		if !ok {
+			return false, fmt.Errorf("invalid number value (%s)", v)
+		}
+		literal, ok := new(big.Rat).SetString(p.Literal)
+		if !ok {
+			return false, fmt.Errorf("invalid number literal (%s)", p.Literal)
+		}
+		return p.order(number.Cmp(literal))
+
+	default:
+		return p.equality(v.String() == unquote(p.Literal))
+	}
+}
+
+// equality evaluates an equality operator for the given result of comparing
+// the value with the literal.
+func (p Predicate) equality(equal bool) (bool, error) {
+	switch p.Operator {
+	case Equal:
+		return equal, nil
+	case NotEqual:
+		return !equal, nil
+	default:
+		return false, fmt.Errorf("unsupported operator for field %s (%s)", p.Field, p.Operator)
+	}
+}
+
+// order evaluates any operator for the given result of comparing the value
+// with the literal, which is negative if the value is lower.
+func (p Predicate) order(cmp int) (bool, error) {
+	switch p.Operator {
+	case Equal:
+		return cmp == 0, nil
+	case NotEqual:
+		return cmp != 0, nil
+	case Less:
+		return cmp < 0, nil
+	case LessOrEqual:
+		return cmp <= 0, nil
+	case Greater:
+		return cmp > 0, nil
+	case GreaterOrEqual:
+		return cmp >= 0, nil
+	default:
+		return false, fmt.Errorf("invalid operator (%s)", p.Operator)
+	}
+}
+
+// unquote removes the double quotes around a string literal, if it has them.
+func unquote(literal string) string {
+	unquoted, err := strconv.Unquote(literal)
+	if err != nil {
+		return literal
+	}
+	return unquoted
+}
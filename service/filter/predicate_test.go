@@ -0,0 +1,133 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/service/filter"
+)
+
+func TestParsePredicate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       filter.Predicate
+		checkErr   require.ErrorAssertionFunc
+	}{
+		{
+			name:       "equality",
+			expression: "to == 0x1234",
+			want:       filter.Predicate{Field: "to", Operator: filter.Equal, Literal: "0x1234"},
+			checkErr:   require.NoError,
+		},
+		{
+			name:       "comparison without spaces",
+			expression: "amount>=100.0",
+			want:       filter.Predicate{Field: "amount", Operator: filter.GreaterOrEqual, Literal: "100.0"},
+			checkErr:   require.NoError,
+		},
+		{
+			name:       "quoted string",
+			expression: `contract != "Token"`,
+			want:       filter.Predicate{Field: "contract", Operator: filter.NotEqual, Literal: `"Token"`},
+			checkErr:   require.NoError,
+		},
+		{
+			name:       "single character operator",
+			expression: "amount < 1",
+			want:       filter.Predicate{Field: "amount", Operator: filter.Less, Literal: "1"},
+			checkErr:   require.NoError,
+		},
+		{
+			name:       "missing operator",
+			expression: "amount 1",
+			checkErr:   require.Error,
+		},
+		{
+			name:       "invalid operator",
+			expression: "amount = 1",
+			checkErr:   require.Error,
+		},
+		{
+			name:       "invalid field name",
+			expression: "amount.value == 1",
+			checkErr:   require.Error,
+		},
+		{
+			name:       "missing literal",
+			expression: "amount ==",
+			checkErr:   require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := filter.ParsePredicate(test.expression)
+
+			test.checkErr(t, err)
+			if err == nil {
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestPredicate_Evaluate(t *testing.T) {
+	amount, err := cadence.NewUFix64("150.5")
+	require.NoError(t, err)
+	address := cadence.BytesToAddress([]byte{0x12, 0x34})
+
+	tests := []struct {
+		name       string
+		expression string
+		value      cadence.Value
+		want       bool
+		checkErr   require.ErrorAssertionFunc
+	}{
+		{name: "address match", expression: "to == 0x1234", value: address, want: true, checkErr: require.NoError},
+		{name: "address padded match", expression: "to == 0x0000000000001234", value: address, want: true, checkErr: require.NoError},
+		{name: "address odd length match", expression: "to == 0x234", value: cadence.BytesToAddress([]byte{0x02, 0x34}), want: true, checkErr: require.NoError},
+		{name: "address odd length mismatch", expression: "to == 0x123", value: address, want: false, checkErr: require.NoError},
+		{name: "address mismatch", expression: "to == 0x1235", value: address, want: false, checkErr: require.NoError},
+		{name: "address inequality", expression: "to != 0x1235", value: address, want: true, checkErr: require.NoError},
+		{name: "address ordering", expression: "to > 0x1234", value: address, checkErr: require.Error},
+		{name: "invalid address literal", expression: "to == 0xzz", value: address, checkErr: require.Error},
+		{name: "fixed point greater", expression: "amount > 100.0", value: amount, want: true, checkErr: require.NoError},
+		{name: "fixed point equal", expression: "amount == 150.5", value: amount, want: true, checkErr: require.NoError},
+		{name: "fixed point lower", expression: "amount < 100", value: amount, want: false, checkErr: require.NoError},
+		{name: "integer", expression: "id <= 42", value: cadence.NewUInt64(42), want: true, checkErr: require.NoError},
+		{name: "negative integer", expression: "delta < 0", value: cadence.NewInt(-3), want: true, checkErr: require.NoError},
+		{name: "invalid number literal", expression: "amount > lots", value: amount, checkErr: require.Error},
+		{name: "string", expression: "contract == Token", value: cadence.String("Token"), want: true, checkErr: require.NoError},
+		{name: "quoted string", expression: `contract == "Token"`, value: cadence.String("Token"), want: true, checkErr: require.NoError},
+		{name: "string ordering", expression: "contract < Z", value: cadence.String("Token"), want: true, checkErr: require.NoError},
+		{name: "boolean", expression: "revoked == true", value: cadence.NewBool(true), want: true, checkErr: require.NoError},
+		{name: "invalid boolean literal", expression: "revoked == yes", value: cadence.NewBool(true), checkErr: require.Error},
+		{name: "optional with value", expression: "to == 0x1234", value: cadence.NewOptional(address), want: true, checkErr: require.NoError},
+		{name: "optional without value", expression: "to == nil", value: cadence.NewOptional(nil), want: true, checkErr: require.NoError},
+		{name: "optional without value compared", expression: "to == 0x1234", value: cadence.NewOptional(nil), want: false, checkErr: require.NoError},
+		{name: "optional without value ordered", expression: "amount > 1", value: cadence.NewOptional(nil), want: false, checkErr: require.NoError},
+		{name: "value compared to nil", expression: "to != nil", value: address, want: true, checkErr: require.NoError},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			predicate, err := filter.ParsePredicate(test.expression)
+			require.NoError(t, err)
+
+			got, err := predicate.Evaluate(test.value)
+
+			test.checkErr(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -5,19 +5,23 @@ package index_test
 
 import (
 	"testing"
+	"time"
 
-	"github.com/dgraph-io/badger/v2"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/model/flow"
 
-	"github.com/onflow/flow-archive/codec/zbor"
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/models/convert"
 	"github.com/onflow/flow-archive/service/index"
-	"github.com/onflow/flow-archive/service/storage"
 	"github.com/onflow/flow-archive/service/storage2"
-	"github.com/onflow/flow-archive/testing/helpers"
 	"github.com/onflow/flow-archive/testing/mocks"
 )
 
@@ -103,6 +107,72 @@ func TestIndex(t *testing.T) {
 		assert.Equal(t, mocks.GenericHeader, got)
 	})
 
+	t.Run("height for timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		// Blocks are a minute apart, except for the last two which share a
+		// timestamp.
+		start := time.Date(2023, time.March, 31, 23, 55, 0, 0, time.UTC)
+		first := mocks.GenericHeight
+		last := mocks.GenericHeight + 4
+		for height := first; height <= last; height++ {
+			header := *mocks.GenericHeader
+			header.Height = height
+			header.Timestamp = start.Add(time.Duration(height-first) * time.Minute)
+			if height == last {
+				header.Timestamp = start.Add(3 * time.Minute)
+			}
+			assert.NoError(t, writer.Header(height, &header))
+		}
+		assert.NoError(t, writer.First(first))
+		assert.NoError(t, writer.Last(last))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		tests := []struct {
+			name      string
+			timestamp time.Time
+			mode      archive.TimestampMode
+			want      uint64
+		}{
+			{name: "exact at or before", timestamp: start.Add(time.Minute), mode: archive.AtOrBefore, want: first + 1},
+			{name: "exact at or after", timestamp: start.Add(time.Minute), mode: archive.AtOrAfter, want: first + 1},
+			{name: "between at or before", timestamp: start.Add(90 * time.Second), mode: archive.AtOrBefore, want: first + 1},
+			{name: "between at or after", timestamp: start.Add(90 * time.Second), mode: archive.AtOrAfter, want: first + 2},
+			{name: "shared at or before", timestamp: start.Add(3 * time.Minute), mode: archive.AtOrBefore, want: last},
+			{name: "shared at or after", timestamp: start.Add(3 * time.Minute), mode: archive.AtOrAfter, want: first + 3},
+			{name: "after last", timestamp: start.Add(time.Hour), mode: archive.AtOrBefore, want: last},
+			{name: "before first", timestamp: start.Add(-time.Hour), mode: archive.AtOrAfter, want: first},
+		}
+
+		// NOTE: The following subtests should NOT be run in parallel, because of the deferral
+		// to close the database above.
+		for _, test := range tests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				got, err := reader.HeightForTimestamp(test.timestamp, test.mode)
+
+				require.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			})
+		}
+
+		t.Run("no block at or before", func(t *testing.T) {
+			_, err := reader.HeightForTimestamp(start.Add(-time.Second), archive.AtOrBefore)
+
+			assert.ErrorIs(t, err, archive.ErrNotFound)
+		})
+
+		t.Run("no block at or after", func(t *testing.T) {
+			_, err := reader.HeightForTimestamp(start.Add(4*time.Minute), archive.AtOrAfter)
+
+			assert.ErrorIs(t, err, archive.ErrNotFound)
+		})
+	})
+
 	t.Run("payloads", func(t *testing.T) {
 		t.Parallel()
 
@@ -124,6 +194,93 @@ func TestIndex(t *testing.T) {
 		assert.ElementsMatch(t, values, got)
 	})
 
+	t.Run("register history", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		payloads := mocks.GenericLedgerPayloads(1)
+		reg := mocks.GenericRegister(0)
+
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight, payloads))
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight+2, payloads))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		got, err := reader.RegisterHistory(reg, mocks.GenericHeight, mocks.GenericHeight+5, 0)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, mocks.GenericHeight, got[0].Height)
+		assert.Equal(t, mocks.GenericHeight+2, got[1].Height)
+		assert.Equal(t, mocks.GenericRegisterValue(0), got[0].Value)
+	})
+
+	t.Run("registers by height", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		payloads := mocks.GenericLedgerPayloads(4)
+		regs := mocks.GenericRegisters(4)
+
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight, payloads[:2]))
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight+1, payloads[2:]))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		got, err := reader.RegistersByHeight(mocks.GenericHeight)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, regs[:2], got)
+
+		got, err = reader.RegistersByHeight(mocks.GenericHeight + 1)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, regs[2:], got)
+
+		got, err = reader.RegistersByHeight(mocks.GenericHeight + 2)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("registers by owner", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		payloads := mocks.GenericLedgerPayloads(4)
+		regs := mocks.GenericRegisters(4)
+		values := mocks.GenericRegisterValues(4)
+
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight, payloads))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		var got flow.RegisterEntries
+		err := reader.RegistersByOwner(mocks.GenericHeight, regs[1].Owner, func(entry flow.RegisterEntry) error {
+			got = append(got, entry)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, regs[1], got[0].Key)
+		assert.Equal(t, values[1], got[0].Value)
+	})
+
+	t.Run("register retention", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+		require.NoError(t, writer.Close())
+
+		// An index that was never pruned retains every height.
+		got, err := reader.RegisterRetention()
+		require.NoError(t, err)
+		assert.Equal(t, archive.RegisterRetention{}, got)
+	})
+
 	t.Run("collections", func(t *testing.T) {
 		t.Parallel()
 
@@ -155,6 +312,13 @@ func TestIndex(t *testing.T) {
 		t.Run("retrieve transactions from collection", func(t *testing.T) {
 			// For now this index is not used.
 		})
+
+		t.Run("retrieve height for collection", func(t *testing.T) {
+			got, err := reader.HeightForCollection(collections[0].ID())
+
+			require.NoError(t, err)
+			assert.Equal(t, mocks.GenericHeight, got)
+		})
 	})
 
 	t.Run("guarantees", func(t *testing.T) {
@@ -216,6 +380,103 @@ func TestIndex(t *testing.T) {
 		})
 	})
 
+	t.Run("transactions by address", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		address := mocks.GenericAddress(0)
+		transaction := mocks.GenericTransaction(0)
+		transaction.Payer = address
+
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight))
+		assert.NoError(t, writer.Transactions(mocks.GenericHeight, []*flow.TransactionBody{transaction}))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		got, err := reader.TransactionsByAddress(address, mocks.GenericHeight, mocks.GenericHeight, archive.RoleAny, 0)
+
+		require.NoError(t, err)
+		want := []archive.AddressTransaction{
+			{Height: mocks.GenericHeight, TransactionID: transaction.ID(), Roles: archive.RolePayer},
+		}
+		assert.Equal(t, want, got)
+
+		got, err = reader.TransactionsByAddress(address, mocks.GenericHeight, mocks.GenericHeight, archive.RoleAuthorizer, 0)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("transfers", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		transfers := mocks.GenericTransfers(2)
+		for i := range transfers {
+			transfers[i].Height = mocks.GenericHeight
+		}
+		address := transfers[0].To
+
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight))
+		assert.NoError(t, writer.Transfers(mocks.GenericHeight, transfers))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		t.Run("retrieve transfers by address", func(t *testing.T) {
+			got, err := reader.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, transfers, got)
+		})
+
+		t.Run("retrieve balance history", func(t *testing.T) {
+			got, err := reader.BalanceHistory(address, archive.FlowSymbol, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+			require.NoError(t, err)
+			want := []archive.BalanceChange{
+				{Height: mocks.GenericHeight, Deposited: transfers[0].Amount, Withdrawn: transfers[1].Amount},
+			}
+			assert.Equal(t, want, got)
+		})
+	})
+
+	t.Run("transfers not indexed", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		address := mocks.GenericAddress(0)
+
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight+1))
+		require.NoError(t, writer.Close())
+
+		_, err := reader.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight+1, 0)
+		assert.ErrorIs(t, err, archive.ErrNotIndexed)
+
+		_, err = reader.BalanceHistory(address, archive.FlowSymbol, mocks.GenericHeight, mocks.GenericHeight+1, 0)
+		assert.ErrorIs(t, err, archive.ErrNotIndexed)
+
+		// Once transfers are indexed, only the heights before them are rejected.
+		writer = index.NewWriter(db)
+		assert.NoError(t, writer.Transfers(mocks.GenericHeight+1, nil))
+		require.NoError(t, writer.Close())
+
+		_, err = reader.TransfersByAddress(address, mocks.GenericHeight, mocks.GenericHeight+1, 0)
+		assert.ErrorIs(t, err, archive.ErrNotIndexed)
+
+		got, err := reader.TransfersByAddress(address, mocks.GenericHeight+1, mocks.GenericHeight+1, 0)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
 	t.Run("results", func(t *testing.T) {
 		t.Parallel()
 
@@ -274,6 +535,258 @@ func TestIndex(t *testing.T) {
 
 			assert.NotEqual(t, got1, got2)
 		})
+
+		t.Run("heights by type", func(t *testing.T) {
+			got, err := reader.EventHeights([]flow.EventType{withdrawalType}, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{mocks.GenericHeight}, got)
+
+			_, err = reader.EventHeights(nil, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("events by contract", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		address := mocks.GenericAddress(0)
+		contractType := flow.EventType("A." + address.Hex() + ".Token.Deposited")
+		events := mocks.GenericEvents(4, contractType, mocks.GenericEventType(0))
+
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight))
+		assert.NoError(t, writer.Events(mocks.GenericHeight, events))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		got, err := reader.EventsByContract(address, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, mocks.GenericHeight, got[0].Height)
+		assert.ElementsMatch(t, []flow.Event{events[0], events[2]}, got[0].Events)
+
+		_, err = reader.EventsByContract(address, mocks.GenericHeight+1, mocks.GenericHeight+2, 0)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("contracts", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		// Both the deployment and the update of the contract happen in the
+		// same block.
+		events := mocks.GenericContractEvents(2)
+		updates := mocks.GenericContractUpdates(2)
+		for i := range updates {
+			updates[i].Height = mocks.GenericHeight
+		}
+		address := updates[0].Address
+		name := updates[0].Name
+
+		names, err := cbor.Marshal([]string{name})
+		require.NoError(t, err)
+		registers := []flow.RegisterEntry{
+			{Key: flow.ContractNamesRegisterID(address), Value: names},
+			{Key: flow.ContractRegisterID(address, name), Value: mocks.GenericContractCode},
+		}
+		payloads := make([]*ledger.Payload, 0, len(registers))
+		for _, register := range registers {
+			key := ledger.NewKey([]ledger.KeyPart{
+				ledger.NewKeyPart(0, []byte(register.Key.Owner)),
+				ledger.NewKeyPart(2, []byte(register.Key.Key)),
+			})
+			payloads = append(payloads, ledger.NewPayload(key, register.Value))
+		}
+
+		// Blocks are indexed one height further than the registers.
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight+1))
+		assert.NoError(t, writer.Events(mocks.GenericHeight, events))
+		assert.NoError(t, writer.ContractUpdates(mocks.GenericHeight, updates))
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight, payloads))
+		assert.NoError(t, writer.LatestRegisterHeight(mocks.GenericHeight))
+		// Close the writer to make it commit its transactions.
+		require.NoError(t, writer.Close())
+
+		t.Run("retrieve contracts by address", func(t *testing.T) {
+			got, err := reader.ContractsByAddress(address, mocks.GenericHeight)
+
+			require.NoError(t, err)
+			want := []archive.Contract{{Address: address, Name: name, Height: mocks.GenericHeight}}
+			assert.Equal(t, want, got)
+		})
+
+		t.Run("retrieve contract history", func(t *testing.T) {
+			got, err := reader.ContractHistory(address, name, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+			require.NoError(t, err)
+			assert.Equal(t, updates, got)
+		})
+
+		t.Run("retrieve contract code", func(t *testing.T) {
+			got, err := reader.ContractCode(address, name, mocks.GenericHeight)
+
+			require.NoError(t, err)
+			assert.Equal(t, mocks.GenericContractCode, got)
+
+			_, err = reader.ContractCode(address, "Unknown", mocks.GenericHeight)
+
+			assert.ErrorIs(t, err, archive.ErrNotFound)
+		})
+
+		t.Run("retrieve contracts above latest register height", func(t *testing.T) {
+			_, err := reader.ContractsByAddress(address, mocks.GenericHeight+1)
+
+			assert.Error(t, err)
+
+			_, err = reader.ContractCode(address, name, mocks.GenericHeight+1)
+
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("contracts indexed before the contract update index", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		// Heights indexed before the contract update index existed only have
+		// their events, from which the contract updates are decoded instead.
+		// Contract events that can not be decoded are skipped.
+		events := mocks.GenericContractEvents(2)
+		invalid := flow.Event{
+			TransactionID: mocks.GenericTransactionIDs(1)[0],
+			Type:          archive.EventAccountContractUpdated,
+			EventIndex:    2,
+			Payload:       mocks.GenericBytes,
+		}
+		updates := mocks.GenericContractUpdates(2)
+		for i := range updates {
+			updates[i].Height = mocks.GenericHeight
+		}
+		address := updates[0].Address
+		name := updates[0].Name
+
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight))
+		assert.NoError(t, writer.Events(mocks.GenericHeight, append(events, invalid)))
+		require.NoError(t, writer.Close())
+
+		got, err := reader.ContractHistory(address, name, mocks.GenericHeight, mocks.GenericHeight, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, updates, got)
+	})
+
+	t.Run("account keys", func(t *testing.T) {
+		t.Parallel()
+
+		reader, _, db := setupIndex(t)
+		defer db.Close()
+
+		history := mocks.GenericAccountKeyHistory(3)
+		address := history[0].Address
+
+		_, err := reader.AccountKeysAt(address, mocks.GenericHeight)
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+
+		require.NoError(t, db.BatchSetAccountKeys(mocks.GenericHeight+5, history))
+
+		t.Run("retrieve keys at height", func(t *testing.T) {
+			got, err := reader.AccountKeysAt(address, mocks.GenericHeight+1)
+
+			require.NoError(t, err)
+			assert.Equal(t, history[1].Keys, got)
+
+			got, err = reader.AccountKeysAt(address, mocks.GenericHeight+5)
+
+			require.NoError(t, err)
+			assert.Equal(t, history[2].Keys, got)
+		})
+
+		t.Run("retrieve keys before first change without registers", func(t *testing.T) {
+			_, err := reader.AccountKeysAt(address, mocks.GenericHeight-1)
+
+			assert.Error(t, err)
+		})
+
+		t.Run("retrieve keys above indexed height", func(t *testing.T) {
+			_, err := reader.AccountKeysAt(address, mocks.GenericHeight+6)
+
+			assert.Error(t, err)
+		})
+
+		t.Run("retrieve key history", func(t *testing.T) {
+			got, err := reader.AccountKeyHistory(address)
+
+			require.NoError(t, err)
+			assert.Equal(t, history, got)
+		})
+	})
+
+	t.Run("account keys not indexed", func(t *testing.T) {
+		t.Parallel()
+
+		reader, writer, db := setupIndex(t)
+		defer db.Close()
+
+		// Accounts whose keys did not change since the keys were first indexed
+		// have their keys read from the registers instead.
+		address := mocks.GenericAddress(0)
+		seed := make([]byte, crypto.KeyGenSeedMinLen)
+		private, err := crypto.GeneratePrivateKey(crypto.ECDSAP256, seed)
+		require.NoError(t, err)
+		key := flow.AccountPublicKey{
+			Index:     0,
+			PublicKey: private.PublicKey(),
+			SignAlgo:  crypto.ECDSAP256,
+			HashAlgo:  hash.SHA3_256,
+			Weight:    1000,
+			SeqNumber: 3,
+		}
+		encoded, err := flow.EncodeAccountPublicKey(key)
+		require.NoError(t, err)
+		status := environment.NewAccountStatus()
+		status.SetPublicKeyCount(1)
+
+		registers := []flow.RegisterEntry{
+			{Key: flow.AccountStatusRegisterID(address), Value: status.ToBytes()},
+			{Key: flow.PublicKeyRegisterID(address, 0), Value: encoded},
+		}
+		payloads := make([]*ledger.Payload, 0, len(registers))
+		for _, register := range registers {
+			key := ledger.NewKey([]ledger.KeyPart{
+				ledger.NewKeyPart(0, []byte(register.Key.Owner)),
+				ledger.NewKeyPart(2, []byte(register.Key.Key)),
+			})
+			payloads = append(payloads, ledger.NewPayload(key, register.Value))
+		}
+
+		assert.NoError(t, writer.First(mocks.GenericHeight))
+		assert.NoError(t, writer.Last(mocks.GenericHeight))
+		assert.NoError(t, writer.Payloads(mocks.GenericHeight, payloads))
+		assert.NoError(t, writer.LatestRegisterHeight(mocks.GenericHeight))
+		require.NoError(t, writer.Close())
+		require.NoError(t, db.BatchSetAccountKeys(mocks.GenericHeight, nil))
+
+		got, err := reader.AccountKeysAt(address, mocks.GenericHeight)
+
+		require.NoError(t, err)
+		assert.Equal(t, convert.AccountKeys([]flow.AccountPublicKey{key}), got)
+
+		_, err = reader.AccountKeysAt(mocks.GenericAddress(1), mocks.GenericHeight)
+
+		assert.ErrorIs(t, err, archive.ErrNotFound)
 	})
 
 	t.Run("seals", func(t *testing.T) {
@@ -303,25 +816,33 @@ func TestIndex(t *testing.T) {
 			require.NoError(t, err)
 			assert.ElementsMatch(t, got, mocks.GenericSealIDs(4))
 		})
-	})
-}
 
-func setupIndex(t *testing.T) (*index.Reader, *index.Writer, *badger.DB) {
-	t.Helper()
+		t.Run("retrieve height for seal", func(t *testing.T) {
+			got, err := reader.HeightForSeal(seals[0].ID())
 
-	codec := zbor.NewCodec()
+			require.NoError(t, err)
+			assert.Equal(t, mocks.GenericHeight, got)
+		})
 
-	db := helpers.InMemoryDB(t)
+		t.Run("retrieve sealed height for block", func(t *testing.T) {
+			got, err := reader.SealedAtHeight(seals[0].BlockID)
+
+			require.NoError(t, err)
+			assert.Equal(t, mocks.GenericHeight, got)
+		})
+	})
+}
 
-	lib := storage.New(codec)
+func setupIndex(t *testing.T) (*index.Reader, *index.Writer, archive.Library2) {
+	t.Helper()
 
 	lib2, err := storage2.NewLibrary2(t.TempDir(), 1<<20)
 	require.NoError(t, err)
 
 	log := zerolog.Nop()
 
-	reader := index.NewReader(log, db, lib, lib2)
-	writer := index.NewWriter(db, lib, lib2, index.WithConcurrentTransactions(4))
+	reader := index.NewReader(log, lib2)
+	writer := index.NewWriter(lib2)
 
-	return reader, writer, db
+	return reader, writer, lib2
 }
@@ -1,48 +1,43 @@
 package index
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/rs/zerolog"
 
-	"golang.org/x/sync/errgroup"
-
-	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/flow-go/fvm/environment"
 	"github.com/onflow/flow-go/model/flow"
 
 	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/models/convert"
+	"github.com/onflow/flow-archive/service/contracts"
+	"github.com/onflow/flow-archive/util"
 )
 
-// ConcurrentPathReadLimit sets the number of concurrent paths in the Values lookup
-const ConcurrentPathReadLimit int = 1024
-
 // Reader implements the `index.Reader` interface on top of the DPS server's
-// Badger database index.
+// pebble-based index.
 type Reader struct {
 	log zerolog.Logger
-	db  *badger.DB
 
-	// Old badger-based index. Eventually all methods from here would migrate to lib2.
-	lib archive.ReadLibrary
-	// New pebble-based index.  Would likely consist of multiple separate pebble databases underneath.
+	// Pebble-based index, which consists of multiple separate pebble databases underneath.
 	lib2 archive.ReadLibrary2
 }
 
-// NewReader creates a new index reader, using the given database as the
-// underlying state repository. It is recommended to provide a read-only Badger
-// database.
+// NewReader creates a new index reader, using the given library as the
+// underlying state repository.
 func NewReader(
 	log zerolog.Logger,
-	db *badger.DB,
-	lib archive.ReadLibrary,
 	lib2 archive.ReadLibrary2,
 ) *Reader {
 
 	r := Reader{
 		log: log.With().Str("component", "index_reader").Logger(),
-		db:  db,
 
-		lib:  lib,
 		lib2: lib2,
 	}
 
@@ -51,72 +46,91 @@ func NewReader(
 
 // First returns the height of the first finalized block that was indexed.
 func (r *Reader) First() (uint64, error) {
-	var height uint64
-	err := r.db.View(r.lib.RetrieveFirst(&height))
-	return height, err
+	return r.lib2.GetFirst()
 }
 
 // Last returns the height of the last finalized block that was indexed.
 func (r *Reader) Last() (uint64, error) {
-	var height uint64
-	err := r.db.View(r.lib.RetrieveLast(&height))
-	return height, err
+	return r.lib2.GetLast()
 }
 
 // LatestRegisterHeight returns the latest height for which all registers are indexed
 func (r *Reader) LatestRegisterHeight() (uint64, error) {
-	var height uint64
-	err := r.db.View(r.lib.RetrieveLatestRegisterHeight(&height))
-	return height, err
+	return r.lib2.GetLatestRegisterHeight()
+}
+
+// RegisterRetention returns the retention that register data was pruned with.
+// Register values can only be read at heights it retains.
+func (r *Reader) RegisterRetention() (archive.RegisterRetention, error) {
+	return r.lib2.GetRegisterRetention()
 }
 
 // HeightForBlock returns the height for the given block identifier.
 func (r *Reader) HeightForBlock(blockID flow.Identifier) (uint64, error) {
-	var height uint64
-	err := r.db.View(r.lib.LookupHeightForBlock(blockID, &height))
-	return height, err
+	return r.lib2.GetHeightForBlock(blockID)
+}
+
+// HeightForTimestamp returns the height of the finalized block at the given
+// timestamp. As there is rarely a block at the exact time, the mode selects
+// either the last block at or before it, or the first block at or after it.
+// Block timestamps increase with height, so the block is found with a binary
+// search over the headers of the indexed heights.
+func (r *Reader) HeightForTimestamp(timestamp time.Time, mode archive.TimestampMode) (uint64, error) {
+	if mode != archive.AtOrBefore && mode != archive.AtOrAfter {
+		return 0, fmt.Errorf("invalid timestamp mode (%d)", mode)
+	}
+	first, err := r.First()
+	if err != nil {
+		return 0, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return 0, fmt.Errorf("could not check last height: %w", err)
+	}
+
+	// We look for the lowest height that comes after the block we want to
+	// select. For at or before, that is the first block after the timestamp;
+	// for at or after, the first block that is not before it.
+	low, high := first, last+1
+	for low < high {
+		mid := low + (high-low)/2
+		header, err := r.lib2.GetHeader(mid)
+		if err != nil {
+			return 0, fmt.Errorf("could not get header (height: %d): %w", mid, err)
+		}
+		beyond := header.Timestamp.After(timestamp)
+		if mode == archive.AtOrAfter {
+			beyond = !header.Timestamp.Before(timestamp)
+		}
+		if beyond {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	if mode == archive.AtOrAfter {
+		if low > last {
+			return 0, fmt.Errorf("no block at or after timestamp (timestamp: %s, last: %d): %w", timestamp, last, archive.ErrNotFound)
+		}
+		return low, nil
+	}
+
+	if low == first {
+		return 0, fmt.Errorf("no block at or before timestamp (timestamp: %s, first: %d): %w", timestamp, first, archive.ErrNotFound)
+	}
+	return low - 1, nil
 }
 
 // Commit returns the commitment of the execution state as it was after the
 // execution of the finalized block at the given height.
 func (r *Reader) Commit(height uint64) (flow.StateCommitment, error) {
-	var commit flow.StateCommitment
-	err := r.db.View(r.lib.RetrieveCommit(height, &commit))
-	return commit, err
+	return r.lib2.GetCommit(height)
 }
 
 // Header returns the header for the finalized block at the given height.
 func (r *Reader) Header(height uint64) (*flow.Header, error) {
-	var header flow.Header
-	err := r.db.View(r.lib.RetrieveHeader(height, &header))
-	return &header, err
-}
-
-func (r *Reader) getValue(height uint64, reg flow.RegisterID) ([]byte, error) {
-	payload, err := r.lib2.GetPayload(height, reg)
-	if err != nil {
-		return nil, fmt.Errorf("could not retrieve payload (register: %x): %w", reg, err)
-	}
-	return payload, nil
-}
-
-func (r *Reader) getValues(height uint64, regs flow.RegisterIDs) ([][]byte, error) {
-	values := make([]flow.RegisterValue, len(regs))
-	g := errgroup.Group{}
-	g.SetLimit(ConcurrentPathReadLimit)
-	for i, reg := range regs {
-		i, reg := i, reg
-		g.Go(func() error {
-			payload, err := r.getValue(height, reg)
-			if err != nil {
-				return fmt.Errorf("failed to getValue for %d/%d: %w", i, len(regs), err)
-			}
-
-			values[i] = payload
-			return nil
-		})
-	}
-	return values, g.Wait()
+	return r.lib2.GetHeader(height)
 }
 
 // Values returns the Ledger values of the execution state at the given paths
@@ -140,66 +154,179 @@ func (r *Reader) Values(height uint64, regs flow.RegisterIDs) ([]flow.RegisterVa
 		return nil, fmt.Errorf("invalid height (given: %d, first: %d, last: %d)", height, first, last)
 	}
 
-	// fast-path for fetching a single register
-	if len(regs) == 1 {
-		value, err := r.getValue(height, regs[0])
-		if err != nil {
-			return nil, fmt.Errorf("could not retrieve value: %w", err)
-		}
-		return []flow.RegisterValue{value}, nil
+	values, err := r.lib2.GetPayloads(height, regs)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve values: %w", err)
 	}
 
-	return r.getValues(height, regs)
+	return values, nil
+}
+
+// RegisterHistory returns the values written to the given register at heights
+// within the given range, ordered by ascending height. If limit is greater than
+// zero, at most limit versions are returned, starting with the oldest one.
+func (r *Reader) RegisterHistory(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]archive.RegisterVersion, error) {
+	versions, err := r.lib2.GetPayloadHistory(reg, startHeight, endHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve register history (register: %x): %w", reg, err)
+	}
+	return versions, nil
+}
+
+// RegistersByOwner calls fn for every register of the given owner, with its
+// value at the given height. Registers that were removed before that height are
+// not included. Iteration stops at the first error returned by fn.
+func (r *Reader) RegistersByOwner(height uint64, owner string, fn func(flow.RegisterEntry) error) error {
+	return r.lib2.IterateOwnerPayloads(height, owner, fn)
 }
 
 // Collection returns the collection with the given ID.
 func (r *Reader) Collection(collID flow.Identifier) (*flow.LightCollection, error) {
-	var collection flow.LightCollection
-	err := r.db.View(r.lib.RetrieveCollection(collID, &collection))
-	return &collection, err
+	return r.lib2.GetCollection(collID)
 }
 
 // CollectionsByHeight returns the collection IDs at the given height.
 func (r *Reader) CollectionsByHeight(height uint64) ([]flow.Identifier, error) {
-	var collIDs []flow.Identifier
-	err := r.db.View(r.lib.LookupCollectionsForHeight(height, &collIDs))
-	return collIDs, err
+	return r.lib2.GetCollectionsForHeight(height)
 }
 
 // Guarantee returns the guarantee with the given collection ID.
 func (r *Reader) Guarantee(collID flow.Identifier) (*flow.CollectionGuarantee, error) {
-	var collection flow.CollectionGuarantee
-	err := r.db.View(r.lib.RetrieveGuarantee(collID, &collection))
-	return &collection, err
+	return r.lib2.GetGuarantee(collID)
 }
 
 // Transaction returns the transaction with the given ID.
 func (r *Reader) Transaction(txID flow.Identifier) (*flow.TransactionBody, error) {
-	var transaction flow.TransactionBody
-	err := r.db.View(r.lib.RetrieveTransaction(txID, &transaction))
-	return &transaction, err
+	return r.lib2.GetTransaction(txID)
 }
 
 // HeightForTransaction returns the height of the block within which the given
 // transaction identifier is.
 func (r *Reader) HeightForTransaction(txID flow.Identifier) (uint64, error) {
-	var height uint64
-	err := r.db.View(r.lib.LookupHeightForTransaction(txID, &height))
-	return height, err
+	return r.lib2.GetHeightForTransaction(txID)
+}
+
+// HeightForCollection returns the height of the block within which the given
+// collection identifier is.
+func (r *Reader) HeightForCollection(collID flow.Identifier) (uint64, error) {
+	return r.lib2.GetHeightForCollection(collID)
 }
 
 // TransactionsByHeight returns the transaction IDs within the block with the given ID.
 func (r *Reader) TransactionsByHeight(height uint64) ([]flow.Identifier, error) {
-	var txIDs []flow.Identifier
-	err := r.db.View(r.lib.LookupTransactionsForHeight(height, &txIDs))
-	return txIDs, err
+	return r.lib2.GetTransactionsForHeight(height)
+}
+
+// TransactionsByAddress returns the transactions of finalized blocks within the
+// given range in which the account with the given address has any of the given
+// roles, ordered by ascending height. If limit is greater than zero, at most
+// limit transactions are returned, starting with the lowest height.
+func (r *Reader) TransactionsByAddress(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+	if roles == 0 {
+		return nil, fmt.Errorf("no transaction roles given")
+	}
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not check last height: %w", err)
+	}
+	if startHeight > endHeight || startHeight > last || endHeight < first {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d, first: %d, last: %d)", startHeight, endHeight, first, last)
+	}
+	if startHeight < first {
+		startHeight = first
+	}
+	if endHeight > last {
+		endHeight = last
+	}
+
+	txs, err := r.lib2.GetTransactionsForAddress(address, startHeight, endHeight, roles, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve transactions (address: %s): %w", address, err)
+	}
+
+	return txs, nil
+}
+
+// TransfersByAddress returns the token transfers of finalized blocks within the
+// given range that were sent or received by the account with the given address,
+// ordered by ascending height. If limit is greater than zero, at most limit
+// transfers are returned, starting with the lowest height.
+func (r *Reader) TransfersByAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not check last height: %w", err)
+	}
+	if startHeight > endHeight || startHeight > last || endHeight < first {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d, first: %d, last: %d)", startHeight, endHeight, first, last)
+	}
+	if startHeight < first {
+		startHeight = first
+	}
+	if endHeight > last {
+		endHeight = last
+	}
+
+	err = r.checkTransfers(startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := r.lib2.GetTransfersForAddress(address, startHeight, endHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve transfers (address: %s): %w", address, err)
+	}
+
+	return transfers, nil
+}
+
+// BalanceHistory returns the changes to the balance of the given token of the
+// account with the given address, for the finalized blocks within the given
+// range, ordered by ascending height. Heights at which the balance did not
+// change are omitted. If limit is greater than zero, at most limit changes are
+// returned, starting with the lowest height.
+func (r *Reader) BalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not check last height: %w", err)
+	}
+	if startHeight > endHeight || startHeight > last || endHeight < first {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d, first: %d, last: %d)", startHeight, endHeight, first, last)
+	}
+	if startHeight < first {
+		startHeight = first
+	}
+	if endHeight > last {
+		endHeight = last
+	}
+
+	err = r.checkTransfers(startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := r.lib2.GetBalanceHistory(address, token, startHeight, endHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve balance history (address: %s, token: %s): %w", address, token, err)
+	}
+
+	return changes, nil
 }
 
 // Result returns the transaction result for the given transaction ID.
 func (r *Reader) Result(txID flow.Identifier) (*flow.TransactionResult, error) {
-	var result flow.TransactionResult
-	err := r.db.View(r.lib.RetrieveResult(txID, &result))
-	return &result, err
+	return r.lib2.GetResult(txID)
 }
 
 // Events returns the events of all transactions that were part of the
@@ -218,8 +345,7 @@ func (r *Reader) Events(height uint64, types ...flow.EventType) ([]flow.Event, e
 		return nil, fmt.Errorf("invalid height (given: %d, first: %d, last: %d)", height, first, last)
 	}
 
-	var events []flow.Event
-	err = r.db.View(r.lib.RetrieveEvents(height, types, &events))
+	events, err := r.lib2.GetEvents(height, types)
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve events: %w", err)
 	}
@@ -227,16 +353,401 @@ func (r *Reader) Events(height uint64, types ...flow.EventType) ([]flow.Event, e
 	return events, nil
 }
 
+// EventHeights returns the heights of the finalized blocks within the given
+// range whose transactions emitted events of any of the given types, by
+// ascending height. If limit is greater than zero, at most limit heights are
+// returned, starting with the lowest one.
+func (r *Reader) EventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no event types given")
+	}
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not check last height: %w", err)
+	}
+	if startHeight > endHeight || startHeight > last || endHeight < first {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d, first: %d, last: %d)", startHeight, endHeight, first, last)
+	}
+	if startHeight < first {
+		startHeight = first
+	}
+	if endHeight > last {
+		endHeight = last
+	}
+
+	heights, err := r.lib2.GetEventHeights(types, startHeight, endHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve event heights: %w", err)
+	}
+
+	return heights, nil
+}
+
+// EventsByContract returns the events of finalized blocks within the given
+// range that were emitted by any of the contracts deployed at the given
+// address, grouped by ascending height. Heights without such events are
+// omitted. If limit is greater than zero, the events of at most limit heights
+// are returned, starting with the lowest one.
+func (r *Reader) EventsByContract(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not check last height: %w", err)
+	}
+	if startHeight > endHeight || startHeight > last || endHeight < first {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d, first: %d, last: %d)", startHeight, endHeight, first, last)
+	}
+	if startHeight < first {
+		startHeight = first
+	}
+	if endHeight > last {
+		endHeight = last
+	}
+
+	blocks, err := r.lib2.GetContractEvents(address, startHeight, endHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contract events (address: %s): %w", address, err)
+	}
+
+	return blocks, nil
+}
+
+// ContractsByAddress returns the contracts deployed on the account with the
+// given address after the finalized block at the given height, ordered by name,
+// along with the height of the latest change made to each of them.
+func (r *Reader) ContractsByAddress(address flow.Address, height uint64) ([]archive.Contract, error) {
+	err := util.ValidateRegisterHeightIndexed(r, height)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.Values(height, flow.RegisterIDs{flow.ContractNamesRegisterID(address)})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contract names (address: %s): %w", address, err)
+	}
+
+	// The execution state keeps the sorted names of the contracts of an account
+	// in a single CBOR-encoded register, which is empty if it has none.
+	var names []string
+	if len(values[0]) != 0 {
+		err = cbor.Unmarshal(values[0], &names)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode contract names (address: %s): %w", address, err)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	updates, err := r.contractUpdates(address, "", first, height, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contract updates (address: %s): %w", address, err)
+	}
+	latest := make(map[string]uint64, len(updates))
+	for _, update := range updates {
+		latest[update.Name] = update.Height
+	}
+
+	contracts := make([]archive.Contract, 0, len(names))
+	for _, name := range names {
+		contracts = append(contracts, archive.Contract{
+			Address: address,
+			Name:    name,
+			Height:  latest[name],
+		})
+	}
+
+	return contracts, nil
+}
+
+// ContractHistory returns the changes made to the contracts of the account
+// with the given address in finalized blocks within the given range, ordered
+// by ascending height. If name is not empty, only the changes to the contract
+// with that name are returned. If limit is greater than zero, at most limit
+// changes are returned, starting with the lowest height.
+func (r *Reader) ContractHistory(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+	first, err := r.First()
+	if err != nil {
+		return nil, fmt.Errorf("could not check first height: %w", err)
+	}
+	last, err := r.Last()
+	if err != nil {
+		return nil, fmt.Errorf("could not check last height: %w", err)
+	}
+	if startHeight > endHeight || startHeight > last || endHeight < first {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d, first: %d, last: %d)", startHeight, endHeight, first, last)
+	}
+	if startHeight < first {
+		startHeight = first
+	}
+	if endHeight > last {
+		endHeight = last
+	}
+
+	updates, err := r.contractUpdates(address, name, startHeight, endHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contract updates (address: %s): %w", address, err)
+	}
+
+	return updates, nil
+}
+
+// ContractCode returns the Cadence source of the contract with the given name
+// deployed on the account with the given address, as it was after the
+// finalized block at the given height. If no such contract was deployed at that
+// height, it returns an error wrapping archive.ErrNotFound.
+func (r *Reader) ContractCode(address flow.Address, name string, height uint64) ([]byte, error) {
+	err := util.ValidateRegisterHeightIndexed(r, height)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.Values(height, flow.RegisterIDs{flow.ContractRegisterID(address, name)})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contract code (address: %s, name: %s): %w", address, name, err)
+	}
+	if len(values[0]) == 0 {
+		return nil, fmt.Errorf("no contract deployed (address: %s, name: %s, height: %d): %w", address, name, height, archive.ErrNotFound)
+	}
+
+	return values[0], nil
+}
+
+// AccountKeysAt returns the keys of the account with the given address as they
+// were after the latest change to them at or below the given height, which has
+// to be covered by the indexed changes. Accounts whose keys did not change
+// since the keys were first indexed have their keys read from the registers at
+// the given height instead. If the account does not exist at that height, it
+// returns an error wrapping archive.ErrNotFound.
+func (r *Reader) AccountKeysAt(address flow.Address, height uint64) ([]archive.AccountKey, error) {
+	latest, err := r.lib2.GetLatestAccountKeysHeight()
+	if err != nil {
+		return nil, fmt.Errorf("could not check latest account keys height: %w", err)
+	}
+	if height > latest {
+		return nil, fmt.Errorf("invalid height (given: %d, latest account keys: %d)", height, latest)
+	}
+
+	keys, err := r.lib2.GetAccountKeys(address, height)
+	if errors.Is(err, archive.ErrNotFound) {
+		return r.accountKeys(address, height)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve account keys (address: %s): %w", address, err)
+	}
+
+	return keys.Keys, nil
+}
+
+// AccountKeyHistory returns the keys of the account with the given address
+// after each indexed height at which they changed, by ascending height.
+func (r *Reader) AccountKeyHistory(address flow.Address) ([]archive.AccountKeys, error) {
+	history, err := r.lib2.GetAccountKeyHistory(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve account key history (address: %s): %w", address, err)
+	}
+
+	return history, nil
+}
+
 // Seal returns the seal with the given ID.
 func (r *Reader) Seal(sealID flow.Identifier) (*flow.Seal, error) {
-	var seal flow.Seal
-	err := r.db.View(r.lib.RetrieveSeal(sealID, &seal))
-	return &seal, err
+	return r.lib2.GetSeal(sealID)
+}
+
+// HeightForSeal returns the height of the block within which the given seal
+// identifier is.
+func (r *Reader) HeightForSeal(sealID flow.Identifier) (uint64, error) {
+	return r.lib2.GetHeightForSeal(sealID)
+}
+
+// SealedAtHeight returns the height of the block that contains the seal for
+// the block with the given identifier. If the block has not been sealed yet,
+// the returned error wraps archive.ErrNotFound.
+func (r *Reader) SealedAtHeight(blockID flow.Identifier) (uint64, error) {
+	return r.lib2.GetSealedHeightForBlock(blockID)
+}
+
+// RegistersByHeight returns the IDs of all registers that were changed by the
+// finalized block at the given height. At the root height, this includes all
+// registers of the bootstrapped execution state.
+func (r *Reader) RegistersByHeight(height uint64) (flow.RegisterIDs, error) {
+	regs, err := r.lib2.GetRegistersForHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve changed registers: %w", err)
+	}
+	return regs, nil
 }
 
 // SealsByHeight returns all of the seals that were part of the finalized block at the given height.
 func (r *Reader) SealsByHeight(height uint64) ([]flow.Identifier, error) {
-	var sealIDs []flow.Identifier
-	err := r.db.View(r.lib.LookupSealsForHeight(height, &sealIDs))
-	return sealIDs, err
+	return r.lib2.GetSealsForHeight(height)
+}
+
+// checkTransfers checks that token transfers are indexed from the given height
+// on. Heights that were indexed before token transfers were have no entries in
+// the transfer and balance change indexes, and there is no fallback for them.
+func (r *Reader) checkTransfers(height uint64) error {
+	first, err := r.lib2.GetFirstTransferHeight()
+	if errors.Is(err, archive.ErrNotFound) {
+		return fmt.Errorf("token transfers are not indexed: %w", archive.ErrNotIndexed)
+	}
+	if err != nil {
+		return fmt.Errorf("could not check first transfer height: %w", err)
+	}
+	if height < first {
+		return fmt.Errorf("token transfers are not indexed below height %d (given: %d): %w", first, height, archive.ErrNotIndexed)
+	}
+
+	return nil
+}
+
+// accountKeys reads the keys of the account with the given address from the
+// registers at the given height, the same way the execution state does.
+func (r *Reader) accountKeys(address flow.Address, height uint64) ([]archive.AccountKey, error) {
+	err := util.ValidateRegisterHeightIndexed(r, height)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.Values(height, flow.RegisterIDs{flow.AccountStatusRegisterID(address)})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve account status (address: %s): %w", address, err)
+	}
+	if len(values[0]) == 0 {
+		return nil, fmt.Errorf("no account (address: %s, height: %d): %w", address, height, archive.ErrNotFound)
+	}
+	status, err := environment.AccountStatusFromBytes(values[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not decode account status (address: %s): %w", address, err)
+	}
+
+	count := status.PublicKeyCount()
+	if count == 0 {
+		return []archive.AccountKey{}, nil
+	}
+	regs := make(flow.RegisterIDs, 0, count)
+	for index := uint64(0); index < count; index++ {
+		regs = append(regs, flow.PublicKeyRegisterID(address, index))
+	}
+	values, err = r.Values(height, regs)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve account keys (address: %s): %w", address, err)
+	}
+
+	keys := make([]flow.AccountPublicKey, 0, count)
+	for index, value := range values {
+		key, err := flow.DecodeAccountPublicKey(value, uint64(index))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode account key (address: %s, index: %d): %w", address, index, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return convert.AccountKeys(keys), nil
+}
+
+// contractUpdates returns the changes made to the contracts of the account with
+// the given address within the given range of heights. Heights that were
+// indexed before the contract update index existed are served by decoding
+// their contract events instead.
+func (r *Reader) contractUpdates(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+	first, err := r.lib2.GetFirstContractUpdateHeight()
+	if errors.Is(err, archive.ErrNotFound) {
+		first = math.MaxUint64
+	} else if err != nil {
+		return nil, fmt.Errorf("could not check first contract update height: %w", err)
+	}
+
+	var updates []archive.ContractUpdate
+	if startHeight < first {
+		scanEnd := endHeight
+		if scanEnd >= first {
+			scanEnd = first - 1
+		}
+		updates, err = r.scanContractUpdates(address, name, startHeight, scanEnd, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if endHeight < first || (limit > 0 && len(updates) >= limit) {
+		return updates, nil
+	}
+
+	indexStart := startHeight
+	if indexStart < first {
+		indexStart = first
+	}
+	remaining := 0
+	if limit > 0 {
+		remaining = limit - len(updates)
+	}
+	indexed, err := r.lib2.GetContractUpdates(address, name, indexStart, endHeight, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(updates, indexed...), nil
+}
+
+// scanContractUpdates decodes the changes made to the contracts of the account
+// with the given address from the contract events within the given range of
+// heights. Contract events that can not be decoded are logged and skipped, the
+// same way the mapper skips them when indexing.
+func (r *Reader) scanContractUpdates(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+	types := contracts.EventTypes()
+	heights, err := r.lib2.GetEventHeights(types, startHeight, endHeight, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contract event heights: %w", err)
+	}
+
+	var updates []archive.ContractUpdate
+	for _, height := range heights {
+		events, err := r.lib2.GetEvents(height, types)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve contract events (height: %d): %w", height, err)
+		}
+
+		// Events are stored by type, so we restore their execution order.
+		sort.Slice(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+			return events[i].EventIndex < events[j].EventIndex
+		})
+
+		for _, event := range events {
+			update, err := contracts.Decode(height, event)
+			if err != nil {
+				r.log.Warn().
+					Err(err).
+					Uint64("height", height).
+					Hex("transaction", event.TransactionID[:]).
+					Uint32("index", event.EventIndex).
+					Msg("skipping contract event that could not be decoded")
+				continue
+			}
+			if update.Address != address || (name != "" && update.Name != name) {
+				continue
+			}
+			updates = append(updates, update)
+			if limit > 0 && len(updates) >= limit {
+				return updates, nil
+			}
+		}
+	}
+
+	return updates, nil
 }
@@ -1,15 +1,7 @@
 package index
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"sync"
-	"time"
-
-	"github.com/dgraph-io/badger/v2"
-	"github.com/hashicorp/go-multierror"
-	"golang.org/x/sync/semaphore"
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -17,65 +9,24 @@ import (
 
 	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/models/convert"
-	"github.com/onflow/flow-archive/util"
 )
 
 // Writer implements the `index.Writer` interface to write indexing data to
-// an underlying Badger database.
+// the underlying pebble-based index.
 type Writer struct {
-	sync.RWMutex
-	db   *badger.DB
-	cfg  Config
-	tx   *badger.Txn
-	sema *semaphore.Weighted
-	err  chan error
-
-	// Old badger-based index. Eventually all methods from here would migrate to lib2.
-	lib archive.WriteLibrary
-	// New pebble-based index.  Would likely consist of multiple separate pebble databases underneath.
+	// Pebble-based index, which consists of multiple separate pebble databases underneath.
 	lib2 archive.WriteLibrary2
-
-	done  chan struct{}   // signals when no more new operations will be added
-	mutex *sync.Mutex     // guards the current transaction against concurrent access
-	wg    *sync.WaitGroup // keeps track of when the flush goroutine should exit
 }
 
 // NewWriter creates a new index writer that writes new indexing data to the
-// given Badger database.
-func NewWriter(
-	db *badger.DB,
-	lib archive.WriteLibrary,
-	lib2 archive.WriteLibrary2,
-	options ...func(*Config),
-) *Writer {
-
-	cfg := DefaultConfig
-	for _, option := range options {
-		option(&cfg)
-	}
+// given library. Every write is committed to the library before returning.
+// Depending on the durability mode of the library, registers might only be
+// synced to disk later, but always before the latest register height covering
+// them is persisted.
+func NewWriter(lib2 archive.WriteLibrary2) *Writer {
 
 	w := Writer{
-		db:   db,
-		cfg:  cfg,
-		tx:   db.NewTransaction(true),
-		sema: semaphore.NewWeighted(int64(cfg.ConcurrentTransactions)),
-		err:  make(chan error, cfg.ConcurrentTransactions),
-
-		lib:  lib,
 		lib2: lib2,
-
-		done:  make(chan struct{}),
-		mutex: &sync.Mutex{},
-		wg:    &sync.WaitGroup{},
-	}
-
-	// No flush interval means that flushing is disabled, and we only commit
-	// badger transactions that are full. This optimizes throughput of writing
-	// to the database, but creates latency if transactions don't fill up fast
-	// enough to be committed at maximum size.
-	if cfg.FlushInterval > 0 {
-		w.wg.Add(1)
-		go w.flush()
 	}
 
 	return &w
@@ -83,46 +34,56 @@ func NewWriter(
 
 // First indexes the height of the first finalized block.
 func (w *Writer) First(height uint64) error {
-	return w.apply(w.lib.SaveFirst(height))
+	return w.lib2.SetFirst(height)
 }
 
 // Last indexes the height of the last finalized block.
 func (w *Writer) Last(height uint64) error {
-	return w.apply(w.lib.SaveLast(height))
+	return w.lib2.SetLast(height)
 }
 
+// LatestRegisterHeight indexes the latest height for which all registers are indexed.
+// The registers written before have to be durable before the height is
+// persisted, so depending on the durability mode of the library, this might
+// sync them to disk, or hold back the height until the next group commit.
 func (w *Writer) LatestRegisterHeight(height uint64) error {
-	return w.apply(w.lib.SaveLatestRegisterHeight(height))
+	return w.lib2.SetLatestRegisterHeight(height)
 }
 
 // Height indexes the height for the given block ID.
 func (w *Writer) Height(blockID flow.Identifier, height uint64) error {
-	return w.apply(w.lib.IndexHeightForBlock(blockID, height))
+	return w.lib2.SetHeightForBlock(blockID, height)
 }
 
 // Commit indexes the given commitment of the execution state as it was after
 // the execution of the finalized block at the given height.
 func (w *Writer) Commit(height uint64, commit flow.StateCommitment) error {
-	return w.apply(w.lib.SaveCommit(height, commit))
+	return w.lib2.SetCommit(height, commit)
 }
 
 // Header indexes the given header of a finalized block at the given height.
 func (w *Writer) Header(height uint64, header *flow.Header) error {
-	return w.apply(w.lib.SaveHeader(height, header))
+	return w.lib2.SetHeader(height, header)
 }
 
 // batch atomically writes a set of entries to the database.
 func (w *Writer) batch(height uint64, entries flow.RegisterEntries) error {
-	batch := util.NewBatch(w.db)
-
-	writeBatch := batch.GetWriter()
-	defer writeBatch.Cancel()
-
 	err := w.lib2.BatchSetPayload(height, entries)
 	if err != nil {
 		return fmt.Errorf("could not batch write registers to database at height %v: %w", height, err)
 	}
 
+	// We also index which registers were changed at this height, so that the
+	// change set of a block can be listed without knowing the registers.
+	regs := make(flow.RegisterIDs, 0, len(entries))
+	for _, entry := range entries {
+		regs = append(regs, entry.Key)
+	}
+	err = w.lib2.BatchSetRegistersForHeight(height, regs)
+	if err != nil {
+		return fmt.Errorf("could not index changed registers at height %v: %w", height, err)
+	}
+
 	return nil
 }
 
@@ -163,199 +124,50 @@ func (w *Writer) Registers(height uint64, registers []*wal.LeafNode) error {
 
 // Collections indexes the collections at the given height.
 func (w *Writer) Collections(height uint64, collections []*flow.LightCollection) error {
-
-	ops := make([]func(*badger.Txn) error, 0, 2*len(collections)+1)
-
-	collIDs := make([]flow.Identifier, 0, len(collections))
-	for _, collection := range collections {
-		collID := collection.ID()
-		collIDs = append(collIDs, collID)
-		ops = append(ops, w.lib.SaveCollection(collection))
-		ops = append(ops, w.lib.IndexTransactionsForCollection(collID, collection.Transactions))
-	}
-
-	ops = append(ops, w.lib.IndexCollectionsForHeight(height, collIDs))
-
-	return w.apply(ops...)
+	return w.lib2.BatchSetCollections(height, collections)
 }
 
 // Guarantees indexes the guarantees at the given height.
 func (w *Writer) Guarantees(_ uint64, guarantees []*flow.CollectionGuarantee) error {
-
-	ops := make([]func(*badger.Txn) error, 0, len(guarantees))
-	for _, guarantee := range guarantees {
-		ops = append(ops, w.lib.SaveGuarantee(guarantee))
-	}
-
-	return w.apply(ops...)
+	return w.lib2.BatchSetGuarantees(guarantees)
 }
 
 // Transactions indexes the transactions at the given height.
 func (w *Writer) Transactions(height uint64, transactions []*flow.TransactionBody) error {
-
-	ops := make([]func(*badger.Txn) error, 0, 2*len(transactions)+1)
-
-	txIDs := make([]flow.Identifier, 0, len(transactions))
-	for _, transaction := range transactions {
-		txID := transaction.ID()
-		txIDs = append(txIDs, txID)
-		ops = append(ops, w.lib.SaveTransaction(transaction))
-		ops = append(ops, w.lib.IndexHeightForTransaction(txID, height))
-	}
-
-	ops = append(ops, w.lib.IndexTransactionsForHeight(height, txIDs))
-
-	return w.apply(ops...)
+	return w.lib2.BatchSetTransactions(height, transactions)
 }
 
 // Results indexes the transaction results at the given height.
 func (w *Writer) Results(results []*flow.TransactionResult) error {
-
-	ops := make([]func(*badger.Txn) error, 0, len(results))
-
-	for _, result := range results {
-		ops = append(ops, w.lib.SaveResult(result))
-	}
-
-	return w.apply(ops...)
+	return w.lib2.BatchSetResults(results)
 }
 
 // Events indexes the events, which should represent all events of the finalized
 // block at the given height.
 func (w *Writer) Events(height uint64, events []flow.Event) error {
-
-	buckets := make(map[flow.EventType][]flow.Event)
-	for _, event := range events {
-		buckets[event.Type] = append(buckets[event.Type], event)
-	}
-
-	ops := make([]func(*badger.Txn) error, 0, len(buckets))
-
-	for typ, set := range buckets {
-		ops = append(ops, w.lib.SaveEvents(height, typ, set))
-	}
-
-	return w.apply(ops...)
+	return w.lib2.BatchSetEvents(height, events)
 }
 
 // Seals indexes the seals, which should represent all seals in the finalized
 // block at the given height.
 func (w *Writer) Seals(height uint64, seals []*flow.Seal) error {
-
-	ops := make([]func(*badger.Txn) error, 0, len(seals)+1)
-
-	sealIDs := make([]flow.Identifier, 0, len(seals))
-	for _, seal := range seals {
-		sealID := seal.ID()
-		sealIDs = append(sealIDs, sealID)
-		ops = append(ops, w.lib.SaveSeal(seal))
-	}
-
-	ops = append(ops, w.lib.IndexSealsForHeight(height, sealIDs))
-
-	return w.apply(ops...)
+	return w.lib2.BatchSetSeals(height, seals)
 }
 
-func (w *Writer) apply(ops ...func(*badger.Txn) error) error {
-
-	// Before applying an additional operation to the transaction we are
-	// currently building, we want to see if there was an error committing any
-	// previous transaction.
-	select {
-	case err := <-w.err:
-		return fmt.Errorf("could not commit transaction: %w", err)
-	default:
-		// skip
-	}
-
-	// If we had no error in a previous transaction, we try applying the
-	// operation to the current transaction. If the transaction is already too
-	// big, we simply commit it with our callback and start a new transaction.
-	// Transaction creation is guarded by a semaphore that limits it to the
-	// configured number of inflight transactions.
-	for _, op := range ops {
-		w.mutex.Lock()
-		err := op(w.tx)
-		if errors.Is(err, badger.ErrTxnTooBig) {
-			_ = w.sema.Acquire(context.Background(), 1)
-			w.tx.CommitWith(w.committed)
-			w.tx = w.db.NewTransaction(true)
-			err = op(w.tx)
-		}
-		w.mutex.Unlock()
-		if err != nil {
-			return fmt.Errorf("could not apply operation: %w", err)
-		}
-	}
-
-	return nil
+// Transfers indexes the token transfers, which should represent all token
+// transfers of the finalized block at the given height.
+func (w *Writer) Transfers(height uint64, transfers []archive.Transfer) error {
+	return w.lib2.BatchSetTransfers(height, transfers)
 }
 
-func (w *Writer) committed(err error) {
-
-	// When a transaction is fully committed, we get the result in this
-	// callback. In case of an error, we pipe it to the apply function through
-	// the error channel.
-	if err != nil {
-		w.err <- err
-	}
-
-	// Releasing one resource on the semaphore will free up one slot for
-	// inflight transactions.
-	w.sema.Release(1)
+// ContractUpdates indexes the changes to contracts, which should represent all
+// contract updates of the finalized block at the given height.
+func (w *Writer) ContractUpdates(height uint64, updates []archive.ContractUpdate) error {
+	return w.lib2.BatchSetContractUpdates(height, updates)
 }
 
-// Close closes the writer and commits the pending transaction, if there is one.
+// Close closes the writer. As every write is committed before returning, there
+// is nothing left to commit when closing.
 func (w *Writer) Close() error {
-
-	// Shut down the ticker that makes sure we commit after a certain time
-	// without new operations, then drain the tick channel.
-	close(w.done)
-	w.wg.Wait()
-
-	// The first transaction we created did not claim a slot on the semaphore.
-	// This makes sense, because we only want to limit in-flight (committing)
-	// transactions. The currently building transaction is not in-progress.
-	// However, we still need to make sure that the currently building
-	// transaction is properly committed. We assume that we are no longer
-	// applying new operations when we call `Close`, so we can explicitly do so
-	// here, without using the callback.
-	err := w.tx.Commit()
-	if err != nil {
-		return fmt.Errorf("could not commit final transaction: %w", err)
-	}
-
-	// Once we acquire all semaphore resources, it means all transactions have
-	// been committed. We can now close the error channel and drain any
-	// remaining errors.
-	_ = w.sema.Acquire(context.Background(), int64(w.cfg.ConcurrentTransactions))
-	close(w.err)
-	var merr *multierror.Error
-	for err := range w.err {
-		merr = multierror.Append(merr, err)
-	}
-
-	return merr.ErrorOrNil()
-}
-
-func (w *Writer) flush() {
-	defer w.wg.Done()
-
-	ticker := time.NewTicker(w.cfg.FlushInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-
-		case <-ticker.C:
-			w.mutex.Lock()
-			_ = w.sema.Acquire(context.Background(), 1)
-			w.tx.CommitWith(w.committed)
-			w.tx = w.db.NewTransaction(true)
-			w.mutex.Unlock()
-
-		case <-w.done:
-			return
-		}
-	}
+	return nil
 }
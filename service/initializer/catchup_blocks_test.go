@@ -3,12 +3,12 @@ package initializer_test
 import (
 	"testing"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/storage/badger/operation"
 
+	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/service/initializer"
 	"github.com/onflow/flow-archive/testing/helpers"
 	"github.com/onflow/flow-archive/testing/mocks"
@@ -57,7 +57,7 @@ func TestCatchupBlocks(t *testing.T) {
 
 		reader := mocks.BaselineReader(t)
 		reader.LastFunc = func() (uint64, error) {
-			return 0, badger.ErrKeyNotFound
+			return 0, archive.ErrNotFound
 		}
 
 		got, err := initializer.CatchupBlocks(db, reader)
@@ -99,7 +99,7 @@ func TestCatchupBlocks(t *testing.T) {
 
 		reader := mocks.BaselineReader(t)
 		reader.LastFunc = func() (uint64, error) {
-			return 0, badger.ErrKeyNotFound
+			return 0, archive.ErrNotFound
 		}
 
 		_, err := initializer.CatchupBlocks(db, reader)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/models/convert"
 	"github.com/onflow/flow-archive/util"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
@@ -127,6 +128,21 @@ func (i *Invoker) Account(
 	)
 }
 
+// AccountKeysAt returns the keys of the account with the given address at the
+// given height, as read from the execution state at that height.
+func (i *Invoker) AccountKeysAt(
+	ctx context.Context,
+	height uint64,
+	address flow.Address,
+) ([]archive.AccountKey, error) {
+	account, err := i.Account(ctx, height, address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get account: %w", err)
+	}
+
+	return convert.AccountKeys(account.Keys), nil
+}
+
 // Script executes the given Cadence script and returns its result.
 func (i *Invoker) Script(
 	ctx context.Context,
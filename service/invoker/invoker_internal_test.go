@@ -382,6 +382,75 @@ func TestInvoker_Account(t *testing.T) {
 	})
 }
 
+func TestInvoker_AccountKeysAt(t *testing.T) {
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		vm := mocks.BaselineVirtualMachine(t)
+		vm.GetAccountFunc = func(
+			ctx fvm.Context,
+			address flow.Address,
+			v snapshot.StorageSnapshot,
+		) (
+			*flow.Account,
+			error,
+		) {
+			assert.Equal(t, mocks.GenericAccount.Address, address)
+
+			return &mocks.GenericAccount, nil
+		}
+
+		index := mocks.BaselineReader(t)
+
+		config := DefaultConfig
+		config.NewCustomVirtualMachine = func() fvm.VM {
+			return vm
+		}
+
+		invoke, err := New(
+			zerolog.Nop(),
+			index,
+			config,
+		)
+		require.NoError(t, err)
+
+		keys, err := invoke.AccountKeysAt(
+			context.Background(),
+			mocks.GenericHeight,
+			mocks.GenericAccount.Address,
+		)
+
+		require.NoError(t, err)
+		require.Len(t, keys, len(mocks.GenericAccount.Keys))
+		assert.Equal(t, mocks.GenericAccount.Keys[0].PublicKey.Encode(), keys[0].PublicKey)
+		assert.Equal(t, mocks.GenericAccount.Keys[0].SeqNumber, keys[0].SeqNumber)
+	})
+
+	t.Run("handles unavailable block data", func(t *testing.T) {
+		t.Parallel()
+
+		index := mocks.BaselineReader(t)
+		index.LastFunc = func() (uint64, error) {
+			return mocks.GenericHeight - 1, nil
+		}
+
+		invoke, err := New(
+			zerolog.Nop(),
+			index,
+			DefaultConfig,
+		)
+		require.NoError(t, err)
+
+		_, err = invoke.AccountKeysAt(
+			context.Background(),
+			mocks.GenericHeight,
+			mocks.GenericAccount.Address,
+		)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestInvoker_ByHeightFrom(t *testing.T) {
 	t.Run("nominal case", func(t *testing.T) {
 		t.Parallel()
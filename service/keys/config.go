@@ -0,0 +1,36 @@
+package keys
+
+import (
+	"time"
+)
+
+// DefaultConfig is the default configuration for the Indexer.
+var DefaultConfig = Config{
+	Frequency: time.Minute,
+	BatchSize: 1000,
+}
+
+// Config contains optional parameters for the Indexer.
+type Config struct {
+	Frequency time.Duration
+	BatchSize uint64
+}
+
+// Option is an option that can be given to the indexer to configure optional
+// parameters on initialization.
+type Option func(*Config)
+
+// WithFrequency sets how often the indexer looks for new key changes.
+func WithFrequency(frequency time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.Frequency = frequency
+	}
+}
+
+// WithBatchSize sets the number of heights the indexer scans for key changes
+// before it persists them together with its progress.
+func WithBatchSize(size uint64) Option {
+	return func(cfg *Config) {
+		cfg.BatchSize = size
+	}
+}
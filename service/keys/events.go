@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// keyEventTypes returns the types of the protocol events emitted when a key is
+// added to or removed from an account.
+func keyEventTypes() []flow.EventType {
+	return []flow.EventType{
+		archive.EventAccountKeyAdded,
+		archive.EventAccountKeyRemoved,
+	}
+}
+
+// decodeKeyAddress decodes the address of the account whose keys changed from
+// the given key event. Only the address is decoded, as the type of the public
+// key field differs between versions of the events.
+func decodeKeyAddress(event flow.Event) (flow.Address, error) {
+	value, err := json.Decode(nil, event.Payload)
+	if err != nil {
+		return flow.EmptyAddress, fmt.Errorf("could not decode payload: %w", err)
+	}
+	ev, ok := value.(cadence.Event)
+	if !ok || ev.EventType == nil || len(ev.Fields) != len(ev.EventType.Fields) {
+		return flow.EmptyAddress, fmt.Errorf("invalid payload (%T)", value)
+	}
+
+	for i, field := range ev.EventType.Fields {
+		if field.Identifier != "address" {
+			continue
+		}
+		address, ok := ev.Fields[i].(cadence.Address)
+		if !ok {
+			return flow.EmptyAddress, fmt.Errorf("invalid address type (%T)", ev.Fields[i])
+		}
+		return flow.Address(address), nil
+	}
+
+	return flow.EmptyAddress, fmt.Errorf("missing account address")
+}
@@ -0,0 +1,224 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/access"
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Indexer periodically indexes the keys of the accounts whose keys were added
+// or removed in the finalized blocks indexed since its last run. The key
+// events only identify the account, so the keys are read from the execution
+// state at the height of each change through the invoker.
+type Indexer struct {
+	log    zerolog.Logger
+	cfg    Config
+	read   archive.Reader
+	invoke access.Invoker
+	lib2   archive.Library2
+}
+
+// New creates a new key indexer, which finds the key events through the given
+// reader, reads the changed keys through the given invoker and stores them in
+// the given library.
+func New(log zerolog.Logger, read archive.Reader, invoke access.Invoker, lib2 archive.Library2, options ...Option) *Indexer {
+
+	cfg := DefaultConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	x := Indexer{
+		log:    log.With().Str("component", "key_indexer").Logger(),
+		cfg:    cfg,
+		read:   read,
+		invoke: invoke,
+		lib2:   lib2,
+	}
+
+	return &x
+}
+
+// Run indexes the key changes at the configured frequency, until the given
+// context is canceled. A failed run is logged and retried on the next tick.
+func (x *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(x.cfg.Frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := x.Index(ctx)
+		if err != nil {
+			x.log.Warn().Err(err).Msg("could not index account keys")
+		}
+	}
+}
+
+// Index indexes the key changes from the height after the last indexed one up
+// to the latest height at which both blocks and registers are indexed. Each
+// batch of heights is persisted together with the progress, so an interrupted
+// run resumes after the last complete batch.
+func (x *Indexer) Index(ctx context.Context) error {
+
+	start, err := x.start()
+	if err != nil {
+		return fmt.Errorf("could not get start height: %w", err)
+	}
+	end, err := x.end()
+	if err != nil {
+		return fmt.Errorf("could not get end height: %w", err)
+	}
+
+	for start <= end {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		batchEnd := end
+		if x.cfg.BatchSize > 0 && end-start >= x.cfg.BatchSize {
+			batchEnd = start + x.cfg.BatchSize - 1
+		}
+
+		err = x.indexBatch(ctx, start, batchEnd)
+		if err != nil {
+			return fmt.Errorf("could not index account keys (start: %d, end: %d): %w", start, batchEnd, err)
+		}
+
+		start = batchEnd + 1
+	}
+
+	return nil
+}
+
+// start returns the first height that has not been indexed yet. Heights whose
+// registers were pruned can not be read anymore, so they are skipped.
+func (x *Indexer) start() (uint64, error) {
+
+	first, err := x.read.First()
+	if err != nil {
+		return 0, fmt.Errorf("could not get first height: %w", err)
+	}
+	retention, err := x.read.RegisterRetention()
+	if err != nil {
+		return 0, fmt.Errorf("could not get register retention: %w", err)
+	}
+	if retention.Height > first {
+		first = retention.Height
+	}
+
+	latest, err := x.lib2.GetLatestAccountKeysHeight()
+	if errors.Is(err, archive.ErrNotFound) {
+		return first, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not get latest account keys height: %w", err)
+	}
+	if latest+1 < first {
+		x.log.Warn().
+			Uint64("latest", latest).
+			Uint64("first", first).
+			Msg("skipping account key changes at pruned heights")
+		return first, nil
+	}
+
+	return latest + 1, nil
+}
+
+// end returns the last height at which both the block data and the registers
+// are indexed, as both are needed to read the keys of an account.
+func (x *Indexer) end() (uint64, error) {
+
+	last, err := x.read.Last()
+	if err != nil {
+		return 0, fmt.Errorf("could not get last height: %w", err)
+	}
+	latest, err := x.read.LatestRegisterHeight()
+	if err != nil {
+		return 0, fmt.Errorf("could not get latest register height: %w", err)
+	}
+	if latest < last {
+		return latest, nil
+	}
+
+	return last, nil
+}
+
+// indexBatch indexes the key changes within the given range of heights. Key
+// events that can not be decoded are logged and skipped, so that they do not
+// block the indexing of all later heights.
+func (x *Indexer) indexBatch(ctx context.Context, start uint64, end uint64) error {
+
+	heights, err := x.read.EventHeights(keyEventTypes(), start, end, 0)
+	if err != nil {
+		return fmt.Errorf("could not get key event heights: %w", err)
+	}
+
+	var changes []archive.AccountKeys
+	for _, height := range heights {
+		events, err := x.read.Events(height, keyEventTypes()...)
+		if err != nil {
+			return fmt.Errorf("could not get key events (height: %d): %w", height, err)
+		}
+
+		// An account can have several keys changed in the same block, but only
+		// the keys after the block are indexed.
+		seen := make(map[flow.Address]struct{})
+		for _, event := range events {
+			address, err := decodeKeyAddress(event)
+			if err != nil {
+				x.log.Warn().
+					Err(err).
+					Uint64("height", height).
+					Hex("transaction", event.TransactionID[:]).
+					Uint32("index", event.EventIndex).
+					Str("type", string(event.Type)).
+					Msg("skipping key event that could not be decoded")
+				continue
+			}
+			_, ok := seen[address]
+			if ok {
+				continue
+			}
+			seen[address] = struct{}{}
+
+			keys, err := x.invoke.AccountKeysAt(ctx, height, address)
+			if err != nil {
+				return fmt.Errorf("could not get account keys (height: %d, address: %s): %w", height, address, err)
+			}
+
+			changes = append(changes, archive.AccountKeys{
+				Height:  height,
+				Address: address,
+				Keys:    keys,
+			})
+		}
+	}
+
+	err = x.lib2.BatchSetAccountKeys(end, changes)
+	if err != nil {
+		return fmt.Errorf("could not persist account keys: %w", err)
+	}
+
+	x.log.Debug().
+		Uint64("start", start).
+		Uint64("end", end).
+		Int("changes", len(changes)).
+		Msg("account keys indexed")
+
+	return nil
+}
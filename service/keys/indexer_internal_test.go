@@ -0,0 +1,204 @@
+package keys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestIndexer_Index(t *testing.T) {
+	events := mocks.GenericAccountKeyEvents(3)
+	keys := mocks.GenericAccountKeys(2)
+
+	changes := []archive.AccountKeys{
+		{Height: 15, Address: mocks.GenericAddress(0), Keys: keys},
+		{Height: 15, Address: mocks.GenericAddress(1), Keys: keys},
+	}
+
+	invalid := events[0]
+	invalid.Payload = mocks.GenericBytes
+
+	tests := []struct {
+		name string
+
+		mockLatest    uint64
+		mockLatestErr error
+		mockRetention archive.RegisterRetention
+		mockEvents    []flow.Event
+		mockEventsErr error
+		mockKeysErr   error
+		mockSetErr    error
+
+		wantBatches []uint64
+		wantChanges []archive.AccountKeys
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			mockLatest: 10,
+			mockEvents: events,
+
+			wantBatches: []uint64{20, 25},
+			wantChanges: changes,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "starts at first height on first run",
+
+			mockLatestErr: archive.ErrNotFound,
+			mockEvents:    events,
+
+			wantBatches: []uint64{10, 20, 25},
+			wantChanges: changes,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "skips pruned heights",
+
+			mockLatest:    5,
+			mockRetention: archive.RegisterRetention{Height: 21},
+
+			wantBatches: []uint64{25},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "skips when up to date",
+
+			mockLatest: 25,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles latest account keys height failure",
+
+			mockLatestErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles events failure",
+
+			mockLatest:    10,
+			mockEventsErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "skips invalid key event",
+
+			mockLatest: 10,
+			mockEvents: append([]flow.Event{invalid}, events...),
+
+			wantBatches: []uint64{20, 25},
+			wantChanges: changes,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles account keys failure",
+
+			mockLatest:  10,
+			mockEvents:  events,
+			mockKeysErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles persist failure",
+
+			mockLatest: 10,
+			mockSetErr: mocks.GenericError,
+
+			wantBatches: []uint64{20},
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			read := mocks.BaselineReader(t)
+			read.FirstFunc = func() (uint64, error) {
+				return 1, nil
+			}
+			read.LastFunc = func() (uint64, error) {
+				return 30, nil
+			}
+			read.LatestRegisterHeightFunc = func() (uint64, error) {
+				return 25, nil
+			}
+			read.RegisterRetentionFunc = func() (archive.RegisterRetention, error) {
+				return test.mockRetention, nil
+			}
+			read.EventHeightsFunc = func(types []flow.EventType, startHeight uint64, endHeight uint64, _ int) ([]uint64, error) {
+				assert.ElementsMatch(t, keyEventTypes(), types)
+				if startHeight <= 15 && 15 <= endHeight {
+					return []uint64{15}, nil
+				}
+				return nil, nil
+			}
+			read.EventsFunc = func(height uint64, types ...flow.EventType) ([]flow.Event, error) {
+				assert.Equal(t, uint64(15), height)
+				assert.ElementsMatch(t, keyEventTypes(), types)
+				return test.mockEvents, test.mockEventsErr
+			}
+
+			invoke := mocks.BaselineInvoker(t)
+			invoke.AccountKeysAtFunc = func(_ context.Context, height uint64, _ flow.Address) ([]archive.AccountKey, error) {
+				assert.Equal(t, uint64(15), height)
+				return keys, test.mockKeysErr
+			}
+
+			var gotBatches []uint64
+			var gotChanges []archive.AccountKeys
+			lib2 := &libraryMock{
+				GetLatestAccountKeysHeightFunc: func() (uint64, error) {
+					return test.mockLatest, test.mockLatestErr
+				},
+				BatchSetAccountKeysFunc: func(height uint64, changes []archive.AccountKeys) error {
+					gotBatches = append(gotBatches, height)
+					gotChanges = append(gotChanges, changes...)
+					return test.mockSetErr
+				},
+			}
+
+			x := New(zerolog.Nop(), read, invoke, lib2, WithBatchSize(10))
+
+			err := x.Index(context.Background())
+
+			test.checkErr(t, err)
+			assert.Equal(t, test.wantBatches, gotBatches)
+			assert.Equal(t, test.wantChanges, gotChanges)
+		})
+	}
+}
+
+type libraryMock struct {
+	archive.Library2
+
+	GetLatestAccountKeysHeightFunc func() (uint64, error)
+	BatchSetAccountKeysFunc        func(height uint64, changes []archive.AccountKeys) error
+}
+
+func (l *libraryMock) GetLatestAccountKeysHeight() (uint64, error) {
+	return l.GetLatestAccountKeysHeightFunc()
+}
+
+func (l *libraryMock) BatchSetAccountKeys(height uint64, changes []archive.AccountKeys) error {
+	return l.BatchSetAccountKeysFunc(height, changes)
+}
@@ -0,0 +1,11 @@
+package mapper
+
+import (
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// BulkLoader represents something that creates loaders, which bulk load all
+// registers of a height into the index in one go.
+type BulkLoader interface {
+	NewRegisterLoader(height uint64) (archive.RegisterLoader, error)
+}
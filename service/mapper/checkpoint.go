@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Layout of the V6 checkpoint files written by flow-go. The registers are
+// spread over one part file per subtrie, each of which ends with the number of
+// nodes it contains, followed by a checksum.
+const (
+	checkpointSubtries  = 16
+	checkpointCountSize = 8
+	checkpointSumSize   = 4
+)
+
+// estimateLeaves estimates the number of leaves in the given V6 checkpoint from
+// the node counts in the footers of its part files. Each subtrie is a binary
+// tree in which every interior node has two children, so it holds one more
+// leaf than it holds interior nodes.
+func estimateLeaves(dir string, fileName string) (uint64, error) {
+
+	total := uint64(0)
+	for i := 0; i < checkpointSubtries; i++ {
+		name := filepath.Join(dir, fmt.Sprintf("%s.%03d", fileName, i))
+		nodes, err := readNodeCount(name)
+		if err != nil {
+			return 0, fmt.Errorf("could not read node count (file: %s): %w", name, err)
+		}
+		total += (nodes + 1) / 2
+	}
+
+	return total, nil
+}
+
+// readNodeCount reads the node count from the footer of a checkpoint part file.
+func readNodeCount(name string) (uint64, error) {
+
+	file, err := os.Open(name)
+	if err != nil {
+		return 0, fmt.Errorf("could not open file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.Seek(-(checkpointCountSize + checkpointSumSize), io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("could not seek footer: %w", err)
+	}
+
+	footer := make([]byte, checkpointCountSize)
+	_, err = io.ReadFull(file, footer)
+	if err != nil {
+		return 0, fmt.Errorf("could not read footer: %w", err)
+	}
+
+	return binary.BigEndian.Uint64(footer), nil
+}
@@ -1,21 +1,54 @@
 package mapper
 
 import (
+	"fmt"
 	"time"
 )
 
 // DefaultConfig is the default configuration for the Mapper.
 var DefaultConfig = Config{
-	BootstrapState: false,
-	SkipRegisters:  false,
-	WaitInterval:   10 * time.Millisecond,
+	BootstrapState:     false,
+	BootstrapWorkers:   10,
+	BootstrapBatchSize: 1000,
+	BootstrapProgress:  nil,
+	BulkLoader:         nil,
+	SkipRegisters:      false,
+	WaitInterval:       10 * time.Millisecond,
+	Metrics:            nil,
+	Verifier:           nil,
+	Transfers:          nil,
+	Contracts:          nil,
+	Seed:               nil,
+	SeedHeight:         0,
 }
 
 // Config contains optional parameters for the Mapper.
 type Config struct {
-	BootstrapState bool
-	SkipRegisters  bool
-	WaitInterval   time.Duration
+	BootstrapState     bool
+	BootstrapWorkers   int
+	BootstrapBatchSize int
+	BootstrapProgress  BootstrapProgress
+	BulkLoader         BulkLoader
+	SkipRegisters      bool
+	WaitInterval       time.Duration
+	Metrics            Metrics
+	Verifier           Verifier
+	Transfers          Transfers
+	Contracts          Contracts
+	Seed               Seed
+	SeedHeight         uint64
+}
+
+// validate checks that the parameters used to bootstrap the state can be used
+// to import the registers of the root checkpoint.
+func (c Config) validate() error {
+	if c.BootstrapWorkers <= 0 {
+		return fmt.Errorf("invalid number of bootstrap workers (%d)", c.BootstrapWorkers)
+	}
+	if c.BootstrapBatchSize <= 0 {
+		return fmt.Errorf("invalid bootstrap batch size (%d)", c.BootstrapBatchSize)
+	}
+	return nil
 }
 
 // Option is an option that can be given to the mapper to configure optional
@@ -30,6 +63,42 @@ func WithBootstrapState(bootstrap bool) Option {
 	}
 }
 
+// WithBootstrapWorkers sets the number of workers that concurrently write the
+// registers of the root checkpoint to the index while bootstrapping.
+func WithBootstrapWorkers(workers int) Option {
+	return func(cfg *Config) {
+		cfg.BootstrapWorkers = workers
+	}
+}
+
+// WithBootstrapBatchSize sets the number of registers of the root checkpoint
+// that are written to the index in a single batch while bootstrapping.
+func WithBootstrapBatchSize(size int) Option {
+	return func(cfg *Config) {
+		cfg.BootstrapBatchSize = size
+	}
+}
+
+// WithBootstrapProgress makes the mapper persist how far it got with importing
+// the root checkpoint, using the given progress store. When bootstrapping is
+// interrupted, the next run then skips the registers that were already written.
+// If not set, an interrupted bootstrap starts over from the beginning.
+func WithBootstrapProgress(progress BootstrapProgress) Option {
+	return func(cfg *Config) {
+		cfg.BootstrapProgress = progress
+	}
+}
+
+// WithBulkLoader makes the mapper bulk load the registers of the root checkpoint
+// with the given loader, instead of writing them in batches. As nothing is
+// written until all registers were read, the progress of a bulk load is not
+// persisted, and an interrupted bulk load starts over.
+func WithBulkLoader(loader BulkLoader) Option {
+	return func(cfg *Config) {
+		cfg.BulkLoader = loader
+	}
+}
+
 // WithSkipRegisters makes the mapper skip indexing of all ledger registers,
 // which speeds up the run significantly and can be used for debugging purposes.
 func WithSkipRegisters(skip bool) Option {
@@ -45,3 +114,49 @@ func WithWaitInterval(interval time.Duration) Option {
 		cfg.WaitInterval = interval
 	}
 }
+
+// WithMetrics makes the mapper record the progress of importing the root
+// checkpoint with the given metrics. If not set, no metrics are recorded.
+func WithMetrics(metrics Metrics) Option {
+	return func(cfg *Config) {
+		cfg.Metrics = metrics
+	}
+}
+
+// WithVerifier makes the mapper verify the registers imported while
+// bootstrapping against the state commitment of the root block, using the given
+// verifier. If not set, the imported registers are not verified.
+func WithVerifier(verifier Verifier) Option {
+	return func(cfg *Config) {
+		cfg.Verifier = verifier
+	}
+}
+
+// WithTransfers makes the mapper index the token transfers of each block, as
+// extracted from its events by the given component. If not set, no token
+// transfers are indexed.
+func WithTransfers(transfers Transfers) Option {
+	return func(cfg *Config) {
+		cfg.Transfers = transfers
+	}
+}
+
+// WithContracts makes the mapper index the changes made to contracts in each
+// block, as decoded from its events by the given component. If not set, no
+// contract updates are indexed.
+func WithContracts(contracts Contracts) Option {
+	return func(cfg *Config) {
+		cfg.Contracts = contracts
+	}
+}
+
+// WithSeed makes the mapper bootstrap the state from the registers of the
+// given seed at the given height, instead of from a root checkpoint. Indexing
+// then starts at that height instead of the root height of the chain, so the
+// same seed height has to be given whenever the mapper is restarted.
+func WithSeed(seed Seed, height uint64) Option {
+	return func(cfg *Config) {
+		cfg.Seed = seed
+		cfg.SeedHeight = height
+	}
+}
@@ -5,8 +5,50 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-archive/testing/mocks"
 )
 
+func TestWithBootstrapWorkers(t *testing.T) {
+	c := &Config{
+		BootstrapWorkers: 10,
+	}
+	workers := 3
+
+	WithBootstrapWorkers(workers)(c)
+
+	assert.Equal(t, workers, c.BootstrapWorkers)
+}
+
+func TestWithBootstrapBatchSize(t *testing.T) {
+	c := &Config{
+		BootstrapBatchSize: 1000,
+	}
+	size := 250
+
+	WithBootstrapBatchSize(size)(c)
+
+	assert.Equal(t, size, c.BootstrapBatchSize)
+}
+
+func TestWithBootstrapProgress(t *testing.T) {
+	c := &Config{}
+	progress := mocks.BaselineBootstrapProgress(t)
+
+	WithBootstrapProgress(progress)(c)
+
+	assert.Same(t, progress, c.BootstrapProgress)
+}
+
+func TestWithBulkLoader(t *testing.T) {
+	c := &Config{}
+	loader := mocks.BaselineBulkLoader(t)
+
+	WithBulkLoader(loader)(c)
+
+	assert.Same(t, loader, c.BulkLoader)
+}
+
 func TestWithSkipRegisters(t *testing.T) {
 	c := Config{
 		SkipRegisters: false,
@@ -28,3 +70,41 @@ func TestWithIndexHeader(t *testing.T) {
 
 	assert.Equal(t, interval, c.WaitInterval)
 }
+
+func TestWithMetrics(t *testing.T) {
+	c := &Config{}
+	metrics := mocks.BaselineBootstrapMetrics(t)
+
+	WithMetrics(metrics)(c)
+
+	assert.Same(t, metrics, c.Metrics)
+}
+
+func TestWithVerifier(t *testing.T) {
+	c := &Config{}
+	verifier := mocks.BaselineVerifier(t)
+
+	WithVerifier(verifier)(c)
+
+	assert.Same(t, verifier, c.Verifier)
+}
+
+func TestWithContracts(t *testing.T) {
+	c := &Config{}
+	contracts := mocks.BaselineContracts(t)
+
+	WithContracts(contracts)(c)
+
+	assert.Same(t, contracts, c.Contracts)
+}
+
+func TestWithSeed(t *testing.T) {
+	c := &Config{}
+	seed := mocks.BaselineSeed(t)
+	height := uint64(42)
+
+	WithSeed(seed, height)(c)
+
+	assert.Same(t, seed, c.Seed)
+	assert.Equal(t, height, c.SeedHeight)
+}
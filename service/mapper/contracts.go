@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Contracts represents something that decodes the changes made to the
+// contracts of accounts in a block from the events emitted by its transactions.
+type Contracts interface {
+	Updates(height uint64, events []flow.Event) ([]archive.ContractUpdate, error)
+}
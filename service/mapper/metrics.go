@@ -0,0 +1,8 @@
+package mapper
+
+// Metrics represents something that records the progress of importing the
+// root checkpoint, as the number of leaves processed so far, the estimated
+// total number of leaves and the number of leaves written per second.
+type Metrics interface {
+	Progress(processed uint64, estimated uint64, rate float64)
+}
@@ -0,0 +1,8 @@
+package mapper
+
+// BootstrapProgress represents something that persists the number of leaves
+// of the root checkpoint that were fully written to the index at a height.
+type BootstrapProgress interface {
+	GetBootstrapProgress(height uint64) (uint64, error)
+	SetBootstrapProgress(height uint64, leaves uint64) error
+}
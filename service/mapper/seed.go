@@ -0,0 +1,12 @@
+package mapper
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Seed represents a source of the registers of the execution state at a given
+// height, which can be used to bootstrap the index instead of a root
+// checkpoint.
+type Seed interface {
+	Registers(height uint64, fn func(flow.RegisterEntry) error) error
+}
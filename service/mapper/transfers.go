@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Transfers represents something that extracts the token transfers of a block
+// from the events emitted by its transactions.
+type Transfers interface {
+	Transfers(height uint64, events []flow.Event) ([]archive.Transfer, error)
+}
@@ -7,12 +7,14 @@ import (
 	"sync"
 	"time"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/models/convert"
+	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
 )
 
 // TransitionFunc is a function that is applied onto the state machine's
@@ -64,7 +66,7 @@ func (t *Transitions) InitializeMapper(s *State) error {
 
 	isBootstrapped := false // default
 
-	first, err := t.chain.Root()
+	first, err := t.root()
 	if err != nil {
 		return fmt.Errorf("could not get root height: %w", err)
 	}
@@ -72,7 +74,7 @@ func (t *Transitions) InitializeMapper(s *State) error {
 	last, err := t.read.Last()
 	if err == nil {
 		isBootstrapped = last >= first
-	} else if !errors.Is(err, badger.ErrKeyNotFound) {
+	} else if !errors.Is(err, archive.ErrNotFound) {
 		return fmt.Errorf("could not get last height: %w", err)
 	}
 
@@ -93,14 +95,19 @@ func (t *Transitions) InitializeMapper(s *State) error {
 	return nil
 }
 
-// BootstrapState bootstraps the state by loading the checkpoint if there is one
-// and initializing the elements subsequently used by the FSM.
+// BootstrapState bootstraps the state by loading the checkpoint if there is one,
+// or the registers of the seed if one is configured, and initializing the
+// elements subsequently used by the FSM.
 func (t *Transitions) BootstrapState(s *State) error {
 	if s.status != StatusBootstrap {
 		return fmt.Errorf("invalid status for bootstrapping state (%s)", s.status)
 	}
+	err := t.cfg.validate()
+	if err != nil {
+		return fmt.Errorf("invalid bootstrap configuration: %w", err)
+	}
 
-	first, err := t.chain.Root()
+	first, err := t.root()
 	if err != nil {
 		return fmt.Errorf("could not get root height: %w", err)
 	}
@@ -111,22 +118,150 @@ func (t *Transitions) BootstrapState(s *State) error {
 
 	log := t.log.With().Uint64("height", s.height).Logger()
 
+	switch {
+	case t.cfg.Seed != nil:
+		err = t.importSeed(s)
+	case t.cfg.BulkLoader != nil:
+		err = t.loadCheckpoint(s)
+	default:
+		err = t.importCheckpoint(s)
+	}
+	if err != nil {
+		return err
+	}
+
+	// If requested, make sure that the imported registers actually hash to the
+	// state commitment of the root block before we start indexing on top of it.
+	if t.cfg.Verifier != nil {
+		commit, err := t.chain.Commit(s.height)
+		if err != nil {
+			return fmt.Errorf("could not get root commit: %w", err)
+		}
+		err = t.cfg.Verifier.Verify(s.height, commit)
+		if err != nil {
+			return fmt.Errorf("could not verify imported registers: %w", err)
+		}
+
+		log.Info().Hex("commit", commit[:]).Msg("imported registers verified against root commit")
+	}
+
+	// We have successfully bootstrapped. However, no chain data for the root
+	// block has been indexed yet. This is why we "pretend" that we just
+	// forwarded the state to this height, so we go straight to the chain data
+	// indexing.
+	s.status = StatusResume // StatusResume will save the height
+
+	// we have imported all payloads, we can skip the StatusCollect and StatusMap
+	// status which is only needed after the bootstrap
+	// since t.updates.AllUpdates() returns nil for bootstrap case, we will do nothing
+	// in the StatusCollect and StatusMap status, which is equivalent to skipping
+
+	return nil
+}
+
+// root returns the height at which indexing starts, which is the root height of
+// the chain, unless the index is bootstrapped from a seed.
+func (t *Transitions) root() (uint64, error) {
+	if t.cfg.Seed != nil {
+		return t.cfg.SeedHeight, nil
+	}
+	return t.chain.Root()
+}
+
+// importSeed imports the registers of the seed at the height of the state.
+func (t *Transitions) importSeed(s *State) error {
+	log := t.log.With().Uint64("height", s.height).Logger()
+
+	log.Info().Msg("bootstrap with registers from seed")
+
+	batchSize := 1000
+	payloads := make([]*ledger.Payload, 0, batchSize)
+	total := 0
+
+	err := t.cfg.Seed.Registers(s.height, func(entry flow.RegisterEntry) error {
+		key := state.RegisterIDToKey(entry.Key)
+		payloads = append(payloads, ledger.NewPayload(key, entry.Value))
+		if len(payloads) < batchSize {
+			return nil
+		}
+
+		err := t.write.Payloads(s.height, payloads)
+		if err != nil {
+			return fmt.Errorf("could not index registers: %w", err)
+		}
+		total += len(payloads)
+		payloads = payloads[:0]
+
+		log.Debug().Int("registers", total).Msg("imported registers from seed")
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not import registers from seed: %w", err)
+	}
+
+	if len(payloads) > 0 {
+		err = t.write.Payloads(s.height, payloads)
+		if err != nil {
+			return fmt.Errorf("could not index registers: %w", err)
+		}
+		total += len(payloads)
+	}
+
+	log.Info().Int("registers", total).Msg("finished importing registers from seed")
+
+	return nil
+}
+
+// importCheckpoint imports the registers of the root checkpoint of the state.
+//
+// The leaves of a checkpoint are always read in the same order. If progress is
+// persisted, the number of leaves that were fully written is stored after every
+// batch, so that a restart after an interruption can skip them.
+func (t *Transitions) importCheckpoint(s *State) error {
+	log := t.log.With().Uint64("height", s.height).Logger()
+
 	log.Info().Msgf("bootstrap with checkpoint file %v%v", s.checkpointDir, s.checkpointFileName)
 
+	skip := uint64(0)
+	if t.cfg.BootstrapProgress != nil {
+		written, err := t.cfg.BootstrapProgress.GetBootstrapProgress(s.height)
+		if err != nil && !errors.Is(err, archive.ErrNotFound) {
+			return fmt.Errorf("could not get bootstrap progress: %w", err)
+		}
+		skip = written
+	}
+	if skip > 0 {
+		log.Info().Uint64("skipped", skip).Msg("resuming bootstrap with leaves written by previous run")
+	}
+
+	// The estimate is only used to report progress, so we can do without it.
+	estimated, err := estimateLeaves(s.checkpointDir, s.checkpointFileName)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not estimate number of leaves in checkpoint")
+	}
+
 	// read leaf will be blocked if the consumer is not processing the leaf nodes fast
 	// enough, which also help limit the amount of memory being used for holding unprocessed
 	// leaf nodes.
 	bufSize := 1000
 	leafNodesCh := make(chan *wal.LeafNode, bufSize)
 
-	batchSize := 1000
+	batchSize := t.cfg.BootstrapBatchSize
 	batch := make([]*wal.LeafNode, 0, batchSize)
-	total := 0
+	total := uint64(0)
 
 	log.Info().Msgf("start processing leaf nodes with batchSize: %v", batchSize)
 
-	nWorker := 10
-	jobs := make(chan []*wal.LeafNode, nWorker)
+	// Batches are numbered in the order in which their leaves were read, so
+	// that we know which leaves were written once the batches are done.
+	type leafBatch struct {
+		index  uint64
+		leaves []*wal.LeafNode
+	}
+
+	nWorker := t.cfg.BootstrapWorkers
+	jobs := make(chan leafBatch, nWorker)
 
 	workerCtx, workerCancel := context.WithCancel(context.Background())
 	defer workerCancel()
@@ -134,19 +269,24 @@ func (t *Transitions) BootstrapState(s *State) error {
 	go func() {
 		defer close(jobs)
 
+		index := uint64(0)
 		for leafNode := range leafNodesCh {
 			total++
+			if total <= skip {
+				continue
+			}
 			batch = append(batch, leafNode)
 
 			// save registers in batch, which could result better speed
 			if len(batch) >= batchSize {
-				jobs <- batch
+				jobs <- leafBatch{index: index, leaves: batch}
+				index++
 				batch = make([]*wal.LeafNode, 0, batchSize)
 			}
 		}
 
 		if len(batch) > 0 {
-			jobs <- batch
+			jobs <- leafBatch{index: index, leaves: batch}
 		}
 	}()
 
@@ -164,6 +304,49 @@ func (t *Transitions) BootstrapState(s *State) error {
 		close(doneRead)
 	}()
 
+	// Workers finish their batches out of order, so the progress can only move
+	// up to the end of the last batch that has no unfinished batch before it.
+	var mu sync.Mutex
+	finished := make(map[uint64]int)
+	next := uint64(0)
+	written := skip
+	processed := skip
+	start := time.Now()
+	complete := func(b leafBatch) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		processed += uint64(len(b.leaves))
+		if t.cfg.Metrics != nil {
+			rate := float64(processed-skip) / time.Since(start).Seconds()
+			t.cfg.Metrics.Progress(processed, estimated, rate)
+		}
+
+		finished[b.index] = len(b.leaves)
+		advanced := false
+		for {
+			size, ok := finished[next]
+			if !ok {
+				break
+			}
+			delete(finished, next)
+			written += uint64(size)
+			next++
+			advanced = true
+		}
+
+		if !advanced || t.cfg.BootstrapProgress == nil {
+			return nil
+		}
+
+		err := t.cfg.BootstrapProgress.SetBootstrapProgress(s.height, written)
+		if err != nil {
+			return fmt.Errorf("could not set bootstrap progress: %w", err)
+		}
+
+		return nil
+	}
+
 	// wait for all workers to finish in order to close the results channel
 	var wg sync.WaitGroup
 	wg.Add(nWorker)
@@ -180,7 +363,10 @@ func (t *Transitions) BootstrapState(s *State) error {
 
 			// process batches from jobs channel
 			for batch := range jobs {
-				err := t.write.Registers(s.height, batch)
+				err := t.write.Registers(s.height, batch.leaves)
+				if err == nil {
+					err = complete(batch)
+				}
 
 				if err != nil {
 					workerErrors <- err
@@ -220,16 +406,110 @@ func (t *Transitions) BootstrapState(s *State) error {
 
 	log.Debug().Msgf("finish importing payloads to storage for height %v, %v payloads", s.height, total)
 
-	// We have successfully bootstrapped. However, no chain data for the root
-	// block has been indexed yet. This is why we "pretend" that we just
-	// forwarded the state to this height, so we go straight to the chain data
-	// indexing.
-	s.status = StatusResume // StatusResume will save the height
+	return nil
+}
 
-	// we have imported all payloads, we can skip the StatusCollect and StatusMap
-	// status which is only needed after the bootstrap
-	// since t.updates.AllUpdates() returns nil for bootstrap case, we will do nothing
-	// in the StatusCollect and StatusMap status, which is equivalent to skipping
+// loadCheckpoint bulk loads the registers of the root checkpoint of the state.
+func (t *Transitions) loadCheckpoint(s *State) error {
+	log := t.log.With().Uint64("height", s.height).Logger()
+
+	log.Info().Msgf("bulk load with checkpoint file %v%v", s.checkpointDir, s.checkpointFileName)
+
+	// The estimate is only used to report progress, so we can do without it.
+	estimated, err := estimateLeaves(s.checkpointDir, s.checkpointFileName)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not estimate number of leaves in checkpoint")
+	}
+
+	loader, err := t.cfg.BulkLoader.NewRegisterLoader(s.height)
+	if err != nil {
+		return fmt.Errorf("could not create register loader: %w", err)
+	}
+	defer func() {
+		err := loader.Close()
+		if err != nil {
+			log.Warn().Err(err).Msg("could not close register loader")
+		}
+	}()
+
+	leafNodesCh := make(chan *wal.LeafNode, 1000)
+	doneRead := make(chan error, 1)
+	go func() {
+		doneRead <- wal.OpenAndReadLeafNodesFromCheckpointV6(leafNodesCh, s.checkpointDir, s.checkpointFileName, &t.log)
+	}()
+
+	// If we stop consuming leaves early, the remaining ones are drained so that
+	// the reader does not block forever.
+	drain := func() {
+		go func() {
+			for range leafNodesCh {
+			}
+		}()
+	}
+
+	batchSize := t.cfg.BootstrapBatchSize
+	entries := make(flow.RegisterEntries, 0, batchSize)
+	processed := uint64(0)
+	start := time.Now()
+	add := func() error {
+		err := loader.Add(entries)
+		if err != nil {
+			return fmt.Errorf("could not add registers: %w", err)
+		}
+		processed += uint64(len(entries))
+		entries = entries[:0]
+
+		if t.cfg.Metrics != nil {
+			rate := float64(processed) / time.Since(start).Seconds()
+			t.cfg.Metrics.Progress(processed, estimated, rate)
+		}
+
+		return nil
+	}
+
+	for leafNode := range leafNodesCh {
+		key, err := leafNode.Payload.Key()
+		if err != nil {
+			drain()
+			return fmt.Errorf("could not get key from register payload: %w", err)
+		}
+		regID, err := convert.KeyToRegisterID(key)
+		if err != nil {
+			drain()
+			return fmt.Errorf("could not get register ID from key: %w", err)
+		}
+		entries = append(entries, flow.RegisterEntry{Key: regID, Value: leafNode.Payload.Value()})
+		if len(entries) < batchSize {
+			continue
+		}
+
+		err = add()
+		if err != nil {
+			drain()
+			return err
+		}
+	}
+
+	err = <-doneRead
+	if err != nil {
+		return fmt.Errorf("fail to read leaf node: %w", err)
+	}
+
+	if len(entries) > 0 {
+		err = add()
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info().Uint64("registers", processed).Msg("ingesting bulk loaded registers")
+
+	err = loader.Finish()
+	if err != nil {
+		return fmt.Errorf("could not ingest registers: %w", err)
+	}
+
+	log.Info().Uint64("registers", processed).Dur("duration", time.Since(start)).Msg("finished bulk loading registers")
 
 	return nil
 }
@@ -247,7 +527,7 @@ func (t *Transitions) ResumeIndexing(s *State) error {
 	// height we resume from. In order to fix them, we explicitly write the
 	// correct `first` height here again, while at the same time using `once` to
 	// disable any subsequent attempts to write it.
-	first, err := t.chain.Root()
+	first, err := t.root()
 	if err != nil {
 		return fmt.Errorf("could not get root height: %w", err)
 	}
@@ -260,7 +540,7 @@ func (t *Transitions) ResumeIndexing(s *State) error {
 	// indexing.
 	last, err := t.read.Last()
 	if err != nil {
-		if errors.Is(err, badger.ErrKeyNotFound) {
+		if errors.Is(err, archive.ErrNotFound) {
 			last = first
 		} else {
 			return fmt.Errorf("could not get last height: %w", err)
@@ -355,7 +635,7 @@ func (t *Transitions) MapRegisters(s *State) error {
 	}
 
 	// We will now collect and index 1000 registers at a time. It
-	// doesn't really matter for badger if they are in random order, so this
+	// doesn't really matter for pebble if they are in random order, so this
 	// way of iterating should be fine.
 	n := 1000
 	if registers < n {
@@ -498,6 +778,34 @@ func (t *Transitions) IndexChain(s *State) error {
 		return fmt.Errorf("could not index events: %w", err)
 	}
 
+	// If token transfers are indexed, we extract them from the events of the
+	// block, which are not needed for anything else.
+	var transfers []archive.Transfer
+	if t.cfg.Transfers != nil {
+		transfers, err = t.cfg.Transfers.Transfers(s.height, events)
+		if err != nil {
+			return fmt.Errorf("could not extract token transfers: %w", err)
+		}
+		err = t.write.Transfers(s.height, transfers)
+		if err != nil {
+			return fmt.Errorf("could not index token transfers: %w", err)
+		}
+	}
+
+	// If contract updates are indexed, we decode them from the contract events
+	// of the block in the same way.
+	var updates []archive.ContractUpdate
+	if t.cfg.Contracts != nil {
+		updates, err = t.cfg.Contracts.Updates(s.height, events)
+		if err != nil {
+			return fmt.Errorf("could not extract contract updates: %w", err)
+		}
+		err = t.write.ContractUpdates(s.height, updates)
+		if err != nil {
+			return fmt.Errorf("could not index contract updates: %w", err)
+		}
+	}
+
 	t.log.Debug().
 		Uint64("height", s.height).
 		Str("block_id", blockID.String()).
@@ -508,6 +816,8 @@ func (t *Transitions) IndexChain(s *State) error {
 		Int("n_tx", len(transactions)).
 		Int("n_result", len(results)).
 		Int("n_events", len(events)).
+		Int("n_transfers", len(transfers)).
+		Int("n_contract_updates", len(updates)).
 		Msgf("successfully indexed block")
 
 	// After indexing the blockchain data, we can move on to the next height
@@ -1,11 +1,15 @@
 package mapper
 
 import (
+	"fmt"
 	"math"
 	"sync"
 	"testing"
 
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/utils/unittest"
 
@@ -115,6 +119,335 @@ func TestTransitions_BootstrapState(t *testing.T) {
 		})
 	})
 
+	t.Run("verifies imported registers against root commit", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			tries := createSimpleTrie(t)
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+			called := false
+			verifier := mocks.BaselineVerifier(t)
+			verifier.VerifyFunc = func(height uint64, commit flow.StateCommitment) error {
+				assert.Equal(t, mocks.GenericHeight, height)
+				assert.Equal(t, mocks.GenericCommit(0), commit)
+				called = true
+				return nil
+			}
+			tr, st := baselineFSM(t, StatusBootstrap)
+			tr.cfg.Verifier = verifier
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+			require.NoError(t, tr.BootstrapState(st))
+			assert.True(t, called)
+		})
+	})
+
+	t.Run("handles failure to verify imported registers", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			tries := createSimpleTrie(t)
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+			verifier := mocks.BaselineVerifier(t)
+			verifier.VerifyFunc = func(uint64, flow.StateCommitment) error {
+				return mocks.GenericError
+			}
+			tr, st := baselineFSM(t, StatusBootstrap)
+			tr.cfg.Verifier = verifier
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+			err := tr.BootstrapState(st)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("persists progress and records metrics", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			trie1 := createTrieWithNPayloads(t, 3001)
+			tries := []*trie.MTrie{trie1}
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+			var mu sync.Mutex
+			var written []uint64
+			progress := mocks.BaselineBootstrapProgress(t)
+			progress.GetBootstrapProgressFunc = func(height uint64) (uint64, error) {
+				return 0, archive.ErrNotFound
+			}
+			progress.SetBootstrapProgressFunc = func(height uint64, leaves uint64) error {
+				assert.Equal(t, mocks.GenericHeight, height)
+				written = append(written, leaves)
+				return nil
+			}
+			var processed, estimated uint64
+			metrics := mocks.BaselineBootstrapMetrics(t)
+			metrics.ProgressFunc = func(p uint64, e uint64, _ float64) {
+				mu.Lock()
+				defer mu.Unlock()
+				processed = p
+				estimated = e
+			}
+
+			tr, st := baselineFSM(t, StatusBootstrap)
+			tr.cfg.BootstrapProgress = progress
+			tr.cfg.Metrics = metrics
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+
+			require.NoError(t, tr.BootstrapState(st))
+			require.NotEmpty(t, written)
+			assert.IsIncreasing(t, written)
+			assert.Equal(t, uint64(3001), written[len(written)-1])
+			assert.Equal(t, uint64(3001), processed)
+			assert.NotZero(t, estimated)
+		})
+	})
+
+	t.Run("skips leaves written by previous run", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			trie1 := createTrieWithNPayloads(t, 3001)
+			tries := []*trie.MTrie{trie1}
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+			progress := mocks.BaselineBootstrapProgress(t)
+			progress.GetBootstrapProgressFunc = func(height uint64) (uint64, error) {
+				return 2000, nil
+			}
+			var last uint64
+			progress.SetBootstrapProgressFunc = func(height uint64, leaves uint64) error {
+				last = leaves
+				return nil
+			}
+
+			var mu sync.Mutex
+			written := 0
+			writer := mocks.BaselineWriter(t)
+			writer.RegistersFunc = func(height uint64, registers []*wal.LeafNode) error {
+				mu.Lock()
+				defer mu.Unlock()
+				written += len(registers)
+				return nil
+			}
+
+			tr, st := baselineFSM(t, StatusBootstrap, withWriter(writer))
+			tr.cfg.BootstrapProgress = progress
+			tr.cfg.BootstrapWorkers = 2
+			tr.cfg.BootstrapBatchSize = 100
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+
+			require.NoError(t, tr.BootstrapState(st))
+			assert.Equal(t, 1001, written)
+			assert.Equal(t, uint64(3001), last)
+		})
+	})
+
+	t.Run("handles failure to get progress", func(t *testing.T) {
+		t.Parallel()
+
+		progress := mocks.BaselineBootstrapProgress(t)
+		progress.GetBootstrapProgressFunc = func(uint64) (uint64, error) {
+			return 0, mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusBootstrap)
+		tr.cfg.BootstrapProgress = progress
+
+		err := tr.BootstrapState(st)
+		assert.ErrorIs(t, err, mocks.GenericError)
+	})
+
+	t.Run("handles failure to set progress", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			tries := createSimpleTrie(t)
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+			progress := mocks.BaselineBootstrapProgress(t)
+			progress.SetBootstrapProgressFunc = func(uint64, uint64) error {
+				return mocks.GenericError
+			}
+
+			tr, st := baselineFSM(t, StatusBootstrap)
+			tr.cfg.BootstrapProgress = progress
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+
+			err := tr.BootstrapState(st)
+			assert.ErrorIs(t, err, mocks.GenericError)
+		})
+	})
+
+	t.Run("bulk loads registers", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			trie1 := createTrieWithNRegisters(t, 3001)
+			tries := []*trie.MTrie{trie1}
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+			added := 0
+			finished := false
+			closed := false
+			loader := mocks.BaselineRegisterLoader(t)
+			loader.AddFunc = func(entries flow.RegisterEntries) error {
+				added += len(entries)
+				return nil
+			}
+			loader.FinishFunc = func() error {
+				finished = true
+				return nil
+			}
+			loader.CloseFunc = func() error {
+				closed = true
+				return nil
+			}
+			bulk := mocks.BaselineBulkLoader(t)
+			bulk.NewRegisterLoaderFunc = func(height uint64) (archive.RegisterLoader, error) {
+				assert.Equal(t, mocks.GenericHeight, height)
+				return loader, nil
+			}
+			writer := mocks.BaselineWriter(t)
+			writer.RegistersFunc = func(uint64, []*wal.LeafNode) error {
+				t.Error("registers written in batches")
+				return nil
+			}
+
+			tr, st := baselineFSM(t, StatusBootstrap, withWriter(writer))
+			tr.cfg.BulkLoader = bulk
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+
+			require.NoError(t, tr.BootstrapState(st))
+			assert.Equal(t, StatusResume, st.status)
+			assert.Equal(t, 3001, added)
+			assert.True(t, finished)
+			assert.True(t, closed)
+		})
+	})
+
+	t.Run("handles bulk load failure", func(t *testing.T) {
+		t.Parallel()
+		unittest.RunWithTempDir(t, func(dir string) {
+			logger := unittest.Logger()
+			trie1 := createTrieWithNRegisters(t, 3001)
+			tries := []*trie.MTrie{trie1}
+			fileName := "test_checkpoint_file"
+			require.NoErrorf(t, wal.StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+			loader := mocks.BaselineRegisterLoader(t)
+			loader.AddFunc = func(flow.RegisterEntries) error {
+				return mocks.GenericError
+			}
+			bulk := mocks.BaselineBulkLoader(t)
+			bulk.NewRegisterLoaderFunc = func(uint64) (archive.RegisterLoader, error) {
+				return loader, nil
+			}
+
+			tr, st := baselineFSM(t, StatusBootstrap)
+			tr.cfg.BulkLoader = bulk
+			st.checkpointFileName = fileName
+			st.checkpointDir = dir
+
+			err := tr.BootstrapState(st)
+			assert.ErrorIs(t, err, mocks.GenericError)
+		})
+	})
+
+	t.Run("handles failure to create bulk loader", func(t *testing.T) {
+		t.Parallel()
+
+		bulk := mocks.BaselineBulkLoader(t)
+		bulk.NewRegisterLoaderFunc = func(uint64) (archive.RegisterLoader, error) {
+			return nil, mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusBootstrap)
+		tr.cfg.BulkLoader = bulk
+
+		err := tr.BootstrapState(st)
+		assert.ErrorIs(t, err, mocks.GenericError)
+	})
+
+	t.Run("imports registers from seed", func(t *testing.T) {
+		t.Parallel()
+
+		seedHeight := mocks.GenericHeight + 10
+		seed := mocks.BaselineSeed(t)
+		seed.RegistersFunc = func(height uint64, fn func(flow.RegisterEntry) error) error {
+			assert.Equal(t, seedHeight, height)
+			for i := 0; i < 1001; i++ {
+				reg := flow.RegisterID{Owner: "owner", Key: fmt.Sprintf("key%d", i)}
+				err := fn(flow.RegisterEntry{Key: reg, Value: []byte("value")})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		written := 0
+		writer := mocks.BaselineWriter(t)
+		writer.FirstFunc = func(height uint64) error {
+			assert.Equal(t, seedHeight, height)
+			return nil
+		}
+		writer.PayloadsFunc = func(height uint64, payloads []*ledger.Payload) error {
+			assert.Equal(t, seedHeight, height)
+			written += len(payloads)
+			return nil
+		}
+
+		tr, st := baselineFSM(t, StatusBootstrap, withWriter(writer))
+		tr.cfg.Seed = seed
+		tr.cfg.SeedHeight = seedHeight
+		st.height = seedHeight
+
+		require.NoError(t, tr.BootstrapState(st))
+		assert.Equal(t, StatusResume, st.status)
+		assert.Equal(t, 1001, written)
+	})
+
+	t.Run("handles seed failure", func(t *testing.T) {
+		t.Parallel()
+
+		seed := mocks.BaselineSeed(t)
+		seed.RegistersFunc = func(uint64, func(flow.RegisterEntry) error) error {
+			return mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusBootstrap)
+		tr.cfg.Seed = seed
+
+		err := tr.BootstrapState(st)
+		assert.ErrorIs(t, err, mocks.GenericError)
+	})
+
+	t.Run("handles writer failure on seed registers", func(t *testing.T) {
+		t.Parallel()
+
+		writer := mocks.BaselineWriter(t)
+		writer.PayloadsFunc = func(uint64, []*ledger.Payload) error {
+			return mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusBootstrap, withWriter(writer))
+		tr.cfg.Seed = mocks.BaselineSeed(t)
+
+		err := tr.BootstrapState(st)
+		assert.ErrorIs(t, err, mocks.GenericError)
+	})
+
 	t.Run("invalid state", func(t *testing.T) {
 		t.Parallel()
 
@@ -124,6 +457,26 @@ func TestTransitions_BootstrapState(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("handles invalid bootstrap workers", func(t *testing.T) {
+		t.Parallel()
+
+		tr, st := baselineFSM(t, StatusBootstrap)
+		tr.cfg.BootstrapWorkers = 0
+
+		err := tr.BootstrapState(st)
+		assert.Error(t, err)
+	})
+
+	t.Run("handles invalid bootstrap batch size", func(t *testing.T) {
+		t.Parallel()
+
+		tr, st := baselineFSM(t, StatusBootstrap)
+		tr.cfg.BootstrapBatchSize = -1
+
+		err := tr.BootstrapState(st)
+		assert.Error(t, err)
+	})
+
 	t.Run("handles failure to get root height", func(t *testing.T) {
 		t.Parallel()
 
@@ -501,6 +854,118 @@ func TestTransitions_IndexChain(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("indexes token transfers if configured to do so", func(t *testing.T) {
+		t.Parallel()
+
+		transfers := mocks.BaselineTransfers(t)
+		transfers.TransfersFunc = func(height uint64, events []flow.Event) ([]archive.Transfer, error) {
+			assert.Equal(t, mocks.GenericHeight, height)
+			assert.Equal(t, mocks.GenericEvents(4), events)
+
+			return mocks.GenericTransfers(4), nil
+		}
+
+		var called bool
+		write := mocks.BaselineWriter(t)
+		write.TransfersFunc = func(height uint64, transfers []archive.Transfer) error {
+			assert.Equal(t, mocks.GenericHeight, height)
+			assert.Equal(t, mocks.GenericTransfers(4), transfers)
+			called = true
+
+			return nil
+		}
+
+		tr, st := baselineFSM(t, StatusIndex)
+		tr.cfg.Transfers = transfers
+		tr.write = write
+
+		err := tr.IndexChain(st)
+
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("handles failure to extract token transfers", func(t *testing.T) {
+		t.Parallel()
+
+		transfers := mocks.BaselineTransfers(t)
+		transfers.TransfersFunc = func(uint64, []flow.Event) ([]archive.Transfer, error) {
+			return nil, mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusIndex)
+		tr.cfg.Transfers = transfers
+
+		err := tr.IndexChain(st)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles writer failure to index token transfers", func(t *testing.T) {
+		t.Parallel()
+
+		write := mocks.BaselineWriter(t)
+		write.TransfersFunc = func(uint64, []archive.Transfer) error {
+			return mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusIndex)
+		tr.cfg.Transfers = mocks.BaselineTransfers(t)
+		tr.write = write
+
+		err := tr.IndexChain(st)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("indexes contract updates if configured to do so", func(t *testing.T) {
+		t.Parallel()
+
+		contracts := mocks.BaselineContracts(t)
+		contracts.UpdatesFunc = func(height uint64, events []flow.Event) ([]archive.ContractUpdate, error) {
+			assert.Equal(t, mocks.GenericHeight, height)
+			assert.Equal(t, mocks.GenericEvents(4), events)
+
+			return mocks.GenericContractUpdates(4), nil
+		}
+
+		var called bool
+		write := mocks.BaselineWriter(t)
+		write.ContractUpdatesFunc = func(height uint64, updates []archive.ContractUpdate) error {
+			assert.Equal(t, mocks.GenericHeight, height)
+			assert.Equal(t, mocks.GenericContractUpdates(4), updates)
+			called = true
+
+			return nil
+		}
+
+		tr, st := baselineFSM(t, StatusIndex)
+		tr.cfg.Contracts = contracts
+		tr.write = write
+
+		err := tr.IndexChain(st)
+
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("handles writer failure to index contract updates", func(t *testing.T) {
+		t.Parallel()
+
+		write := mocks.BaselineWriter(t)
+		write.ContractUpdatesFunc = func(uint64, []archive.ContractUpdate) error {
+			return mocks.GenericError
+		}
+
+		tr, st := baselineFSM(t, StatusIndex)
+		tr.cfg.Contracts = mocks.BaselineContracts(t)
+		tr.write = write
+
+		err := tr.IndexChain(st)
+
+		assert.Error(t, err)
+	})
 }
 
 func TestTransitions_UpdateTree(t *testing.T) {
@@ -878,6 +1343,25 @@ func TestTransitions_InitializeMapper(t *testing.T) {
 		assert.Equal(t, StatusResume, st.status)
 	})
 
+	t.Run("bootstraps at seed height if configured with a seed", func(t *testing.T) {
+		t.Parallel()
+
+		reader := mocks.BaselineReader(t)
+		reader.LastFunc = func() (uint64, error) {
+			return 0, archive.ErrNotFound
+		}
+
+		tr, st := baselineFSM(t, StatusInitialize, withReader(reader))
+		tr.cfg.Seed = mocks.BaselineSeed(t)
+		tr.cfg.SeedHeight = mocks.GenericHeight + 10
+
+		err := tr.InitializeMapper(st)
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusBootstrap, st.status)
+		assert.Equal(t, mocks.GenericHeight+10, st.height)
+	})
+
 	t.Run("handles invalid status", func(t *testing.T) {
 		t.Parallel()
 
@@ -1071,6 +1555,26 @@ func createSimpleTrie(t *testing.T) []*trie.MTrie {
 	return tries
 }
 
+// createTrieWithNRegisters creates a trie with n registers, whose payloads have
+// valid register keys.
+func createTrieWithNRegisters(t *testing.T, n int) *trie.MTrie {
+	paths := make([]ledger.Path, 0, n)
+	payloads := make([]ledger.Payload, 0, n)
+	for i := 0; i < n; i++ {
+		reg := flow.RegisterID{Owner: "owner", Key: fmt.Sprintf("key%d", i)}
+		key := state.RegisterIDToKey(reg)
+		path, err := pathfinder.KeyToPath(key, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		paths = append(paths, path)
+		payloads = append(payloads, *ledger.NewPayload(key, []byte("value")))
+	}
+
+	updatedTrie, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	return updatedTrie
+}
+
 func createTrieWithNPayloads(t *testing.T, n int) *trie.MTrie {
 	require.True(t, n <= math.MaxUint16, "invalid n")
 
@@ -1105,8 +1609,10 @@ func baselineFSM(t *testing.T, status Status, opts ...func(tr *Transitions)) (*T
 
 	tr := Transitions{
 		cfg: Config{
-			SkipRegisters: false,
-			WaitInterval:  0,
+			BootstrapWorkers:   10,
+			BootstrapBatchSize: 1000,
+			SkipRegisters:      false,
+			WaitInterval:       0,
 		},
 		log:     mocks.NoopLogger,
 		chain:   chain,
@@ -0,0 +1,11 @@
+package mapper
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Verifier represents something that verifies that the indexed registers at a
+// height hash to the given state commitment.
+type Verifier interface {
+	Verify(height uint64, commit flow.StateCommitment) error
+}
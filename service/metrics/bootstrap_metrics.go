@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// BootstrapMetrics records the progress of importing the root checkpoint and
+// exposes it as prometheus gauges.
+type BootstrapMetrics struct {
+	processed prometheus.Gauge
+	estimated prometheus.Gauge
+	rate      prometheus.Gauge
+}
+
+// NewBootstrapMetrics creates the prometheus gauges for bootstrapping.
+func NewBootstrapMetrics() *BootstrapMetrics {
+	processedOpts := prometheus.GaugeOpts{
+		Name:      "bootstrap_processed_leaves",
+		Namespace: namespaceArchive,
+		Help:      "number of root checkpoint leaves written to the index, including those of interrupted runs",
+	}
+	processed := promauto.NewGauge(processedOpts)
+
+	estimatedOpts := prometheus.GaugeOpts{
+		Name:      "bootstrap_estimated_leaves",
+		Namespace: namespaceArchive,
+		Help:      "estimated total number of leaves in the root checkpoint",
+	}
+	estimated := promauto.NewGauge(estimatedOpts)
+
+	rateOpts := prometheus.GaugeOpts{
+		Name:      "bootstrap_leaves_per_second",
+		Namespace: namespaceArchive,
+		Help:      "average number of root checkpoint leaves written per second by the current run",
+	}
+	rate := promauto.NewGauge(rateOpts)
+
+	m := BootstrapMetrics{
+		processed: processed,
+		estimated: estimated,
+		rate:      rate,
+	}
+
+	return &m
+}
+
+func (m *BootstrapMetrics) Progress(processed uint64, estimated uint64, rate float64) {
+	m.processed.Set(float64(processed))
+	m.estimated.Set(float64(estimated))
+	m.rate.Set(rate)
+}
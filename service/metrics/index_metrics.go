@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/service/index"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -20,6 +21,7 @@ type MetricsWriter struct {
 	transaction    prometheus.Counter
 	event          prometheus.Counter
 	seal           prometheus.Counter
+	transfer       prometheus.Counter
 }
 
 // NewMetricsWriter creates a counter that counts indexed elements and exposes this information
@@ -74,6 +76,13 @@ func NewMetricsWriter(write *index.Writer) *MetricsWriter {
 	}
 	seal := promauto.NewCounter(sealOpts)
 
+	transferOpts := prometheus.CounterOpts{
+		Name:      "indexed_transfers",
+		Namespace: namespaceArchive,
+		Help:      "number of indexed token transfers",
+	}
+	transfer := promauto.NewCounter(transferOpts)
+
 	w := MetricsWriter{
 		write:          write,
 		block:          block,
@@ -83,6 +92,7 @@ func NewMetricsWriter(write *index.Writer) *MetricsWriter {
 		transaction:    transaction,
 		event:          event,
 		seal:           seal,
+		transfer:       transfer,
 	}
 
 	return &w
@@ -124,6 +134,15 @@ func (w *MetricsWriter) Seals(height uint64, seals []*flow.Seal) error {
 	return w.write.Seals(height, seals)
 }
 
+func (w *MetricsWriter) Transfers(height uint64, transfers []archive.Transfer) error {
+	w.transfer.Add(float64(len(transfers)))
+	return w.write.Transfers(height, transfers)
+}
+
+func (w *MetricsWriter) ContractUpdates(height uint64, updates []archive.ContractUpdate) error {
+	return w.write.ContractUpdates(height, updates)
+}
+
 func (w *MetricsWriter) First(height uint64) error {
 	return w.write.First(height)
 }
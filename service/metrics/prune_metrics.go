@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// PruneMetrics records the progress of register pruning and exposes it as
+// prometheus gauges.
+type PruneMetrics struct {
+	scanned   prometheus.Gauge
+	deleted   prometheus.Gauge
+	retention prometheus.Gauge
+	duration  prometheus.Gauge
+}
+
+// NewPruneMetrics creates the prometheus gauges for register pruning.
+func NewPruneMetrics() *PruneMetrics {
+	scannedOpts := prometheus.GaugeOpts{
+		Name:      "prune_scanned_versions",
+		Namespace: namespaceArchive,
+		Help:      "number of register versions scanned by the current or last pruning run",
+	}
+	scanned := promauto.NewGauge(scannedOpts)
+
+	deletedOpts := prometheus.GaugeOpts{
+		Name:      "prune_deleted_versions",
+		Namespace: namespaceArchive,
+		Help:      "number of register versions deleted by the current or last pruning run",
+	}
+	deleted := promauto.NewGauge(deletedOpts)
+
+	retentionOpts := prometheus.GaugeOpts{
+		Name:      "prune_retention_height",
+		Namespace: namespaceArchive,
+		Help:      "height from which all register versions are retained",
+	}
+	retention := promauto.NewGauge(retentionOpts)
+
+	durationOpts := prometheus.GaugeOpts{
+		Name:      "prune_duration_seconds",
+		Namespace: namespaceArchive,
+		Help:      "duration of the last pruning run in seconds",
+	}
+	duration := promauto.NewGauge(durationOpts)
+
+	m := PruneMetrics{
+		scanned:   scanned,
+		deleted:   deleted,
+		retention: retention,
+		duration:  duration,
+	}
+
+	return &m
+}
+
+func (m *PruneMetrics) Progress(progress archive.PruneProgress) {
+	m.scanned.Set(float64(progress.Scanned))
+	m.deleted.Set(float64(progress.Deleted))
+}
+
+func (m *PruneMetrics) Pruned(retention archive.RegisterRetention, duration time.Duration) {
+	m.retention.Set(float64(retention.Height))
+	m.duration.Set(duration.Seconds())
+}
@@ -0,0 +1,35 @@
+package migrator
+
+// DefaultConfig is the default configuration for the Migrator.
+var DefaultConfig = Config{
+	BatchSize:      10_000,
+	SampleInterval: 1000,
+}
+
+// Config contains optional parameters for the Migrator.
+type Config struct {
+	BatchSize      int
+	SampleInterval uint64
+}
+
+// Option is an option that can be given to the migrator to configure optional
+// parameters on initialization.
+type Option func(*Config)
+
+// WithBatchSize sets the number of records that are written to the pebble
+// index at once.
+func WithBatchSize(size int) Option {
+	return func(cfg *Config) {
+		cfg.BatchSize = size
+	}
+}
+
+// WithSampleInterval sets how many records are counted between two records
+// whose values are compared between both indexes during verification. The
+// first record of every prefix is always compared, unless the interval is zero,
+// which disables the comparison of values.
+func WithSampleInterval(interval uint64) Option {
+	return func(cfg *Config) {
+		cfg.SampleInterval = interval
+	}
+}
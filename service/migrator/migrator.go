@@ -0,0 +1,337 @@
+package migrator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/storage2/blocks"
+)
+
+// Prefixes are the prefixes of the legacy badger index that are migrated, in
+// the order in which they are migrated. The legacy payload records are not
+// migrated, as registers are indexed separately in the pebble index.
+var Prefixes = []uint8{
+	storage.PrefixFirst,
+	storage.PrefixLast,
+	storage.PrefixHeader,
+	storage.PrefixCommit,
+	storage.PrefixEvents,
+	storage.PrefixHeightForBlock,
+	storage.PrefixTransaction,
+	storage.PrefixTransactionsForHeight,
+	storage.PrefixCollection,
+	storage.PrefixCollectionsForHeight,
+	storage.PrefixTransactionsForCollection,
+	storage.PrefixResults,
+	storage.PrefixSeal,
+	storage.PrefixSealsForHeight,
+	storage.PrefixHeightForTransaction,
+	storage.PrefixGuarantee,
+	storage.PrefixLatestRegisterHeight,
+}
+
+// Migrator copies the records of the legacy badger index into the pebble block
+// storage. Both layouts share the same keys and value encoding, so records are
+// copied as is, and a migration that was interrupted can be resumed from the
+// last key that was copied for each prefix.
+type Migrator struct {
+	log    zerolog.Logger
+	cfg    Config
+	db     *badger.DB
+	lib    archive.ReadLibrary
+	blocks *blocks.Storage
+}
+
+// New creates a new migrator, which reads the legacy index from the given
+// badger database using the given library, and writes it to the given block
+// storage.
+func New(log zerolog.Logger, db *badger.DB, lib archive.ReadLibrary, blocks *blocks.Storage, options ...Option) *Migrator {
+
+	cfg := DefaultConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	m := Migrator{
+		log:    log.With().Str("component", "migrator").Logger(),
+		cfg:    cfg,
+		db:     db,
+		lib:    lib,
+		blocks: blocks,
+	}
+
+	return &m
+}
+
+// Migrate copies the records of every migrated prefix. For each prefix, it
+// resumes after the greatest key that already exists in the block storage.
+func (m *Migrator) Migrate() error {
+	for _, prefix := range Prefixes {
+		err := m.migratePrefix(prefix)
+		if err != nil {
+			return fmt.Errorf("could not migrate prefix %d: %w", prefix, err)
+		}
+	}
+
+	return nil
+}
+
+// Verify checks that the block storage holds as many records as the legacy
+// index for every migrated prefix, and that a sample of the records decodes to
+// the same values from both.
+func (m *Migrator) Verify() error {
+	for _, prefix := range Prefixes {
+		err := m.verifyPrefix(prefix)
+		if err != nil {
+			return fmt.Errorf("could not verify prefix %d: %w", prefix, err)
+		}
+	}
+
+	return nil
+}
+
+// DropPayloads deletes the legacy payload records from the badger index. The
+// badger database needs to be opened in read-write mode for it to succeed.
+func (m *Migrator) DropPayloads() error {
+	err := m.db.DropPrefix(storage.EncodeKey(storage.PrefixPayload))
+	if err != nil {
+		return fmt.Errorf("could not drop payloads: %w", err)
+	}
+
+	m.log.Info().Msg("legacy payloads dropped")
+
+	return nil
+}
+
+func (m *Migrator) migratePrefix(prefix uint8) error {
+
+	log := m.log.With().Uint8("prefix", prefix).Logger()
+
+	seek := storage.EncodeKey(prefix)
+	last, err := m.blocks.GetLastKey(prefix)
+	if err != nil {
+		return fmt.Errorf("could not get last migrated key: %w", err)
+	}
+	if last != nil {
+		log.Info().Hex("key", last).Msg("resuming migration after last migrated key")
+		seek = last
+	}
+
+	count := uint64(0)
+	err = m.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = storage.EncodeKey(prefix)
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		records := make([]blocks.Record, 0, m.cfg.BatchSize)
+		for it.Seek(seek); it.ValidForPrefix(opts.Prefix); it.Next() {
+			item := it.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return fmt.Errorf("could not copy value: %w", err)
+			}
+
+			records = append(records, blocks.Record{Key: item.KeyCopy(nil), Value: value})
+			if len(records) < m.cfg.BatchSize {
+				continue
+			}
+
+			err = m.blocks.BatchSetRecords(records)
+			if err != nil {
+				return fmt.Errorf("could not write records: %w", err)
+			}
+			count += uint64(len(records))
+			records = records[:0]
+
+			log.Debug().Uint64("records", count).Msg("migration progress")
+		}
+
+		err := m.blocks.BatchSetRecords(records)
+		if err != nil {
+			return fmt.Errorf("could not write records: %w", err)
+		}
+		count += uint64(len(records))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Uint64("records", count).Msg("prefix migrated")
+
+	return nil
+}
+
+func (m *Migrator) verifyPrefix(prefix uint8) error {
+
+	count := uint64(0)
+	err := m.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = storage.EncodeKey(prefix)
+		opts.PrefetchValues = false
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(opts.Prefix); it.ValidForPrefix(opts.Prefix); it.Next() {
+			if m.cfg.SampleInterval != 0 && count%m.cfg.SampleInterval == 0 {
+				key := it.Item().KeyCopy(nil)
+				err := m.compare(key)
+				if err != nil {
+					return fmt.Errorf("could not compare record (key: %x): %w", key, err)
+				}
+			}
+			count++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	migrated, err := m.blocks.CountRecords(prefix)
+	if err != nil {
+		return fmt.Errorf("could not count migrated records: %w", err)
+	}
+	if migrated != count {
+		return fmt.Errorf("record count mismatch (legacy: %d, migrated: %d)", count, migrated)
+	}
+
+	m.log.Info().Uint8("prefix", prefix).Uint64("records", count).Msg("prefix verified")
+
+	return nil
+}
+
+// compare decodes the record with the given key from both the legacy index and
+// the block storage, and checks that both values are the same.
+func (m *Migrator) compare(key []byte) error {
+
+	var (
+		want    interface{}
+		got     interface{}
+		errWant error
+		errGot  error
+	)
+	switch key[0] {
+	case storage.PrefixFirst:
+		var height uint64
+		errWant = m.db.View(m.lib.RetrieveFirst(&height))
+		want = height
+		got, errGot = m.blocks.GetFirst()
+	case storage.PrefixLast:
+		var height uint64
+		errWant = m.db.View(m.lib.RetrieveLast(&height))
+		want = height
+		got, errGot = m.blocks.GetLast()
+	case storage.PrefixLatestRegisterHeight:
+		var height uint64
+		errWant = m.db.View(m.lib.RetrieveLatestRegisterHeight(&height))
+		want = height
+		got, errGot = m.blocks.GetLatestRegisterHeight()
+	case storage.PrefixHeightForBlock:
+		var height uint64
+		errWant = m.db.View(m.lib.LookupHeightForBlock(keyIdentifier(key), &height))
+		want = height
+		got, errGot = m.blocks.GetHeightForBlock(keyIdentifier(key))
+	case storage.PrefixHeightForTransaction:
+		var height uint64
+		errWant = m.db.View(m.lib.LookupHeightForTransaction(keyIdentifier(key), &height))
+		want = height
+		got, errGot = m.blocks.GetHeightForTransaction(keyIdentifier(key))
+	case storage.PrefixCommit:
+		var commit flow.StateCommitment
+		errWant = m.db.View(m.lib.RetrieveCommit(keyHeight(key), &commit))
+		want = commit
+		got, errGot = m.blocks.GetCommit(keyHeight(key))
+	case storage.PrefixHeader:
+		var header flow.Header
+		errWant = m.db.View(m.lib.RetrieveHeader(keyHeight(key), &header))
+		want = &header
+		got, errGot = m.blocks.GetHeader(keyHeight(key))
+	case storage.PrefixEvents:
+		var events []flow.Event
+		errWant = m.db.View(m.lib.RetrieveEvents(keyHeight(key), nil, &events))
+		want = events
+		got, errGot = m.blocks.GetEvents(keyHeight(key), nil)
+	case storage.PrefixTransaction:
+		var transaction flow.TransactionBody
+		errWant = m.db.View(m.lib.RetrieveTransaction(keyIdentifier(key), &transaction))
+		want = &transaction
+		got, errGot = m.blocks.GetTransaction(keyIdentifier(key))
+	case storage.PrefixCollection:
+		var collection flow.LightCollection
+		errWant = m.db.View(m.lib.RetrieveCollection(keyIdentifier(key), &collection))
+		want = &collection
+		got, errGot = m.blocks.GetCollection(keyIdentifier(key))
+	case storage.PrefixGuarantee:
+		var guarantee flow.CollectionGuarantee
+		errWant = m.db.View(m.lib.RetrieveGuarantee(keyIdentifier(key), &guarantee))
+		want = &guarantee
+		got, errGot = m.blocks.GetGuarantee(keyIdentifier(key))
+	case storage.PrefixTransactionsForHeight:
+		var txIDs []flow.Identifier
+		errWant = m.db.View(m.lib.LookupTransactionsForHeight(keyHeight(key), &txIDs))
+		want = txIDs
+		got, errGot = m.blocks.GetTransactionsForHeight(keyHeight(key))
+	case storage.PrefixTransactionsForCollection:
+		var txIDs []flow.Identifier
+		errWant = m.db.View(m.lib.LookupTransactionsForCollection(keyIdentifier(key), &txIDs))
+		want = txIDs
+		got, errGot = m.blocks.GetTransactionsForCollection(keyIdentifier(key))
+	case storage.PrefixCollectionsForHeight:
+		var collIDs []flow.Identifier
+		errWant = m.db.View(m.lib.LookupCollectionsForHeight(keyHeight(key), &collIDs))
+		want = collIDs
+		got, errGot = m.blocks.GetCollectionsForHeight(keyHeight(key))
+	case storage.PrefixResults:
+		var result flow.TransactionResult
+		errWant = m.db.View(m.lib.RetrieveResult(keyIdentifier(key), &result))
+		want = &result
+		got, errGot = m.blocks.GetResult(keyIdentifier(key))
+	case storage.PrefixSeal:
+		var seal flow.Seal
+		errWant = m.db.View(m.lib.RetrieveSeal(keyIdentifier(key), &seal))
+		want = &seal
+		got, errGot = m.blocks.GetSeal(keyIdentifier(key))
+	case storage.PrefixSealsForHeight:
+		var sealIDs []flow.Identifier
+		errWant = m.db.View(m.lib.LookupSealsForHeight(keyHeight(key), &sealIDs))
+		want = sealIDs
+		got, errGot = m.blocks.GetSealsForHeight(keyHeight(key))
+	default:
+		return fmt.Errorf("unknown prefix %d", key[0])
+	}
+	if errWant != nil {
+		return fmt.Errorf("could not read legacy record: %w", errWant)
+	}
+	if errGot != nil {
+		return fmt.Errorf("could not read migrated record: %w", errGot)
+	}
+	if !reflect.DeepEqual(want, got) {
+		return fmt.Errorf("migrated record does not match legacy record")
+	}
+
+	return nil
+}
+
+// keyHeight returns the height encoded after the prefix of the given key.
+func keyHeight(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[1:9])
+}
+
+// keyIdentifier returns the identifier encoded after the prefix of the given key.
+func keyIdentifier(key []byte) flow.Identifier {
+	return flow.HashToID(key[1:33])
+}
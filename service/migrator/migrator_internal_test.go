@@ -0,0 +1,215 @@
+package migrator
+
+import (
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/codec/zbor"
+	"github.com/onflow/flow-archive/service/storage"
+	"github.com/onflow/flow-archive/service/storage2/blocks"
+	"github.com/onflow/flow-archive/testing/helpers"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+const testHeights = 5
+
+func TestMigrator_Migrate(t *testing.T) {
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		db, lib := legacyIndex(t)
+		store := blockStorage(t)
+
+		m := New(zerolog.Nop(), db, lib, store, WithBatchSize(2), WithSampleInterval(1))
+
+		require.NoError(t, m.Migrate())
+		require.NoError(t, m.Verify())
+
+		first, err := store.GetFirst()
+		require.NoError(t, err)
+		assert.Equal(t, mocks.GenericHeight, first)
+
+		last, err := store.GetLast()
+		require.NoError(t, err)
+		assert.Equal(t, mocks.GenericHeight+testHeights-1, last)
+
+		count, err := store.CountRecords(blocks.PrefixHeader)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(testHeights), count)
+	})
+
+	t.Run("resumes interrupted migration", func(t *testing.T) {
+		t.Parallel()
+
+		db, lib := legacyIndex(t)
+		store := blockStorage(t)
+
+		// Migrate the first headers only, as if the migration was interrupted.
+		codec := zbor.NewCodec()
+		var records []blocks.Record
+		for i := 0; i < 2; i++ {
+			header := mocks.GenericHeader
+			header.Height = mocks.GenericHeight + uint64(i)
+			value, err := codec.Marshal(header)
+			require.NoError(t, err)
+			records = append(records, blocks.Record{
+				Key:   storage.EncodeKey(storage.PrefixHeader, header.Height),
+				Value: value,
+			})
+		}
+		require.NoError(t, store.BatchSetRecords(records))
+
+		m := New(zerolog.Nop(), db, lib, store, WithSampleInterval(1))
+
+		require.NoError(t, m.Migrate())
+		require.NoError(t, m.Verify())
+	})
+}
+
+func TestMigrator_Verify(t *testing.T) {
+	t.Run("detects missing records", func(t *testing.T) {
+		t.Parallel()
+
+		db, lib := legacyIndex(t)
+		store := blockStorage(t)
+
+		m := New(zerolog.Nop(), db, lib, store, WithSampleInterval(0))
+
+		require.NoError(t, m.Migrate())
+
+		err := db.Update(lib.SaveHeader(mocks.GenericHeight+testHeights, mocks.GenericHeader))
+		require.NoError(t, err)
+
+		assert.Error(t, m.Verify())
+	})
+
+	t.Run("detects mismatching records", func(t *testing.T) {
+		t.Parallel()
+
+		db, lib := legacyIndex(t)
+		store := blockStorage(t)
+
+		m := New(zerolog.Nop(), db, lib, store, WithSampleInterval(1))
+
+		require.NoError(t, m.Migrate())
+
+		require.NoError(t, store.SetCommit(mocks.GenericHeight, mocks.GenericCommit(1)))
+
+		assert.Error(t, m.Verify())
+	})
+}
+
+func TestMigrator_DropPayloads(t *testing.T) {
+	db, lib := legacyIndex(t)
+	store := blockStorage(t)
+
+	key := storage.EncodeKey(storage.PrefixPayload, mocks.GenericLedgerPath(0), mocks.GenericHeight)
+	err := db.Update(func(tx *badger.Txn) error {
+		return tx.Set(key, []byte{1})
+	})
+	require.NoError(t, err)
+
+	m := New(zerolog.Nop(), db, lib, store)
+
+	require.NoError(t, m.DropPayloads())
+
+	err = db.View(func(tx *badger.Txn) error {
+		_, err := tx.Get(key)
+		return err
+	})
+	assert.ErrorIs(t, err, badger.ErrKeyNotFound)
+
+	// Other records are kept.
+	var first uint64
+	require.NoError(t, db.View(lib.RetrieveFirst(&first)))
+	assert.Equal(t, mocks.GenericHeight, first)
+}
+
+// legacyIndex returns an in-memory legacy index, which contains a few heights
+// of block data.
+func legacyIndex(t *testing.T) (*badger.DB, *storage.Library) {
+	t.Helper()
+
+	db := helpers.InMemoryDB(t)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	lib := storage.New(zbor.NewCodec())
+
+	collections := mocks.GenericCollections(testHeights)
+	guarantees := mocks.GenericGuarantees(testHeights)
+	transactions := mocks.GenericTransactions(testHeights)
+	results := mocks.GenericResults(testHeights)
+	seals := mocks.GenericSeals(testHeights)
+	types := mocks.GenericEventTypes(2)
+
+	err := db.Update(func(tx *badger.Txn) error {
+		ops := []func(*badger.Txn) error{
+			lib.SaveFirst(mocks.GenericHeight),
+			lib.SaveLast(mocks.GenericHeight + testHeights - 1),
+			lib.SaveLatestRegisterHeight(mocks.GenericHeight + testHeights - 1),
+		}
+		for i := 0; i < testHeights; i++ {
+			height := mocks.GenericHeight + uint64(i)
+			header := *mocks.GenericHeader
+			header.Height = height
+
+			ops = append(ops,
+				lib.SaveHeader(height, &header),
+				lib.SaveCommit(height, mocks.GenericCommit(i)),
+				lib.IndexHeightForBlock(header.ID(), height),
+				lib.SaveCollection(collections[i]),
+				lib.SaveGuarantee(guarantees[i]),
+				lib.SaveTransaction(transactions[i]),
+				lib.SaveResult(results[i]),
+				lib.SaveSeal(seals[i]),
+				lib.IndexHeightForTransaction(transactions[i].ID(), height),
+				lib.IndexCollectionsForHeight(height, []flow.Identifier{collections[i].ID()}),
+				lib.IndexTransactionsForCollection(collections[i].ID(), collections[i].Transactions),
+				lib.IndexTransactionsForHeight(height, []flow.Identifier{transactions[i].ID()}),
+				lib.IndexSealsForHeight(height, []flow.Identifier{seals[i].ID()}),
+			)
+			for _, typ := range types {
+				ops = append(ops, lib.SaveEvents(height, typ, mocks.GenericEvents(2, typ)))
+			}
+		}
+
+		for _, op := range ops {
+			err := op(tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return db, lib
+}
+
+// blockStorage returns an empty block storage in a temporary directory.
+func blockStorage(t *testing.T) *blocks.Storage {
+	t.Helper()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	store, err := blocks.NewStorage(path.Join(t.TempDir(), "blocks.db"), cache, zbor.NewCodec())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	return store
+}
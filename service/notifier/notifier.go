@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"sync"
+)
+
+// Notifier broadcasts to any number of waiting goroutines that a new height
+// was indexed. Each waiter gets a channel that is closed on the next
+// notification, so a waiter that gets its channel before checking the last
+// indexed height can never miss a height that is indexed after the check.
+type Notifier struct {
+	mutex sync.Mutex
+	next  chan struct{}
+}
+
+// New creates a new notifier without any waiters.
+func New() *Notifier {
+
+	n := Notifier{
+		next: make(chan struct{}),
+	}
+
+	return &n
+}
+
+// Notify wakes up all goroutines that are waiting for the next notification.
+func (n *Notifier) Notify() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	close(n.next)
+	n.next = make(chan struct{})
+}
+
+// Wait returns a channel that is closed on the next notification.
+func (n *Notifier) Wait() <-chan struct{} {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.next
+}
@@ -0,0 +1,47 @@
+package notifier_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-archive/service/notifier"
+)
+
+func TestNotifier(t *testing.T) {
+	t.Run("closes channels of waiters on notify", func(t *testing.T) {
+		t.Parallel()
+
+		n := notifier.New()
+		first := n.Wait()
+		second := n.Wait()
+
+		assert.NotPanics(t, n.Notify)
+
+		assert.True(t, closed(first))
+		assert.True(t, closed(second))
+	})
+
+	t.Run("does not close channels of later waiters", func(t *testing.T) {
+		t.Parallel()
+
+		n := notifier.New()
+		n.Notify()
+		next := n.Wait()
+
+		assert.False(t, closed(next))
+
+		n.Notify()
+
+		assert.True(t, closed(next))
+	})
+}
+
+func closed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
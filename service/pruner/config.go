@@ -0,0 +1,48 @@
+package pruner
+
+import (
+	"time"
+)
+
+// DefaultConfig is the default configuration for the Pruner.
+var DefaultConfig = Config{
+	RetainBlocks: 0,
+	Interval:     0,
+	Frequency:    time.Hour,
+}
+
+// Config contains optional parameters for the Pruner.
+type Config struct {
+	RetainBlocks uint64
+	Interval     uint64
+	Frequency    time.Duration
+}
+
+// Option is an option that can be given to the pruner to configure optional
+// parameters on initialization.
+type Option func(*Config)
+
+// WithRetainBlocks sets the number of most recent blocks for which the full
+// register state is kept. Register versions that are only needed to read
+// older heights are pruned.
+func WithRetainBlocks(blocks uint64) Option {
+	return func(cfg *Config) {
+		cfg.RetainBlocks = blocks
+	}
+}
+
+// WithInterval makes the pruner keep the register state of every height that
+// is a multiple of the given interval, even below the retained blocks. Zero
+// means no register state is kept below the retained blocks.
+func WithInterval(interval uint64) Option {
+	return func(cfg *Config) {
+		cfg.Interval = interval
+	}
+}
+
+// WithFrequency sets how often the pruner runs.
+func WithFrequency(frequency time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.Frequency = frequency
+	}
+}
@@ -0,0 +1,19 @@
+package pruner
+
+import (
+	"time"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Metrics represents something that records the progress of the pruner.
+type Metrics interface {
+	Progress(progress archive.PruneProgress)
+	Pruned(retention archive.RegisterRetention, duration time.Duration)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) Progress(archive.PruneProgress) {}
+
+func (nopMetrics) Pruned(archive.RegisterRetention, time.Duration) {}
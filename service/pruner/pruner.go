@@ -0,0 +1,110 @@
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Pruner periodically removes the register versions that are no longer needed
+// to read the registers at the heights kept by its configured retention.
+type Pruner struct {
+	log     zerolog.Logger
+	cfg     Config
+	read    archive.Reader
+	lib2    archive.WriteLibrary2
+	metrics Metrics
+}
+
+// New creates a new pruner, which reads the latest indexed height from the
+// given reader and prunes the given library.
+func New(log zerolog.Logger, read archive.Reader, lib2 archive.WriteLibrary2, metrics Metrics, options ...Option) *Pruner {
+
+	cfg := DefaultConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	if metrics == nil {
+		metrics = nopMetrics{}
+	}
+
+	p := Pruner{
+		log:     log.With().Str("component", "pruner").Logger(),
+		cfg:     cfg,
+		read:    read,
+		lib2:    lib2,
+		metrics: metrics,
+	}
+
+	return &p
+}
+
+// Run prunes the registers at the configured frequency, until the given
+// context is canceled. A failed run is logged and retried on the next tick.
+func (p *Pruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := p.Prune()
+		if err != nil {
+			p.log.Warn().Err(err).Msg("could not prune registers")
+		}
+	}
+}
+
+// Prune moves the retention up to the latest indexed register height minus
+// the retained blocks, and deletes the register versions that fell out of it.
+func (p *Pruner) Prune() error {
+
+	latest, err := p.read.LatestRegisterHeight()
+	if err != nil {
+		return fmt.Errorf("could not get latest register height: %w", err)
+	}
+	if latest <= p.cfg.RetainBlocks {
+		return nil
+	}
+
+	current, err := p.read.RegisterRetention()
+	if err != nil {
+		return fmt.Errorf("could not get current retention: %w", err)
+	}
+	retention := archive.RegisterRetention{
+		Height:   latest - p.cfg.RetainBlocks,
+		Interval: p.cfg.Interval,
+	}
+	if retention.Height <= current.Height {
+		return nil
+	}
+
+	log := p.log.With().
+		Uint64("height", retention.Height).
+		Uint64("interval", retention.Interval).
+		Logger()
+
+	start := time.Now()
+	log.Info().Msg("pruning registers")
+
+	err = p.lib2.PruneRegisters(retention, p.metrics.Progress)
+	if err != nil {
+		return fmt.Errorf("could not prune registers (height: %d, interval: %d): %w", retention.Height, retention.Interval, err)
+	}
+
+	duration := time.Since(start)
+	p.metrics.Pruned(retention, duration)
+
+	log.Info().Str("duration", duration.Round(time.Second).String()).Msg("registers pruned")
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package pruner
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	tests := []struct {
+		name string
+
+		retainBlocks uint64
+		interval     uint64
+
+		mockLatest    uint64
+		mockLatestErr error
+		mockCurrent   archive.RegisterRetention
+		mockPruneErr  error
+
+		wantRetention *archive.RegisterRetention
+
+		checkErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "nominal case",
+
+			retainBlocks: 10,
+			interval:     10,
+
+			mockLatest: mocks.GenericHeight,
+
+			wantRetention: &archive.RegisterRetention{Height: mocks.GenericHeight - 10, Interval: 10},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "skips when not enough blocks are indexed",
+
+			retainBlocks: mocks.GenericHeight,
+
+			mockLatest: mocks.GenericHeight,
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "skips when retention did not move",
+
+			retainBlocks: 10,
+
+			mockLatest:  mocks.GenericHeight,
+			mockCurrent: archive.RegisterRetention{Height: mocks.GenericHeight - 10},
+
+			checkErr: require.NoError,
+		},
+		{
+			name: "handles latest register height failure",
+
+			mockLatestErr: mocks.GenericError,
+
+			checkErr: require.Error,
+		},
+		{
+			name: "handles prune failure",
+
+			retainBlocks: 10,
+
+			mockLatest:   mocks.GenericHeight,
+			mockPruneErr: mocks.GenericError,
+
+			wantRetention: &archive.RegisterRetention{Height: mocks.GenericHeight - 10},
+
+			checkErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			read := mocks.BaselineReader(t)
+			read.LatestRegisterHeightFunc = func() (uint64, error) {
+				return test.mockLatest, test.mockLatestErr
+			}
+			read.RegisterRetentionFunc = func() (archive.RegisterRetention, error) {
+				return test.mockCurrent, nil
+			}
+
+			var gotRetention *archive.RegisterRetention
+			lib2 := &libraryMock{
+				PruneRegistersFunc: func(retention archive.RegisterRetention, _ func(archive.PruneProgress)) error {
+					gotRetention = &retention
+					return test.mockPruneErr
+				},
+			}
+
+			p := New(zerolog.Nop(), read, lib2, nil,
+				WithRetainBlocks(test.retainBlocks),
+				WithInterval(test.interval),
+			)
+
+			err := p.Prune()
+
+			test.checkErr(t, err)
+			assert.Equal(t, test.wantRetention, gotRetention)
+		})
+	}
+}
+
+type libraryMock struct {
+	archive.WriteLibrary2
+
+	PruneRegistersFunc func(retention archive.RegisterRetention, progress func(archive.PruneProgress)) error
+}
+
+func (l *libraryMock) PruneRegisters(retention archive.RegisterRetention, progress func(archive.PruneProgress)) error {
+	return l.PruneRegistersFunc(retention, progress)
+}
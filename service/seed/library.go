@@ -0,0 +1,54 @@
+package seed
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Library is a seed that reads the registers from a local pebble-based index,
+// such as a checkpoint created with the `create-checkpoint` command or a
+// restored index snapshot.
+type Library struct {
+	lib2 archive.ReadLibrary2
+}
+
+// FromLibrary creates a seed that reads the registers from the given library.
+func FromLibrary(lib2 archive.ReadLibrary2) *Library {
+
+	l := Library{
+		lib2: lib2,
+	}
+
+	return &l
+}
+
+// Registers calls fn for every register of the index with its value at the
+// given height. Removed registers are not included.
+func (l *Library) Registers(height uint64, fn func(flow.RegisterEntry) error) error {
+
+	latest, err := l.lib2.GetLatestRegisterHeight()
+	if err != nil {
+		return fmt.Errorf("could not get latest register height: %w", err)
+	}
+	if height > latest {
+		return fmt.Errorf("registers are not indexed at height (height: %d, latest: %d)", height, latest)
+	}
+	retention, err := l.lib2.GetRegisterRetention()
+	if err != nil {
+		return fmt.Errorf("could not get register retention: %w", err)
+	}
+	if !retention.Retains(height) {
+		return fmt.Errorf("registers at height have been pruned (height: %d, retention height: %d, retention interval: %d)",
+			height, retention.Height, retention.Interval)
+	}
+
+	err = l.lib2.IteratePayloads(height, fn)
+	if err != nil {
+		return fmt.Errorf("could not iterate over registers: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2"
+)
+
+func TestLibrary_Registers(t *testing.T) {
+	reg1 := flow.RegisterID{Owner: "", Key: "uuid"}
+	reg2 := flow.RegisterID{Owner: string(flow.HexToAddress("0x01").Bytes()), Key: "public/key"}
+
+	setup := func(t *testing.T) archive.Library2 {
+		t.Helper()
+
+		lib2, err := storage2.NewLibrary2(t.TempDir(), 1<<20)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, lib2.Close())
+		})
+
+		require.NoError(t, lib2.BatchSetPayload(1, flow.RegisterEntries{{Key: reg1, Value: []byte("1")}, {Key: reg2, Value: []byte("2")}}))
+		require.NoError(t, lib2.BatchSetPayload(3, flow.RegisterEntries{{Key: reg1, Value: []byte("3")}}))
+		require.NoError(t, lib2.SetLatestRegisterHeight(3))
+
+		return lib2
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		seed := FromLibrary(setup(t))
+
+		got := make(map[flow.RegisterID]string)
+		err := seed.Registers(2, func(entry flow.RegisterEntry) error {
+			got[entry.Key] = string(entry.Value)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[flow.RegisterID]string{reg1: "1", reg2: "2"}, got)
+	})
+
+	t.Run("handles height above latest register height", func(t *testing.T) {
+		t.Parallel()
+
+		seed := FromLibrary(setup(t))
+
+		err := seed.Registers(4, func(flow.RegisterEntry) error { return nil })
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles pruned height", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := setup(t)
+		require.NoError(t, lib2.PruneRegisters(archive.RegisterRetention{Height: 3}, func(archive.PruneProgress) {}))
+		seed := FromLibrary(lib2)
+
+		err := seed.Registers(2, func(flow.RegisterEntry) error { return nil })
+
+		assert.Error(t, err)
+	})
+}
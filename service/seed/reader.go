@@ -0,0 +1,78 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/util"
+)
+
+// Reader is a seed that reads the registers through an index reader, such as
+// the client of a remote Archive API.
+//
+// Index readers can only list the registers of a given owner, so the owners are
+// enumerated from the address generator state of the execution state: the
+// registers without owner come first, followed by the registers of every
+// account address that was generated up to the height.
+type Reader struct {
+	read archive.Reader
+}
+
+// FromReader creates a seed that reads the registers from the given reader.
+func FromReader(read archive.Reader) *Reader {
+
+	r := Reader{
+		read: read,
+	}
+
+	return &r
+}
+
+// Registers calls fn for every register of the index with its value at the
+// given height. Removed registers are not included.
+func (r *Reader) Registers(height uint64, fn func(flow.RegisterEntry) error) error {
+
+	err := util.ValidateRegisterHeightIndexed(r.read, height)
+	if err != nil {
+		return err
+	}
+
+	header, err := r.read.Header(height)
+	if err != nil {
+		return fmt.Errorf("could not get header: %w", err)
+	}
+	chain := header.ChainID.Chain()
+
+	// The registers without owner include the state of the address generator,
+	// which tells us how many accounts exist at the height.
+	var state []byte
+	err = r.read.RegistersByOwner(height, "", func(entry flow.RegisterEntry) error {
+		if entry.Key == flow.AddressStateRegisterID {
+			state = entry.Value
+		}
+		return fn(entry)
+	})
+	if err != nil {
+		return fmt.Errorf("could not list registers without owner: %w", err)
+	}
+	if state == nil {
+		return errors.New("missing address generator state")
+	}
+
+	count := chain.BytesToAddressGenerator(state).AddressCount()
+	for index := uint64(1); index <= count; index++ {
+		address, err := chain.AddressAtIndex(index)
+		if err != nil {
+			return fmt.Errorf("could not get address (index: %d): %w", index, err)
+		}
+		err = r.read.RegistersByOwner(height, string(address.Bytes()), fn)
+		if err != nil {
+			return fmt.Errorf("could not list registers of owner (address: %s): %w", address, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestReader_Registers(t *testing.T) {
+	chain := mocks.GenericHeader.ChainID.Chain()
+	generator := chain.NewAddressGenerator()
+	var addresses []flow.Address
+	for i := 0; i < 3; i++ {
+		address, err := generator.NextAddress()
+		require.NoError(t, err)
+		addresses = append(addresses, address)
+	}
+
+	// Every owner has a single register, except for the empty owner, which
+	// also holds the address generator state.
+	registers := map[string]flow.RegisterEntries{
+		"": {
+			{Key: flow.UUIDRegisterID, Value: []byte("uuid")},
+			{Key: flow.AddressStateRegisterID, Value: generator.Bytes()},
+		},
+	}
+	for _, address := range addresses {
+		owner := string(address.Bytes())
+		registers[owner] = flow.RegisterEntries{{Key: flow.RegisterID{Owner: owner, Key: "public/key"}, Value: address.Bytes()}}
+	}
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		read := mocks.BaselineReader(t)
+		read.RegistersByOwnerFunc = func(height uint64, owner string, fn func(flow.RegisterEntry) error) error {
+			assert.Equal(t, mocks.GenericHeight, height)
+			for _, entry := range registers[owner] {
+				err := fn(entry)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		seed := FromReader(read)
+
+		var got flow.RegisterEntries
+		err := seed.Registers(mocks.GenericHeight, func(entry flow.RegisterEntry) error {
+			got = append(got, entry)
+			return nil
+		})
+
+		require.NoError(t, err)
+		var want flow.RegisterEntries
+		want = append(want, registers[""]...)
+		for _, address := range addresses {
+			want = append(want, registers[string(address.Bytes())]...)
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("handles missing address generator state", func(t *testing.T) {
+		t.Parallel()
+
+		read := mocks.BaselineReader(t)
+		read.RegistersByOwnerFunc = func(uint64, string, func(flow.RegisterEntry) error) error {
+			return nil
+		}
+
+		seed := FromReader(read)
+
+		err := seed.Registers(mocks.GenericHeight, func(flow.RegisterEntry) error { return nil })
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles height without registers", func(t *testing.T) {
+		t.Parallel()
+
+		read := mocks.BaselineReader(t)
+		read.LatestRegisterHeightFunc = func() (uint64, error) {
+			return mocks.GenericHeight - 1, nil
+		}
+
+		seed := FromReader(read)
+
+		err := seed.Registers(mocks.GenericHeight, func(flow.RegisterEntry) error { return nil })
+
+		assert.Error(t, err)
+	})
+
+	t.Run("handles reader failure", func(t *testing.T) {
+		t.Parallel()
+
+		read := mocks.BaselineReader(t)
+		read.RegistersByOwnerFunc = func(uint64, string, func(flow.RegisterEntry) error) error {
+			return mocks.GenericError
+		}
+
+		seed := FromReader(read)
+
+		err := seed.Registers(mocks.GenericHeight, func(flow.RegisterEntry) error { return nil })
+
+		assert.ErrorIs(t, err, mocks.GenericError)
+	})
+
+	t.Run("handles header failure", func(t *testing.T) {
+		t.Parallel()
+
+		read := mocks.BaselineReader(t)
+		read.HeaderFunc = func(uint64) (*flow.Header, error) {
+			return nil, archive.ErrNotFound
+		}
+
+		seed := FromReader(read)
+
+		err := seed.Registers(mocks.GenericHeight, func(flow.RegisterEntry) error { return nil })
+
+		assert.ErrorIs(t, err, archive.ErrNotFound)
+	})
+}
@@ -0,0 +1,195 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Write writes the snapshot in the given directory to the writer as a single
+// tar stream. The database files listed in the manifest at the root of the
+// directory come first, and the manifest itself is written last, so that a
+// truncated stream can never be mistaken for a complete snapshot.
+func Write(writer io.Writer, dir string) error {
+
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	tarball := tar.NewWriter(writer)
+	for _, file := range m.Files {
+		err = writeFile(tarball, dir, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		return fmt.Errorf("could not read manifest: %w", err)
+	}
+	header := tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ManifestName,
+		Size:     int64(len(data)),
+		Mode:     0644,
+		ModTime:  time.Now(),
+	}
+	err = tarball.WriteHeader(&header)
+	if err != nil {
+		return fmt.Errorf("could not write manifest header: %w", err)
+	}
+	_, err = tarball.Write(data)
+	if err != nil {
+		return fmt.Errorf("could not write manifest: %w", err)
+	}
+
+	return tarball.Close()
+}
+
+// Read extracts the snapshot read from the reader into the given directory,
+// which should be empty. It verifies that the extracted files are exactly the
+// ones listed in the manifest of the snapshot, with matching checksums, and
+// returns the manifest.
+func Read(reader io.Reader, dir string) (*Manifest, error) {
+
+	var m *Manifest
+	files := make(map[string]File)
+	tarball := tar.NewReader(reader)
+	for {
+		header, err := tarball.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read file header: %w", err)
+		}
+
+		// Make sure that no file can be written outside the directory.
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return nil, fmt.Errorf("invalid file path in archive (name: %s)", header.Name)
+		}
+
+		switch {
+		case header.Typeflag == tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+			if err != nil {
+				return nil, fmt.Errorf("could not create directory (path: %s): %w", path, err)
+			}
+		case header.Typeflag == tar.TypeReg && header.Name == ManifestName:
+			data, err := io.ReadAll(tarball)
+			if err != nil {
+				return nil, fmt.Errorf("could not read manifest: %w", err)
+			}
+			m, err = decodeManifest(data)
+			if err != nil {
+				return nil, err
+			}
+			err = extractFile(path, bytes.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+		case header.Typeflag == tar.TypeReg:
+			if m != nil {
+				return nil, fmt.Errorf("unexpected file after manifest (name: %s)", header.Name)
+			}
+			hash := sha256.New()
+			err = extractFile(path, io.TeeReader(tarball, hash))
+			if err != nil {
+				return nil, err
+			}
+			files[header.Name] = File{
+				Name:     header.Name,
+				Size:     header.Size,
+				Checksum: hex.EncodeToString(hash.Sum(nil)),
+			}
+		default:
+			return nil, fmt.Errorf("unsupported file type in archive (name: %s, type: %d)", header.Name, header.Typeflag)
+		}
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("missing snapshot manifest")
+	}
+
+	for _, want := range m.Files {
+		got, ok := files[want.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing snapshot file (name: %s)", want.Name)
+		}
+		if got.Size != want.Size || got.Checksum != want.Checksum {
+			return nil, fmt.Errorf("snapshot file checksum mismatch (name: %s)", want.Name)
+		}
+		delete(files, want.Name)
+	}
+	if len(files) != 0 {
+		return nil, fmt.Errorf("snapshot contains files that are not in its manifest (count: %d)", len(files))
+	}
+
+	return m, nil
+}
+
+func writeFile(tarball *tar.Writer, dir string, file File) error {
+
+	path := filepath.Join(dir, filepath.FromSlash(file.Name))
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not get file info (path: %s): %w", path, err)
+	}
+	if info.Size() != file.Size {
+		return fmt.Errorf("file size does not match manifest (path: %s)", path)
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("could not create file header (path: %s): %w", path, err)
+	}
+	header.Name = file.Name
+
+	err = tarball.WriteHeader(header)
+	if err != nil {
+		return fmt.Errorf("could not write file header (path: %s): %w", path, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open file (path: %s): %w", path, err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tarball, f)
+	if err != nil {
+		return fmt.Errorf("could not write file (path: %s): %w", path, err)
+	}
+
+	return nil
+}
+
+func extractFile(path string, reader io.Reader) error {
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("could not create directory (path: %s): %w", filepath.Dir(path), err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("could not create file (path: %s): %w", path, err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	if err != nil {
+		return fmt.Errorf("could not write file (path: %s): %w", path, err)
+	}
+
+	return file.Sync()
+}
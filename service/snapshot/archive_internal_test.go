@@ -0,0 +1,117 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRead(t *testing.T) {
+	lib2 := testIndex(t)
+
+	// Use a checkpoint of the test index as the snapshot directory.
+	src := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, lib2.Checkpoint(src))
+
+	m, err := NewManifest(lib2, src)
+	require.NoError(t, err)
+	require.NotEmpty(t, m.Files)
+	require.NoError(t, WriteManifest(src, m))
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, src))
+	archive := buf.Bytes()
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		dst := t.TempDir()
+		got, err := Read(bytes.NewReader(archive), dst)
+		require.NoError(t, err)
+		assert.Equal(t, m, got)
+
+		for _, file := range m.Files {
+			want, err := os.ReadFile(filepath.Join(src, file.Name))
+			require.NoError(t, err)
+			data, err := os.ReadFile(filepath.Join(dst, file.Name))
+			require.NoError(t, err)
+			assert.Equal(t, want, data)
+		}
+		_, err = os.Stat(filepath.Join(dst, ManifestName))
+		assert.NoError(t, err)
+	})
+
+	t.Run("handles truncated archive", func(t *testing.T) {
+		t.Parallel()
+
+		// Cutting off the end removes the manifest, which is written last.
+		_, err := Read(bytes.NewReader(archive[:len(archive)/2]), t.TempDir())
+		assert.Error(t, err)
+	})
+
+	t.Run("handles corrupted file", func(t *testing.T) {
+		t.Parallel()
+
+		corrupted := *m
+		corrupted.Files = append([]File{}, m.Files...)
+		corrupted.Files[0].Checksum = "00"
+
+		var buf bytes.Buffer
+		tarball := tar.NewWriter(&buf)
+		for _, file := range m.Files {
+			require.NoError(t, writeFile(tarball, src, file))
+		}
+		writeTestManifest(t, tarball, &corrupted)
+		require.NoError(t, tarball.Close())
+
+		_, err := Read(&buf, t.TempDir())
+		assert.Error(t, err)
+	})
+
+	t.Run("handles missing file", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		tarball := tar.NewWriter(&buf)
+		for _, file := range m.Files[1:] {
+			require.NoError(t, writeFile(tarball, src, file))
+		}
+		writeTestManifest(t, tarball, m)
+		require.NoError(t, tarball.Close())
+
+		_, err := Read(&buf, t.TempDir())
+		assert.Error(t, err)
+	})
+
+	t.Run("handles path outside of directory", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		tarball := tar.NewWriter(&buf)
+		header := tar.Header{Typeflag: tar.TypeReg, Name: "../outside", Mode: 0644}
+		require.NoError(t, tarball.WriteHeader(&header))
+		require.NoError(t, tarball.Close())
+
+		_, err := Read(&buf, t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func writeTestManifest(t *testing.T, tarball *tar.Writer, m *Manifest) {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, WriteManifest(dir, m))
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	require.NoError(t, err)
+
+	header := tar.Header{Typeflag: tar.TypeReg, Name: ManifestName, Size: int64(len(data)), Mode: 0644}
+	require.NoError(t, tarball.WriteHeader(&header))
+	_, err = tarball.Write(data)
+	require.NoError(t, err)
+}
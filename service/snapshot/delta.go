@@ -0,0 +1,383 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/contracts"
+	"github.com/onflow/flow-archive/service/tokens"
+)
+
+// deltaChunkSize is the number of heights written to each file of a delta
+// snapshot.
+const deltaChunkSize = 1000
+
+// deltaDir is the directory of the files of a delta snapshot.
+const deltaDir = "delta"
+
+// Height is the index data of a single height within a delta snapshot. Block
+// data and registers are exported for different ranges of heights, as the
+// registers of an index can lag behind its blocks, so either can be missing.
+type Height struct {
+	Height uint64
+
+	Header       *flow.Header
+	Commit       flow.StateCommitment
+	Events       []flow.Event
+	Collections  []*flow.LightCollection
+	Guarantees   []*flow.CollectionGuarantee
+	Transactions []*flow.TransactionBody
+	Results      []*flow.TransactionResult
+	Seals        []*flow.Seal
+
+	Registers flow.RegisterEntries
+}
+
+// NewDelta exports the index data that was added to the given index since the
+// given base snapshot as files in the given directory, and returns the
+// manifest of the resulting delta snapshot. Block data is exported for the
+// heights above the last height of the base, and registers for the heights
+// above its latest register height.
+func NewDelta(lib archive.ReadLibrary2, codec archive.Codec, base *Manifest, dir string) (*Manifest, error) {
+
+	m := Manifest{
+		Version: FormatVersion,
+		Base: &Base{
+			Last:                 base.Last,
+			LatestRegisterHeight: base.LatestRegisterHeight,
+		},
+	}
+
+	err := m.readHeights(lib)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.ChainID != base.ChainID || m.First != base.First {
+		return nil, fmt.Errorf("base snapshot was not taken from this index (chain: %s, first: %d)", base.ChainID, base.First)
+	}
+	if m.Last < base.Last || m.LatestRegisterHeight < base.LatestRegisterHeight {
+		return nil, fmt.Errorf("base snapshot is ahead of index (last: %d, latest register height: %d)", base.Last, base.LatestRegisterHeight)
+	}
+
+	// Register versions that were pruned can not be exported anymore.
+	retention, err := lib.GetRegisterRetention()
+	if err != nil {
+		return nil, fmt.Errorf("could not get register retention: %w", err)
+	}
+	if m.LatestRegisterHeight > base.LatestRegisterHeight && retention.Height > base.LatestRegisterHeight+1 {
+		return nil, fmt.Errorf("registers above base were pruned (retention height: %d)", retention.Height)
+	}
+
+	start := base.Last
+	if base.LatestRegisterHeight < start {
+		start = base.LatestRegisterHeight
+	}
+	end := m.Last
+	if m.LatestRegisterHeight > end {
+		end = m.LatestRegisterHeight
+	}
+
+	err = os.MkdirAll(filepath.Join(dir, deltaDir), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create delta directory: %w", err)
+	}
+
+	chunk := make([]Height, 0, deltaChunkSize)
+	for height := start + 1; height <= end; height++ {
+		data := Height{Height: height}
+		if height > base.Last && height <= m.Last {
+			err = readBlock(lib, &data)
+			if err != nil {
+				return nil, fmt.Errorf("could not read block data (height: %d): %w", height, err)
+			}
+		}
+		if height > base.LatestRegisterHeight && height <= m.LatestRegisterHeight {
+			err = readRegisters(lib, &data)
+			if err != nil {
+				return nil, fmt.Errorf("could not read registers (height: %d): %w", height, err)
+			}
+		}
+
+		chunk = append(chunk, data)
+		if len(chunk) < deltaChunkSize && height != end {
+			continue
+		}
+
+		err = writeChunk(codec, dir, chunk)
+		if err != nil {
+			return nil, err
+		}
+		chunk = chunk[:0]
+	}
+
+	m.Files, err = checksumFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ApplyDelta writes the index data of the delta snapshot with the given
+// manifest, which was extracted to the given directory, to the given index.
+// The index must be at the heights of the base of the delta snapshot, and is
+// verified to be at the heights of the delta snapshot afterwards.
+func ApplyDelta(lib archive.Library2, codec archive.Codec, m *Manifest, dir string) error {
+
+	if !m.IsDelta() {
+		return fmt.Errorf("snapshot is not a delta snapshot")
+	}
+
+	var current Manifest
+	err := current.readHeights(lib)
+	if err != nil {
+		return err
+	}
+	if current.ChainID != m.ChainID || current.First != m.First {
+		return fmt.Errorf("delta snapshot was not taken from this index (chain: %s, first: %d)", m.ChainID, m.First)
+	}
+	if current.Last != m.Base.Last || current.LatestRegisterHeight != m.Base.LatestRegisterHeight {
+		return fmt.Errorf("delta snapshot does not apply to index (base last: %d, index last: %d, base latest register height: %d, index latest register height: %d)",
+			m.Base.Last, current.Last, m.Base.LatestRegisterHeight, current.LatestRegisterHeight)
+	}
+
+	for _, file := range m.Files {
+		var chunk []Height
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Name)))
+		if err != nil {
+			return fmt.Errorf("could not read delta file (name: %s): %w", file.Name, err)
+		}
+		err = codec.Unmarshal(data, &chunk)
+		if err != nil {
+			return fmt.Errorf("could not decode delta file (name: %s): %w", file.Name, err)
+		}
+
+		for _, height := range chunk {
+			err = writeHeight(lib, height)
+			if err != nil {
+				return fmt.Errorf("could not write height (height: %d): %w", height.Height, err)
+			}
+		}
+	}
+
+	err = lib.SetLast(m.Last)
+	if err != nil {
+		return fmt.Errorf("could not set last height: %w", err)
+	}
+	if m.LatestRegisterHeight != m.Base.LatestRegisterHeight {
+		err = lib.SetLatestRegisterHeight(m.LatestRegisterHeight)
+		if err != nil {
+			return fmt.Errorf("could not set latest register height: %w", err)
+		}
+	}
+
+	return m.Check(lib)
+}
+
+func readBlock(lib archive.ReadLibrary2, data *Height) error {
+
+	var err error
+	data.Header, err = lib.GetHeader(data.Height)
+	if err != nil {
+		return fmt.Errorf("could not get header: %w", err)
+	}
+	data.Commit, err = lib.GetCommit(data.Height)
+	if err != nil {
+		return fmt.Errorf("could not get commit: %w", err)
+	}
+	data.Events, err = lib.GetEvents(data.Height, nil)
+	if err != nil {
+		return fmt.Errorf("could not get events: %w", err)
+	}
+
+	collIDs, err := lib.GetCollectionsForHeight(data.Height)
+	if err != nil && !errors.Is(err, archive.ErrNotFound) {
+		return fmt.Errorf("could not get collections: %w", err)
+	}
+	for _, collID := range collIDs {
+		collection, err := lib.GetCollection(collID)
+		if err != nil {
+			return fmt.Errorf("could not get collection (id: %x): %w", collID, err)
+		}
+		data.Collections = append(data.Collections, collection)
+
+		guarantee, err := lib.GetGuarantee(collID)
+		if errors.Is(err, archive.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not get guarantee (id: %x): %w", collID, err)
+		}
+		data.Guarantees = append(data.Guarantees, guarantee)
+	}
+
+	txIDs, err := lib.GetTransactionsForHeight(data.Height)
+	if err != nil && !errors.Is(err, archive.ErrNotFound) {
+		return fmt.Errorf("could not get transactions: %w", err)
+	}
+	for _, txID := range txIDs {
+		transaction, err := lib.GetTransaction(txID)
+		if err != nil {
+			return fmt.Errorf("could not get transaction (id: %x): %w", txID, err)
+		}
+		data.Transactions = append(data.Transactions, transaction)
+
+		result, err := lib.GetResult(txID)
+		if errors.Is(err, archive.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not get result (id: %x): %w", txID, err)
+		}
+		data.Results = append(data.Results, result)
+	}
+
+	sealIDs, err := lib.GetSealsForHeight(data.Height)
+	if err != nil && !errors.Is(err, archive.ErrNotFound) {
+		return fmt.Errorf("could not get seals: %w", err)
+	}
+	for _, sealID := range sealIDs {
+		seal, err := lib.GetSeal(sealID)
+		if err != nil {
+			return fmt.Errorf("could not get seal (id: %x): %w", sealID, err)
+		}
+		data.Seals = append(data.Seals, seal)
+	}
+
+	return nil
+}
+
+func readRegisters(lib archive.ReadLibrary2, data *Height) error {
+
+	regs, err := lib.GetRegistersForHeight(data.Height)
+	if err != nil {
+		return fmt.Errorf("could not get changed registers: %w", err)
+	}
+	if len(regs) == 0 {
+		return nil
+	}
+
+	values, err := lib.GetPayloads(data.Height, regs)
+	if err != nil {
+		return fmt.Errorf("could not get payloads: %w", err)
+	}
+
+	data.Registers = make(flow.RegisterEntries, 0, len(regs))
+	for i, reg := range regs {
+		data.Registers = append(data.Registers, flow.RegisterEntry{Key: reg, Value: values[i]})
+	}
+
+	return nil
+}
+
+func writeChunk(codec archive.Codec, dir string, chunk []Height) error {
+
+	data, err := codec.Marshal(chunk)
+	if err != nil {
+		return fmt.Errorf("could not encode delta file: %w", err)
+	}
+
+	// Heights are zero-padded so that the files sort in height order.
+	name := path.Join(deltaDir, fmt.Sprintf("%020d", chunk[0].Height))
+	err = os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write delta file (name: %s): %w", name, err)
+	}
+
+	return nil
+}
+
+func writeHeight(lib archive.WriteLibrary2, data Height) error {
+
+	if data.Header != nil {
+		err := lib.SetHeightForBlock(data.Header.ID(), data.Height)
+		if err != nil {
+			return fmt.Errorf("could not index block height: %w", err)
+		}
+		err = lib.SetHeader(data.Height, data.Header)
+		if err != nil {
+			return fmt.Errorf("could not write header: %w", err)
+		}
+		err = lib.SetCommit(data.Height, data.Commit)
+		if err != nil {
+			return fmt.Errorf("could not write commit: %w", err)
+		}
+		err = lib.BatchSetEvents(data.Height, data.Events)
+		if err != nil {
+			return fmt.Errorf("could not write events: %w", err)
+		}
+		err = lib.BatchSetCollections(data.Height, data.Collections)
+		if err != nil {
+			return fmt.Errorf("could not write collections: %w", err)
+		}
+		err = lib.BatchSetGuarantees(data.Guarantees)
+		if err != nil {
+			return fmt.Errorf("could not write guarantees: %w", err)
+		}
+		err = lib.BatchSetTransactions(data.Height, data.Transactions)
+		if err != nil {
+			return fmt.Errorf("could not write transactions: %w", err)
+		}
+		err = lib.BatchSetResults(data.Results)
+		if err != nil {
+			return fmt.Errorf("could not write results: %w", err)
+		}
+		err = lib.BatchSetSeals(data.Height, data.Seals)
+		if err != nil {
+			return fmt.Errorf("could not write seals: %w", err)
+		}
+
+		// Token transfers are not part of the snapshot, as they can be derived
+		// from the events of the height for the tokens of known chains.
+		params, ok := archive.FlowParams[data.Header.ChainID]
+		if ok {
+			transfers, err := tokens.NewExtractor(zerolog.Nop(), params).Transfers(data.Height, data.Events)
+			if err != nil {
+				return fmt.Errorf("could not extract token transfers: %w", err)
+			}
+			err = lib.BatchSetTransfers(data.Height, transfers)
+			if err != nil {
+				return fmt.Errorf("could not write token transfers: %w", err)
+			}
+		}
+
+		// The same goes for contract updates, except that they exist on every
+		// chain. Contract events that can not be decoded are skipped, just as
+		// when they were indexed by the mapper.
+		updates, err := contracts.NewExtractor(zerolog.Nop()).Updates(data.Height, data.Events)
+		if err != nil {
+			return fmt.Errorf("could not extract contract updates: %w", err)
+		}
+		err = lib.BatchSetContractUpdates(data.Height, updates)
+		if err != nil {
+			return fmt.Errorf("could not write contract updates: %w", err)
+		}
+	}
+
+	if len(data.Registers) != 0 {
+		err := lib.BatchSetPayload(data.Height, data.Registers)
+		if err != nil {
+			return fmt.Errorf("could not write registers: %w", err)
+		}
+
+		regs := make(flow.RegisterIDs, 0, len(data.Registers))
+		for _, entry := range data.Registers {
+			regs = append(regs, entry.Key)
+		}
+		err = lib.BatchSetRegistersForHeight(data.Height, regs)
+		if err != nil {
+			return fmt.Errorf("could not index changed registers: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package snapshot
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/codec/zbor"
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestDelta(t *testing.T) {
+	codec := zbor.NewCodec()
+
+	// The index is at height 3 when the base snapshot is taken, with registers
+	// lagging one height behind.
+	source := deltaIndex(t, t.TempDir())
+	for height := uint64(1); height <= 3; height++ {
+		writeTestHeight(t, source, height, height <= 2)
+	}
+	require.NoError(t, source.SetLast(3))
+	require.NoError(t, source.SetLatestRegisterHeight(2))
+
+	checkpoint := filepath.Join(t.TempDir(), "base")
+	require.NoError(t, source.Checkpoint(checkpoint))
+	base, err := NewManifest(source, checkpoint)
+	require.NoError(t, err)
+
+	for height := uint64(3); height <= 5; height++ {
+		writeTestHeight(t, source, height, true)
+	}
+	require.NoError(t, source.SetLast(5))
+	require.NoError(t, source.SetLatestRegisterHeight(5))
+
+	dir := t.TempDir()
+	m, err := NewDelta(source, codec, base, dir)
+	require.NoError(t, err)
+	require.True(t, m.IsDelta())
+	assert.Equal(t, &Base{Last: 3, LatestRegisterHeight: 2}, m.Base)
+	assert.Equal(t, uint64(5), m.Last)
+	assert.Equal(t, uint64(5), m.LatestRegisterHeight)
+	require.NoError(t, WriteManifest(dir, m))
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, dir))
+	delta := buf.Bytes()
+
+	t.Run("nominal case", func(t *testing.T) {
+		target := deltaIndex(t, checkpoint)
+
+		extracted := t.TempDir()
+		got, err := Read(bytes.NewReader(delta), extracted)
+		require.NoError(t, err)
+		require.NoError(t, ApplyDelta(target, codec, got, extracted))
+
+		for height := uint64(3); height <= 5; height++ {
+			want, err := source.GetHeader(height)
+			require.NoError(t, err)
+			header, err := target.GetHeader(height)
+			require.NoError(t, err)
+			assert.Equal(t, want, header)
+
+			blockHeight, err := target.GetHeightForBlock(header.ID())
+			require.NoError(t, err)
+			assert.Equal(t, height, blockHeight)
+
+			wantEvents, err := source.GetEvents(height, nil)
+			require.NoError(t, err)
+			events, err := target.GetEvents(height, nil)
+			require.NoError(t, err)
+			assert.Equal(t, wantEvents, events)
+
+			wantTxIDs, err := source.GetTransactionsForHeight(height)
+			require.NoError(t, err)
+			txIDs, err := target.GetTransactionsForHeight(height)
+			require.NoError(t, err)
+			assert.Equal(t, wantTxIDs, txIDs)
+
+			wantRegs, err := source.GetRegistersForHeight(height)
+			require.NoError(t, err)
+			regs, err := target.GetRegistersForHeight(height)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, wantRegs, regs)
+
+			wantValues, err := source.GetPayloads(height, wantRegs)
+			require.NoError(t, err)
+			values, err := target.GetPayloads(height, wantRegs)
+			require.NoError(t, err)
+			assert.Equal(t, wantValues, values)
+		}
+	})
+
+	t.Run("handles index not at base", func(t *testing.T) {
+		target := deltaIndex(t, checkpoint)
+		require.NoError(t, target.SetLast(4))
+
+		extracted := t.TempDir()
+		got, err := Read(bytes.NewReader(delta), extracted)
+		require.NoError(t, err)
+
+		assert.Error(t, ApplyDelta(target, codec, got, extracted))
+	})
+
+	t.Run("handles full snapshot", func(t *testing.T) {
+		target := deltaIndex(t, checkpoint)
+
+		assert.Error(t, ApplyDelta(target, codec, base, checkpoint))
+	})
+
+	t.Run("handles base of other index", func(t *testing.T) {
+		other := *base
+		other.First++
+
+		_, err := NewDelta(source, codec, &other, t.TempDir())
+		assert.Error(t, err)
+	})
+
+	t.Run("handles pruned registers", func(t *testing.T) {
+		pruned := deltaIndex(t, t.TempDir())
+		for height := uint64(1); height <= 5; height++ {
+			writeTestHeight(t, pruned, height, true)
+		}
+		require.NoError(t, pruned.SetLast(5))
+		require.NoError(t, pruned.SetLatestRegisterHeight(5))
+		require.NoError(t, pruned.PruneRegisters(archive.RegisterRetention{Height: 4}, func(archive.PruneProgress) {}))
+
+		_, err := NewDelta(pruned, codec, base, t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func deltaIndex(t *testing.T, dir string) archive.Library2 {
+	t.Helper()
+
+	lib2, err := storage2.NewLibrary2(dir, 1<<20)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, lib2.Close())
+	})
+
+	return lib2
+}
+
+// writeTestHeight writes the block data of the given height to the index, as
+// well as its registers if requested.
+func writeTestHeight(t *testing.T, lib2 archive.Library2, height uint64, registers bool) {
+	t.Helper()
+
+	header := *mocks.GenericHeader
+	header.Height = height
+	transactions := mocks.GenericTransactions(2)
+	for _, transaction := range transactions {
+		transaction.ReferenceBlockID = flow.Identifier{byte(height)}
+	}
+
+	if height == 1 {
+		require.NoError(t, lib2.SetFirst(height))
+	}
+	require.NoError(t, lib2.SetHeightForBlock(header.ID(), height))
+	require.NoError(t, lib2.SetHeader(height, &header))
+	require.NoError(t, lib2.SetCommit(height, mocks.GenericCommit(int(height))))
+	require.NoError(t, lib2.BatchSetEvents(height, mocks.GenericEvents(4, mocks.GenericEventTypes(2)...)))
+	require.NoError(t, lib2.BatchSetCollections(height, mocks.GenericCollections(2)))
+	require.NoError(t, lib2.BatchSetGuarantees(mocks.GenericGuarantees(2)))
+	require.NoError(t, lib2.BatchSetTransactions(height, transactions))
+	require.NoError(t, lib2.BatchSetResults(mocks.GenericResults(2)))
+	require.NoError(t, lib2.BatchSetSeals(height, mocks.GenericSeals(2)))
+
+	if !registers {
+		return
+	}
+
+	entries := flow.RegisterEntries{
+		{Key: mocks.GenericRegister(0), Value: []byte{byte(height)}},
+		{Key: mocks.GenericRegister(int(height)), Value: []byte{byte(height), 1}},
+	}
+	regs := flow.RegisterIDs{entries[0].Key, entries[1].Key}
+	require.NoError(t, lib2.BatchSetPayload(height, entries))
+	require.NoError(t, lib2.BatchSetRegistersForHeight(height, regs))
+}
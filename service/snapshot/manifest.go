@@ -0,0 +1,250 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// FormatVersion is the version of the snapshot format that is written by this
+// package. Snapshots with a different version can not be restored.
+const FormatVersion = 1
+
+// ManifestName is the name of the manifest file at the root of a snapshot.
+const ManifestName = "snapshot.json"
+
+// Manifest describes the content of a snapshot: the heights and chain of the
+// index it was taken from, and the checksums of all of its files.
+type Manifest struct {
+	Version              uint         `json:"version"`
+	ChainID              flow.ChainID `json:"chain_id"`
+	First                uint64       `json:"first"`
+	Last                 uint64       `json:"last"`
+	LatestRegisterHeight uint64       `json:"latest_register_height"`
+	Base                 *Base        `json:"base,omitempty"`
+	Files                []File       `json:"files"`
+}
+
+// Base identifies the index that a delta snapshot applies on top of, by the
+// heights it was at. Only delta snapshots have a base.
+type Base struct {
+	Last                 uint64 `json:"last"`
+	LatestRegisterHeight uint64 `json:"latest_register_height"`
+}
+
+// File is a database file of a snapshot, with its path relative to the root of
+// the snapshot and the hex-encoded SHA-256 checksum of its content.
+type File struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// NewManifest creates the manifest of a snapshot of the given index, whose
+// database files are in the given directory. The index must not be written to
+// between taking the snapshot and creating its manifest.
+func NewManifest(lib archive.ReadLibrary2, dir string) (*Manifest, error) {
+
+	m := Manifest{
+		Version: FormatVersion,
+	}
+
+	err := m.readHeights(lib)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Files, err = checksumFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// IsDelta returns whether the manifest describes a delta snapshot.
+func (m *Manifest) IsDelta() bool {
+	return m.Base != nil
+}
+
+// Check verifies that the given index is at the heights and on the chain that
+// are described by the manifest.
+func (m *Manifest) Check(lib archive.ReadLibrary2) error {
+
+	var got Manifest
+	err := got.readHeights(lib)
+	if err != nil {
+		return err
+	}
+
+	if got.ChainID != m.ChainID {
+		return fmt.Errorf("chain ID mismatch (manifest: %s, index: %s)", m.ChainID, got.ChainID)
+	}
+	if got.First != m.First {
+		return fmt.Errorf("first height mismatch (manifest: %d, index: %d)", m.First, got.First)
+	}
+	if got.Last != m.Last {
+		return fmt.Errorf("last height mismatch (manifest: %d, index: %d)", m.Last, got.Last)
+	}
+	if got.LatestRegisterHeight != m.LatestRegisterHeight {
+		return fmt.Errorf("latest register height mismatch (manifest: %d, index: %d)", m.LatestRegisterHeight, got.LatestRegisterHeight)
+	}
+
+	return nil
+}
+
+// WriteManifest writes the given manifest to the root of the snapshot in the
+// given directory.
+func WriteManifest(dir string, m *Manifest) error {
+	return WriteManifestFile(filepath.Join(dir, ManifestName), m)
+}
+
+// WriteManifestFile writes the given manifest to the file at the given path.
+func WriteManifestFile(path string, m *Manifest) error {
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode manifest: %w", err)
+	}
+
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write manifest (path: %s): %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadManifest reads the manifest at the root of the snapshot in the given
+// directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	return ReadManifestFile(filepath.Join(dir, ManifestName))
+}
+
+// ReadManifestFile reads the manifest in the file at the given path.
+func ReadManifestFile(path string) (*Manifest, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest (path: %s): %w", path, err)
+	}
+
+	return decodeManifest(data)
+}
+
+func decodeManifest(data []byte) (*Manifest, error) {
+
+	var m Manifest
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+
+	if m.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version (version: %d, supported: %d)", m.Version, FormatVersion)
+	}
+
+	return &m, nil
+}
+
+// readHeights reads the heights and chain ID of the given index into the
+// manifest.
+func (m *Manifest) readHeights(lib archive.ReadLibrary2) error {
+
+	first, err := lib.GetFirst()
+	if err != nil {
+		return fmt.Errorf("could not get first height: %w", err)
+	}
+	last, err := lib.GetLast()
+	if err != nil {
+		return fmt.Errorf("could not get last height: %w", err)
+	}
+	header, err := lib.GetHeader(first)
+	if err != nil {
+		return fmt.Errorf("could not get first header: %w", err)
+	}
+
+	// Indexes that skip registers never index a register height.
+	latest, err := lib.GetLatestRegisterHeight()
+	if err != nil && !errors.Is(err, archive.ErrNotFound) {
+		return fmt.Errorf("could not get latest register height: %w", err)
+	}
+
+	m.ChainID = header.ChainID
+	m.First = first
+	m.Last = last
+	m.LatestRegisterHeight = latest
+
+	return nil
+}
+
+// checksumFiles returns the files within the given directory, except for the
+// manifest, with their checksums.
+func checksumFiles(dir string) ([]File, error) {
+
+	var files []File
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("could not get relative path (path: %s): %w", path, err)
+		}
+		name = filepath.ToSlash(name)
+		if name == ManifestName {
+			return nil
+		}
+
+		file, err := checksumFile(path)
+		if err != nil {
+			return err
+		}
+		file.Name = name
+
+		files = append(files, file)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not checksum snapshot files: %w", err)
+	}
+
+	return files, nil
+}
+
+func checksumFile(path string) (File, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return File{}, fmt.Errorf("could not open file (path: %s): %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return File{}, fmt.Errorf("could not read file (path: %s): %w", path, err)
+	}
+
+	f := File{
+		Size:     size,
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+	}
+
+	return f, nil
+}
@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/testing/helpers"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestNewManifest(t *testing.T) {
+	lib2 := testIndex(t)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blocks.db"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blocks.db", "000001.sst"), []byte("test"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestName), []byte("{}"), 0644))
+
+	m, err := NewManifest(lib2, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(FormatVersion), m.Version)
+	assert.Equal(t, mocks.GenericHeader.ChainID, m.ChainID)
+	assert.Equal(t, mocks.GenericHeight, m.First)
+	assert.Equal(t, mocks.GenericHeight+1, m.Last)
+	assert.Equal(t, mocks.GenericHeight+1, m.LatestRegisterHeight)
+
+	// The manifest itself is not part of the files of the snapshot.
+	require.Len(t, m.Files, 1)
+	assert.Equal(t, "blocks.db/000001.sst", m.Files[0].Name)
+	assert.Equal(t, int64(4), m.Files[0].Size)
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", m.Files[0].Checksum)
+}
+
+func TestManifest_Check(t *testing.T) {
+	lib2 := testIndex(t)
+
+	m, err := NewManifest(lib2, t.TempDir())
+	require.NoError(t, err)
+
+	t.Run("nominal case", func(t *testing.T) {
+		assert.NoError(t, m.Check(lib2))
+	})
+
+	t.Run("handles mismatching heights", func(t *testing.T) {
+		other := *m
+		other.Last++
+
+		assert.Error(t, other.Check(lib2))
+	})
+
+	t.Run("handles mismatching chain", func(t *testing.T) {
+		other := *m
+		other.ChainID = "flow-unknown"
+
+		assert.Error(t, other.Check(lib2))
+	})
+}
+
+func TestManifest_Roundtrip(t *testing.T) {
+	lib2 := testIndex(t)
+	dir := t.TempDir()
+
+	m, err := NewManifest(lib2, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, WriteManifest(dir, m))
+
+	got, err := ReadManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, m, got)
+
+	m.Version = FormatVersion + 1
+	require.NoError(t, WriteManifest(dir, m))
+
+	_, err = ReadManifest(dir)
+	assert.Error(t, err)
+}
+
+// testIndex returns a pebble-based index with a couple of indexed heights.
+func testIndex(t *testing.T) archive.Library2 {
+	t.Helper()
+
+	lib2 := helpers.Library2(t)
+	require.NoError(t, lib2.SetFirst(mocks.GenericHeight))
+	require.NoError(t, lib2.SetLast(mocks.GenericHeight+1))
+	require.NoError(t, lib2.SetLatestRegisterHeight(mocks.GenericHeight+1))
+	require.NoError(t, lib2.SetHeader(mocks.GenericHeight, mocks.GenericHeader))
+
+	return lib2
+}
@@ -0,0 +1,1354 @@
+package blocks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2/config"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Storage is a pebble-backed storage of the chain data of finalized blocks:
+// headers, commits, events, transactions, collections, guarantees, results and
+// seals, along with the heights they were indexed at.
+type Storage struct {
+	db    *pebble.DB
+	codec archive.Codec
+	write *pebble.WriteOptions
+}
+
+// NewStorage creates a pebble-backed block storage.
+//
+// Records are keyed by a one byte prefix followed by the height or identifier
+// they are looked up with, and their values are encoded and compressed with
+// the given codec.
+func NewStorage(dbPath string, cache *pebble.Cache, codec archive.Codec, options ...config.Option) (*Storage, error) {
+	opts := config.DefaultPebbleOptions(cache, pebble.DefaultComparer, options...)
+	db, err := pebble.Open(dbPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db: %w", err)
+	}
+
+	return &Storage{
+		db:    db,
+		codec: codec,
+		write: pebble.Sync,
+	}, nil
+}
+
+// GetFirst returns the height of the first indexed block.
+func (s *Storage) GetFirst() (uint64, error) {
+	return s.getHeight(newKey(PrefixFirst))
+}
+
+// GetLast returns the height of the last indexed block.
+func (s *Storage) GetLast() (uint64, error) {
+	return s.getHeight(newKey(PrefixLast))
+}
+
+// GetLatestRegisterHeight returns the latest height for which all registers are indexed.
+func (s *Storage) GetLatestRegisterHeight() (uint64, error) {
+	return s.getHeight(newKey(PrefixLatestRegisterHeight))
+}
+
+// GetBootstrapProgress returns the number of leaves of the root checkpoint
+// that were written while bootstrapping the registers at the given height.
+func (s *Storage) GetBootstrapProgress(height uint64) (uint64, error) {
+	return s.getHeight(newHeightKey(PrefixBootstrapProgress, height))
+}
+
+// GetHeightForBlock returns the height of the block with the given identifier.
+func (s *Storage) GetHeightForBlock(blockID flow.Identifier) (uint64, error) {
+	return s.getHeight(newIdentifierKey(PrefixHeightForBlock, blockID))
+}
+
+// GetHeightForTransaction returns the height of the block that contains the
+// transaction with the given identifier.
+func (s *Storage) GetHeightForTransaction(txID flow.Identifier) (uint64, error) {
+	return s.getHeight(newIdentifierKey(PrefixHeightForTransaction, txID))
+}
+
+// GetHeightForCollection returns the height of the block that contains the
+// collection with the given identifier.
+func (s *Storage) GetHeightForCollection(collID flow.Identifier) (uint64, error) {
+	return s.getHeight(newIdentifierKey(PrefixHeightForCollection, collID))
+}
+
+// GetHeightForSeal returns the height of the block that contains the seal with
+// the given identifier.
+func (s *Storage) GetHeightForSeal(sealID flow.Identifier) (uint64, error) {
+	return s.getHeight(newIdentifierKey(PrefixHeightForSeal, sealID))
+}
+
+// GetSealedHeightForBlock returns the height of the block that contains the
+// seal for the block with the given identifier. If the block has not been
+// sealed yet, the returned error wraps archive.ErrNotFound.
+//
+// Blocks sealed before the sealed block index existed are searched by decoding
+// the seals of the heights that follow them instead, which is much slower.
+func (s *Storage) GetSealedHeightForBlock(blockID flow.Identifier) (uint64, error) {
+	height, err := s.getHeight(newIdentifierKey(PrefixSealedHeightForBlock, blockID))
+	if !errors.Is(err, archive.ErrNotFound) {
+		return height, err
+	}
+
+	first, err := s.getHeight(newKey(PrefixFirstSealedHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		first = math.MaxUint64
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to get first sealed height: %w", err)
+	}
+
+	blockHeight, err := s.GetHeightForBlock(blockID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block height: %w", err)
+	}
+	if blockHeight == math.MaxUint64 || blockHeight+1 >= first {
+		return 0, fmt.Errorf("block not sealed (id: %x): %w", blockID, archive.ErrNotFound)
+	}
+
+	return s.scanSealedHeight(blockID, blockHeight+1, first-1)
+}
+
+// GetCommit returns the state commitment after the block at the given height.
+func (s *Storage) GetCommit(height uint64) (flow.StateCommitment, error) {
+	var commit flow.StateCommitment
+	err := s.get(newHeightKey(PrefixCommit, height), &commit)
+	return commit, err
+}
+
+// GetHeader returns the header of the block at the given height.
+func (s *Storage) GetHeader(height uint64) (*flow.Header, error) {
+	var header flow.Header
+	err := s.get(newHeightKey(PrefixHeader, height), &header)
+	return &header, err
+}
+
+// GetEvents returns the events emitted by the block at the given height whose
+// type is one of the given types. If no types are given, all events are returned.
+func (s *Storage) GetEvents(height uint64, types []flow.EventType) ([]flow.Event, error) {
+	lookup := make(map[uint64]struct{}, len(types))
+	for _, typ := range types {
+		lookup[eventTypeHash(typ)] = struct{}{}
+	}
+
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newHeightKey(PrefixEvents, height),
+		UpperBound: upperBound(newHeightKey(PrefixEvents, height)),
+	})
+	defer iter.Close()
+
+	var events []flow.Event
+	for valid := iter.First(); valid; valid = iter.Next() {
+		// If types were given for filtering, skip the events of other types.
+		hash := binary.BigEndian.Uint64(iter.Key()[prefixLen+heightLen:])
+		_, ok := lookup[hash]
+		if len(lookup) != 0 && !ok {
+			continue
+		}
+
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		var evts []flow.Event
+		err = s.codec.Unmarshal(val, &evts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode events (key: %x): %w", iter.Key(), err)
+		}
+
+		events = append(events, evts...)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetEventHeights returns the heights within the given range, in ascending
+// order, at which events of any of the given types were emitted. If limit is
+// greater than zero, at most limit heights are returned, starting with the
+// lowest one.
+//
+// Heights are looked up in the event type index. Heights that were indexed
+// before the event type index existed are found by scanning the keys of their
+// events instead, which does not require decoding any of them.
+func (s *Storage) GetEventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+	first, err := s.getHeight(newKey(PrefixFirstEventTypeHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		first = math.MaxUint64
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get first event type height: %w", err)
+	}
+
+	var heights []uint64
+	if startHeight < first {
+		scanEnd := endHeight
+		if scanEnd >= first {
+			scanEnd = first - 1
+		}
+		heights, err = s.scanEventHeights(types, startHeight, scanEnd, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if endHeight < first || (limit > 0 && len(heights) >= limit) {
+		return heights, nil
+	}
+
+	indexStart := startHeight
+	if indexStart < first {
+		indexStart = first
+	}
+	remaining := 0
+	if limit > 0 {
+		remaining = limit - len(heights)
+	}
+	indexed, err := s.indexedEventHeights(types, indexStart, endHeight, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(heights, indexed...), nil
+}
+
+// GetContractEvents returns the events within the given range of heights that
+// were emitted by any of the contracts deployed at the given address, grouped
+// by height in ascending order. If limit is greater than zero, the events of
+// at most limit heights are returned, starting with the lowest one.
+//
+// Heights are looked up in the contract index. Heights that were indexed
+// before the contract index existed are searched by decoding their events
+// instead, which is much slower.
+func (s *Storage) GetContractEvents(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+	first, err := s.getHeight(newKey(PrefixFirstContractEventsHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		first = math.MaxUint64
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get first contract events height: %w", err)
+	}
+
+	var blocks []archive.BlockEvents
+	if startHeight < first {
+		scanEnd := endHeight
+		if scanEnd >= first {
+			scanEnd = first - 1
+		}
+		blocks, err = s.scanContractEvents(address, startHeight, scanEnd, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if endHeight < first || (limit > 0 && len(blocks) >= limit) {
+		return blocks, nil
+	}
+
+	indexStart := startHeight
+	if indexStart < first {
+		indexStart = first
+	}
+	remaining := 0
+	if limit > 0 {
+		remaining = limit - len(blocks)
+	}
+	indexed, err := s.indexedContractEvents(address, indexStart, endHeight, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(blocks, indexed...), nil
+}
+
+// GetFirstContractUpdateHeight returns the first height that was indexed with
+// the contract update index. Heights below it have no entries in the index.
+func (s *Storage) GetFirstContractUpdateHeight() (uint64, error) {
+	return s.getHeight(newKey(PrefixFirstContractUpdateHeight))
+}
+
+// GetContractUpdates returns the changes to the contracts of the account with
+// the given address within the given range of heights from the contract update
+// index, ordered by ascending height and in execution order within a height.
+// If name is not empty, only the changes to the contract with that name are
+// returned. If limit is greater than zero, at most limit changes are returned,
+// starting with the lowest height.
+func (s *Storage) GetContractUpdates(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newContractUpdateHeightKey(address, startHeight),
+		UpperBound: upperBound(newContractUpdateHeightKey(address, endHeight)),
+	})
+	defer iter.Close()
+
+	var updates []archive.ContractUpdate
+	for valid := iter.First(); valid && (limit == 0 || len(updates) < limit); valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		var update archive.ContractUpdate
+		err = s.codec.Unmarshal(val, &update)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode contract update (key: %x): %w", iter.Key(), err)
+		}
+		if name != "" && update.Name != name {
+			continue
+		}
+		updates = append(updates, update)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over contract update index: %w", err)
+	}
+
+	return updates, nil
+}
+
+// GetLatestAccountKeysHeight returns the height up to which the changes to the
+// keys of accounts are indexed.
+func (s *Storage) GetLatestAccountKeysHeight() (uint64, error) {
+	return s.getHeight(newKey(PrefixLatestAccountKeysHeight))
+}
+
+// GetAccountKeys returns the keys of the account with the given address as
+// they were after the latest change to them at or below the given height. If
+// no change is indexed at or below that height, the returned error wraps
+// archive.ErrNotFound.
+func (s *Storage) GetAccountKeys(address flow.Address, height uint64) (archive.AccountKeys, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newAccountKeysKey(address, 0),
+		UpperBound: upperBound(newAccountKeysKey(address, height)),
+	})
+	defer iter.Close()
+
+	if !iter.Last() {
+		err := iter.Error()
+		if err != nil {
+			return archive.AccountKeys{}, fmt.Errorf("failed to seek account keys: %w", err)
+		}
+		return archive.AccountKeys{}, fmt.Errorf("no account keys at or below height (address: %s, height: %d): %w", address, height, archive.ErrNotFound)
+	}
+
+	return s.decodeAccountKeys(address, iter)
+}
+
+// GetAccountKeyHistory returns the keys of the account with the given address
+// after each height at which they changed, by ascending height.
+func (s *Storage) GetAccountKeyHistory(address flow.Address) ([]archive.AccountKeys, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newAccountKeysKey(address, 0),
+		UpperBound: upperBound(newAccountKeysKey(address, math.MaxUint64)),
+	})
+	defer iter.Close()
+
+	var history []archive.AccountKeys
+	for valid := iter.First(); valid; valid = iter.Next() {
+		keys, err := s.decodeAccountKeys(address, iter)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, keys)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over account keys: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetTransactionsForHeight returns the identifiers of the transactions in the
+// block at the given height.
+func (s *Storage) GetTransactionsForHeight(height uint64) ([]flow.Identifier, error) {
+	var txIDs []flow.Identifier
+	err := s.get(newHeightKey(PrefixTransactionsForHeight, height), &txIDs)
+	return txIDs, err
+}
+
+// GetTransactionsForAddress returns the transactions within the given range of
+// heights in which the account with the given address has any of the given
+// roles, ordered by ascending height and by identifier within a height. If
+// limit is greater than zero, at most limit transactions are returned,
+// starting with the lowest height.
+//
+// Transactions are looked up in the address index. Heights that were indexed
+// before the address index existed are searched by decoding their
+// transactions instead, which is much slower.
+func (s *Storage) GetTransactionsForAddress(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+	first, err := s.getHeight(newKey(PrefixFirstAddressTransactionHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		first = math.MaxUint64
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get first address transaction height: %w", err)
+	}
+
+	var txs []archive.AddressTransaction
+	if startHeight < first {
+		scanEnd := endHeight
+		if scanEnd >= first {
+			scanEnd = first - 1
+		}
+		txs, err = s.scanAddressTransactions(address, startHeight, scanEnd, roles, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if endHeight < first || (limit > 0 && len(txs) >= limit) {
+		return txs, nil
+	}
+
+	indexStart := startHeight
+	if indexStart < first {
+		indexStart = first
+	}
+	remaining := 0
+	if limit > 0 {
+		remaining = limit - len(txs)
+	}
+	indexed, err := s.indexedAddressTransactions(address, indexStart, endHeight, roles, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(txs, indexed...), nil
+}
+
+// GetFirstTransferHeight returns the first height that was indexed with the
+// transfer and balance change indexes. Heights below it have no entries in them.
+func (s *Storage) GetFirstTransferHeight() (uint64, error) {
+	return s.getHeight(newKey(PrefixFirstTransferHeight))
+}
+
+// GetTransfersForAddress returns the token transfers within the given range
+// of heights that the account with the given address sent or received, ordered
+// by ascending height, and by transaction ID and event index within a height.
+// If limit is greater than zero, at most limit transfers are returned.
+func (s *Storage) GetTransfersForAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newTransferHeightKey(address, startHeight),
+		UpperBound: upperBound(newTransferHeightKey(address, endHeight)),
+	})
+	defer iter.Close()
+
+	var transfers []archive.Transfer
+	for valid := iter.First(); valid && (limit == 0 || len(transfers) < limit); valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		var transfer archive.Transfer
+		err = s.codec.Unmarshal(val, &transfer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transfer (key: %x): %w", iter.Key(), err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over transfer index: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// GetBalanceHistory returns the changes of the balance of the account with the
+// given address in the given token within the given range of heights, ordered
+// by ascending height. Heights at which the balance did not change are left
+// out. If limit is greater than zero, at most limit changes are returned.
+func (s *Storage) GetBalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newBalanceChangeKey(address, token, startHeight),
+		UpperBound: upperBound(newBalanceChangeKey(address, token, endHeight)),
+	})
+	defer iter.Close()
+
+	var changes []archive.BalanceChange
+	for valid := iter.First(); valid && (limit == 0 || len(changes) < limit); valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		var change archive.BalanceChange
+		err = s.codec.Unmarshal(val, &change)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode balance change (key: %x): %w", iter.Key(), err)
+		}
+		changes = append(changes, change)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over balance changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetTransactionsForCollection returns the identifiers of the transactions in
+// the collection with the given identifier.
+func (s *Storage) GetTransactionsForCollection(collID flow.Identifier) ([]flow.Identifier, error) {
+	var txIDs []flow.Identifier
+	err := s.get(newIdentifierKey(PrefixTransactionsForCollection, collID), &txIDs)
+	return txIDs, err
+}
+
+// GetCollectionsForHeight returns the identifiers of the collections in the
+// block at the given height.
+func (s *Storage) GetCollectionsForHeight(height uint64) ([]flow.Identifier, error) {
+	var collIDs []flow.Identifier
+	err := s.get(newHeightKey(PrefixCollectionsForHeight, height), &collIDs)
+	return collIDs, err
+}
+
+// GetSealsForHeight returns the identifiers of the seals in the block at the
+// given height.
+func (s *Storage) GetSealsForHeight(height uint64) ([]flow.Identifier, error) {
+	var sealIDs []flow.Identifier
+	err := s.get(newHeightKey(PrefixSealsForHeight, height), &sealIDs)
+	return sealIDs, err
+}
+
+// GetCollection returns the collection with the given identifier.
+func (s *Storage) GetCollection(collID flow.Identifier) (*flow.LightCollection, error) {
+	var collection flow.LightCollection
+	err := s.get(newIdentifierKey(PrefixCollection, collID), &collection)
+	return &collection, err
+}
+
+// GetGuarantee returns the guarantee of the collection with the given identifier.
+func (s *Storage) GetGuarantee(collID flow.Identifier) (*flow.CollectionGuarantee, error) {
+	var guarantee flow.CollectionGuarantee
+	err := s.get(newIdentifierKey(PrefixGuarantee, collID), &guarantee)
+	return &guarantee, err
+}
+
+// GetTransaction returns the transaction with the given identifier.
+func (s *Storage) GetTransaction(txID flow.Identifier) (*flow.TransactionBody, error) {
+	var transaction flow.TransactionBody
+	err := s.get(newIdentifierKey(PrefixTransaction, txID), &transaction)
+	return &transaction, err
+}
+
+// GetResult returns the result of the transaction with the given identifier.
+func (s *Storage) GetResult(txID flow.Identifier) (*flow.TransactionResult, error) {
+	var result flow.TransactionResult
+	err := s.get(newIdentifierKey(PrefixResults, txID), &result)
+	return &result, err
+}
+
+// GetSeal returns the seal with the given identifier.
+func (s *Storage) GetSeal(sealID flow.Identifier) (*flow.Seal, error) {
+	var seal flow.Seal
+	err := s.get(newIdentifierKey(PrefixSeal, sealID), &seal)
+	return &seal, err
+}
+
+// SetFirst sets the height of the first indexed block.
+func (s *Storage) SetFirst(height uint64) error {
+	return s.set(newKey(PrefixFirst), height)
+}
+
+// SetLast sets the height of the last indexed block.
+func (s *Storage) SetLast(height uint64) error {
+	return s.set(newKey(PrefixLast), height)
+}
+
+// SetLatestRegisterHeight sets the latest height for which all registers are indexed.
+func (s *Storage) SetLatestRegisterHeight(height uint64) error {
+	return s.set(newKey(PrefixLatestRegisterHeight), height)
+}
+
+// SetBootstrapProgress sets the number of leaves of the root checkpoint that
+// were written while bootstrapping the registers at the given height.
+func (s *Storage) SetBootstrapProgress(height uint64, leaves uint64) error {
+	return s.set(newHeightKey(PrefixBootstrapProgress, height), leaves)
+}
+
+// SetHeightForBlock indexes the given height for the block with the given identifier.
+func (s *Storage) SetHeightForBlock(blockID flow.Identifier, height uint64) error {
+	return s.set(newIdentifierKey(PrefixHeightForBlock, blockID), height)
+}
+
+// SetCommit sets the state commitment after the block at the given height.
+func (s *Storage) SetCommit(height uint64, commit flow.StateCommitment) error {
+	return s.set(newHeightKey(PrefixCommit, height), commit)
+}
+
+// SetHeader sets the header of the block at the given height.
+func (s *Storage) SetHeader(height uint64, header *flow.Header) error {
+	return s.set(newHeightKey(PrefixHeader, height), header)
+}
+
+// BatchSetEvents sets the events emitted by the block at the given height.
+// Events are stored grouped by type, so that they can be filtered by type
+// without decoding the events of other types, and the height is added to the
+// event type index of each of their types.
+func (s *Storage) BatchSetEvents(height uint64, events []flow.Event) error {
+	buckets := make(map[flow.EventType][]flow.Event)
+	for _, event := range events {
+		buckets[event.Type] = append(buckets[event.Type], event)
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	// The first height written with the event type and contract indexes is
+	// recorded for each of them, so that lookups know which heights were
+	// indexed before they existed.
+	for _, prefix := range []byte{PrefixFirstEventTypeHeight, PrefixFirstContractEventsHeight} {
+		_, err := s.getHeight(newKey(prefix))
+		if errors.Is(err, archive.ErrNotFound) {
+			err = s.batchSet(batch, newKey(prefix), height)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for typ, set := range buckets {
+		err := s.batchSet(batch, newEventsKey(height, typ), set)
+		if err != nil {
+			return err
+		}
+		err = batch.Set(newEventTypeKey(eventTypeHash(typ), height), nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to set key: %w", err)
+		}
+
+		address, ok := contractAddress(typ)
+		if !ok {
+			continue
+		}
+		err = batch.Set(newContractEventTypeKey(address, height, typ), []byte(typ), nil)
+		if err != nil {
+			return fmt.Errorf("failed to set key: %w", err)
+		}
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetContractUpdates indexes the given changes to contracts, decoded from
+// the contract events of the block at the given height, for the accounts on
+// which the contracts are deployed.
+func (s *Storage) BatchSetContractUpdates(height uint64, updates []archive.ContractUpdate) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	// The first height written with the contract update index is recorded, so
+	// that lookups know which heights were indexed before it existed.
+	_, err := s.getHeight(newKey(PrefixFirstContractUpdateHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		err = s.batchSet(batch, newKey(PrefixFirstContractUpdateHeight), height)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, update := range updates {
+		err := s.batchSet(batch, newContractUpdateKey(update.Address, height, update.TransactionIndex, update.EventIndex), update)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetCollections sets the given collections, along with the transactions
+// in each of them, and indexes them, as well as their heights, for the block at
+// the given height.
+func (s *Storage) BatchSetCollections(height uint64, collections []*flow.LightCollection) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	collIDs := make([]flow.Identifier, 0, len(collections))
+	for _, collection := range collections {
+		collID := collection.ID()
+		collIDs = append(collIDs, collID)
+
+		err := s.batchSet(batch, newIdentifierKey(PrefixCollection, collID), collection)
+		if err != nil {
+			return err
+		}
+		err = s.batchSet(batch, newIdentifierKey(PrefixTransactionsForCollection, collID), collection.Transactions)
+		if err != nil {
+			return err
+		}
+		err = s.batchSet(batch, newIdentifierKey(PrefixHeightForCollection, collID), height)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.batchSet(batch, newHeightKey(PrefixCollectionsForHeight, height), collIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetGuarantees sets the given collection guarantees.
+func (s *Storage) BatchSetGuarantees(guarantees []*flow.CollectionGuarantee) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for _, guarantee := range guarantees {
+		err := s.batchSet(batch, newIdentifierKey(PrefixGuarantee, guarantee.CollectionID), guarantee)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetTransactions sets the given transactions and indexes them, as well
+// as their identifiers, for the block at the given height. Each transaction is
+// also indexed for the accounts of its payer, proposer and authorizers.
+func (s *Storage) BatchSetTransactions(height uint64, transactions []*flow.TransactionBody) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	// The first height written with the address index is recorded, so that
+	// lookups know which heights were indexed before it existed.
+	_, err := s.getHeight(newKey(PrefixFirstAddressTransactionHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		err = s.batchSet(batch, newKey(PrefixFirstAddressTransactionHeight), height)
+	}
+	if err != nil {
+		return err
+	}
+
+	txIDs := make([]flow.Identifier, 0, len(transactions))
+	for _, transaction := range transactions {
+		txID := transaction.ID()
+		txIDs = append(txIDs, txID)
+
+		err := s.batchSet(batch, newIdentifierKey(PrefixTransaction, txID), transaction)
+		if err != nil {
+			return err
+		}
+		err = s.batchSet(batch, newIdentifierKey(PrefixHeightForTransaction, txID), height)
+		if err != nil {
+			return err
+		}
+
+		roles := make(map[flow.Address]archive.TransactionRole)
+		roles[transaction.Payer] |= archive.RolePayer
+		roles[transaction.ProposalKey.Address] |= archive.RoleProposer
+		for _, authorizer := range transaction.Authorizers {
+			roles[authorizer] |= archive.RoleAuthorizer
+		}
+		for address, role := range roles {
+			if address == flow.EmptyAddress {
+				continue
+			}
+			err = batch.Set(newAddressTransactionKey(address, height, txID), []byte{byte(role)}, nil)
+			if err != nil {
+				return fmt.Errorf("failed to set key: %w", err)
+			}
+		}
+	}
+
+	err = s.batchSet(batch, newHeightKey(PrefixTransactionsForHeight, height), txIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetResults sets the given transaction results.
+func (s *Storage) BatchSetResults(results []*flow.TransactionResult) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for _, result := range results {
+		err := s.batchSet(batch, newIdentifierKey(PrefixResults, result.TransactionID), result)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetSeals sets the given seals and indexes their identifiers for the
+// block at the given height. The height is also indexed for each seal, and for
+// each of the blocks they seal.
+func (s *Storage) BatchSetSeals(height uint64, seals []*flow.Seal) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	// The first height written with the sealed block index is recorded, so
+	// that lookups know which blocks were sealed before it existed.
+	_, err := s.getHeight(newKey(PrefixFirstSealedHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		err = s.batchSet(batch, newKey(PrefixFirstSealedHeight), height)
+	}
+	if err != nil {
+		return err
+	}
+
+	sealIDs := make([]flow.Identifier, 0, len(seals))
+	for _, seal := range seals {
+		sealID := seal.ID()
+		sealIDs = append(sealIDs, sealID)
+
+		err := s.batchSet(batch, newIdentifierKey(PrefixSeal, sealID), seal)
+		if err != nil {
+			return err
+		}
+		err = s.batchSet(batch, newIdentifierKey(PrefixHeightForSeal, sealID), height)
+		if err != nil {
+			return err
+		}
+		err = s.batchSet(batch, newIdentifierKey(PrefixSealedHeightForBlock, seal.BlockID), height)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.batchSet(batch, newHeightKey(PrefixSealsForHeight, height), sealIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetTransfers indexes the given token transfers of the block at the
+// given height for the accounts that sent and received them, and records how
+// they changed the balance of each of these accounts in each token.
+func (s *Storage) BatchSetTransfers(height uint64, transfers []archive.Transfer) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	// The first height written with the transfer and balance change indexes is
+	// recorded, so that lookups know which heights were indexed before them.
+	_, err := s.getHeight(newKey(PrefixFirstTransferHeight))
+	if errors.Is(err, archive.ErrNotFound) {
+		err = s.batchSet(batch, newKey(PrefixFirstTransferHeight), height)
+	}
+	if err != nil {
+		return err
+	}
+
+	type balanceKey struct {
+		address flow.Address
+		token   string
+	}
+	changes := make(map[balanceKey]*archive.BalanceChange)
+	change := func(address flow.Address, token string) *archive.BalanceChange {
+		key := balanceKey{address: address, token: token}
+		c, ok := changes[key]
+		if !ok {
+			c = &archive.BalanceChange{Height: height}
+			changes[key] = c
+		}
+		return c
+	}
+
+	for _, transfer := range transfers {
+		for _, address := range []flow.Address{transfer.From, transfer.To} {
+			if address == flow.EmptyAddress {
+				continue
+			}
+			err := s.batchSet(batch, newAddressTransferKey(address, height, transfer.TransactionID, transfer.EventIndex), transfer)
+			if err != nil {
+				return err
+			}
+		}
+
+		if transfer.From != flow.EmptyAddress {
+			change(transfer.From, transfer.Token).Withdrawn += transfer.Amount
+		}
+		if transfer.To != flow.EmptyAddress {
+			change(transfer.To, transfer.Token).Deposited += transfer.Amount
+		}
+	}
+
+	for key, c := range changes {
+		err := s.batchSet(batch, newBalanceChangeKey(key.address, key.token, height), c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.commit(batch)
+}
+
+// BatchSetAccountKeys sets the keys of accounts after the heights at which
+// they changed, and the height up to which all changes are indexed, in a single
+// batch, so that the indexed changes never lag behind that height.
+func (s *Storage) BatchSetAccountKeys(height uint64, changes []archive.AccountKeys) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for _, change := range changes {
+		err := s.batchSet(batch, newAccountKeysKey(change.Address, change.Height), change.Keys)
+		if err != nil {
+			return err
+		}
+	}
+	err := s.batchSet(batch, newKey(PrefixLatestAccountKeysHeight), height)
+	if err != nil {
+		return err
+	}
+
+	return s.commit(batch)
+}
+
+// SetSync sets whether the batches of records are synced to disk when they are
+// committed. If not, they are only durable once Sync is called.
+func (s *Storage) SetSync(sync bool) {
+	s.write = pebble.NoSync
+	if sync {
+		s.write = pebble.Sync
+	}
+}
+
+// Sync syncs all committed batches to disk, by syncing the write-ahead log.
+func (s *Storage) Sync() error {
+	err := s.db.LogData(nil, pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) Checkpoint(dir string) error {
+	return s.db.Checkpoint(dir)
+}
+
+// Close closes the storage.
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+// scanEventHeights returns up to limit heights within the given range at which
+// events of any of the given types were emitted, by scanning the keys of the
+// events at each of these heights.
+func (s *Storage) scanEventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+	lookup := make(map[uint64]struct{}, len(types))
+	for _, typ := range types {
+		lookup[eventTypeHash(typ)] = struct{}{}
+	}
+
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newHeightKey(PrefixEvents, startHeight),
+		UpperBound: upperBound(newHeightKey(PrefixEvents, endHeight)),
+	})
+	defer iter.Close()
+
+	var heights []uint64
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := iter.Key()
+		hash := binary.BigEndian.Uint64(key[prefixLen+heightLen:])
+		_, ok := lookup[hash]
+		if !ok {
+			continue
+		}
+
+		// Events of several of the given types can be emitted at the same
+		// height, in which case their keys are next to each other.
+		height := binary.BigEndian.Uint64(key[prefixLen:])
+		if len(heights) > 0 && heights[len(heights)-1] == height {
+			continue
+		}
+		if limit > 0 && len(heights) == limit {
+			break
+		}
+		heights = append(heights, height)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over events: %w", err)
+	}
+
+	return heights, nil
+}
+
+// indexedEventHeights returns up to limit heights within the given range at
+// which events of any of the given types were emitted, by merging the entries
+// of each type in the event type index.
+func (s *Storage) indexedEventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+	lookup := make(map[uint64]struct{})
+	for _, typ := range types {
+		hash := eventTypeHash(typ)
+		iter := s.db.NewIter(&pebble.IterOptions{
+			LowerBound: newEventTypeKey(hash, startHeight),
+			UpperBound: upperBound(newEventTypeKey(hash, endHeight)),
+		})
+
+		// Any height beyond the limit of a single type is also beyond the
+		// limit of all types combined, so it does not need to be visited.
+		count := 0
+		for valid := iter.First(); valid && (limit == 0 || count < limit); valid = iter.Next() {
+			height := binary.BigEndian.Uint64(iter.Key()[prefixLen+typeHashLen:])
+			lookup[height] = struct{}{}
+			count++
+		}
+
+		err := iter.Error()
+		_ = iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate over event type index: %w", err)
+		}
+	}
+
+	heights := make([]uint64, 0, len(lookup))
+	for height := range lookup {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	if limit > 0 && len(heights) > limit {
+		heights = heights[:limit]
+	}
+
+	return heights, nil
+}
+
+// scanContractEvents returns the events of up to limit heights within the
+// given range that were emitted by the contracts deployed at the given address,
+// by decoding all events at each of these heights.
+func (s *Storage) scanContractEvents(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newHeightKey(PrefixEvents, startHeight),
+		UpperBound: upperBound(newHeightKey(PrefixEvents, endHeight)),
+	})
+	defer iter.Close()
+
+	var blocks []archive.BlockEvents
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		var evts []flow.Event
+		err = s.codec.Unmarshal(val, &evts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode events (key: %x): %w", iter.Key(), err)
+		}
+
+		// All events stored at a key are of the same type.
+		if len(evts) == 0 {
+			continue
+		}
+		emitter, ok := contractAddress(evts[0].Type)
+		if !ok || emitter != address {
+			continue
+		}
+
+		height := binary.BigEndian.Uint64(iter.Key()[prefixLen:])
+		if len(blocks) == 0 || blocks[len(blocks)-1].Height != height {
+			if limit > 0 && len(blocks) == limit {
+				break
+			}
+			blocks = append(blocks, archive.BlockEvents{Height: height})
+		}
+		last := &blocks[len(blocks)-1]
+		last.Events = append(last.Events, evts...)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over events: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// indexedContractEvents returns the events of up to limit heights within the
+// given range that were emitted by the contracts deployed at the given address,
+// by looking up the event types of each height in the contract index.
+func (s *Storage) indexedContractEvents(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newContractHeightKey(address, startHeight),
+		UpperBound: upperBound(newContractHeightKey(address, endHeight)),
+	})
+	defer iter.Close()
+
+	var heights []uint64
+	types := make(map[uint64][]flow.EventType)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		height := binary.BigEndian.Uint64(iter.Key()[prefixLen+addressLen:])
+		if len(heights) == 0 || heights[len(heights)-1] != height {
+			if limit > 0 && len(heights) == limit {
+				break
+			}
+			heights = append(heights, height)
+		}
+
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		types[height] = append(types[height], flow.EventType(val))
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over contract index: %w", err)
+	}
+
+	blocks := make([]archive.BlockEvents, 0, len(heights))
+	for _, height := range heights {
+		events, err := s.GetEvents(height, types[height])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events (height: %d): %w", height, err)
+		}
+		blocks = append(blocks, archive.BlockEvents{
+			Height: height,
+			Events: events,
+		})
+	}
+
+	return blocks, nil
+}
+
+// decodeAccountKeys decodes the keys of the account with the given address at
+// the current position of the given iterator over the account keys index.
+func (s *Storage) decodeAccountKeys(address flow.Address, iter *pebble.Iterator) (archive.AccountKeys, error) {
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return archive.AccountKeys{}, fmt.Errorf("failed to get value: %w", err)
+	}
+	var keys []archive.AccountKey
+	err = s.codec.Unmarshal(val, &keys)
+	if err != nil {
+		return archive.AccountKeys{}, fmt.Errorf("failed to decode account keys (key: %x): %w", iter.Key(), err)
+	}
+
+	change := archive.AccountKeys{
+		Height:  binary.BigEndian.Uint64(iter.Key()[prefixLen+addressLen:]),
+		Address: address,
+		Keys:    keys,
+	}
+
+	return change, nil
+}
+
+// scanSealedHeight returns the height of the block that contains the seal for
+// the block with the given identifier, by decoding the seals at each height
+// within the given range.
+func (s *Storage) scanSealedHeight(blockID flow.Identifier, startHeight uint64, endHeight uint64) (uint64, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newHeightKey(PrefixSealsForHeight, startHeight),
+		UpperBound: upperBound(newHeightKey(PrefixSealsForHeight, endHeight)),
+	})
+	defer iter.Close()
+
+	for valid := iter.First(); valid; valid = iter.Next() {
+		height := binary.BigEndian.Uint64(iter.Key()[prefixLen:])
+
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get value: %w", err)
+		}
+		var sealIDs []flow.Identifier
+		err = s.codec.Unmarshal(val, &sealIDs)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode seal IDs (key: %x): %w", iter.Key(), err)
+		}
+
+		for _, sealID := range sealIDs {
+			seal, err := s.GetSeal(sealID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get seal (id: %x): %w", sealID, err)
+			}
+			if seal.BlockID == blockID {
+				return height, nil
+			}
+		}
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate over seals: %w", err)
+	}
+
+	return 0, fmt.Errorf("block not sealed (id: %x): %w", blockID, archive.ErrNotFound)
+}
+
+// scanAddressTransactions returns up to limit transactions within the given
+// range of heights in which the account with the given address has any of the
+// given roles, by decoding all transactions at each of these heights.
+func (s *Storage) scanAddressTransactions(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newHeightKey(PrefixTransactionsForHeight, startHeight),
+		UpperBound: upperBound(newHeightKey(PrefixTransactionsForHeight, endHeight)),
+	})
+	defer iter.Close()
+
+	var txs []archive.AddressTransaction
+	for valid := iter.First(); valid; valid = iter.Next() {
+		height := binary.BigEndian.Uint64(iter.Key()[prefixLen:])
+
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		var txIDs []flow.Identifier
+		err = s.codec.Unmarshal(val, &txIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transaction IDs (key: %x): %w", iter.Key(), err)
+		}
+
+		// Transactions are ordered by identifier within a height, as they
+		// are in the address index.
+		sort.Slice(txIDs, func(i, j int) bool {
+			return bytes.Compare(txIDs[i][:], txIDs[j][:]) < 0
+		})
+
+		for _, txID := range txIDs {
+			tx, err := s.GetTransaction(txID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get transaction (id: %x): %w", txID, err)
+			}
+
+			matched := archive.TransactionRoles(tx, address)
+			if matched&roles == 0 {
+				continue
+			}
+			if limit > 0 && len(txs) == limit {
+				return txs, nil
+			}
+
+			txs = append(txs, archive.AddressTransaction{
+				Height:        height,
+				TransactionID: txID,
+				Roles:         matched,
+			})
+		}
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over transactions: %w", err)
+	}
+
+	return txs, nil
+}
+
+// indexedAddressTransactions returns up to limit transactions within the given
+// range of heights in which the account with the given address has any of the
+// given roles, from the entries of the address index.
+func (s *Storage) indexedAddressTransactions(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newAddressHeightKey(address, startHeight),
+		UpperBound: upperBound(newAddressHeightKey(address, endHeight)),
+	})
+	defer iter.Close()
+
+	var txs []archive.AddressTransaction
+	for valid := iter.First(); valid && (limit == 0 || len(txs) < limit); valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		matched := archive.TransactionRole(val[0])
+		if matched&roles == 0 {
+			continue
+		}
+
+		key := iter.Key()
+		tx := archive.AddressTransaction{
+			Height: binary.BigEndian.Uint64(key[prefixLen+addressLen:]),
+			Roles:  matched,
+		}
+		copy(tx.TransactionID[:], key[prefixLen+addressLen+heightLen:])
+		txs = append(txs, tx)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over address index: %w", err)
+	}
+
+	return txs, nil
+}
+
+// getHeight decodes the height stored at the given key.
+func (s *Storage) getHeight(key []byte) (uint64, error) {
+	var height uint64
+	err := s.get(key, &height)
+	return height, err
+}
+
+// get decodes the value stored at the given key into v. If there is no value
+// at the given key, the returned error wraps archive.ErrNotFound.
+func (s *Storage) get(key []byte, v interface{}) error {
+	val, closer, err := s.db.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return fmt.Errorf("failed to get value (key: %x): %w", key, archive.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get value (key: %x): %w", key, err)
+	}
+	defer closer.Close()
+
+	err = s.codec.Unmarshal(val, v)
+	if err != nil {
+		return fmt.Errorf("failed to decode value (key: %x): %w", key, err)
+	}
+
+	return nil
+}
+
+// set encodes the given value and stores it at the given key.
+func (s *Storage) set(key []byte, value interface{}) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	err := s.batchSet(batch, key, value)
+	if err != nil {
+		return err
+	}
+
+	return s.commit(batch)
+}
+
+// batchSet encodes the given value and adds it to the batch at the given key.
+func (s *Storage) batchSet(batch *pebble.Batch, key []byte, value interface{}) error {
+	val, err := s.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value (key: %x): %w", key, err)
+	}
+
+	err = batch.Set(key, val, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set key: %w", err)
+	}
+
+	return nil
+}
+
+// commit commits the batch, syncing it to disk unless syncing was disabled.
+func (s *Storage) commit(batch *pebble.Batch) error {
+	err := batch.Commit(s.write)
+	if err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,748 @@
+package blocks
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/codec/zbor"
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "blocks.db")
+	s, err := NewStorage(dbpath, cache, zbor.NewCodec())
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+
+	return s
+}
+
+func Test_BlocksStorage_Heights(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	_, err := s.GetFirst()
+	require.ErrorIs(t, err, archive.ErrNotFound)
+
+	require.NoError(t, s.SetFirst(1))
+	require.NoError(t, s.SetLast(2))
+	require.NoError(t, s.SetLatestRegisterHeight(3))
+
+	got, err := s.GetFirst()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), got)
+
+	got, err = s.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), got)
+
+	got, err = s.GetLatestRegisterHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), got)
+}
+
+func Test_BlocksStorage_BootstrapProgress(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	_, err := s.GetBootstrapProgress(mocks.GenericHeight)
+	require.ErrorIs(t, err, archive.ErrNotFound)
+
+	require.NoError(t, s.SetBootstrapProgress(mocks.GenericHeight, 1000))
+	require.NoError(t, s.SetBootstrapProgress(mocks.GenericHeight, 2000))
+
+	got, err := s.GetBootstrapProgress(mocks.GenericHeight)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000), got)
+
+	_, err = s.GetBootstrapProgress(mocks.GenericHeight + 1)
+	require.ErrorIs(t, err, archive.ErrNotFound)
+}
+
+func Test_BlocksStorage_Block(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	header := mocks.GenericHeader
+	blockID := header.ID()
+	commit := mocks.GenericCommit(0)
+
+	require.NoError(t, s.SetHeightForBlock(blockID, mocks.GenericHeight))
+	require.NoError(t, s.SetHeader(mocks.GenericHeight, header))
+	require.NoError(t, s.SetCommit(mocks.GenericHeight, commit))
+
+	height, err := s.GetHeightForBlock(blockID)
+	require.NoError(t, err)
+	require.Equal(t, mocks.GenericHeight, height)
+
+	gotHeader, err := s.GetHeader(mocks.GenericHeight)
+	require.NoError(t, err)
+	require.Equal(t, header, gotHeader)
+
+	gotCommit, err := s.GetCommit(mocks.GenericHeight)
+	require.NoError(t, err)
+	require.Equal(t, commit, gotCommit)
+
+	_, err = s.GetHeader(mocks.GenericHeight + 1)
+	require.ErrorIs(t, err, archive.ErrNotFound)
+}
+
+func Test_BlocksStorage_Events(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	types := mocks.GenericEventTypes(2)
+	events := mocks.GenericEvents(4, types...)
+
+	// Events of the next height must not be returned for this height.
+	require.NoError(t, s.BatchSetEvents(mocks.GenericHeight, events))
+	require.NoError(t, s.BatchSetEvents(mocks.GenericHeight+1, mocks.GenericEvents(1)))
+
+	got, err := s.GetEvents(mocks.GenericHeight, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, events, got)
+
+	got, err = s.GetEvents(mocks.GenericHeight, []flow.EventType{types[0]})
+	require.NoError(t, err)
+	require.NotEmpty(t, got)
+	for _, event := range got {
+		require.Equal(t, types[0], event.Type)
+	}
+
+	got, err = s.GetEvents(mocks.GenericHeight+2, nil)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func Test_BlocksStorage_EventHeights(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	types := mocks.GenericEventTypes(3)
+
+	// Events of heights indexed before the event type index existed only have
+	// their events records, which are imported as is.
+	var records []Record
+	for _, height := range []uint64{5, 7} {
+		val, err := s.codec.Marshal(mocks.GenericEvents(1, types[0]))
+		require.NoError(t, err)
+		records = append(records, Record{Key: newEventsKey(height, types[0]), Value: val})
+	}
+	require.NoError(t, s.BatchSetRecords(records))
+
+	require.NoError(t, s.BatchSetEvents(10, nil))
+	require.NoError(t, s.BatchSetEvents(11, mocks.GenericEvents(2, types[0])))
+	require.NoError(t, s.BatchSetEvents(13, mocks.GenericEvents(2, types[1])))
+	require.NoError(t, s.BatchSetEvents(14, mocks.GenericEvents(4, types[0], types[1])))
+	require.NoError(t, s.BatchSetEvents(17, mocks.GenericEvents(1, types[2])))
+
+	tests := []struct {
+		name  string
+		types []flow.EventType
+		start uint64
+		end   uint64
+		limit int
+		want  []uint64
+	}{
+		{name: "single type", types: types[:1], start: 10, end: 20, want: []uint64{11, 14}},
+		{name: "multiple types", types: types[:2], start: 10, end: 20, want: []uint64{11, 13, 14}},
+		{name: "limit", types: types[:2], start: 10, end: 20, limit: 2, want: []uint64{11, 13}},
+		{name: "range", types: types[:2], start: 12, end: 13, want: []uint64{13}},
+		{name: "other type", types: types[2:], start: 10, end: 20, want: []uint64{17}},
+		{name: "unknown type", types: []flow.EventType{"A.0x1.Unknown.Event"}, start: 0, end: 20, want: nil},
+		{name: "heights before index", types: types[:1], start: 0, end: 9, want: []uint64{5, 7}},
+		{name: "heights across index", types: types[:1], start: 0, end: 20, want: []uint64{5, 7, 11, 14}},
+		{name: "limit across index", types: types[:1], start: 6, end: 20, limit: 2, want: []uint64{7, 11}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.GetEventHeights(test.types, test.start, test.end, test.limit)
+			require.NoError(t, err)
+			if len(test.want) == 0 {
+				require.Empty(t, got)
+				return
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func Test_BlocksStorage_ContractEvents(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	addresses := mocks.GenericAddresses(3)
+	typeA1 := flow.EventType(fmt.Sprintf("A.%s.Token.Deposited", addresses[0].Hex()))
+	typeA2 := flow.EventType(fmt.Sprintf("A.%s.Market.Listed", addresses[0].Hex()))
+	typeB := flow.EventType(fmt.Sprintf("A.%s.Token.Deposited", addresses[1].Hex()))
+	typeProtocol := flow.EventType("flow.AccountCreated")
+
+	// Events of heights indexed before the contract index existed only have
+	// their events records, which are imported as is.
+	before := mocks.GenericEvents(1, typeA1)
+	val, err := s.codec.Marshal(before)
+	require.NoError(t, err)
+	require.NoError(t, s.BatchSetRecords([]Record{{Key: newEventsKey(5, typeA1), Value: val}}))
+
+	atEleven := mocks.GenericEvents(4, typeA1, typeA2)
+	atTwelve := mocks.GenericEvents(2, typeB, typeProtocol)
+	atFourteen := mocks.GenericEvents(1, typeA2)
+	require.NoError(t, s.BatchSetEvents(10, nil))
+	require.NoError(t, s.BatchSetEvents(11, atEleven))
+	require.NoError(t, s.BatchSetEvents(12, atTwelve))
+	require.NoError(t, s.BatchSetEvents(14, atFourteen))
+
+	tests := []struct {
+		name    string
+		address flow.Address
+		start   uint64
+		end     uint64
+		limit   int
+		want    []archive.BlockEvents
+	}{
+		{
+			name:    "all contracts of address",
+			address: addresses[0],
+			start:   10,
+			end:     20,
+			want:    []archive.BlockEvents{{Height: 11, Events: atEleven}, {Height: 14, Events: atFourteen}},
+		},
+		{
+			name:    "other address",
+			address: addresses[1],
+			start:   10,
+			end:     20,
+			want:    []archive.BlockEvents{{Height: 12, Events: atTwelve[:1]}},
+		},
+		{
+			name:    "range",
+			address: addresses[0],
+			start:   12,
+			end:     20,
+			want:    []archive.BlockEvents{{Height: 14, Events: atFourteen}},
+		},
+		{
+			name:    "limit across index",
+			address: addresses[0],
+			start:   0,
+			end:     20,
+			limit:   2,
+			want:    []archive.BlockEvents{{Height: 5, Events: before}, {Height: 11, Events: atEleven}},
+		},
+		{
+			name:    "heights before index",
+			address: addresses[0],
+			start:   0,
+			end:     9,
+			want:    []archive.BlockEvents{{Height: 5, Events: before}},
+		},
+		{
+			name:    "unknown address",
+			address: addresses[2],
+			start:   0,
+			end:     20,
+			want:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.GetContractEvents(test.address, test.start, test.end, test.limit)
+			require.NoError(t, err)
+			if len(test.want) == 0 {
+				require.Empty(t, got)
+				return
+			}
+			require.Len(t, got, len(test.want))
+			for i, block := range got {
+				require.Equal(t, test.want[i].Height, block.Height)
+				require.ElementsMatch(t, test.want[i].Events, block.Events)
+			}
+		})
+	}
+}
+
+func Test_BlocksStorage_TransactionsForAddress(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	addresses := mocks.GenericAddresses(4)
+	a, b, c := addresses[0], addresses[1], addresses[2]
+
+	newTx := func(index int, payer flow.Address, proposer flow.Address, authorizers ...flow.Address) *flow.TransactionBody {
+		tx := mocks.GenericTransaction(index)
+		tx.Payer = payer
+		tx.ProposalKey.Address = proposer
+		tx.Authorizers = authorizers
+		return tx
+	}
+	txs := []*flow.TransactionBody{
+		newTx(0, a, b),
+		newTx(1, a, a, b),
+		newTx(2, b, b, a, c),
+		newTx(3, c, c, c),
+		newTx(4, b, a),
+	}
+
+	// Transactions of heights indexed before the address index existed only
+	// have their transaction records, which are imported as is.
+	val, err := s.codec.Marshal(txs[0])
+	require.NoError(t, err)
+	ids, err := s.codec.Marshal([]flow.Identifier{txs[0].ID()})
+	require.NoError(t, err)
+	require.NoError(t, s.BatchSetRecords([]Record{
+		{Key: newIdentifierKey(PrefixTransaction, txs[0].ID()), Value: val},
+		{Key: newHeightKey(PrefixTransactionsForHeight, 5), Value: ids},
+	}))
+
+	require.NoError(t, s.BatchSetTransactions(10, txs[1:3]))
+	require.NoError(t, s.BatchSetTransactions(12, txs[3:4]))
+	require.NoError(t, s.BatchSetTransactions(13, txs[4:5]))
+
+	// Transactions of the same height are ordered by identifier.
+	tx1 := archive.AddressTransaction{Height: 10, TransactionID: txs[1].ID(), Roles: archive.RolePayer | archive.RoleProposer}
+	tx2 := archive.AddressTransaction{Height: 10, TransactionID: txs[2].ID(), Roles: archive.RoleAuthorizer}
+	atTen := []archive.AddressTransaction{tx1, tx2}
+	if bytes.Compare(tx2.TransactionID[:], tx1.TransactionID[:]) < 0 {
+		atTen = []archive.AddressTransaction{tx2, tx1}
+	}
+	tx0 := archive.AddressTransaction{Height: 5, TransactionID: txs[0].ID(), Roles: archive.RolePayer}
+	tx4 := archive.AddressTransaction{Height: 13, TransactionID: txs[4].ID(), Roles: archive.RoleProposer}
+
+	tests := []struct {
+		name    string
+		address flow.Address
+		start   uint64
+		end     uint64
+		roles   archive.TransactionRole
+		limit   int
+		want    []archive.AddressTransaction
+	}{
+		{name: "any role", address: a, start: 0, end: 20, roles: archive.RoleAny, want: []archive.AddressTransaction{tx0, atTen[0], atTen[1], tx4}},
+		{name: "payer", address: a, start: 0, end: 20, roles: archive.RolePayer, want: []archive.AddressTransaction{tx0, tx1}},
+		{name: "authorizer", address: a, start: 10, end: 20, roles: archive.RoleAuthorizer, want: []archive.AddressTransaction{tx2}},
+		{name: "range", address: a, start: 11, end: 13, roles: archive.RoleAny, want: []archive.AddressTransaction{tx4}},
+		{name: "limit across index", address: a, start: 0, end: 20, roles: archive.RoleAny, limit: 2, want: []archive.AddressTransaction{tx0, atTen[0]}},
+		{name: "heights before index", address: a, start: 0, end: 9, roles: archive.RoleAny, want: []archive.AddressTransaction{tx0}},
+		{
+			name:    "all roles in one transaction",
+			address: c,
+			start:   0,
+			end:     20,
+			roles:   archive.RoleAny,
+			want: []archive.AddressTransaction{
+				{Height: 10, TransactionID: txs[2].ID(), Roles: archive.RoleAuthorizer},
+				{Height: 12, TransactionID: txs[3].ID(), Roles: archive.RoleAny},
+			},
+		},
+		{name: "unknown address", address: addresses[3], start: 0, end: 20, roles: archive.RoleAny, want: nil},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.GetTransactionsForAddress(test.address, test.start, test.end, test.roles, test.limit)
+			require.NoError(t, err)
+			if len(test.want) == 0 {
+				require.Empty(t, got)
+				return
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func Test_BlocksStorage_Transfers(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	addresses := mocks.GenericAddresses(4)
+	a, b, c := addresses[0], addresses[1], addresses[2]
+	txIDs := mocks.GenericTransactionIDs(4)
+
+	const other = "OTHER"
+	fee := archive.Transfer{Height: 10, TransactionID: txIDs[0], EventIndex: 0, Token: archive.FlowSymbol, From: a, To: c, Amount: 10}
+	send := archive.Transfer{Height: 10, TransactionID: txIDs[0], EventIndex: 2, Token: archive.FlowSymbol, From: a, To: b, Amount: 500}
+	mint := archive.Transfer{Height: 12, TransactionID: txIDs[1], EventIndex: 0, Token: archive.FlowSymbol, To: a, Amount: 700}
+	burn := archive.Transfer{Height: 13, TransactionID: txIDs[2], EventIndex: 1, Token: other, From: a, Amount: 300}
+
+	_, err := s.GetFirstTransferHeight()
+	require.ErrorIs(t, err, archive.ErrNotFound)
+
+	require.NoError(t, s.BatchSetTransfers(10, []archive.Transfer{fee, send}))
+	require.NoError(t, s.BatchSetTransfers(11, nil))
+	require.NoError(t, s.BatchSetTransfers(12, []archive.Transfer{mint}))
+	require.NoError(t, s.BatchSetTransfers(13, []archive.Transfer{burn}))
+
+	first, err := s.GetFirstTransferHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), first)
+
+	t.Run("transfers by address", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			address flow.Address
+			start   uint64
+			end     uint64
+			limit   int
+			want    []archive.Transfer
+		}{
+			{name: "sent and received", address: a, start: 0, end: 20, want: []archive.Transfer{fee, send, mint, burn}},
+			{name: "received only", address: b, start: 0, end: 20, want: []archive.Transfer{send}},
+			{name: "range", address: a, start: 11, end: 12, want: []archive.Transfer{mint}},
+			{name: "limit", address: a, start: 0, end: 20, limit: 3, want: []archive.Transfer{fee, send, mint}},
+			{name: "unknown address", address: addresses[3], start: 0, end: 20, want: nil},
+		}
+
+		for _, test := range tests {
+			got, err := s.GetTransfersForAddress(test.address, test.start, test.end, test.limit)
+			require.NoError(t, err, test.name)
+			if len(test.want) == 0 {
+				require.Empty(t, got, test.name)
+				continue
+			}
+			require.Equal(t, test.want, got, test.name)
+		}
+	})
+
+	t.Run("balance history", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			address flow.Address
+			token   string
+			start   uint64
+			end     uint64
+			limit   int
+			want    []archive.BalanceChange
+		}{
+			{
+				name:    "aggregates changes per height",
+				address: a,
+				token:   archive.FlowSymbol,
+				start:   0,
+				end:     20,
+				want: []archive.BalanceChange{
+					{Height: 10, Withdrawn: 510},
+					{Height: 12, Deposited: 700},
+				},
+			},
+			{name: "other token", address: a, token: other, start: 0, end: 20, want: []archive.BalanceChange{{Height: 13, Withdrawn: 300}}},
+			{name: "range", address: a, token: archive.FlowSymbol, start: 11, end: 20, want: []archive.BalanceChange{{Height: 12, Deposited: 700}}},
+			{name: "limit", address: a, token: archive.FlowSymbol, start: 0, end: 20, limit: 1, want: []archive.BalanceChange{{Height: 10, Withdrawn: 510}}},
+			{name: "receiver", address: c, token: archive.FlowSymbol, start: 0, end: 20, want: []archive.BalanceChange{{Height: 10, Deposited: 10}}},
+			{name: "unknown token", address: b, token: other, start: 0, end: 20, want: nil},
+		}
+
+		for _, test := range tests {
+			got, err := s.GetBalanceHistory(test.address, test.token, test.start, test.end, test.limit)
+			require.NoError(t, err, test.name)
+			if len(test.want) == 0 {
+				require.Empty(t, got, test.name)
+				continue
+			}
+			require.Equal(t, test.want, got, test.name)
+		}
+	})
+}
+
+func Test_BlocksStorage_ContractUpdates(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	_, err := s.GetFirstContractUpdateHeight()
+	require.ErrorIs(t, err, archive.ErrNotFound)
+
+	updates := mocks.GenericContractUpdates(3)
+	added, first, second := updates[0], updates[1], updates[2]
+	added.Height = 10
+	first.Height = 11
+	second.Height = 14
+
+	require.NoError(t, s.BatchSetContractUpdates(10, []archive.ContractUpdate{added}))
+	require.NoError(t, s.BatchSetContractUpdates(11, []archive.ContractUpdate{first}))
+	require.NoError(t, s.BatchSetContractUpdates(12, nil))
+	require.NoError(t, s.BatchSetContractUpdates(14, []archive.ContractUpdate{second}))
+
+	height, err := s.GetFirstContractUpdateHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), height)
+
+	tests := []struct {
+		name     string
+		address  flow.Address
+		contract string
+		start    uint64
+		end      uint64
+		limit    int
+		want     []archive.ContractUpdate
+	}{
+		{name: "all contracts of address", address: added.Address, start: 0, end: 20, want: []archive.ContractUpdate{added, first, second}},
+		{name: "single contract", address: added.Address, contract: added.Name, start: 0, end: 20, want: []archive.ContractUpdate{added, first, second}},
+		{name: "range", address: added.Address, start: 12, end: 20, want: []archive.ContractUpdate{second}},
+		{name: "limit", address: added.Address, start: 0, end: 20, limit: 2, want: []archive.ContractUpdate{added, first}},
+		{name: "unknown contract", address: added.Address, contract: "Unknown", start: 0, end: 20, want: nil},
+		{name: "unknown address", address: mocks.GenericAddress(1), start: 0, end: 20, want: nil},
+	}
+
+	for _, test := range tests {
+		got, err := s.GetContractUpdates(test.address, test.contract, test.start, test.end, test.limit)
+		require.NoError(t, err, test.name)
+		if len(test.want) == 0 {
+			require.Empty(t, got, test.name)
+			continue
+		}
+		require.Equal(t, test.want, got, test.name)
+	}
+}
+
+func Test_BlocksStorage_AccountKeys(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	history := mocks.GenericAccountKeyHistory(3)
+	history[0].Height = 5
+	history[1].Height = 11
+	history[2].Height = 14
+	other := archive.AccountKeys{
+		Height:  11,
+		Address: mocks.GenericAddress(1),
+		Keys:    mocks.GenericAccountKeys(1),
+	}
+
+	_, err := s.GetLatestAccountKeysHeight()
+	require.ErrorIs(t, err, archive.ErrNotFound)
+
+	require.NoError(t, s.BatchSetAccountKeys(10, history[:1]))
+	require.NoError(t, s.BatchSetAccountKeys(20, []archive.AccountKeys{history[1], other, history[2]}))
+
+	latest, err := s.GetLatestAccountKeysHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), latest)
+
+	tests := []struct {
+		name    string
+		address flow.Address
+		height  uint64
+		want    archive.AccountKeys
+		wantErr error
+	}{
+		{name: "at change", address: history[0].Address, height: 11, want: history[1]},
+		{name: "between changes", address: history[0].Address, height: 13, want: history[1]},
+		{name: "after last change", address: history[0].Address, height: 20, want: history[2]},
+		{name: "other address", address: other.Address, height: 20, want: other},
+		{name: "at maximum height", address: history[0].Address, height: math.MaxUint64, want: history[2]},
+		{name: "before first change", address: history[0].Address, height: 4, wantErr: archive.ErrNotFound},
+		{name: "unknown address", address: mocks.GenericAddress(2), height: 20, wantErr: archive.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		got, err := s.GetAccountKeys(test.address, test.height)
+		if test.wantErr != nil {
+			require.ErrorIs(t, err, test.wantErr, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.want, got, test.name)
+	}
+
+	got, err := s.GetAccountKeyHistory(history[0].Address)
+	require.NoError(t, err)
+	require.Equal(t, history, got)
+
+	got, err = s.GetAccountKeyHistory(mocks.GenericAddress(2))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func Test_BlocksStorage_Payload(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	collections := mocks.GenericCollections(2)
+	guarantees := mocks.GenericGuarantees(2)
+	transactions := mocks.GenericTransactions(2)
+	results := mocks.GenericResults(2)
+	seals := mocks.GenericSeals(2)
+
+	require.NoError(t, s.BatchSetCollections(mocks.GenericHeight, collections))
+	require.NoError(t, s.BatchSetGuarantees(guarantees))
+	require.NoError(t, s.BatchSetTransactions(mocks.GenericHeight, transactions))
+	require.NoError(t, s.BatchSetResults(results))
+	require.NoError(t, s.BatchSetSeals(mocks.GenericHeight, seals))
+
+	collIDs, err := s.GetCollectionsForHeight(mocks.GenericHeight)
+	require.NoError(t, err)
+	require.Len(t, collIDs, len(collections))
+	for i, collection := range collections {
+		require.Equal(t, collection.ID(), collIDs[i])
+
+		got, err := s.GetCollection(collIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, collection, got)
+
+		txIDs, err := s.GetTransactionsForCollection(collIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, collection.Transactions, txIDs)
+
+		height, err := s.GetHeightForCollection(collIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, mocks.GenericHeight, height)
+	}
+
+	for _, guarantee := range guarantees {
+		got, err := s.GetGuarantee(guarantee.CollectionID)
+		require.NoError(t, err)
+		require.Equal(t, guarantee, got)
+	}
+
+	txIDs, err := s.GetTransactionsForHeight(mocks.GenericHeight)
+	require.NoError(t, err)
+	require.Len(t, txIDs, len(transactions))
+	for i, transaction := range transactions {
+		require.Equal(t, transaction.ID(), txIDs[i])
+
+		got, err := s.GetTransaction(txIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, transaction, got)
+
+		height, err := s.GetHeightForTransaction(txIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, mocks.GenericHeight, height)
+	}
+
+	for _, result := range results {
+		got, err := s.GetResult(result.TransactionID)
+		require.NoError(t, err)
+		require.Equal(t, result, got)
+	}
+
+	sealIDs, err := s.GetSealsForHeight(mocks.GenericHeight)
+	require.NoError(t, err)
+	require.Len(t, sealIDs, len(seals))
+	for i, seal := range seals {
+		require.Equal(t, seal.ID(), sealIDs[i])
+
+		got, err := s.GetSeal(sealIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, seal, got)
+
+		height, err := s.GetHeightForSeal(sealIDs[i])
+		require.NoError(t, err)
+		require.Equal(t, mocks.GenericHeight, height)
+	}
+}
+
+func Test_BlocksStorage_SealedHeights(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	blockIDs := mocks.GenericBlockIDs(4)
+	for i, blockID := range blockIDs {
+		require.NoError(t, s.SetHeightForBlock(blockID, uint64(10+i)))
+	}
+	seals := mocks.GenericSeals(3)
+	for i, seal := range seals {
+		seal.BlockID = blockIDs[i]
+	}
+
+	// Seals of heights indexed before the sealed block index existed only have
+	// their seal records, which are imported as is.
+	val, err := s.codec.Marshal(seals[0])
+	require.NoError(t, err)
+	ids, err := s.codec.Marshal([]flow.Identifier{seals[0].ID()})
+	require.NoError(t, err)
+	require.NoError(t, s.BatchSetRecords([]Record{
+		{Key: newIdentifierKey(PrefixSeal, seals[0].ID()), Value: val},
+		{Key: newHeightKey(PrefixSealsForHeight, 11), Value: ids},
+	}))
+
+	require.NoError(t, s.BatchSetSeals(12, seals[1:2]))
+	require.NoError(t, s.BatchSetSeals(13, seals[2:3]))
+
+	tests := []struct {
+		name    string
+		blockID flow.Identifier
+		want    uint64
+		wantErr error
+	}{
+		{name: "sealed before index", blockID: blockIDs[0], want: 11},
+		{name: "sealed after index", blockID: blockIDs[1], want: 12},
+		{name: "sealed at last height", blockID: blockIDs[2], want: 13},
+		{name: "not sealed", blockID: blockIDs[3], wantErr: archive.ErrNotFound},
+		{name: "unknown block", blockID: flow.ZeroID, wantErr: archive.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.GetSealedHeightForBlock(test.blockID)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func Test_BlocksStorage_Records(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStorage(t)
+
+	last, err := s.GetLastKey(PrefixHeader)
+	require.NoError(t, err)
+	require.Nil(t, last)
+
+	records := []Record{
+		{Key: newHeightKey(PrefixHeader, 1), Value: []byte{1}},
+		{Key: newHeightKey(PrefixHeader, 2), Value: []byte{2}},
+		{Key: newHeightKey(PrefixCommit, 1), Value: []byte{3}},
+	}
+	require.NoError(t, s.BatchSetRecords(records))
+
+	last, err = s.GetLastKey(PrefixHeader)
+	require.NoError(t, err)
+	require.Equal(t, newHeightKey(PrefixHeader, 2), last)
+
+	count, err := s.CountRecords(PrefixHeader)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	count, err = s.CountRecords(PrefixEvents)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
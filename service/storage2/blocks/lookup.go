@@ -0,0 +1,276 @@
+package blocks
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/OneOfOne/xxhash"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// Size of the prefix at the start of every key.
+	prefixLen = 1
+	// Size of a block height encoded within a key.
+	heightLen = 8
+	// Size of the event type hash encoded within events and event type keys.
+	typeHashLen = 8
+	// Size of an account address encoded within a key.
+	addressLen = flow.AddressLength
+	// Size of the token symbol hash encoded within balance change keys.
+	tokenHashLen = 8
+	// Size of an event index encoded within a key.
+	eventIndexLen = 4
+	// Size of a transaction index encoded within a key.
+	txIndexLen = 4
+)
+
+// newKey returns the key of a record that exists only once, such as the first
+// and last indexed heights.
+func newKey(prefix byte) []byte {
+	return []byte{prefix}
+}
+
+// newHeightKey returns the key of a record indexed by block height.
+//
+// The key is "<prefix><height>", with the height in big-endian byte order, so
+// that the records of consecutive heights are next to each other.
+func newHeightKey(prefix byte, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+heightLen)
+	key = append(key, prefix)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newIdentifierKey returns the key of a record indexed by its identifier.
+//
+// The key is "<prefix><identifier>".
+func newIdentifierKey(prefix byte, id flow.Identifier) []byte {
+	key := make([]byte, 0, prefixLen+len(id))
+	key = append(key, prefix)
+	key = append(key, id[:]...)
+
+	return key
+}
+
+// newEventsKey returns the key of the events of the given type emitted at the
+// given height.
+//
+// The key is "<prefix><height><type hash>". All events at a height share the
+// height key as prefix, so they can be listed with a single range scan.
+func newEventsKey(height uint64, typ flow.EventType) []byte {
+	key := make([]byte, 0, prefixLen+heightLen+typeHashLen)
+	key = append(key, PrefixEvents)
+	key = binary.BigEndian.AppendUint64(key, height)
+	key = binary.BigEndian.AppendUint64(key, eventTypeHash(typ))
+
+	return key
+}
+
+// newEventTypeKey returns the key recording that events of the type with the
+// given hash were emitted at the given height.
+//
+// The key is "<prefix><type hash><height>". All heights with events of a type
+// share the type hash as prefix, so that they can be listed by ascending height
+// with a single range scan, without visiting the heights in between.
+func newEventTypeKey(hash uint64, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+typeHashLen+heightLen)
+	key = append(key, PrefixHeightsForEventType)
+	key = binary.BigEndian.AppendUint64(key, hash)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newAddressTransactionKey returns the key recording that the account with the
+// given address has a role in the transaction with the given identifier, which
+// is part of the block at the given height.
+//
+// The key is "<prefix><address><height><transaction ID>". All transactions of
+// an account share the address as prefix, so that they can be listed by
+// ascending height with a single range scan.
+func newAddressTransactionKey(address flow.Address, height uint64, txID flow.Identifier) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen+len(txID))
+	key = append(key, PrefixTransactionsForAddress)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+	key = append(key, txID[:]...)
+
+	return key
+}
+
+// newAddressHeightKey returns the lowest key of the transactions of the account
+// with the given address at the given height, to be used as iteration bound.
+func newAddressHeightKey(address flow.Address, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen)
+	key = append(key, PrefixTransactionsForAddress)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newContractEventTypeKey returns the key recording that a contract deployed
+// at the given address emitted events of the given type at the given height.
+//
+// The key is "<prefix><address><height><type hash>". All event types of the
+// contracts of an account share the address as prefix, so that they can be
+// listed by ascending height with a single range scan.
+func newContractEventTypeKey(address flow.Address, height uint64, typ flow.EventType) []byte {
+	key := newContractHeightKey(address, height)
+	key = binary.BigEndian.AppendUint64(key, eventTypeHash(typ))
+
+	return key
+}
+
+// newContractHeightKey returns the lowest key of the event types of the
+// contracts deployed at the given address at the given height, to be used as
+// iteration bound.
+func newContractHeightKey(address flow.Address, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen+typeHashLen)
+	key = append(key, PrefixEventTypesForContract)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newAddressTransferKey returns the key of a transfer of tokens to or from the
+// account with the given address, which is made up of the events emitted by
+// the given transaction, starting at the given event index.
+//
+// The key is "<prefix><address><height><transaction ID><event index>". All
+// transfers of an account share the address as prefix, so that they can be
+// listed by ascending height with a single range scan.
+func newAddressTransferKey(address flow.Address, height uint64, txID flow.Identifier, eventIndex uint32) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen+len(txID)+eventIndexLen)
+	key = append(key, PrefixTransfersForAddress)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+	key = append(key, txID[:]...)
+	key = binary.BigEndian.AppendUint32(key, eventIndex)
+
+	return key
+}
+
+// newTransferHeightKey returns the lowest key of the transfers of the account
+// with the given address at the given height, to be used as iteration bound.
+func newTransferHeightKey(address flow.Address, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen)
+	key = append(key, PrefixTransfersForAddress)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newBalanceChangeKey returns the key of the change of the balance of the
+// account with the given address in the given token at the given height.
+//
+// The key is "<prefix><address><token hash><height>". All balance changes of an
+// account in a token share the address and token hash as prefix, so that they
+// can be listed by ascending height with a single range scan.
+func newBalanceChangeKey(address flow.Address, token string, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+tokenHashLen+heightLen)
+	key = append(key, PrefixBalanceChanges)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, tokenHash(token))
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newContractUpdateKey returns the key of a change to a contract of the
+// account with the given address, recorded by the event with the given event
+// index within the transaction with the given index in the block.
+//
+// The key is "<prefix><address><height><tx index><event index>". All changes to
+// the contracts of an account share the address as prefix, so that they can be
+// listed by ascending height, and in execution order within a height, with a
+// single range scan.
+func newContractUpdateKey(address flow.Address, height uint64, txIndex uint32, eventIndex uint32) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen+txIndexLen+eventIndexLen)
+	key = append(key, newContractUpdateHeightKey(address, height)...)
+	key = binary.BigEndian.AppendUint32(key, txIndex)
+	key = binary.BigEndian.AppendUint32(key, eventIndex)
+
+	return key
+}
+
+// newContractUpdateHeightKey returns the lowest key of the changes to the
+// contracts of the account with the given address at the given height, to be
+// used as iteration bound.
+func newContractUpdateHeightKey(address flow.Address, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen)
+	key = append(key, PrefixContractUpdates)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// newAccountKeysKey returns the key of the keys of the account with the given
+// address as they were after the given height.
+//
+// The key is "<prefix><address><height>". All key changes of an account share
+// the address as prefix, so that they can be listed by ascending height with a
+// single range scan, and the latest one at or below a height can be found by
+// seeking backwards from the next height.
+func newAccountKeysKey(address flow.Address, height uint64) []byte {
+	key := make([]byte, 0, prefixLen+addressLen+heightLen)
+	key = append(key, PrefixAccountKeys)
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, height)
+
+	return key
+}
+
+// upperBound returns the exclusive upper bound of a range scan that includes
+// all keys starting with the given key. Range scans up to an inclusive height
+// use it on the key of that height, rather than on the key of the next height,
+// which would overflow at the maximum height. If the key only consists of 0xff
+// bytes, there is no such bound and it returns nil.
+func upperBound(key []byte) []byte {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == 0xff {
+			continue
+		}
+		bound := make([]byte, i+1)
+		copy(bound, key[:i+1])
+		bound[i]++
+		return bound
+	}
+
+	return nil
+}
+
+// contractAddress returns the address of the account on which the contract
+// that emits events of the given type is deployed. Types of contract events
+// have the form "A.<address>.<contract>.<event>"; other types, such as those
+// of protocol events, have no address.
+func contractAddress(typ flow.EventType) (flow.Address, bool) {
+	parts := strings.Split(string(typ), ".")
+	if len(parts) < 4 || parts[0] != "A" {
+		return flow.EmptyAddress, false
+	}
+	address, err := hex.DecodeString(parts[1])
+	if err != nil || len(address) > addressLen {
+		return flow.EmptyAddress, false
+	}
+
+	return flow.BytesToAddress(address), true
+}
+
+// tokenHash returns the hash identifying a token symbol within balance change
+// keys.
+func tokenHash(token string) uint64 {
+	return xxhash.ChecksumString64(token)
+}
+
+// eventTypeHash returns the hash identifying an event type within events keys.
+func eventTypeHash(typ flow.EventType) uint64 {
+	return xxhash.ChecksumString64(string(typ))
+}
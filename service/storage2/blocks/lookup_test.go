@@ -0,0 +1,174 @@
+package blocks
+
+import (
+	"math"
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newHeightKey(t *testing.T) {
+	t.Parallel()
+
+	key := newHeightKey(PrefixHeader, 777)
+
+	require.Equal(t, []byte("\x03\x00\x00\x00\x00\x00\x00\x03\x09"), key)
+}
+
+func Test_newIdentifierKey(t *testing.T) {
+	t.Parallel()
+
+	id := flow.Identifier{0x01, 0x02}
+	key := newIdentifierKey(PrefixTransaction, id)
+
+	require.Len(t, key, prefixLen+len(id))
+	require.Equal(t, byte(PrefixTransaction), key[0])
+	require.Equal(t, id[:], key[prefixLen:])
+}
+
+func Test_newEventsKey(t *testing.T) {
+	t.Parallel()
+
+	key := newEventsKey(777, "A.0x1.Test.Event")
+
+	require.Len(t, key, prefixLen+heightLen+typeHashLen)
+	require.Equal(t, newHeightKey(PrefixEvents, 777), key[:prefixLen+heightLen])
+
+	// Events of other heights do not share the height prefix.
+	require.NotEqual(t, newHeightKey(PrefixEvents, 778), key[:prefixLen+heightLen])
+}
+
+func Test_newEventTypeKey(t *testing.T) {
+	t.Parallel()
+
+	hash := eventTypeHash("A.0x1.Test.Event")
+	key := newEventTypeKey(hash, 777)
+
+	require.Len(t, key, prefixLen+typeHashLen+heightLen)
+	require.Equal(t, byte(PrefixHeightsForEventType), key[0])
+
+	// Heights of the same type are ordered by height after the type hash.
+	require.Equal(t, key[:prefixLen+typeHashLen], newEventTypeKey(hash, 778)[:prefixLen+typeHashLen])
+	require.Less(t, string(key), string(newEventTypeKey(hash, 778)))
+}
+
+func Test_newAddressTransactionKey(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+	key := newAddressTransactionKey(address, 777, flow.Identifier{0x01, 0x02})
+
+	require.Len(t, key, prefixLen+addressLen+heightLen+len(flow.Identifier{}))
+	require.Equal(t, newAddressHeightKey(address, 777), key[:prefixLen+addressLen+heightLen])
+
+	// Transactions of later heights are ordered after all those of this height.
+	require.Less(t, string(key), string(newAddressHeightKey(address, 778)))
+}
+
+func Test_newAddressTransferKey(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+	key := newAddressTransferKey(address, 777, flow.Identifier{0x01, 0x02}, 3)
+
+	require.Len(t, key, prefixLen+addressLen+heightLen+len(flow.Identifier{})+eventIndexLen)
+	require.Equal(t, byte(PrefixTransfersForAddress), key[0])
+	require.Equal(t, newTransferHeightKey(address, 777), key[:prefixLen+addressLen+heightLen])
+
+	// Transfers of later heights are ordered after all those of this height.
+	require.Less(t, string(key), string(newTransferHeightKey(address, 778)))
+}
+
+func Test_newBalanceChangeKey(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+	key := newBalanceChangeKey(address, "FLOW", 777)
+
+	require.Len(t, key, prefixLen+addressLen+tokenHashLen+heightLen)
+	require.Equal(t, byte(PrefixBalanceChanges), key[0])
+
+	// Changes of the same token are ordered by height after the token hash.
+	next := newBalanceChangeKey(address, "FLOW", 778)
+	require.Equal(t, key[:prefixLen+addressLen+tokenHashLen], next[:prefixLen+addressLen+tokenHashLen])
+	require.Less(t, string(key), string(next))
+	require.NotEqual(t, key[:prefixLen+addressLen+tokenHashLen], newBalanceChangeKey(address, "USDC", 777)[:prefixLen+addressLen+tokenHashLen])
+}
+
+func Test_contractAddress(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("f8d6e0586b0a20c7")
+
+	got, ok := contractAddress("A.f8d6e0586b0a20c7.FlowToken.TokensDeposited")
+	require.True(t, ok)
+	require.Equal(t, address, got)
+
+	_, ok = contractAddress("flow.AccountCreated")
+	require.False(t, ok)
+	_, ok = contractAddress("A.zz.FlowToken.TokensDeposited")
+	require.False(t, ok)
+	_, ok = contractAddress("A.f8d6e0586b0a20c7")
+	require.False(t, ok)
+}
+
+func Test_newContractEventTypeKey(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+	key := newContractEventTypeKey(address, 777, "A.0000000000000001.Token.Deposited")
+
+	require.Len(t, key, prefixLen+addressLen+heightLen+typeHashLen)
+	require.Equal(t, newContractHeightKey(address, 777), key[:prefixLen+addressLen+heightLen])
+
+	// Event types of later heights are ordered after all those of this height.
+	require.Less(t, string(key), string(newContractHeightKey(address, 778)))
+}
+
+func Test_newContractUpdateKey(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+	key := newContractUpdateKey(address, 777, 1, 2)
+
+	require.Len(t, key, prefixLen+addressLen+heightLen+txIndexLen+eventIndexLen)
+	require.Equal(t, newContractUpdateHeightKey(address, 777), key[:prefixLen+addressLen+heightLen])
+
+	// Updates are ordered by transaction and event index within a height.
+	require.Less(t, string(key), string(newContractUpdateKey(address, 777, 1, 3)))
+	require.Less(t, string(key), string(newContractUpdateKey(address, 777, 2, 0)))
+	require.Less(t, string(key), string(newContractUpdateHeightKey(address, 778)))
+}
+
+func Test_upperBound(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+
+	// The bound of a height is the key of the next height, as long as it does
+	// not overflow.
+	require.Equal(t, newHeightKey(PrefixEvents, 778), upperBound(newHeightKey(PrefixEvents, 777)))
+	require.Equal(t, newAccountKeysKey(address, 778), upperBound(newAccountKeysKey(address, 777)))
+
+	// At the maximum height, the bound is the successor of the prefix that
+	// comes before the height.
+	bound := upperBound(newAccountKeysKey(address, math.MaxUint64))
+	require.Less(t, string(newAccountKeysKey(address, math.MaxUint64)), string(bound))
+	require.Less(t, string(bound), string(newAccountKeysKey(flow.HexToAddress("0x2"), 0)))
+
+	require.Nil(t, upperBound([]byte{0xff, 0xff}))
+}
+
+func Test_newAccountKeysKey(t *testing.T) {
+	t.Parallel()
+
+	address := flow.HexToAddress("0x1")
+	key := newAccountKeysKey(address, 777)
+
+	require.Len(t, key, prefixLen+addressLen+heightLen)
+
+	// Changes are ordered by address first, then by height.
+	require.Less(t, string(key), string(newAccountKeysKey(address, 778)))
+	require.Less(t, string(key), string(newAccountKeysKey(flow.HexToAddress("0x2"), 0)))
+}
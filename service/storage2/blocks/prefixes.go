@@ -0,0 +1,53 @@
+package blocks
+
+// Key prefixes of the block storage. The values match the prefixes of the
+// legacy badger index, so that records can be copied over with their key as is.
+const (
+	PrefixFirst                = 1
+	PrefixLast                 = 2
+	PrefixLatestRegisterHeight = 18
+	PrefixBootstrapProgress    = 19
+
+	PrefixHeightForBlock       = 7
+	PrefixHeightForTransaction = 16
+	PrefixHeightForCollection  = 26
+	PrefixHeightForSeal        = 27
+
+	PrefixSealedHeightForBlock = 28
+	PrefixFirstSealedHeight    = 29
+
+	PrefixTransactionsForAddress        = 22
+	PrefixFirstAddressTransactionHeight = 23
+
+	PrefixCommit = 4
+	PrefixHeader = 3
+	PrefixEvents = 5
+
+	PrefixHeightsForEventType  = 20
+	PrefixFirstEventTypeHeight = 21
+
+	PrefixEventTypesForContract     = 24
+	PrefixFirstContractEventsHeight = 25
+
+	PrefixTransaction = 8
+	PrefixCollection  = 10
+	PrefixGuarantee   = 17
+
+	PrefixTransactionsForHeight     = 9
+	PrefixTransactionsForCollection = 12
+	PrefixCollectionsForHeight      = 11
+	PrefixResults                   = 13
+
+	PrefixSeal           = 14
+	PrefixSealsForHeight = 15
+
+	PrefixTransfersForAddress = 30
+	PrefixBalanceChanges      = 31
+	PrefixFirstTransferHeight = 36
+
+	PrefixContractUpdates           = 32
+	PrefixFirstContractUpdateHeight = 33
+
+	PrefixAccountKeys             = 34
+	PrefixLatestAccountKeysHeight = 35
+)
@@ -0,0 +1,77 @@
+package blocks
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// Record is an encoded key and value of the block storage.
+type Record struct {
+	Key   []byte
+	Value []byte
+}
+
+// BatchSetRecords writes the given records as is, without encoding them. It
+// is meant for importing records that already use the key layout and encoding
+// of the block storage, such as the records of the legacy badger index.
+func (s *Storage) BatchSetRecords(records []Record) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for _, record := range records {
+		err := batch.Set(record.Key, record.Value, nil)
+		if err != nil {
+			return fmt.Errorf("failed to set key: %w", err)
+		}
+	}
+
+	return s.commit(batch)
+}
+
+// GetLastKey returns the greatest key with the given prefix, or nil if there
+// is no key with that prefix.
+func (s *Storage) GetLastKey(prefix byte) ([]byte, error) {
+	iter := s.db.NewIter(prefixBounds(prefix))
+	defer iter.Close()
+
+	if !iter.Last() {
+		err := iter.Error()
+		if err != nil {
+			return nil, fmt.Errorf("failed to seek last key: %w", err)
+		}
+		return nil, nil
+	}
+
+	key := make([]byte, len(iter.Key()))
+	copy(key, iter.Key())
+
+	return key, nil
+}
+
+// CountRecords returns the number of records with the given prefix.
+func (s *Storage) CountRecords(prefix byte) (uint64, error) {
+	iter := s.db.NewIter(prefixBounds(prefix))
+	defer iter.Close()
+
+	count := uint64(0)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		count++
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate over records: %w", err)
+	}
+
+	return count, nil
+}
+
+// prefixBounds returns iterator options that restrict iteration to the keys
+// with the given prefix.
+func prefixBounds(prefix byte) *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: []byte{prefix},
+		UpperBound: []byte{prefix + 1},
+	}
+}
@@ -0,0 +1,190 @@
+package changes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2/config"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// pruneBatchSize is the number of deletions committed at once while pruning.
+const pruneBatchSize = 10_000
+
+// Storage is a pebble-backed index of the registers changed at each height.
+type Storage struct {
+	db    *pebble.DB
+	opts  *pebble.Options
+	write *pebble.WriteOptions
+}
+
+// NewStorage creates a pebble-backed register change set storage.
+//
+// Keys are ordered by height first, so all the registers changed at a height
+// can be listed with a single range scan. Values are empty, as the register
+// values themselves are available in the payload storage.
+func NewStorage(dbPath string, cache *pebble.Cache, options ...config.Option) (*Storage, error) {
+	opts := config.DefaultPebbleOptions(cache, pebble.DefaultComparer, options...)
+	db, err := pebble.Open(dbPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db: %w", err)
+	}
+
+	return &Storage{
+		db:    db,
+		opts:  opts,
+		write: pebble.Sync,
+	}, nil
+}
+
+// GetRegistersForHeight returns the IDs of all registers that were changed at
+// the given height, ordered by their encoded key.
+//
+// If no register was changed at the given height, an empty slice is returned.
+func (s *Storage) GetRegistersForHeight(height uint64) (flow.RegisterIDs, error) {
+	// The registers changed at the maximum height have the last keys, so there
+	// is no upper bound for them, and the next height would overflow.
+	var upper []byte
+	if height < math.MaxUint64 {
+		upper = newHeightPrefix(height + 1)
+	}
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: newHeightPrefix(height),
+		UpperBound: upper,
+	})
+	defer iter.Close()
+
+	regs := make(flow.RegisterIDs, 0)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		_, reg, err := lookupKeyToRegisterID(iter.Key())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key: %w", err)
+		}
+		regs = append(regs, reg)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over registers: %w", err)
+	}
+
+	return regs, nil
+}
+
+// BatchSetRegistersForHeight indexes the given registers as changed at the given height.
+// Unless syncing was disabled, the batch is synced to disk before returning.
+func (s *Storage) BatchSetRegistersForHeight(
+	height uint64,
+	regs flow.RegisterIDs,
+) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for _, reg := range regs {
+		err := batch.Set(newLookupKey(height, reg), nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to set key: %w", err)
+		}
+	}
+
+	err := batch.Commit(s.write)
+	if err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
+
+// Prune removes the change sets of all heights below the retention height
+// that are not kept by the given retention.
+func (s *Storage) Prune(retention archive.RegisterRetention) error {
+	lower := newHeightPrefix(0)
+	upper := newHeightPrefix(retention.Height)
+
+	// Without an interval, nothing is kept below the retention height, so we
+	// can drop the whole range at once.
+	if retention.Interval == 0 {
+		err := s.db.DeleteRange(lower, upper, pebble.Sync)
+		if err != nil {
+			return fmt.Errorf("failed to delete range: %w", err)
+		}
+		return nil
+	}
+
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	defer iter.Close()
+
+	batch := s.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for valid := iter.First(); valid; valid = iter.Next() {
+		height, _, err := lookupKeyToRegisterID(iter.Key())
+		if err != nil {
+			return fmt.Errorf("failed to decode key: %w", err)
+		}
+		if retention.Retains(height) {
+			continue
+		}
+		err = batch.Delete(iter.Key(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete key: %w", err)
+		}
+
+		if batch.Count() < pruneBatchSize {
+			continue
+		}
+		err = batch.Commit(pebble.Sync)
+		if err != nil {
+			return fmt.Errorf("failed to commit batch: %w", err)
+		}
+		_ = batch.Close()
+		batch = s.db.NewBatch()
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return fmt.Errorf("failed to iterate over registers: %w", err)
+	}
+
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
+
+// SetSync sets whether the batches of changed registers are synced to disk when
+// they are committed. If not, they are only durable once Sync is called.
+func (s *Storage) SetSync(sync bool) {
+	s.write = pebble.NoSync
+	if sync {
+		s.write = pebble.Sync
+	}
+}
+
+// Sync syncs all committed batches to disk, by syncing the write-ahead log.
+func (s *Storage) Sync() error {
+	err := s.db.LogData(nil, pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) Checkpoint(dir string) error {
+	return s.db.Checkpoint(dir)
+}
+
+// Close closes the storage.
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
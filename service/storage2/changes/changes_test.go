@@ -0,0 +1,125 @@
+package changes
+
+import (
+	"math"
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+func Test_ChangesStorage_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "roundtrip.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	regs1 := flow.RegisterIDs{
+		{Owner: "owner", Key: "key1"},
+		{Owner: "", Key: "key2"},
+	}
+	regs2 := flow.RegisterIDs{
+		{Owner: "owner", Key: "key1"},
+	}
+
+	// Heights are encoded big-endian, so 255 and 256 must not mix.
+	err = s.BatchSetRegistersForHeight(255, regs1)
+	require.NoError(t, err)
+	err = s.BatchSetRegistersForHeight(256, regs2)
+	require.NoError(t, err)
+
+	got, err := s.GetRegistersForHeight(255)
+	require.NoError(t, err)
+	require.ElementsMatch(t, regs1, got)
+
+	got, err = s.GetRegistersForHeight(256)
+	require.NoError(t, err)
+	require.ElementsMatch(t, regs2, got)
+
+	got, err = s.GetRegistersForHeight(257)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	// The next height would overflow at the maximum height.
+	err = s.BatchSetRegistersForHeight(math.MaxUint64, regs2)
+	require.NoError(t, err)
+
+	got, err = s.GetRegistersForHeight(math.MaxUint64)
+	require.NoError(t, err)
+	require.ElementsMatch(t, regs2, got)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func Test_ChangesStorage_Prune(t *testing.T) {
+	t.Parallel()
+
+	regs := flow.RegisterIDs{
+		{Owner: "owner", Key: "key1"},
+	}
+
+	tests := []struct {
+		name      string
+		retention archive.RegisterRetention
+		remaining map[uint64]bool
+	}{
+		{
+			name:      "height only",
+			retention: archive.RegisterRetention{Height: 20},
+			remaining: map[uint64]bool{20: true, 25: true},
+		},
+		{
+			name:      "height and interval",
+			retention: archive.RegisterRetention{Height: 20, Interval: 10},
+			remaining: map[uint64]bool{10: true, 20: true, 25: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := pebble.NewCache(1 << 20)
+			defer cache.Unref()
+
+			dbpath := path.Join(t.TempDir(), "prune.db")
+			s, err := NewStorage(dbpath, cache)
+			require.NoError(t, err)
+			require.NotNil(t, s)
+
+			heights := []uint64{5, 10, 15, 20, 25}
+			for _, height := range heights {
+				err = s.BatchSetRegistersForHeight(height, regs)
+				require.NoError(t, err)
+			}
+
+			err = s.Prune(tt.retention)
+			require.NoError(t, err)
+
+			for _, height := range heights {
+				got, err := s.GetRegistersForHeight(height)
+				require.NoError(t, err)
+				if tt.remaining[height] {
+					require.Equal(t, regs, got, "height: %d", height)
+				} else {
+					require.Empty(t, got, "height: %d", height)
+				}
+			}
+
+			err = s.Close()
+			require.NoError(t, err)
+		})
+	}
+}
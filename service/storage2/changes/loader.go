@@ -0,0 +1,68 @@
+package changes
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/service/storage2/ingest"
+)
+
+// Loader bulk loads the registers changed at a single height, by sorting them
+// into sstables on disk that are ingested into the storage in one go.
+type Loader struct {
+	storage *Storage
+	height  uint64
+	sorter  *ingest.Sorter
+}
+
+// NewLoader creates a loader for the registers changed at the given height,
+// which writes its temporary files to the given directory. It has to be on the
+// same file system as the storage. The limit is the number of bytes buffered in
+// memory before sorted keys are spilled to disk.
+func (s *Storage) NewLoader(dir string, height uint64, limit int) *Loader {
+
+	l := Loader{
+		storage: s,
+		height:  height,
+		sorter:  ingest.NewSorter(dir, s.opts.Comparer.Compare, limit),
+	}
+
+	return &l
+}
+
+// Add adds the given registers to the loader.
+func (l *Loader) Add(regs flow.RegisterIDs) error {
+
+	for _, reg := range regs {
+		err := l.sorter.Add(newLookupKey(l.height, reg), nil)
+		if err != nil {
+			return fmt.Errorf("failed to add register: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Ingest writes all added registers into sstables and ingests them into the
+// storage. The registers are only visible in the storage once it returns.
+func (l *Loader) Ingest() error {
+
+	opts := l.storage.opts
+	last := len(opts.Levels) - 1
+	writerOpts := opts.MakeWriterOptions(last, l.storage.db.FormatMajorVersion().MaxTableFormat())
+	paths, err := l.sorter.Write(writerOpts, uint64(opts.Levels[last].TargetFileSize))
+	if err != nil {
+		return fmt.Errorf("failed to write sstables: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	err = l.storage.db.Ingest(paths)
+	if err != nil {
+		return fmt.Errorf("failed to ingest sstables: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package changes
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ChangesStorage_Loader checks that bulk loading changed registers results
+// in the same change sets as writing them in batches.
+func Test_ChangesStorage_Loader(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dir := t.TempDir()
+	batched, err := NewStorage(path.Join(dir, "batched.db"), cache)
+	require.NoError(t, err)
+	defer batched.Close()
+	loaded, err := NewStorage(path.Join(dir, "loaded.db"), cache)
+	require.NoError(t, err)
+	defer loaded.Close()
+
+	previous := flow.RegisterIDs{{Owner: "owner", Key: "key"}}
+	require.NoError(t, batched.BatchSetRegistersForHeight(1, previous))
+	require.NoError(t, loaded.BatchSetRegistersForHeight(1, previous))
+
+	height := uint64(2)
+	regs := make(flow.RegisterIDs, 0, 1000)
+	for _, i := range rand.Perm(1000) {
+		regs = append(regs, flow.RegisterID{Owner: fmt.Sprintf("owner%d", i%10), Key: fmt.Sprintf("key%04d", i)})
+	}
+
+	loader := loaded.NewLoader(t.TempDir(), height, 1<<10)
+	for start := 0; start < len(regs); start += 100 {
+		require.NoError(t, batched.BatchSetRegistersForHeight(height, regs[start:start+100]))
+		require.NoError(t, loader.Add(regs[start:start+100]))
+	}
+	require.NoError(t, loader.Ingest())
+
+	for _, h := range []uint64{1, 2} {
+		want, err := batched.GetRegistersForHeight(h)
+		require.NoError(t, err)
+		got, err := loaded.GetRegistersForHeight(h)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,61 @@
+package changes
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// Size of the block height encoded at the start of the key.
+	heightPrefixLen = 8
+	// Size of the owner length encoded after the height.
+	ownerLenLen = 2
+)
+
+// newHeightPrefix returns the prefix shared by all lookup keys of registers
+// changed at the given height.
+func newHeightPrefix(height uint64) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, heightPrefixLen), height)
+}
+
+// newLookupKey takes a height and registerID, returns the key for indexing the
+// register as changed at that height.
+//
+// The key is "<height><owner length><owner><key>". Unlike the payload storage,
+// the owner is length-prefixed rather than separated by a slash, because
+// owners are raw address bytes that can contain any byte value.
+func newLookupKey(height uint64, reg flow.RegisterID) []byte {
+	key := make([]byte, 0, heightPrefixLen+ownerLenLen+len(reg.Owner)+len(reg.Key))
+	key = binary.BigEndian.AppendUint64(key, height)
+	key = binary.BigEndian.AppendUint16(key, uint16(len(reg.Owner)))
+	key = append(key, reg.Owner...)
+	key = append(key, reg.Key...)
+
+	return key
+}
+
+// lookupKeyToRegisterID takes a lookup key and decodes it into height and RegisterID.
+func lookupKeyToRegisterID(lookupKey []byte) (uint64, flow.RegisterID, error) {
+	const minLookupKeyLen = heightPrefixLen + ownerLenLen
+	if len(lookupKey) < minLookupKeyLen {
+		return 0, flow.RegisterID{}, fmt.Errorf("invalid lookup key format: expected >= %d bytes, got %d bytes",
+			minLookupKeyLen, len(lookupKey))
+	}
+
+	height := binary.BigEndian.Uint64(lookupKey[:heightPrefixLen])
+
+	ownerLen := int(binary.BigEndian.Uint16(lookupKey[heightPrefixLen:minLookupKeyLen]))
+	if len(lookupKey)-minLookupKeyLen < ownerLen {
+		return 0, flow.RegisterID{}, fmt.Errorf("invalid lookup key format: expected >= %d bytes of owner, got %d bytes",
+			ownerLen, len(lookupKey)-minLookupKeyLen)
+	}
+
+	regID := flow.RegisterID{
+		Owner: string(lookupKey[minLookupKeyLen : minLookupKeyLen+ownerLen]),
+		Key:   string(lookupKey[minLookupKeyLen+ownerLen:]),
+	}
+
+	return height, regID, nil
+}
@@ -0,0 +1,65 @@
+package changes
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lookupKey_Bytes(t *testing.T) {
+	t.Parallel()
+
+	key := newLookupKey(777, flow.RegisterID{Owner: "owner", Key: "key"})
+
+	require.Equal(t, []byte("\x00\x00\x00\x00\x00\x00\x03\x09\x00\x05ownerkey"), key)
+
+	height, reg, err := lookupKeyToRegisterID(key)
+	require.NoError(t, err)
+	require.Equal(t, uint64(777), height)
+	require.Equal(t, "owner", reg.Owner)
+	require.Equal(t, "key", reg.Key)
+}
+
+func Test_decodeKey_Bytes(t *testing.T) {
+	height := uint64(10)
+
+	cases := []struct {
+		owner string
+		key   string
+	}{
+		{owner: "owner/address", key: "public/storage/hasslash-in-key"},
+		{owner: "owneraddress", key: ""},
+		{owner: "", key: "somekey"},
+		{owner: "", key: ""},
+	}
+
+	for _, c := range cases {
+		owner, key := c.owner, c.key
+
+		lookupKey := newLookupKey(height, flow.RegisterID{Owner: owner, Key: key})
+		require.Equal(t, newHeightPrefix(height), lookupKey[:heightPrefixLen])
+
+		decodedHeight, decodedReg, err := lookupKeyToRegisterID(lookupKey)
+		require.NoError(t, err)
+
+		require.Equal(t, height, decodedHeight)
+		require.Equal(t, owner, decodedReg.Owner)
+		require.Equal(t, key, decodedReg.Key)
+	}
+}
+
+func Test_decodeKey_fail(t *testing.T) {
+	var err error
+	// less than min length (10)
+	_, _, err = lookupKeyToRegisterID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	require.Contains(t, err.Error(), "bytes")
+
+	// owner length beyond key length
+	_, _, err = lookupKeyToRegisterID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 0, 2, 'a'})
+	require.Contains(t, err.Error(), "owner")
+
+	// valid key
+	_, _, err = lookupKeyToRegisterID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 0, 1, 'a'})
+	require.NoError(t, err)
+}
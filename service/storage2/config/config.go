@@ -3,11 +3,24 @@ package config
 import (
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/bloom"
+	"github.com/cockroachdb/pebble/vfs"
 )
 
+// Option is an option that can be given to a storage to adjust the pebble
+// options its database is opened with.
+type Option func(*pebble.Options)
+
+// WithFS sets the filesystem the database is stored on, instead of the one of
+// the operating system.
+func WithFS(fs vfs.FS) Option {
+	return func(opts *pebble.Options) {
+		opts.FS = fs
+	}
+}
+
 // DefaultPebbleOptions returns an optimized set of pebble options.
 // This is mostly copied form pebble's nightly performance benchmark.
-func DefaultPebbleOptions(cache *pebble.Cache, comparer *pebble.Comparer) *pebble.Options {
+func DefaultPebbleOptions(cache *pebble.Cache, comparer *pebble.Comparer, options ...Option) *pebble.Options {
 	opts := &pebble.Options{
 		Cache:              cache,
 		Comparer:           comparer,
@@ -59,5 +72,9 @@ func DefaultPebbleOptions(cache *pebble.Cache, comparer *pebble.Comparer) *pebbl
 	opts.FlushSplitBytes = opts.Levels[0].TargetFileSize
 	opts.EnsureDefaults()
 
+	for _, option := range options {
+		option(opts)
+	}
+
 	return opts
 }
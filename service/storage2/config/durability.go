@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Durability is the mode in which register and block writes are made durable.
+type Durability uint8
+
+// The supported durability modes. With DurabilityBatch, every batch of
+// registers or block data is synced to disk when it is committed. With
+// DurabilityBlock, the writes of a block are synced once, right before the
+// latest register height or the last height is moved to that block. With
+// DurabilityPeriodic, writes are synced in a group commit at a regular
+// interval, and the latest register height and last height only move forward
+// after each sync.
+const (
+	DurabilityBatch Durability = iota + 1
+	DurabilityBlock
+	DurabilityPeriodic
+)
+
+// ParseDurability parses the name of a durability mode.
+func ParseDurability(name string) (Durability, error) {
+	switch name {
+	case "batch":
+		return DurabilityBatch, nil
+	case "block":
+		return DurabilityBlock, nil
+	case "periodic":
+		return DurabilityPeriodic, nil
+	default:
+		return 0, fmt.Errorf("invalid durability mode (%s)", name)
+	}
+}
+
+// String returns the name of the durability mode.
+func (d Durability) String() string {
+	switch d {
+	case DurabilityBatch:
+		return "batch"
+	case DurabilityBlock:
+		return "block"
+	case DurabilityPeriodic:
+		return "periodic"
+	default:
+		return "invalid"
+	}
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDurability(t *testing.T) {
+	t.Parallel()
+
+	for _, want := range []Durability{DurabilityBatch, DurabilityBlock, DurabilityPeriodic} {
+		got, err := ParseDurability(want.String())
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	_, err := ParseDurability("never")
+	require.Error(t, err)
+}
@@ -0,0 +1,311 @@
+package ingest
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// Sorter sorts key-value pairs that do not fit into memory, and writes them
+// into sstables that can be ingested into a pebble database.
+//
+// Pairs are buffered in memory until the buffer limit is reached, at which
+// point they are sorted and spilled into a run file on disk. Once all pairs
+// were added, the runs are merged into a single sorted stream that is split
+// across as many sstables as needed. When the same key is added more than once,
+// the value that was added last is kept.
+type Sorter struct {
+	dir     string
+	compare pebble.Compare
+	limit   int
+
+	pairs []pair
+	size  int
+	runs  []string
+}
+
+type pair struct {
+	key   []byte
+	value []byte
+}
+
+// NewSorter creates a sorter that orders keys with the given compare function,
+// and writes its run files and sstables to the given directory, which has to
+// exist. The limit is the number of bytes of keys and values that are buffered
+// in memory before being spilled to disk.
+func NewSorter(dir string, compare pebble.Compare, limit int) *Sorter {
+
+	s := Sorter{
+		dir:     dir,
+		compare: compare,
+		limit:   limit,
+	}
+
+	return &s
+}
+
+// Add adds a copy of the given key-value pair to the sorter.
+func (s *Sorter) Add(key []byte, value []byte) error {
+
+	p := pair{
+		key:   append([]byte(nil), key...),
+		value: append([]byte(nil), value...),
+	}
+	s.pairs = append(s.pairs, p)
+	s.size += len(key) + len(value)
+
+	if s.size < s.limit {
+		return nil
+	}
+
+	return s.spill()
+}
+
+// Write merges all pairs added to the sorter in key order, and writes them
+// into sstables of about the target size with the given options. It returns
+// the paths of the sstables in key order, which do not overlap.
+func (s *Sorter) Write(opts sstable.WriterOptions, target uint64) ([]string, error) {
+
+	// The pairs that are still buffered are spilled as a last run, so that all
+	// pairs can be merged the same way.
+	if len(s.pairs) > 0 {
+		err := s.spill()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merge := &merger{compare: s.compare}
+	defer merge.close()
+	for i, name := range s.runs {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("could not open run file: %w", err)
+		}
+		r := &run{index: i, file: file, reader: bufio.NewReader(file)}
+		merge.all = append(merge.all, r)
+		ok, err := r.next()
+		if err != nil {
+			return nil, fmt.Errorf("could not read run file: %w", err)
+		}
+		if ok {
+			merge.runs = append(merge.runs, r)
+		}
+	}
+	heap.Init(merge)
+
+	var paths []string
+	var writer *sstable.Writer
+	var pending *pair
+	flush := func() error {
+		if writer == nil {
+			path := filepath.Join(s.dir, fmt.Sprintf("%06d.sst", len(paths)))
+			file, err := vfs.Default.Create(path)
+			if err != nil {
+				return fmt.Errorf("could not create sstable: %w", err)
+			}
+			writer = sstable.NewWriter(objstorageprovider.NewFileWritable(file), opts)
+			paths = append(paths, path)
+		}
+
+		err := writer.Set(pending.key, pending.value)
+		if err != nil {
+			return fmt.Errorf("could not write pair: %w", err)
+		}
+
+		if writer.EstimatedSize() < target {
+			return nil
+		}
+
+		err = writer.Close()
+		writer = nil
+		if err != nil {
+			return fmt.Errorf("could not close sstable: %w", err)
+		}
+
+		return nil
+	}
+
+	for merge.Len() > 0 {
+		r := merge.runs[0]
+		current := r.current
+
+		// Equal keys come out of the merge in the order they were added, so a
+		// later pair replaces the pending one.
+		if pending != nil && s.compare(pending.key, current.key) != 0 {
+			err := flush()
+			if err != nil {
+				return nil, err
+			}
+		}
+		pending = &current
+
+		ok, err := r.next()
+		if err != nil {
+			return nil, fmt.Errorf("could not read run file: %w", err)
+		}
+		if ok {
+			heap.Fix(merge, 0)
+		} else {
+			heap.Pop(merge)
+		}
+	}
+
+	if pending != nil {
+		err := flush()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if writer != nil {
+		err := writer.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not close sstable: %w", err)
+		}
+	}
+
+	return paths, nil
+}
+
+// spill sorts the buffered pairs and writes them into a new run file.
+func (s *Sorter) spill() error {
+
+	// The sort is stable, so that pairs with equal keys stay in the order in
+	// which they were added.
+	sort.SliceStable(s.pairs, func(i, j int) bool {
+		return s.compare(s.pairs[i].key, s.pairs[j].key) < 0
+	})
+
+	name := filepath.Join(s.dir, fmt.Sprintf("%06d.run", len(s.runs)))
+	file, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("could not create run file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, p := range s.pairs {
+		for _, data := range [][]byte{p.key, p.value} {
+			n := binary.PutUvarint(buf, uint64(len(data)))
+			_, err = writer.Write(buf[:n])
+			if err != nil {
+				return fmt.Errorf("could not write run file: %w", err)
+			}
+			_, err = writer.Write(data)
+			if err != nil {
+				return fmt.Errorf("could not write run file: %w", err)
+			}
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush run file: %w", err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("could not sync run file: %w", err)
+	}
+
+	s.runs = append(s.runs, name)
+	s.pairs = s.pairs[:0]
+	s.size = 0
+
+	return nil
+}
+
+// run reads the pairs of a run file back in order.
+type run struct {
+	index   int
+	file    *os.File
+	reader  *bufio.Reader
+	current pair
+}
+
+// next reads the next pair of the run, and returns false once the run is
+// exhausted.
+func (r *run) next() (bool, error) {
+
+	key, err := r.read()
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	value, err := r.read()
+	if err != nil {
+		return false, err
+	}
+
+	r.current = pair{key: key, value: value}
+
+	return true, nil
+}
+
+func (r *run) read() ([]byte, error) {
+
+	length, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(r.reader, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// merger is a heap of runs ordered by their current key, and by the order in
+// which the runs were written for equal keys.
+type merger struct {
+	compare pebble.Compare
+	runs    []*run
+	all     []*run
+}
+
+func (m *merger) Len() int {
+	return len(m.runs)
+}
+
+func (m *merger) Less(i, j int) bool {
+	c := m.compare(m.runs[i].current.key, m.runs[j].current.key)
+	if c != 0 {
+		return c < 0
+	}
+	return m.runs[i].index < m.runs[j].index
+}
+
+func (m *merger) Swap(i, j int) {
+	m.runs[i], m.runs[j] = m.runs[j], m.runs[i]
+}
+
+func (m *merger) Push(x interface{}) {
+	m.runs = append(m.runs, x.(*run))
+}
+
+func (m *merger) Pop() interface{} {
+	last := m.runs[len(m.runs)-1]
+	m.runs = m.runs[:len(m.runs)-1]
+	return last
+}
+
+func (m *merger) close() {
+	for _, r := range m.all {
+		_ = r.file.Close()
+	}
+}
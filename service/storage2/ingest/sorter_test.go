@@ -0,0 +1,77 @@
+package ingest
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Sorter_Write(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	db, err := pebble.Open(path.Join(dir, "test.db"), &pebble.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// A small limit and target size makes the sorter spill many runs and
+	// split its output across many sstables.
+	sorter := NewSorter(dir, pebble.DefaultComparer.Compare, 1<<10)
+
+	expected := make(map[string]string)
+	for _, i := range rand.Perm(1000) {
+		key := fmt.Sprintf("key%04d", i)
+		value := fmt.Sprintf("value%d", i)
+		require.NoError(t, sorter.Add([]byte(key), []byte(value)))
+		expected[key] = value
+	}
+
+	// Keys that are added again replace the value that was added before.
+	for i := 0; i < 1000; i += 7 {
+		key := fmt.Sprintf("key%04d", i)
+		value := fmt.Sprintf("updated%d", i)
+		require.NoError(t, sorter.Add([]byte(key), []byte(value)))
+		expected[key] = value
+	}
+
+	opts := (&pebble.Options{}).EnsureDefaults().MakeWriterOptions(0, db.FormatMajorVersion().MaxTableFormat())
+	paths, err := sorter.Write(opts, 1<<10)
+	require.NoError(t, err)
+	require.Greater(t, len(paths), 1)
+
+	require.NoError(t, db.Ingest(paths))
+
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	iter := db.NewIter(nil)
+	defer iter.Close()
+
+	i := 0
+	for valid := iter.First(); valid; valid = iter.Next() {
+		require.Less(t, i, len(keys))
+		require.Equal(t, keys[i], string(iter.Key()))
+		require.Equal(t, expected[keys[i]], string(iter.Value()))
+		i++
+	}
+	require.NoError(t, iter.Error())
+	require.Equal(t, len(keys), i)
+}
+
+func Test_Sorter_WriteEmpty(t *testing.T) {
+	t.Parallel()
+
+	sorter := NewSorter(t.TempDir(), pebble.DefaultComparer.Compare, 1<<10)
+
+	paths, err := sorter.Write((&pebble.Options{}).EnsureDefaults().MakeWriterOptions(0, 0), 1<<10)
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
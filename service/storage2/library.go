@@ -2,42 +2,596 @@ package storage2
 
 import (
 	"fmt"
+	"os"
 	"path"
+	"sync"
+	"time"
 
 	"go.uber.org/multierr"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/onflow/flow-archive/codec/zbor"
 	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2/blocks"
+	"github.com/onflow/flow-archive/service/storage2/changes"
+	"github.com/onflow/flow-archive/service/storage2/config"
 	"github.com/onflow/flow-archive/service/storage2/payload"
+	"github.com/onflow/flow-go/model/flow"
 )
 
 var _ archive.Library2 = (*library2Impl)(nil)
 
 type library2Impl struct {
 	*payload.Storage
+
+	changes *changes.Storage
+	blocks  *blocks.Storage
+
+	dir string
+	cfg Config
+
+	// With periodic durability, the latest register height and the last height
+	// are held back until the registers up to them were synced.
+	mu            sync.Mutex
+	pendingLatest *uint64
+	pendingLast   *uint64
+	failed        error
+	done          chan struct{}
+	wg            sync.WaitGroup
 }
 
 func StoragePath(dir string) string {
 	return path.Join(dir, "payload.db")
 }
 
-func NewLibrary2(dir string, blockCacheSize int64) (archive.Library2, error) {
+func ChangesPath(dir string) string {
+	return path.Join(dir, "changes.db")
+}
+
+func BlocksPath(dir string) string {
+	return path.Join(dir, "blocks.db")
+}
+
+func IngestPath(dir string) string {
+	return path.Join(dir, "ingest")
+}
+
+// NewLibrary2 opens the pebble-based index in the given directory, creating its
+// databases if they do not exist yet.
+func NewLibrary2(dir string, blockCacheSize int64, options ...Option) (archive.Library2, error) {
+	cfg := DefaultConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+	switch cfg.Durability {
+	case config.DurabilityBatch, config.DurabilityBlock:
+	case config.DurabilityPeriodic:
+		if cfg.SyncInterval <= 0 {
+			return nil, fmt.Errorf("invalid sync interval for periodic durability (%s)", cfg.SyncInterval)
+		}
+	default:
+		return nil, fmt.Errorf("invalid durability mode (%d)", cfg.Durability)
+	}
+
+	var storOpts []config.Option
+	if cfg.FS != nil {
+		storOpts = append(storOpts, config.WithFS(cfg.FS))
+	}
+
 	// TODO(rbtz): cache metrics
 	cache := pebble.NewCache(blockCacheSize)
 	defer cache.Unref()
 
 	payloadStor, err := payload.NewStorage(
-		StoragePath(dir), cache)
+		StoragePath(dir), cache, storOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create payload storage: %w", err)
 	}
 
-	return &library2Impl{
+	changesStor, err := changes.NewStorage(
+		ChangesPath(dir), cache, storOpts...)
+	if err != nil {
+		multierr.AppendInto(&err, payloadStor.Close())
+		return nil, fmt.Errorf("failed to create changes storage: %w", err)
+	}
+
+	blocksStor, err := blocks.NewStorage(
+		BlocksPath(dir), cache, zbor.NewCodec(), storOpts...)
+	if err != nil {
+		multierr.AppendInto(&err, payloadStor.Close())
+		multierr.AppendInto(&err, changesStor.Close())
+		return nil, fmt.Errorf("failed to create blocks storage: %w", err)
+	}
+
+	// Unless every batch is synced, registers and block data are synced
+	// together before the latest register height and last height move past them.
+	durable := cfg.Durability == config.DurabilityBatch
+	payloadStor.SetSync(durable)
+	changesStor.SetSync(durable)
+	blocksStor.SetSync(durable)
+
+	l := library2Impl{
 		Storage: payloadStor,
-	}, nil
+		changes: changesStor,
+		blocks:  blocksStor,
+		dir:     dir,
+		cfg:     cfg,
+		done:    make(chan struct{}),
+	}
+
+	if cfg.Durability == config.DurabilityPeriodic {
+		l.wg.Add(1)
+		go l.commit()
+	}
+
+	return &l, nil
+}
+
+// commit regularly syncs the register and block writes, and persists the
+// heights that were held back until then.
+func (l *library2Impl) commit() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.cfg.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+		}
+
+		// There is nobody to return the error to, so it is returned on the
+		// next attempt to persist a held back height instead.
+		err := l.Sync()
+		if err != nil {
+			l.mu.Lock()
+			l.failed = err
+			l.mu.Unlock()
+		}
+	}
+}
+
+// Sync syncs all register writes to disk, and then persists the latest register
+// height and the last height that were held back until they were durable, if
+// any, before syncing the block data along with them.
+func (l *library2Impl) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.syncRegisters()
+	if err != nil {
+		return err
+	}
+
+	if l.pendingLatest != nil {
+		err = l.blocks.SetLatestRegisterHeight(*l.pendingLatest)
+		if err != nil {
+			return fmt.Errorf("failed to set latest register height: %w", err)
+		}
+	}
+	if l.pendingLast != nil {
+		err = l.blocks.SetLast(*l.pendingLast)
+		if err != nil {
+			return fmt.Errorf("failed to set last height: %w", err)
+		}
+	}
+	err = l.blocks.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync blocks: %w", err)
+	}
+	if l.pendingLast != nil {
+		l.notify()
+	}
+	l.pendingLatest = nil
+	l.pendingLast = nil
+
+	return nil
+}
+
+// notify notifies the configured notifier, if any, that a new last height was
+// persisted.
+func (l *library2Impl) notify() {
+	if l.cfg.Notifier != nil {
+		l.cfg.Notifier.Notify()
+	}
+}
+
+// GetRegistersForHeight returns the IDs of all registers changed at the given height.
+func (l *library2Impl) GetRegistersForHeight(height uint64) (flow.RegisterIDs, error) {
+	return l.changes.GetRegistersForHeight(height)
+}
+
+// BatchSetRegistersForHeight indexes the given registers as changed at the given height.
+func (l *library2Impl) BatchSetRegistersForHeight(height uint64, regs flow.RegisterIDs) error {
+	return l.changes.BatchSetRegistersForHeight(height, regs)
+}
+
+// GetFirst returns the height of the first indexed block.
+func (l *library2Impl) GetFirst() (uint64, error) {
+	return l.blocks.GetFirst()
+}
+
+// GetLast returns the height of the last indexed block.
+func (l *library2Impl) GetLast() (uint64, error) {
+	return l.blocks.GetLast()
+}
+
+// GetLatestRegisterHeight returns the latest height for which all registers are indexed.
+func (l *library2Impl) GetLatestRegisterHeight() (uint64, error) {
+	return l.blocks.GetLatestRegisterHeight()
+}
+
+// GetBootstrapProgress returns the number of checkpoint leaves written while bootstrapping the given height.
+func (l *library2Impl) GetBootstrapProgress(height uint64) (uint64, error) {
+	return l.blocks.GetBootstrapProgress(height)
+}
+
+// GetHeightForBlock returns the height of the block with the given identifier.
+func (l *library2Impl) GetHeightForBlock(blockID flow.Identifier) (uint64, error) {
+	return l.blocks.GetHeightForBlock(blockID)
+}
+
+// GetHeightForTransaction returns the height of the block that contains the given transaction.
+func (l *library2Impl) GetHeightForTransaction(txID flow.Identifier) (uint64, error) {
+	return l.blocks.GetHeightForTransaction(txID)
+}
+
+// GetHeightForCollection returns the height of the block that contains the given collection.
+func (l *library2Impl) GetHeightForCollection(collID flow.Identifier) (uint64, error) {
+	return l.blocks.GetHeightForCollection(collID)
+}
+
+// GetHeightForSeal returns the height of the block that contains the given seal.
+func (l *library2Impl) GetHeightForSeal(sealID flow.Identifier) (uint64, error) {
+	return l.blocks.GetHeightForSeal(sealID)
+}
+
+// GetSealedHeightForBlock returns the height of the block that contains the seal for the given block.
+func (l *library2Impl) GetSealedHeightForBlock(blockID flow.Identifier) (uint64, error) {
+	return l.blocks.GetSealedHeightForBlock(blockID)
+}
+
+// GetCommit returns the state commitment after the block at the given height.
+func (l *library2Impl) GetCommit(height uint64) (flow.StateCommitment, error) {
+	return l.blocks.GetCommit(height)
+}
+
+// GetHeader returns the header of the block at the given height.
+func (l *library2Impl) GetHeader(height uint64) (*flow.Header, error) {
+	return l.blocks.GetHeader(height)
+}
+
+// GetEvents returns the events of the given types emitted at the given height.
+func (l *library2Impl) GetEvents(height uint64, types []flow.EventType) ([]flow.Event, error) {
+	return l.blocks.GetEvents(height, types)
+}
+
+// GetEventHeights returns the heights within the given range at which events
+// of any of the given types were emitted, by ascending height.
+func (l *library2Impl) GetEventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+	return l.blocks.GetEventHeights(types, startHeight, endHeight, limit)
+}
+
+// GetContractEvents returns the events within the given range of heights that
+// were emitted by the contracts deployed at the given address, by ascending height.
+func (l *library2Impl) GetContractEvents(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+	return l.blocks.GetContractEvents(address, startHeight, endHeight, limit)
+}
+
+// GetTransactionsForHeight returns the identifiers of the transactions at the given height.
+func (l *library2Impl) GetTransactionsForHeight(height uint64) ([]flow.Identifier, error) {
+	return l.blocks.GetTransactionsForHeight(height)
+}
+
+// GetTransactionsForAddress returns the transactions within the given range of
+// heights in which the account with the given address has any of the given
+// roles, by ascending height.
+func (l *library2Impl) GetTransactionsForAddress(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+	return l.blocks.GetTransactionsForAddress(address, startHeight, endHeight, roles, limit)
+}
+
+// GetFirstTransferHeight returns the first height indexed with the transfer and balance change indexes.
+func (l *library2Impl) GetFirstTransferHeight() (uint64, error) {
+	return l.blocks.GetFirstTransferHeight()
+}
+
+// GetTransfersForAddress returns the token transfers within the given range of
+// heights that the account with the given address sent or received, by
+// ascending height.
+func (l *library2Impl) GetTransfersForAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+	return l.blocks.GetTransfersForAddress(address, startHeight, endHeight, limit)
+}
+
+// GetBalanceHistory returns the changes of the balance of the account with the
+// given address in the given token within the given range of heights, by
+// ascending height.
+func (l *library2Impl) GetBalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+	return l.blocks.GetBalanceHistory(address, token, startHeight, endHeight, limit)
+}
+
+// GetFirstContractUpdateHeight returns the first height indexed with the contract update index.
+func (l *library2Impl) GetFirstContractUpdateHeight() (uint64, error) {
+	return l.blocks.GetFirstContractUpdateHeight()
+}
+
+// GetContractUpdates returns the changes to the contracts of the account with
+// the given address within the given range of heights from the contract update
+// index, by ascending height.
+func (l *library2Impl) GetContractUpdates(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+	return l.blocks.GetContractUpdates(address, name, startHeight, endHeight, limit)
+}
+
+// GetLatestAccountKeysHeight returns the height up to which the changes to the keys of accounts are indexed.
+func (l *library2Impl) GetLatestAccountKeysHeight() (uint64, error) {
+	return l.blocks.GetLatestAccountKeysHeight()
+}
+
+// GetAccountKeys returns the keys of the account with the given address after
+// the latest change to them at or below the given height.
+func (l *library2Impl) GetAccountKeys(address flow.Address, height uint64) (archive.AccountKeys, error) {
+	return l.blocks.GetAccountKeys(address, height)
+}
+
+// GetAccountKeyHistory returns the keys of the account with the given address
+// after each height at which they changed, by ascending height.
+func (l *library2Impl) GetAccountKeyHistory(address flow.Address) ([]archive.AccountKeys, error) {
+	return l.blocks.GetAccountKeyHistory(address)
+}
+
+// GetTransactionsForCollection returns the identifiers of the transactions in the given collection.
+func (l *library2Impl) GetTransactionsForCollection(collID flow.Identifier) ([]flow.Identifier, error) {
+	return l.blocks.GetTransactionsForCollection(collID)
+}
+
+// GetCollectionsForHeight returns the identifiers of the collections at the given height.
+func (l *library2Impl) GetCollectionsForHeight(height uint64) ([]flow.Identifier, error) {
+	return l.blocks.GetCollectionsForHeight(height)
+}
+
+// GetSealsForHeight returns the identifiers of the seals at the given height.
+func (l *library2Impl) GetSealsForHeight(height uint64) ([]flow.Identifier, error) {
+	return l.blocks.GetSealsForHeight(height)
+}
+
+// GetCollection returns the collection with the given identifier.
+func (l *library2Impl) GetCollection(collID flow.Identifier) (*flow.LightCollection, error) {
+	return l.blocks.GetCollection(collID)
+}
+
+// GetGuarantee returns the guarantee of the collection with the given identifier.
+func (l *library2Impl) GetGuarantee(collID flow.Identifier) (*flow.CollectionGuarantee, error) {
+	return l.blocks.GetGuarantee(collID)
+}
+
+// GetTransaction returns the transaction with the given identifier.
+func (l *library2Impl) GetTransaction(txID flow.Identifier) (*flow.TransactionBody, error) {
+	return l.blocks.GetTransaction(txID)
+}
+
+// GetResult returns the result of the transaction with the given identifier.
+func (l *library2Impl) GetResult(txID flow.Identifier) (*flow.TransactionResult, error) {
+	return l.blocks.GetResult(txID)
+}
+
+// GetSeal returns the seal with the given identifier.
+func (l *library2Impl) GetSeal(sealID flow.Identifier) (*flow.Seal, error) {
+	return l.blocks.GetSeal(sealID)
+}
+
+// SetFirst sets the height of the first indexed block.
+func (l *library2Impl) SetFirst(height uint64) error {
+	return l.blocks.SetFirst(height)
+}
+
+// SetLast sets the height of the last indexed block.
+//
+// Resuming indexing starts right after the last height, so it must never be
+// persisted before the registers written until then are durable. It is thus
+// held back like the latest register height, and the notifier is only notified
+// once it was persisted.
+func (l *library2Impl) SetLast(height uint64) error {
+	err := l.persist(&l.pendingLast, height, l.blocks.SetLast)
+	if err != nil {
+		return err
+	}
+
+	// With block and periodic durability, the notification happens when the
+	// held back height is persisted on sync.
+	if l.cfg.Durability == config.DurabilityBatch {
+		l.notify()
+	}
+
+	return nil
+}
+
+// syncRegisters syncs the payload and changes databases to disk.
+func (l *library2Impl) syncRegisters() error {
+	err := l.Storage.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync payloads: %w", err)
+	}
+	err = l.changes.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync changed registers: %w", err)
+	}
+	return nil
+}
+
+// SetLatestRegisterHeight sets the latest height for which all registers are indexed.
+func (l *library2Impl) SetLatestRegisterHeight(height uint64) error {
+	return l.persist(&l.pendingLatest, height, l.blocks.SetLatestRegisterHeight)
+}
+
+// persist persists the given height with the given setter once the registers
+// written before are durable. With block durability, they are synced right
+// away. With periodic durability, the height is held back in the given pending
+// height until the next group commit.
+func (l *library2Impl) persist(pending **uint64, height uint64, set func(uint64) error) error {
+	switch l.cfg.Durability {
+	case config.DurabilityBlock:
+		l.mu.Lock()
+		*pending = &height
+		l.mu.Unlock()
+		return l.Sync()
+	case config.DurabilityPeriodic:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.failed != nil {
+			return fmt.Errorf("failed to sync registers: %w", l.failed)
+		}
+		*pending = &height
+		return nil
+	default:
+		return set(height)
+	}
+}
+
+// SetBootstrapProgress sets the number of checkpoint leaves written while bootstrapping the given height.
+//
+// Like the latest register height, the progress must never cover registers
+// that are not durable yet, so they are synced first unless every batch is.
+func (l *library2Impl) SetBootstrapProgress(height uint64, leaves uint64) error {
+	if l.cfg.Durability != config.DurabilityBatch {
+		err := l.syncRegisters()
+		if err != nil {
+			return err
+		}
+	}
+	return l.blocks.SetBootstrapProgress(height, leaves)
+}
+
+// SetHeightForBlock indexes the given height for the block with the given identifier.
+func (l *library2Impl) SetHeightForBlock(blockID flow.Identifier, height uint64) error {
+	return l.blocks.SetHeightForBlock(blockID, height)
+}
+
+// SetCommit sets the state commitment after the block at the given height.
+func (l *library2Impl) SetCommit(height uint64, commit flow.StateCommitment) error {
+	return l.blocks.SetCommit(height, commit)
+}
+
+// SetHeader sets the header of the block at the given height.
+func (l *library2Impl) SetHeader(height uint64, header *flow.Header) error {
+	return l.blocks.SetHeader(height, header)
+}
+
+// BatchSetEvents sets the events emitted at the given height.
+func (l *library2Impl) BatchSetEvents(height uint64, events []flow.Event) error {
+	return l.blocks.BatchSetEvents(height, events)
+}
+
+// BatchSetCollections sets the collections at the given height.
+func (l *library2Impl) BatchSetCollections(height uint64, collections []*flow.LightCollection) error {
+	return l.blocks.BatchSetCollections(height, collections)
+}
+
+// BatchSetGuarantees sets the given collection guarantees.
+func (l *library2Impl) BatchSetGuarantees(guarantees []*flow.CollectionGuarantee) error {
+	return l.blocks.BatchSetGuarantees(guarantees)
+}
+
+// BatchSetTransactions sets the transactions at the given height.
+func (l *library2Impl) BatchSetTransactions(height uint64, transactions []*flow.TransactionBody) error {
+	return l.blocks.BatchSetTransactions(height, transactions)
+}
+
+// BatchSetResults sets the given transaction results.
+func (l *library2Impl) BatchSetResults(results []*flow.TransactionResult) error {
+	return l.blocks.BatchSetResults(results)
+}
+
+// BatchSetSeals sets the seals at the given height.
+func (l *library2Impl) BatchSetSeals(height uint64, seals []*flow.Seal) error {
+	return l.blocks.BatchSetSeals(height, seals)
+}
+
+// BatchSetTransfers sets the token transfers at the given height.
+func (l *library2Impl) BatchSetTransfers(height uint64, transfers []archive.Transfer) error {
+	return l.blocks.BatchSetTransfers(height, transfers)
+}
+
+// BatchSetContractUpdates indexes the given changes to contracts at the given height.
+func (l *library2Impl) BatchSetContractUpdates(height uint64, updates []archive.ContractUpdate) error {
+	return l.blocks.BatchSetContractUpdates(height, updates)
+}
+
+// BatchSetAccountKeys sets the keys of accounts after the heights at which they
+// changed, along with the height up to which all changes are indexed.
+func (l *library2Impl) BatchSetAccountKeys(height uint64, changes []archive.AccountKeys) error {
+	return l.blocks.BatchSetAccountKeys(height, changes)
+}
+
+// GetRegisterRetention returns the retention the registers were last pruned with.
+func (l *library2Impl) GetRegisterRetention() (archive.RegisterRetention, error) {
+	return l.Storage.GetRetention()
+}
+
+// PruneRegisters deletes all register data that is not needed to serve the
+// heights kept by the given retention. It fails if the retention would keep
+// heights that were already pruned.
+func (l *library2Impl) PruneRegisters(retention archive.RegisterRetention, progress func(archive.PruneProgress)) error {
+	current, err := l.Storage.GetRetention()
+	if err != nil {
+		return fmt.Errorf("failed to get current retention: %w", err)
+	}
+	if !current.Covers(retention) {
+		return fmt.Errorf("retention (height: %d, interval: %d) keeps heights already pruned by retention (height: %d, interval: %d)",
+			retention.Height, retention.Interval, current.Height, current.Interval)
+	}
+
+	// The retention is stored before anything is deleted, so that readers stop
+	// serving pruned heights before their data starts disappearing.
+	err = l.Storage.SetRetention(retention)
+	if err != nil {
+		return fmt.Errorf("failed to set retention: %w", err)
+	}
+	err = l.Storage.Prune(retention, progress)
+	if err != nil {
+		return fmt.Errorf("failed to prune payload storage: %w", err)
+	}
+	err = l.changes.Prune(retention)
+	if err != nil {
+		return fmt.Errorf("failed to prune changes storage: %w", err)
+	}
+
+	return nil
+}
+
+// Checkpoint creates a consistent snapshot of every database in the library
+// within the given directory, using the same layout as the library itself.
+func (l *library2Impl) Checkpoint(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	err = l.Storage.Checkpoint(StoragePath(dir))
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint payload storage: %w", err)
+	}
+	err = l.changes.Checkpoint(ChangesPath(dir))
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint changes storage: %w", err)
+	}
+	err = l.blocks.Checkpoint(BlocksPath(dir))
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint blocks storage: %w", err)
+	}
+	return nil
 }
 
 func (l *library2Impl) Close() (err error) {
+	// Registers that were not synced yet are made durable before closing, so
+	// that the held back heights are not lost on a clean shutdown.
+	close(l.done)
+	l.wg.Wait()
+	if l.cfg.Durability != config.DurabilityBatch {
+		multierr.AppendInto(&err, l.Sync())
+	}
+
 	multierr.AppendInto(&err, l.Storage.Close())
+	multierr.AppendInto(&err, l.changes.Close())
+	multierr.AppendInto(&err, l.blocks.Close())
 	return
 }
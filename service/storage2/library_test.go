@@ -0,0 +1,281 @@
+package storage2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/notifier"
+	"github.com/onflow/flow-archive/service/storage2/config"
+)
+
+func Test_Library2_Durability(t *testing.T) {
+	t.Parallel()
+
+	reg := flow.RegisterID{Owner: "owner", Key: "key"}
+	entries := flow.RegisterEntries{{Key: reg, Value: []byte("value")}}
+
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+
+		lib, err := NewLibrary2(t.TempDir(), 1<<20)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.SetLatestRegisterHeight(1))
+
+		latest, err := lib.GetLatestRegisterHeight()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), latest)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		lib, err := NewLibrary2(t.TempDir(), 1<<20, WithDurability(config.DurabilityBlock))
+		require.NoError(t, err)
+		defer lib.Close()
+
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.BatchSetRegistersForHeight(1, flow.RegisterIDs{reg}))
+		require.NoError(t, lib.SetLatestRegisterHeight(1))
+
+		latest, err := lib.GetLatestRegisterHeight()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), latest)
+
+		value, err := lib.GetPayload(1, reg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+
+	t.Run("periodic holds back latest register height until synced", func(t *testing.T) {
+		t.Parallel()
+
+		lib, err := NewLibrary2(t.TempDir(), 1<<20,
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(time.Hour),
+		)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.SetLatestRegisterHeight(1))
+
+		_, err = lib.GetLatestRegisterHeight()
+		require.ErrorIs(t, err, archive.ErrNotFound)
+
+		require.NoError(t, lib.(*library2Impl).Sync())
+
+		latest, err := lib.GetLatestRegisterHeight()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), latest)
+	})
+
+	t.Run("periodic syncs at interval", func(t *testing.T) {
+		t.Parallel()
+
+		lib, err := NewLibrary2(t.TempDir(), 1<<20,
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.SetLatestRegisterHeight(1))
+
+		require.Eventually(t, func() bool {
+			latest, err := lib.GetLatestRegisterHeight()
+			return err == nil && latest == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("periodic syncs on close", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		lib, err := NewLibrary2(dir, 1<<20,
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(time.Hour),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.SetLatestRegisterHeight(1))
+		require.NoError(t, lib.Close())
+
+		lib, err = NewLibrary2(dir, 1<<20)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		latest, err := lib.GetLatestRegisterHeight()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), latest)
+	})
+
+	t.Run("periodic crash between syncs", func(t *testing.T) {
+		t.Parallel()
+
+		fs := vfs.NewStrictMem()
+		options := []Option{
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(time.Hour),
+			WithFS(fs),
+		}
+
+		// Pebble syncs the directories of its databases, but not their parents,
+		// which have to survive the crash as well.
+		syncDir := func(name string) {
+			dir, err := fs.OpenDir(name)
+			require.NoError(t, err)
+			require.NoError(t, dir.Sync())
+			require.NoError(t, dir.Close())
+		}
+
+		require.NoError(t, fs.MkdirAll("index", 0755))
+		syncDir("")
+		lib, err := NewLibrary2("index", 1<<20, options...)
+		require.NoError(t, err)
+		syncDir("index")
+
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.SetLatestRegisterHeight(1))
+		require.NoError(t, lib.SetHeader(1, &flow.Header{Height: 1}))
+		require.NoError(t, lib.SetLast(1))
+		require.NoError(t, lib.(*library2Impl).Sync())
+
+		changed := flow.RegisterEntries{{Key: reg, Value: []byte("changed")}}
+		require.NoError(t, lib.BatchSetPayload(2, changed))
+		require.NoError(t, lib.SetLatestRegisterHeight(2))
+		require.NoError(t, lib.SetHeader(2, &flow.Header{Height: 2}))
+		require.NoError(t, lib.SetLast(2))
+
+		// The last height is held back until the next sync, just like the
+		// latest register height.
+		last, err := lib.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), last)
+
+		// Everything that was not synced is lost on a crash, including what is
+		// synced when closing.
+		fs.SetIgnoreSyncs(true)
+		require.NoError(t, lib.Close())
+		fs.ResetToSyncedState()
+		fs.SetIgnoreSyncs(false)
+
+		lib, err = NewLibrary2("index", 1<<20, options...)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		last, err = lib.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), last)
+
+		latest, err := lib.GetLatestRegisterHeight()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), latest)
+
+		value, err := lib.GetPayload(last, reg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+
+	t.Run("batch notifies when setting last height", func(t *testing.T) {
+		t.Parallel()
+
+		notify := notifier.New()
+		lib, err := NewLibrary2(t.TempDir(), 1<<20, WithNotifier(notify))
+		require.NoError(t, err)
+		defer lib.Close()
+
+		wait := notify.Wait()
+		require.NoError(t, lib.SetLast(1))
+		require.True(t, closed(wait))
+	})
+
+	t.Run("periodic notifies once last height is persisted", func(t *testing.T) {
+		t.Parallel()
+
+		notify := notifier.New()
+		lib, err := NewLibrary2(t.TempDir(), 1<<20,
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(time.Hour),
+			WithNotifier(notify),
+		)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		// Waiters woken up before the sync would still read the previous last
+		// height, so they must not be woken up yet.
+		wait := notify.Wait()
+		require.NoError(t, lib.BatchSetPayload(1, entries))
+		require.NoError(t, lib.SetLast(1))
+		require.False(t, closed(wait))
+
+		require.NoError(t, lib.(*library2Impl).Sync())
+		require.True(t, closed(wait))
+
+		last, err := lib.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), last)
+
+		// Syncing without a new last height does not notify anyone.
+		wait = notify.Wait()
+		require.NoError(t, lib.(*library2Impl).Sync())
+		require.False(t, closed(wait))
+	})
+
+	t.Run("periodic notifies at interval", func(t *testing.T) {
+		t.Parallel()
+
+		notify := notifier.New()
+		lib, err := NewLibrary2(t.TempDir(), 1<<20,
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(10*time.Millisecond),
+			WithNotifier(notify),
+		)
+		require.NoError(t, err)
+		defer lib.Close()
+
+		wait := notify.Wait()
+		require.NoError(t, lib.SetLast(1))
+
+		select {
+		case <-wait:
+		case <-time.After(time.Second):
+			t.Fatal("not notified after sync interval")
+		}
+		last, err := lib.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), last)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewLibrary2(t.TempDir(), 1<<20, WithDurability(0))
+		require.Error(t, err)
+
+		_, err = NewLibrary2(t.TempDir(), 1<<20,
+			WithDurability(config.DurabilityPeriodic),
+			WithSyncInterval(0),
+		)
+		require.Error(t, err)
+	})
+}
+
+// closed returns whether the given channel is closed.
+func closed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,96 @@
+package storage2
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2/changes"
+	"github.com/onflow/flow-archive/service/storage2/payload"
+)
+
+// loaderBufferSize is the number of bytes each database buffers in memory while
+// bulk loading, before spilling sorted data to disk.
+const loaderBufferSize = 256 << 20
+
+var _ archive.RegisterLoader = (*registerLoader)(nil)
+
+type registerLoader struct {
+	dir      string
+	payloads *payload.Loader
+	changes  *changes.Loader
+}
+
+// NewRegisterLoader creates a loader that bulk loads the registers of the given
+// height into both the payload and the changes databases, by ingesting sorted
+// sstables instead of committing batches.
+//
+// The temporary files are written to a directory within the index, so that the
+// sstables can be linked into the databases. Only one loader can be used at a
+// time, as files left behind by a previous loader are removed.
+func (l *library2Impl) NewRegisterLoader(height uint64) (archive.RegisterLoader, error) {
+	dir := IngestPath(l.dir)
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove previous ingest directory: %w", err)
+	}
+
+	payloadDir := path.Join(dir, "payload")
+	changesDir := path.Join(dir, "changes")
+	for _, d := range []string{payloadDir, changesDir} {
+		err = os.MkdirAll(d, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ingest directory: %w", err)
+		}
+	}
+
+	r := registerLoader{
+		dir:      dir,
+		payloads: l.Storage.NewLoader(payloadDir, height, loaderBufferSize),
+		changes:  l.changes.NewLoader(changesDir, height, loaderBufferSize),
+	}
+
+	return &r, nil
+}
+
+// Add adds the given registers to the loader.
+func (r *registerLoader) Add(entries flow.RegisterEntries) error {
+	err := r.payloads.Add(entries)
+	if err != nil {
+		return fmt.Errorf("failed to add payloads: %w", err)
+	}
+
+	regs := make(flow.RegisterIDs, 0, len(entries))
+	for _, entry := range entries {
+		regs = append(regs, entry.Key)
+	}
+	err = r.changes.Add(regs)
+	if err != nil {
+		return fmt.Errorf("failed to add changed registers: %w", err)
+	}
+
+	return nil
+}
+
+// Finish ingests all added registers into the databases.
+func (r *registerLoader) Finish() error {
+	err := r.payloads.Ingest()
+	if err != nil {
+		return fmt.Errorf("failed to ingest payloads: %w", err)
+	}
+
+	err = r.changes.Ingest()
+	if err != nil {
+		return fmt.Errorf("failed to ingest changed registers: %w", err)
+	}
+
+	return nil
+}
+
+// Close removes the temporary files of the loader.
+func (r *registerLoader) Close() error {
+	return os.RemoveAll(r.dir)
+}
@@ -0,0 +1,7 @@
+package storage2
+
+// Notifier represents something that is notified whenever a new last height
+// was persisted, and its data can thus be read.
+type Notifier interface {
+	Notify()
+}
@@ -0,0 +1,62 @@
+package storage2
+
+import (
+	"time"
+
+	"github.com/cockroachdb/pebble/vfs"
+
+	"github.com/onflow/flow-archive/service/storage2/config"
+)
+
+// DefaultConfig is the default configuration of the library.
+var DefaultConfig = Config{
+	Durability:   config.DurabilityBatch,
+	SyncInterval: time.Second,
+}
+
+// Config contains optional parameters of the library.
+type Config struct {
+	Durability   config.Durability
+	SyncInterval time.Duration
+	FS           vfs.FS
+	Notifier     Notifier
+}
+
+// Option is an option that can be given to the library to configure optional
+// parameters on initialization.
+type Option func(*Config)
+
+// WithDurability sets the mode in which register and block writes are made
+// durable. The latest register height and the last height are only ever
+// persisted once all registers up to them are durable, whatever the mode.
+func WithDurability(durability config.Durability) Option {
+	return func(cfg *Config) {
+		cfg.Durability = durability
+	}
+}
+
+// WithSyncInterval sets the interval between two group commits of register and
+// block writes, which is only used with periodic durability.
+func WithSyncInterval(interval time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.SyncInterval = interval
+	}
+}
+
+// WithFS sets the filesystem the databases of the library are stored on. By
+// default, the filesystem of the operating system is used.
+func WithFS(fs vfs.FS) Option {
+	return func(cfg *Config) {
+		cfg.FS = fs
+	}
+}
+
+// WithNotifier makes the library notify the given notifier whenever it has
+// persisted a new last height. With block or periodic durability, the last
+// height is held back until it is durable, so this can be later than the call
+// to set it. If not set, nothing is notified.
+func WithNotifier(notifier Notifier) Option {
+	return func(cfg *Config) {
+		cfg.Notifier = notifier
+	}
+}
@@ -0,0 +1,71 @@
+package payload
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/service/storage2/ingest"
+)
+
+// Loader bulk loads the payloads of registers at a single height. Instead of
+// committing batches, it sorts the payloads into sstables on disk, which are
+// ingested into the storage in one go.
+type Loader struct {
+	storage *Storage
+	height  uint64
+	sorter  *ingest.Sorter
+}
+
+// NewLoader creates a loader for payloads at the given height, which writes
+// its temporary files to the given directory. It has to be on the same file
+// system as the storage, so that the sstables can be linked into it. The limit
+// is the number of bytes buffered in memory before sorted payloads are spilled
+// to disk.
+func (s *Storage) NewLoader(dir string, height uint64, limit int) *Loader {
+
+	l := Loader{
+		storage: s,
+		height:  height,
+		sorter:  ingest.NewSorter(dir, s.opts.Comparer.Compare, limit),
+	}
+
+	return &l
+}
+
+// Add adds the given entries to the loader.
+func (l *Loader) Add(entries flow.RegisterEntries) error {
+
+	for _, entry := range entries {
+		encoded := newLookupKey(l.height, entry.Key).Bytes()
+		err := l.sorter.Add(encoded, entry.Value)
+		if err != nil {
+			return fmt.Errorf("failed to add payload: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Ingest writes all added payloads into sstables and ingests them into the
+// storage. The entries are only visible in the storage once it returns.
+func (l *Loader) Ingest() error {
+
+	opts := l.storage.opts
+	last := len(opts.Levels) - 1
+	writerOpts := opts.MakeWriterOptions(last, l.storage.db.FormatMajorVersion().MaxTableFormat())
+	paths, err := l.sorter.Write(writerOpts, uint64(opts.Levels[last].TargetFileSize))
+	if err != nil {
+		return fmt.Errorf("failed to write sstables: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	err = l.storage.db.Ingest(paths)
+	if err != nil {
+		return fmt.Errorf("failed to ingest sstables: %w", err)
+	}
+
+	return nil
+}
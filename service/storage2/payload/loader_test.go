@@ -0,0 +1,83 @@
+package payload
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_PayloadStorage_Loader checks that bulk loading payloads results in the
+// same database content as writing them in batches.
+func Test_PayloadStorage_Loader(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dir := t.TempDir()
+	batched, err := NewStorage(path.Join(dir, "batched.db"), cache)
+	require.NoError(t, err)
+	defer batched.Close()
+	loaded, err := NewStorage(path.Join(dir, "loaded.db"), cache)
+	require.NoError(t, err)
+	defer loaded.Close()
+
+	// Both storages already contain an older version of some registers.
+	previous := flow.RegisterEntries{
+		{Key: flow.RegisterID{Owner: "owner", Key: "key0001"}, Value: []byte("old")},
+		{Key: flow.RegisterID{Owner: "owner", Key: "key0002"}, Value: []byte("old")},
+	}
+	require.NoError(t, batched.BatchSetPayload(1, previous))
+	require.NoError(t, loaded.BatchSetPayload(1, previous))
+
+	height := uint64(2)
+	entries := make(flow.RegisterEntries, 0, 1000)
+	for _, i := range rand.Perm(1000) {
+		entries = append(entries, flow.RegisterEntry{
+			Key:   flow.RegisterID{Owner: fmt.Sprintf("owner%d", i%10), Key: fmt.Sprintf("key%04d", i)},
+			Value: []byte(fmt.Sprintf("value%d", i)),
+		})
+	}
+	entries = append(entries, flow.RegisterEntry{Key: previous[0].Key, Value: []byte("new")})
+
+	loader := loaded.NewLoader(t.TempDir(), height, 1<<10)
+	for start := 0; start < len(entries); start += 100 {
+		end := start + 100
+		if end > len(entries) {
+			end = len(entries)
+		}
+		require.NoError(t, batched.BatchSetPayload(height, entries[start:end]))
+		require.NoError(t, loader.Add(entries[start:end]))
+	}
+	require.NoError(t, loader.Ingest())
+
+	want := batched.db.NewIter(nil)
+	defer want.Close()
+	got := loaded.db.NewIter(nil)
+	defer got.Close()
+
+	count := 0
+	validWant, validGot := want.First(), got.First()
+	for validWant && validGot {
+		require.Equal(t, want.Key(), got.Key())
+		require.Equal(t, want.Value(), got.Value())
+		validWant, validGot = want.Next(), got.Next()
+		count++
+	}
+	require.False(t, validWant)
+	require.False(t, validGot)
+	require.Equal(t, len(entries)+len(previous), count)
+
+	value, err := loaded.GetPayload(height, previous[0].Key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), value)
+
+	value, err = loaded.GetPayload(1, previous[0].Key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("old"), value)
+}
@@ -81,6 +81,33 @@ func lookupKeyToRegisterID(lookupKey []byte) (uint64, flow.RegisterID, error) {
 	return height, regID, nil
 }
 
+// prefixToRegisterID decodes the "<owner>/<key>/" prefix of a lookup key into
+// a register ID.
+//
+// Both owners and keys can contain slashes, so the prefix can not be split at
+// a separator. Owners of Flow registers are either empty or an account
+// address, so the owner is decoded by its fixed width instead, preferring the
+// address when both layouts match.
+func prefixToRegisterID(prefix []byte) (flow.RegisterID, error) {
+	last := len(prefix) - 1
+	switch {
+	case len(prefix) > flow.AddressLength+1 && prefix[flow.AddressLength] == '/' && prefix[last] == '/':
+		reg := flow.RegisterID{
+			Owner: string(prefix[:flow.AddressLength]),
+			Key:   string(prefix[flow.AddressLength+1 : last]),
+		}
+		return reg, nil
+	case len(prefix) > 1 && prefix[0] == '/' && prefix[last] == '/':
+		reg := flow.RegisterID{
+			Owner: "",
+			Key:   string(prefix[1:last]),
+		}
+		return reg, nil
+	default:
+		return flow.RegisterID{}, fmt.Errorf("invalid lookup key prefix: owner is neither empty nor %d bytes long", flow.AddressLength)
+	}
+}
+
 // Bytes returns the encoded lookup key.
 func (h lookupKey) Bytes() []byte {
 	return h.encoded
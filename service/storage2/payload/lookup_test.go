@@ -7,6 +7,8 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/service/storage2/config"
 )
 
 // Test_lookupKey_Bytes tests the lookup key encoding.
@@ -90,3 +92,31 @@ func Test_decodeKey_fail(t *testing.T) {
 	_, _, err = lookupKeyToRegisterID([]byte{1, 2, 3, '/', 5, '/', 7, 8, 9, 10, 11, 12, 13, 14})
 	require.NoError(t, err)
 }
+
+func Test_prefixToRegisterID(t *testing.T) {
+	t.Parallel()
+
+	address := string(flow.HexToAddress("0x01").Bytes())
+	cases := []flow.RegisterID{
+		{Owner: address, Key: "public/storage/hasslash-in-key"},
+		{Owner: address, Key: ""},
+		{Owner: "", Key: "somekey"},
+		{Owner: "", Key: "some/key"},
+		{Owner: "", Key: ""},
+		// Owners are decoded by their width, even if they contain slashes.
+		{Owner: string([]byte{1, '/', 3, 4, 5, 6, 7, 8}), Key: "key"},
+	}
+
+	for _, reg := range cases {
+		key := newLookupKey(10, reg).Bytes()
+		prefix := key[:len(key)-config.HeightSuffixLen]
+		got, err := prefixToRegisterID(prefix)
+		require.NoError(t, err)
+		require.Equal(t, reg, got)
+	}
+
+	// Owners that are neither empty nor an address can not be decoded.
+	key := newLookupKey(10, flow.RegisterID{Owner: "owner", Key: "key"}).Bytes()
+	_, err := prefixToRegisterID(key[:len(key)-config.HeightSuffixLen])
+	require.Error(t, err)
+}
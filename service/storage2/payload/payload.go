@@ -1,16 +1,22 @@
 package payload
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/pebble"
 
+	"github.com/onflow/flow-archive/models/archive"
 	"github.com/onflow/flow-archive/service/storage2/config"
 	"github.com/onflow/flow-go/model/flow"
 )
 
 type Storage struct {
-	db *pebble.DB
+	db    *pebble.DB
+	opts  *pebble.Options
+	write *pebble.WriteOptions
 }
 
 // NewStorage creates a pebble-backed payload storage.
@@ -18,15 +24,17 @@ type Storage struct {
 //
 // It needs to access the last available payload with height less or equal to the requested height.
 // This means all point-lookups are range scans.
-func NewStorage(dbPath string, cache *pebble.Cache) (*Storage, error) {
-	opts := config.DefaultPebbleOptions(cache, config.NewMVCCComparer())
+func NewStorage(dbPath string, cache *pebble.Cache, options ...config.Option) (*Storage, error) {
+	opts := config.DefaultPebbleOptions(cache, config.NewMVCCComparer(), options...)
 	db, err := pebble.Open(dbPath, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db: %w", err)
 	}
 
 	return &Storage{
-		db: db,
+		db:    db,
+		opts:  opts,
+		write: pebble.Sync,
 	}, nil
 }
 
@@ -63,7 +71,300 @@ func (s *Storage) GetPayload(
 	return valueCopy, nil
 }
 
-// BatchSetPayload sets the given entries in a batch.
+// GetPayloads returns the most recent updated payloads for the given registers,
+// in the same order as the registers, as GetPayload would for each of them.
+//
+// All payloads are read from a single snapshot, so they are consistent with
+// each other even while new heights are being written. The lookups are done in
+// key order with a single iterator, which avoids jumping back and forth within
+// the storage for large sets of registers.
+func (s *Storage) GetPayloads(
+	height uint64,
+	regs flow.RegisterIDs,
+) ([]flow.RegisterValue, error) {
+	snapshot := s.db.NewSnapshot()
+	defer snapshot.Close()
+
+	iter := snapshot.NewIter(&pebble.IterOptions{
+		UseL6Filters: true,
+	})
+	defer iter.Close()
+
+	keys := make([][]byte, len(regs))
+	order := make([]int, len(regs))
+	for i, reg := range regs {
+		keys[i] = newLookupKey(height, reg).Bytes()
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return bytes.Compare(keys[order[a]], keys[order[b]]) < 0
+	})
+
+	values := make([]flow.RegisterValue, len(regs))
+	for _, i := range order {
+		ok := iter.SeekPrefixGE(keys[i])
+		if !ok {
+			err := iter.Error()
+			if err != nil {
+				return nil, fmt.Errorf("failed to seek key: %w", err)
+			}
+			values[i] = []byte{}
+			continue
+		}
+
+		binaryValue, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		// preventing caller from modifying the iterator's value slices
+		valueCopy := make([]byte, len(binaryValue))
+		copy(valueCopy, binaryValue)
+
+		values[i] = valueCopy
+	}
+
+	return values, nil
+}
+
+// IterateOwnerPayloads calls fn for every register of the given owner, with the
+// most recent payload of that register up to the given height. Registers whose
+// most recent payload is empty were removed, and are skipped. An empty owner
+// iterates over the registers that are not owned by any account.
+//
+// All payloads are read from a single snapshot. Superseded versions of each
+// register are skipped by seeking, rather than iterating over them. Iteration
+// stops at the first error returned by fn, which is then returned as is.
+func (s *Storage) IterateOwnerPayloads(
+	height uint64,
+	owner string,
+	fn func(flow.RegisterEntry) error,
+) error {
+	snapshot := s.db.NewSnapshot()
+	defer snapshot.Close()
+
+	// All registers of the owner share the "<owner>/" prefix, and the slash is
+	// followed by the "0" character in byte order.
+	lower := append([]byte(owner), '/')
+	upper := append([]byte(owner), '/'+1)
+
+	iter := snapshot.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	defer iter.Close()
+
+	for valid := iter.First(); valid; {
+		key := iter.Key()
+		split := len(key) - config.HeightSuffixLen
+		if split < len(lower)+1 || key[split-1] != '/' {
+			valid = iter.Next()
+			continue
+		}
+		prefix := make([]byte, split)
+		copy(prefix, key)
+
+		// Skip the older versions of the register, which sort right after the
+		// version for height zero.
+		skip := binary.BigEndian.AppendUint64(prefix[:len(prefix):len(prefix)], ^uint64(0))
+		skip = append(skip, 0)
+
+		reg := flow.RegisterID{
+			Owner: owner,
+			Key:   string(prefix[len(lower) : len(prefix)-1]),
+		}
+
+		// Owners are either empty or account addresses, so the registers of
+		// accounts whose address starts with a slash sort within the bounds of
+		// the empty owner too. Their owner is decoded the same way as when
+		// iterating over all registers, and they are skipped.
+		if owner == "" {
+			decoded, err := prefixToRegisterID(prefix)
+			if err != nil {
+				return fmt.Errorf("failed to decode register (key: %x): %w", prefix, err)
+			}
+			if decoded.Owner != owner {
+				valid = iter.SeekGE(skip)
+				continue
+			}
+			reg = decoded
+		}
+
+		// Versions are ordered from newest to oldest, so the first version at
+		// or below the height is the current one at that height.
+		target := binary.BigEndian.AppendUint64(prefix[:len(prefix):len(prefix)], ^height)
+		found := iter.SeekGE(target)
+		if found && len(iter.Key()) == len(target) && bytes.HasPrefix(iter.Key(), prefix) {
+			value, err := iter.ValueAndErr()
+			if err != nil {
+				return fmt.Errorf("failed to get value: %w", err)
+			}
+
+			if len(value) > 0 {
+				// preventing caller from modifying the iterator's value slices
+				valueCopy := make([]byte, len(value))
+				copy(valueCopy, value)
+
+				entry := flow.RegisterEntry{
+					Key:   reg,
+					Value: valueCopy,
+				}
+				err = fn(entry)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		valid = iter.SeekGE(skip)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return fmt.Errorf("failed to iterate over owner registers: %w", err)
+	}
+
+	return nil
+}
+
+// IteratePayloads calls fn for every register, with the most recent payload
+// of that register up to the given height. Registers whose most recent payload
+// is empty were removed, and are skipped.
+//
+// Like IterateOwnerPayloads, all payloads are read from a single snapshot and
+// iteration stops at the first error returned by fn, which is returned as is.
+func (s *Storage) IteratePayloads(
+	height uint64,
+	fn func(flow.RegisterEntry) error,
+) error {
+	snapshot := s.db.NewSnapshot()
+	defer snapshot.Close()
+
+	iter := snapshot.NewIter(nil)
+	defer iter.Close()
+
+	for valid := iter.First(); valid; {
+		key := iter.Key()
+		split := len(key) - config.HeightSuffixLen
+		if split < 2 || key[split-1] != '/' {
+			valid = iter.Next()
+			continue
+		}
+		prefix := make([]byte, split)
+		copy(prefix, key)
+
+		// Versions are ordered from newest to oldest, so the first version at
+		// or below the height is the current one at that height.
+		target := binary.BigEndian.AppendUint64(prefix[:len(prefix):len(prefix)], ^height)
+		found := iter.SeekGE(target)
+		if found && len(iter.Key()) == len(target) && bytes.HasPrefix(iter.Key(), prefix) {
+			value, err := iter.ValueAndErr()
+			if err != nil {
+				return fmt.Errorf("failed to get value: %w", err)
+			}
+
+			if len(value) > 0 {
+				// preventing caller from modifying the iterator's value slices
+				valueCopy := make([]byte, len(value))
+				copy(valueCopy, value)
+
+				reg, err := prefixToRegisterID(prefix)
+				if err != nil {
+					return fmt.Errorf("failed to decode register (key: %x): %w", prefix, err)
+				}
+				entry := flow.RegisterEntry{
+					Key:   reg,
+					Value: valueCopy,
+				}
+				err = fn(entry)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// Skip the older versions of the register, which sort right after the
+		// version for height zero.
+		skip := binary.BigEndian.AppendUint64(prefix[:len(prefix):len(prefix)], ^uint64(0))
+		valid = iter.SeekGE(append(skip, 0))
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return fmt.Errorf("failed to iterate over registers: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayloadHistory returns every version of the given register that was written
+// at a height within [startHeight, endHeight], ordered by ascending height.
+//
+// If limit is greater than zero, at most limit versions are returned, starting
+// with the oldest one. Callers can page through the history by requesting the
+// next range starting right after the height of the last returned version.
+func (s *Storage) GetPayloadHistory(
+	reg flow.RegisterID,
+	startHeight uint64,
+	endHeight uint64,
+	limit int,
+) ([]archive.RegisterVersion, error) {
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("invalid height range (start: %d, end: %d)", startHeight, endHeight)
+	}
+
+	// Heights are stored as ones' complement, so the newest version within the
+	// range has the smallest key and the oldest one has the largest key. The
+	// upper bound is exclusive, which is why we use the immediate successor of
+	// the key for the start height.
+	lower := newLookupKey(endHeight, reg).Bytes()
+	start := newLookupKey(startHeight, reg).Bytes()
+	upper := make([]byte, len(start)+1)
+	copy(upper, start)
+
+	iter := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	defer iter.Close()
+
+	prefix := lower[:len(lower)-config.HeightSuffixLen]
+
+	var versions []archive.RegisterVersion
+	for valid := iter.Last(); valid; valid = iter.Prev() {
+		// A register whose key extends this register's key with a slash shares
+		// the same prefix, so we need to skip any of its versions in range.
+		key := iter.Key()
+		if len(key) != len(lower) || !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+
+		binaryValue, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value: %w", err)
+		}
+		// preventing caller from modifying the iterator's value slices
+		valueCopy := make([]byte, len(binaryValue))
+		copy(valueCopy, binaryValue)
+
+		versions = append(versions, archive.RegisterVersion{
+			Height: ^binary.BigEndian.Uint64(key[len(prefix):]),
+			Value:  valueCopy,
+		})
+		if limit > 0 && len(versions) >= limit {
+			break
+		}
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over register versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// BatchSetPayload sets the given entries in a batch. Unless syncing was
+// disabled, the batch is synced to disk before returning.
 func (s *Storage) BatchSetPayload(
 	height uint64,
 	entries flow.RegisterEntries,
@@ -80,7 +381,7 @@ func (s *Storage) BatchSetPayload(
 		}
 	}
 
-	err := batch.Commit(pebble.Sync)
+	err := batch.Commit(s.write)
 	if err != nil {
 		return fmt.Errorf("failed to commit batch: %w", err)
 	}
@@ -88,6 +389,24 @@ func (s *Storage) BatchSetPayload(
 	return nil
 }
 
+// SetSync sets whether the batches of payloads are synced to disk when they are
+// committed. If not, they are only durable once Sync is called.
+func (s *Storage) SetSync(sync bool) {
+	s.write = pebble.NoSync
+	if sync {
+		s.write = pebble.Sync
+	}
+}
+
+// Sync syncs all committed batches to disk, by syncing the write-ahead log.
+func (s *Storage) Sync() error {
+	err := s.db.LogData(nil, pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	return nil
+}
+
 func (s *Storage) Checkpoint(dir string) error {
 	return s.db.Checkpoint(dir)
 }
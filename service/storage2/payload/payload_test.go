@@ -11,6 +11,8 @@ import (
 	"github.com/cockroachdb/pebble"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
 )
 
 // Test_PayloadStorage_RoundTrip tests the round trip of a payload storage.
@@ -114,6 +116,314 @@ func Test_PayloadStorage_Versioning(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_PayloadStorage_GetPayloads(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "batch.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	regs := make(flow.RegisterIDs, 0, 100)
+	for i := 0; i < 100; i++ {
+		regs = append(regs, flow.RegisterID{Owner: fmt.Sprintf("owner%d", i%7), Key: fmt.Sprintf("key%d", i)})
+	}
+
+	// Every register is written at a different set of heights.
+	for height := uint64(1); height <= 10; height++ {
+		entries := make(flow.RegisterEntries, 0, len(regs))
+		for i, reg := range regs {
+			if uint64(i)%height != 0 {
+				continue
+			}
+			entries = append(entries, flow.RegisterEntry{
+				Key:   reg,
+				Value: []byte(fmt.Sprintf("%s-%d", reg.Key, height)),
+			})
+		}
+		err = s.BatchSetPayload(height, entries)
+		require.NoError(t, err)
+	}
+
+	// Request the registers in random order, with a duplicate and a
+	// register that was never written.
+	missing := flow.RegisterID{Owner: "owner", Key: "missing"}
+	request := make(flow.RegisterIDs, 0, len(regs)+2)
+	for _, i := range rand.Perm(len(regs)) {
+		request = append(request, regs[i])
+	}
+	request = append(request, missing, request[0])
+
+	for height := uint64(0); height <= 11; height++ {
+		values, err := s.GetPayloads(height, request)
+		require.NoError(t, err)
+		require.Len(t, values, len(request))
+
+		for i, reg := range request {
+			value, err := s.GetPayload(height, reg)
+			require.NoError(t, err)
+			require.Equal(t, value, []byte(values[i]), "height: %d, register: %s", height, reg)
+		}
+	}
+
+	values, err := s.GetPayloads(10, flow.RegisterIDs{})
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func Test_PayloadStorage_IterateOwnerPayloads(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "owner.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	key1 := flow.RegisterID{Owner: "owner1", Key: "key1"}
+	key1Nested := flow.RegisterID{Owner: "owner1", Key: "key1/nested"}
+	key2 := flow.RegisterID{Owner: "owner1", Key: "key2"}
+	// Registers of other owners, including one the owner is a prefix of, must
+	// never be returned.
+	other := flow.RegisterID{Owner: "owner10", Key: "key1"}
+	short := flow.RegisterID{Owner: "owner", Key: "key1"}
+
+	writes := map[uint64]flow.RegisterEntries{
+		2: {{Key: key1, Value: []byte("key1-2")}, {Key: other, Value: []byte("other-2")}, {Key: short, Value: []byte("short-2")}},
+		3: {{Key: key1Nested, Value: []byte("nested-3")}},
+		4: {{Key: key2, Value: []byte("key2-4")}},
+		5: {{Key: key1, Value: []byte("key1-5")}},
+		// Setting an empty value removes the register.
+		6: {{Key: key2, Value: []byte{}}},
+	}
+	for height, entries := range writes {
+		err = s.BatchSetPayload(height, entries)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		height uint64
+		want   map[flow.RegisterID]string
+	}{
+		{height: 1, want: map[flow.RegisterID]string{}},
+		{height: 2, want: map[flow.RegisterID]string{key1: "key1-2"}},
+		{height: 4, want: map[flow.RegisterID]string{key1: "key1-2", key1Nested: "nested-3", key2: "key2-4"}},
+		{height: 5, want: map[flow.RegisterID]string{key1: "key1-5", key1Nested: "nested-3", key2: "key2-4"}},
+		{height: 10, want: map[flow.RegisterID]string{key1: "key1-5", key1Nested: "nested-3"}},
+	}
+
+	for _, tt := range tests {
+		got := make(map[flow.RegisterID]string)
+		err = s.IterateOwnerPayloads(tt.height, "owner1", func(entry flow.RegisterEntry) error {
+			_, ok := got[entry.Key]
+			require.False(t, ok, "duplicate register: %s", entry.Key)
+			got[entry.Key] = string(entry.Value)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "height: %d", tt.height)
+	}
+
+	// Errors returned by the callback stop the iteration.
+	calls := 0
+	err = s.IterateOwnerPayloads(10, "owner1", func(flow.RegisterEntry) error {
+		calls++
+		return fmt.Errorf("stop")
+	})
+	require.EqualError(t, err, "stop")
+	require.Equal(t, 1, calls)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func Test_PayloadStorage_IterateOwnerPayloads_EmptyOwner(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "owner.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	global := flow.RegisterID{Owner: "", Key: "uuid"}
+	nested := flow.RegisterID{Owner: "", Key: "key/nested"}
+	// The separator after a seven byte global key sits where the separator
+	// after an account address would be.
+	seven := flow.RegisterID{Owner: "", Key: "abcdefg"}
+	// The keys of registers owned by an account whose address starts with a
+	// slash share their first byte with the keys of the empty owner.
+	slashed := flow.RegisterID{Owner: string([]byte{'/', 1, 2, 3, 4, 5, 6, 7}), Key: "key1"}
+	account := flow.RegisterID{Owner: string(flow.HexToAddress("01").Bytes()), Key: "key1"}
+
+	err = s.BatchSetPayload(1, flow.RegisterEntries{
+		{Key: global, Value: []byte("global")},
+		{Key: nested, Value: []byte("nested")},
+		{Key: seven, Value: []byte("seven")},
+		{Key: slashed, Value: []byte("slashed")},
+		{Key: account, Value: []byte("account")},
+	})
+	require.NoError(t, err)
+
+	got := make(map[flow.RegisterID]string)
+	err = s.IterateOwnerPayloads(1, "", func(entry flow.RegisterEntry) error {
+		got[entry.Key] = string(entry.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[flow.RegisterID]string{global: "global", nested: "nested", seven: "seven"}, got)
+
+	got = make(map[flow.RegisterID]string)
+	err = s.IterateOwnerPayloads(1, slashed.Owner, func(entry flow.RegisterEntry) error {
+		got[entry.Key] = string(entry.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[flow.RegisterID]string{slashed: "slashed"}, got)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func Test_PayloadStorage_IteratePayloads(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "all.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	address := string(flow.HexToAddress("0x01").Bytes())
+	key1 := flow.RegisterID{Owner: address, Key: "key1"}
+	nested := flow.RegisterID{Owner: address, Key: "public/key1/nested"}
+	global := flow.RegisterID{Owner: "", Key: "uuid"}
+	key2 := flow.RegisterID{Owner: string(flow.HexToAddress("0x02").Bytes()), Key: "key2"}
+
+	writes := map[uint64]flow.RegisterEntries{
+		2: {{Key: key1, Value: []byte("key1-2")}, {Key: global, Value: []byte("global-2")}},
+		3: {{Key: nested, Value: []byte("nested-3")}},
+		4: {{Key: key2, Value: []byte("key2-4")}},
+		5: {{Key: key1, Value: []byte("key1-5")}},
+		// Setting an empty value removes the register.
+		6: {{Key: key2, Value: []byte{}}},
+	}
+	for height, entries := range writes {
+		err = s.BatchSetPayload(height, entries)
+		require.NoError(t, err)
+	}
+
+	// The retention metadata lives in the same database and must be skipped.
+	err = s.SetRetention(archive.RegisterRetention{Height: 1})
+	require.NoError(t, err)
+
+	tests := []struct {
+		height uint64
+		want   map[flow.RegisterID]string
+	}{
+		{height: 1, want: map[flow.RegisterID]string{}},
+		{height: 2, want: map[flow.RegisterID]string{key1: "key1-2", global: "global-2"}},
+		{height: 4, want: map[flow.RegisterID]string{key1: "key1-2", global: "global-2", nested: "nested-3", key2: "key2-4"}},
+		{height: 10, want: map[flow.RegisterID]string{key1: "key1-5", global: "global-2", nested: "nested-3"}},
+	}
+
+	for _, tt := range tests {
+		got := make(map[flow.RegisterID]string)
+		err = s.IteratePayloads(tt.height, func(entry flow.RegisterEntry) error {
+			_, ok := got[entry.Key]
+			require.False(t, ok, "duplicate register: %s", entry.Key)
+			got[entry.Key] = string(entry.Value)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "height: %d", tt.height)
+	}
+
+	// Errors returned by the callback stop the iteration.
+	calls := 0
+	err = s.IteratePayloads(10, func(flow.RegisterEntry) error {
+		calls++
+		return fmt.Errorf("stop")
+	})
+	require.EqualError(t, err, "stop")
+	require.Equal(t, 1, calls)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func Test_PayloadStorage_History(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "history.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+	// key1Nested shares the "owner/key1/" prefix with key1 and should never show up
+	// in the history of key1.
+	key1Nested := flow.RegisterID{Owner: "owner", Key: "key1/nested"}
+
+	for _, height := range []uint64{2, 5, 7, 11} {
+		entries := flow.RegisterEntries{
+			{Key: key1, Value: []byte(fmt.Sprintf("value1-%d", height))},
+			{Key: key1Nested, Value: []byte(fmt.Sprintf("nested-%d", height))},
+		}
+		err = s.BatchSetPayload(height, entries)
+		require.NoError(t, err)
+	}
+
+	versions, err := s.GetPayloadHistory(key1, 0, 20, 0)
+	require.NoError(t, err)
+	require.Len(t, versions, 4)
+	for i, height := range []uint64{2, 5, 7, 11} {
+		require.Equal(t, height, versions[i].Height)
+		require.Equal(t, []byte(fmt.Sprintf("value1-%d", height)), []byte(versions[i].Value))
+	}
+
+	// Range boundaries are inclusive.
+	versions, err = s.GetPayloadHistory(key1, 5, 7, 0)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, uint64(5), versions[0].Height)
+	require.Equal(t, uint64(7), versions[1].Height)
+
+	// Limit returns the oldest versions first.
+	versions, err = s.GetPayloadHistory(key1, 3, 20, 2)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, uint64(5), versions[0].Height)
+	require.Equal(t, uint64(7), versions[1].Height)
+
+	// Empty range.
+	versions, err = s.GetPayloadHistory(key1, 8, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	// Invalid range.
+	_, err = s.GetPayloadHistory(key1, 10, 8, 0)
+	require.Error(t, err)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
 // Benchmark_PayloadStorage benchmarks the SetBatch method.
 func Benchmark_PayloadStorage(b *testing.B) {
 	cache := pebble.NewCache(32 << 20)
@@ -0,0 +1,150 @@
+package payload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2/config"
+)
+
+// pruneBatchSize is the number of deletions committed at once while pruning.
+const pruneBatchSize = 10_000
+
+// retentionKey is the key under which the register retention is stored. It
+// contains no separator, so it can never collide with a register lookup key.
+var retentionKey = append([]byte("retention"), make([]byte, config.HeightSuffixLen)...)
+
+// GetRetention returns the retention the storage was last pruned with. If the
+// storage was never pruned, the zero retention is returned.
+func (s *Storage) GetRetention() (archive.RegisterRetention, error) {
+	value, closer, err := s.db.Get(retentionKey)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return archive.RegisterRetention{}, nil
+	}
+	if err != nil {
+		return archive.RegisterRetention{}, fmt.Errorf("failed to get retention: %w", err)
+	}
+	defer closer.Close()
+
+	if len(value) != 2*config.HeightSuffixLen {
+		return archive.RegisterRetention{}, fmt.Errorf("invalid retention format: expected %d bytes, got %d bytes",
+			2*config.HeightSuffixLen, len(value))
+	}
+
+	retention := archive.RegisterRetention{
+		Height:   binary.BigEndian.Uint64(value[:config.HeightSuffixLen]),
+		Interval: binary.BigEndian.Uint64(value[config.HeightSuffixLen:]),
+	}
+
+	return retention, nil
+}
+
+// SetRetention durably stores the retention the storage is being pruned with.
+func (s *Storage) SetRetention(retention archive.RegisterRetention) error {
+	value := make([]byte, 0, 2*config.HeightSuffixLen)
+	value = binary.BigEndian.AppendUint64(value, retention.Height)
+	value = binary.BigEndian.AppendUint64(value, retention.Interval)
+
+	err := s.db.Set(retentionKey, value, pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to set retention: %w", err)
+	}
+
+	return nil
+}
+
+// Prune deletes every register version that is no longer returned by
+// GetPayload for any height retained by the given retention.
+//
+// Pruning runs online: it works on a snapshot of the storage and commits its
+// deletions in batches, calling progress after each of them. Versions written
+// concurrently at heights above the retention height are never deleted.
+func (s *Storage) Prune(retention archive.RegisterRetention, progress func(archive.PruneProgress)) error {
+	iter := s.db.NewIter(nil)
+	defer iter.Close()
+
+	batch := s.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	var (
+		stats    archive.PruneProgress
+		prefix   []byte
+		newer    uint64
+		hasNewer bool
+	)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := iter.Key()
+		if len(key) < config.HeightSuffixLen || bytes.Equal(key, retentionKey) {
+			continue
+		}
+		stats.Scanned++
+
+		// The versions of a register are sorted from newest to oldest. If the
+		// versions of two registers ever interleave, we start over with no
+		// newer version, which can only make us keep more versions than needed.
+		split := len(key) - config.HeightSuffixLen
+		height := ^binary.BigEndian.Uint64(key[split:])
+		if !bytes.Equal(key[:split], prefix) {
+			prefix = append(prefix[:0], key[:split]...)
+			hasNewer = false
+		}
+
+		if !needed(retention, height, newer, hasNewer) {
+			err := batch.Delete(key, nil)
+			if err != nil {
+				return fmt.Errorf("failed to delete key: %w", err)
+			}
+			stats.Deleted++
+		}
+		newer = height
+		hasNewer = true
+
+		if batch.Count() < pruneBatchSize {
+			continue
+		}
+		err := batch.Commit(pebble.Sync)
+		if err != nil {
+			return fmt.Errorf("failed to commit batch: %w", err)
+		}
+		_ = batch.Close()
+		batch = s.db.NewBatch()
+		progress(stats)
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return fmt.Errorf("failed to iterate over register versions: %w", err)
+	}
+
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	progress(stats)
+
+	return nil
+}
+
+// needed returns whether the register version at the given height is still
+// needed to read the register at a height kept by the given retention. The
+// newer height is the one of the next version of the same register, if any.
+func needed(retention archive.RegisterRetention, height uint64, newer uint64, hasNewer bool) bool {
+	if height >= retention.Height || !hasNewer || newer > retention.Height {
+		return true
+	}
+	if retention.Interval == 0 {
+		return false
+	}
+
+	// This version is returned for every height up to the newer version, so
+	// it is needed if any of them is a multiple of the interval.
+	last := newer - 1
+	return last-last%retention.Interval >= height
+}
@@ -0,0 +1,171 @@
+package payload
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+func Test_PayloadStorage_Retention(t *testing.T) {
+	t.Parallel()
+
+	cache := pebble.NewCache(1 << 20)
+	defer cache.Unref()
+
+	dbpath := path.Join(t.TempDir(), "retention.db")
+	s, err := NewStorage(dbpath, cache)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	retention, err := s.GetRetention()
+	require.NoError(t, err)
+	require.Equal(t, archive.RegisterRetention{}, retention)
+
+	want := archive.RegisterRetention{Height: 42, Interval: 10}
+	err = s.SetRetention(want)
+	require.NoError(t, err)
+
+	retention, err = s.GetRetention()
+	require.NoError(t, err)
+	require.Equal(t, want, retention)
+
+	err = s.Close()
+	require.NoError(t, err)
+}
+
+func Test_PayloadStorage_Prune(t *testing.T) {
+	t.Parallel()
+
+	key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+	// key1Nested shares the "owner/key1/" prefix with key1 and is written at
+	// different heights, so that pruning one must not affect the other.
+	key1Nested := flow.RegisterID{Owner: "owner", Key: "key1/nested"}
+	heights := map[flow.RegisterID][]uint64{
+		key1:       {2, 5, 7, 11, 12, 19, 23, 25},
+		key1Nested: {3, 4, 13, 21},
+	}
+	const maxHeight = 30
+
+	tests := []struct {
+		name      string
+		retention archive.RegisterRetention
+		remaining map[flow.RegisterID][]uint64
+	}{
+		{
+			name:      "nothing pruned",
+			retention: archive.RegisterRetention{},
+			remaining: heights,
+		},
+		{
+			name:      "height only",
+			retention: archive.RegisterRetention{Height: 20},
+			remaining: map[flow.RegisterID][]uint64{
+				key1:       {19, 23, 25},
+				key1Nested: {13, 21},
+			},
+		},
+		{
+			name:      "height at version",
+			retention: archive.RegisterRetention{Height: 19},
+			remaining: map[flow.RegisterID][]uint64{
+				key1:       {19, 23, 25},
+				key1Nested: {13, 21},
+			},
+		},
+		{
+			name:      "height and interval",
+			retention: archive.RegisterRetention{Height: 20, Interval: 10},
+			remaining: map[flow.RegisterID][]uint64{
+				key1:       {7, 19, 23, 25},
+				key1Nested: {4, 13, 21},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := pebble.NewCache(1 << 20)
+			defer cache.Unref()
+
+			dbpath := path.Join(t.TempDir(), "prune.db")
+			s, err := NewStorage(dbpath, cache)
+			require.NoError(t, err)
+			require.NotNil(t, s)
+
+			for reg, regHeights := range heights {
+				for _, height := range regHeights {
+					entries := flow.RegisterEntries{
+						{Key: reg, Value: []byte(fmt.Sprintf("%s-%d", reg.Key, height))},
+					}
+					err = s.BatchSetPayload(height, entries)
+					require.NoError(t, err)
+				}
+			}
+
+			// Remember the values at all heights before pruning.
+			values := make(map[flow.RegisterID][][]byte)
+			for reg := range heights {
+				for height := uint64(0); height <= maxHeight; height++ {
+					value, err := s.GetPayload(height, reg)
+					require.NoError(t, err)
+					values[reg] = append(values[reg], value)
+				}
+			}
+
+			err = s.SetRetention(tt.retention)
+			require.NoError(t, err)
+
+			var progress archive.PruneProgress
+			err = s.Prune(tt.retention, func(p archive.PruneProgress) {
+				progress = p
+			})
+			require.NoError(t, err)
+			require.Equal(t, uint64(12), progress.Scanned)
+
+			deleted := uint64(0)
+			for reg, want := range tt.remaining {
+				versions, err := s.GetPayloadHistory(reg, 0, maxHeight, 0)
+				require.NoError(t, err)
+
+				got := make([]uint64, 0, len(versions))
+				for _, version := range versions {
+					got = append(got, version.Height)
+				}
+				require.Equal(t, want, got)
+
+				deleted += uint64(len(heights[reg]) - len(want))
+			}
+			require.Equal(t, deleted, progress.Deleted)
+
+			// Every retained height still returns the same values.
+			for reg := range heights {
+				for height := uint64(0); height <= maxHeight; height++ {
+					if !tt.retention.Retains(height) {
+						continue
+					}
+					value, err := s.GetPayload(height, reg)
+					require.NoError(t, err)
+					require.Equal(t, values[reg][height], value, "register: %s, height: %d", reg, height)
+				}
+			}
+
+			// The retention itself survives pruning.
+			retention, err := s.GetRetention()
+			require.NoError(t, err)
+			require.Equal(t, tt.retention, retention)
+
+			err = s.Close()
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package tokens
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// Names of the events that fungible token contracts emit when tokens are
+// withdrawn from and deposited into a vault, as declared by the FungibleToken
+// contract interface.
+const (
+	withdrawnEvent = "TokensWithdrawn"
+	depositedEvent = "TokensDeposited"
+)
+
+// tokenEvent is a withdrawal or deposit event of a known token.
+type tokenEvent struct {
+	symbol  string
+	deposit bool
+}
+
+// Extractor extracts the token transfers of a block from the withdrawal and
+// deposit events emitted by the contracts of the tokens of a chain.
+type Extractor struct {
+	log    zerolog.Logger
+	events map[flow.EventType]tokenEvent
+}
+
+// NewExtractor creates a new extractor for the tokens within the given chain
+// parameters, which logs the token events it can not decode with the given
+// logger.
+func NewExtractor(log zerolog.Logger, params archive.Params) *Extractor {
+
+	events := make(map[flow.EventType]tokenEvent)
+	for symbol, token := range params.Tokens {
+		events[eventType(token, withdrawnEvent)] = tokenEvent{symbol: symbol, deposit: false}
+		events[eventType(token, depositedEvent)] = tokenEvent{symbol: symbol, deposit: true}
+	}
+
+	e := Extractor{
+		log:    log.With().Str("component", "token_extractor").Logger(),
+		events: events,
+	}
+
+	return &e
+}
+
+// Transfers returns the token transfers made by the given events, which were
+// emitted by the transactions of the block at the given height.
+//
+// Within each transaction, a deposit is paired with the earliest preceding
+// withdrawal of the same amount of the same token. A deposit without such a
+// withdrawal is a mint, and a withdrawal without a deposit is a burn. The
+// transfers are ordered by transaction, and by the index of their first event
+// within a transaction.
+//
+// A token event that can not be decoded is logged and skipped, so that a single
+// malformed event does not prevent the block from being indexed.
+func (e *Extractor) Transfers(height uint64, events []flow.Event) ([]archive.Transfer, error) {
+
+	// We only keep the events of known tokens, and group them by transaction,
+	// so that we can pair them in the order they were emitted.
+	var txIDs []flow.Identifier
+	txEvents := make(map[flow.Identifier][]flow.Event)
+	txIndices := make(map[flow.Identifier]uint32)
+	for _, event := range events {
+		_, ok := e.events[event.Type]
+		if !ok {
+			continue
+		}
+		if _, ok := txEvents[event.TransactionID]; !ok {
+			txIDs = append(txIDs, event.TransactionID)
+			txIndices[event.TransactionID] = event.TransactionIndex
+		}
+		txEvents[event.TransactionID] = append(txEvents[event.TransactionID], event)
+	}
+	sort.SliceStable(txIDs, func(i, j int) bool {
+		return txIndices[txIDs[i]] < txIndices[txIDs[j]]
+	})
+
+	var transfers []archive.Transfer
+	for _, txID := range txIDs {
+		transfers = append(transfers, e.pair(height, txEvents[txID])...)
+	}
+
+	return transfers, nil
+}
+
+// pair pairs the given token events of a single transaction into transfers.
+func (e *Extractor) pair(height uint64, events []flow.Event) []archive.Transfer {
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].EventIndex < events[j].EventIndex
+	})
+
+	var transfers []archive.Transfer
+	var pending []int
+	for _, event := range events {
+		token := e.events[event.Type]
+		amount, address, err := decodeEvent(event)
+		if err != nil {
+			e.log.Warn().
+				Err(err).
+				Uint64("height", height).
+				Hex("transaction", event.TransactionID[:]).
+				Uint32("index", event.EventIndex).
+				Str("type", string(event.Type)).
+				Msg("skipping token event that could not be decoded")
+			continue
+		}
+
+		if !token.deposit {
+			transfers = append(transfers, archive.Transfer{
+				Height:        height,
+				TransactionID: event.TransactionID,
+				EventIndex:    event.EventIndex,
+				Token:         token.symbol,
+				From:          address,
+				Amount:        amount,
+			})
+			pending = append(pending, len(transfers)-1)
+			continue
+		}
+
+		paired := false
+		for i, index := range pending {
+			withdrawal := &transfers[index]
+			if withdrawal.Token != token.symbol || withdrawal.Amount != amount {
+				continue
+			}
+			withdrawal.To = address
+			pending = append(pending[:i], pending[i+1:]...)
+			paired = true
+			break
+		}
+		if paired {
+			continue
+		}
+
+		transfers = append(transfers, archive.Transfer{
+			Height:        height,
+			TransactionID: event.TransactionID,
+			EventIndex:    event.EventIndex,
+			Token:         token.symbol,
+			To:            address,
+			Amount:        amount,
+		})
+	}
+
+	return transfers
+}
+
+// decodeEvent decodes the amount and the account address of a withdrawal or
+// deposit event. The address is flow.EmptyAddress if the vault was not owned
+// by an account.
+func decodeEvent(event flow.Event) (uint64, flow.Address, error) {
+
+	value, err := json.Decode(nil, event.Payload)
+	if err != nil {
+		return 0, flow.EmptyAddress, fmt.Errorf("could not decode payload: %w", err)
+	}
+	ev, ok := value.(cadence.Event)
+	if !ok || ev.EventType == nil {
+		return 0, flow.EmptyAddress, fmt.Errorf("invalid payload type (%T)", value)
+	}
+	if len(ev.Fields) != len(ev.EventType.Fields) {
+		return 0, flow.EmptyAddress, fmt.Errorf("invalid number of fields (%d, want %d)", len(ev.Fields), len(ev.EventType.Fields))
+	}
+
+	var amount uint64
+	var address flow.Address
+	var hasAmount bool
+	for i, field := range ev.EventType.Fields {
+		switch field.Identifier {
+		case "amount":
+			value, ok := ev.Fields[i].(cadence.UFix64)
+			if !ok {
+				return 0, flow.EmptyAddress, fmt.Errorf("invalid amount type (%T)", ev.Fields[i])
+			}
+			amount = uint64(value)
+			hasAmount = true
+		case "from", "to":
+			optional, ok := ev.Fields[i].(cadence.Optional)
+			if !ok {
+				return 0, flow.EmptyAddress, fmt.Errorf("invalid address type (%T)", ev.Fields[i])
+			}
+			if optional.Value == nil {
+				continue
+			}
+			value, ok := optional.Value.(cadence.Address)
+			if !ok {
+				return 0, flow.EmptyAddress, fmt.Errorf("invalid address type (%T)", optional.Value)
+			}
+			address = flow.Address(value)
+		}
+	}
+	if !hasAmount {
+		return 0, flow.EmptyAddress, fmt.Errorf("missing amount field")
+	}
+
+	return amount, address, nil
+}
+
+// eventType returns the type of the event with the given name, emitted by the
+// contract of the given token.
+func eventType(token archive.Token, name string) flow.EventType {
+	return flow.EventType(fmt.Sprintf("A.%s.%s.%s", token.Address.Hex(), token.Type, name))
+}
@@ -0,0 +1,166 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+func TestExtractor_Transfers(t *testing.T) {
+	params := archive.FlowParams[archive.FlowTestnet]
+	token := params.Tokens[archive.FlowSymbol]
+	extract := NewExtractor(zerolog.Nop(), params)
+
+	alice := flow.HexToAddress("01cf0e2f2f715450")
+	bob := flow.HexToAddress("179b6b1cb6755e31")
+	fees := params.FlowFees
+	tx1 := flow.Identifier{1}
+	tx2 := flow.Identifier{2}
+
+	const height = 42
+
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		events := []flow.Event{
+			// The fees of the first transaction, followed by a transfer.
+			genericTokenEvent(t, token, withdrawnEvent, tx1, 0, 0, 100, &alice),
+			genericTokenEvent(t, token, depositedEvent, tx1, 0, 1, 100, &fees),
+			genericTokenEvent(t, token, withdrawnEvent, tx1, 0, 2, 5000, &alice),
+			genericTokenEvent(t, token, depositedEvent, tx1, 0, 3, 5000, &bob),
+			// A mint and a burn in the second transaction.
+			genericTokenEvent(t, token, depositedEvent, tx2, 1, 0, 700, &bob),
+			genericTokenEvent(t, token, withdrawnEvent, tx2, 1, 1, 300, &bob),
+		}
+
+		got, err := extract.Transfers(height, events)
+
+		require.NoError(t, err)
+		want := []archive.Transfer{
+			{Height: height, TransactionID: tx1, EventIndex: 0, Token: archive.FlowSymbol, From: alice, To: fees, Amount: 100},
+			{Height: height, TransactionID: tx1, EventIndex: 2, Token: archive.FlowSymbol, From: alice, To: bob, Amount: 5000},
+			{Height: height, TransactionID: tx2, EventIndex: 0, Token: archive.FlowSymbol, To: bob, Amount: 700},
+			{Height: height, TransactionID: tx2, EventIndex: 1, Token: archive.FlowSymbol, From: bob, Amount: 300},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("pairs events of the same amount", func(t *testing.T) {
+		t.Parallel()
+
+		events := []flow.Event{
+			genericTokenEvent(t, token, withdrawnEvent, tx1, 0, 0, 100, &alice),
+			genericTokenEvent(t, token, withdrawnEvent, tx1, 0, 1, 200, &bob),
+			genericTokenEvent(t, token, depositedEvent, tx1, 0, 2, 200, &alice),
+			genericTokenEvent(t, token, depositedEvent, tx1, 0, 3, 100, &bob),
+		}
+
+		got, err := extract.Transfers(height, events)
+
+		require.NoError(t, err)
+		want := []archive.Transfer{
+			{Height: height, TransactionID: tx1, EventIndex: 0, Token: archive.FlowSymbol, From: alice, To: bob, Amount: 100},
+			{Height: height, TransactionID: tx1, EventIndex: 1, Token: archive.FlowSymbol, From: bob, To: alice, Amount: 200},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("handles vaults without owner", func(t *testing.T) {
+		t.Parallel()
+
+		events := []flow.Event{
+			genericTokenEvent(t, token, withdrawnEvent, tx1, 0, 0, 100, nil),
+			genericTokenEvent(t, token, depositedEvent, tx1, 0, 1, 100, &bob),
+		}
+
+		got, err := extract.Transfers(height, events)
+
+		require.NoError(t, err)
+		want := []archive.Transfer{
+			{Height: height, TransactionID: tx1, EventIndex: 0, Token: archive.FlowSymbol, From: flow.EmptyAddress, To: bob, Amount: 100},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("ignores events of unknown tokens", func(t *testing.T) {
+		t.Parallel()
+
+		other := token
+		other.Address = flow.HexToAddress("0000000000000001")
+		events := []flow.Event{
+			genericTokenEvent(t, other, withdrawnEvent, tx1, 0, 0, 100, &alice),
+			genericTokenEvent(t, other, depositedEvent, tx1, 0, 1, 100, &bob),
+			{Type: "flow.AccountCreated", TransactionID: tx1, EventIndex: 2, Payload: []byte("invalid")},
+		}
+
+		got, err := extract.Transfers(height, events)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("skips invalid payloads", func(t *testing.T) {
+		t.Parallel()
+
+		// Without its withdrawal, the deposit is indexed as a mint.
+		invalid := genericTokenEvent(t, token, withdrawnEvent, tx1, 0, 0, 100, &alice)
+		invalid.Payload = []byte("invalid")
+		events := []flow.Event{
+			invalid,
+			genericTokenEvent(t, token, depositedEvent, tx1, 0, 1, 100, &bob),
+		}
+
+		got, err := extract.Transfers(height, events)
+
+		require.NoError(t, err)
+		want := []archive.Transfer{
+			{Height: height, TransactionID: tx1, EventIndex: 1, Token: archive.FlowSymbol, To: bob, Amount: 100},
+		}
+		assert.Equal(t, want, got)
+	})
+}
+
+func genericTokenEvent(t *testing.T, token archive.Token, name string, txID flow.Identifier, txIndex uint32, eventIndex uint32, amount uint64, address *flow.Address) flow.Event {
+	t.Helper()
+
+	field := "from"
+	if name == depositedEvent {
+		field = "to"
+	}
+
+	typ := cadence.NewEventType(
+		common.AddressLocation{Address: common.Address(token.Address), Name: token.Type},
+		token.Type+"."+name,
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.NewUFix64Type()},
+			{Identifier: field, Type: cadence.NewOptionalType(cadence.NewAddressType())},
+		},
+		nil,
+	)
+
+	value := cadence.NewOptional(nil)
+	if address != nil {
+		value = cadence.NewOptional(cadence.NewAddress(*address))
+	}
+	event := cadence.NewEvent([]cadence.Value{cadence.UFix64(amount), value}).WithType(typ)
+
+	payload, err := json.Encode(event)
+	require.NoError(t, err)
+
+	return flow.Event{
+		Type:             eventType(token, name),
+		TransactionID:    txID,
+		TransactionIndex: txIndex,
+		EventIndex:       eventIndex,
+		Payload:          payload,
+	}
+}
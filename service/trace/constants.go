@@ -4,20 +4,41 @@ const (
 	// App names
 
 	// Archive API
-	GetFirst                  SpanName = "archive.getFirst"
-	GetLast                   SpanName = "archive.getLast"
-	GetHeightForBlock         SpanName = "archive.getHeightForBlock"
-	GetCommit                 SpanName = "archive.getCommit"
-	GetHeader                 SpanName = "archive.getHeader"
-	GetEvents                 SpanName = "archive.getEvents"
-	GetRegisterValues         SpanName = "archive.getRegisterValues"
-	GetCollection             SpanName = "archive.getCollection"
-	ListCollectionsForHeight  SpanName = "archive.listCollectionsForHeight"
-	GetGuarantee              SpanName = "archive.getGuarantee"
-	GetTransaction            SpanName = "archive.getTransaction"
-	GetHeightForTransaction   SpanName = "archive.getHeightForTransaction"
-	ListTransactionsForHeight SpanName = "archive.listTransactionsForHeight"
-	GetResult                 SpanName = "archive.getResult"
-	GetSeal                   SpanName = "archive.getSeal"
-	ListSealsForHeight        SpanName = "archive.listSealsForHeight"
+	GetFirst                   SpanName = "archive.getFirst"
+	GetLast                    SpanName = "archive.getLast"
+	GetRegisterRetention       SpanName = "archive.getRegisterRetention"
+	GetHeightForBlock          SpanName = "archive.getHeightForBlock"
+	GetHeightForTimestamp      SpanName = "archive.getHeightForTimestamp"
+	GetCommit                  SpanName = "archive.getCommit"
+	GetHeader                  SpanName = "archive.getHeader"
+	GetEvents                  SpanName = "archive.getEvents"
+	ListHeightsForEventTypes   SpanName = "archive.listHeightsForEventTypes"
+	ListEventsForContract      SpanName = "archive.listEventsForContract"
+	GetRegisterValues          SpanName = "archive.getRegisterValues"
+	GetRegisterHistory         SpanName = "archive.getRegisterHistory"
+	GetCollection              SpanName = "archive.getCollection"
+	ListCollectionsForHeight   SpanName = "archive.listCollectionsForHeight"
+	GetHeightForCollection     SpanName = "archive.getHeightForCollection"
+	GetGuarantee               SpanName = "archive.getGuarantee"
+	GetTransaction             SpanName = "archive.getTransaction"
+	GetHeightForTransaction    SpanName = "archive.getHeightForTransaction"
+	ListTransactionsForHeight  SpanName = "archive.listTransactionsForHeight"
+	ListTransactionsForAddress SpanName = "archive.listTransactionsForAddress"
+	ListTransfersForAddress    SpanName = "archive.listTransfersForAddress"
+	ListBalanceHistory         SpanName = "archive.listBalanceHistory"
+	ListContractsForAddress    SpanName = "archive.listContractsForAddress"
+	ListContractUpdates        SpanName = "archive.listContractUpdates"
+	GetContractCode            SpanName = "archive.getContractCode"
+	GetAccountKeys             SpanName = "archive.getAccountKeys"
+	ListAccountKeyHistory      SpanName = "archive.listAccountKeyHistory"
+	GetResult                  SpanName = "archive.getResult"
+	GetSeal                    SpanName = "archive.getSeal"
+	GetHeightForSeal           SpanName = "archive.getHeightForSeal"
+	GetSealedHeightForBlock    SpanName = "archive.getSealedHeightForBlock"
+	ListSealsForHeight         SpanName = "archive.listSealsForHeight"
+	ListRegistersForHeight     SpanName = "archive.listRegistersForHeight"
+	ListRegistersForOwner      SpanName = "archive.listRegistersForOwner"
+	SubscribeHeaders           SpanName = "archive.subscribeHeaders"
+	SubscribeEvents            SpanName = "archive.subscribeEvents"
+	SubscribeRegisterChanges   SpanName = "archive.subscribeRegisterChanges"
 )
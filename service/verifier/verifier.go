@@ -0,0 +1,136 @@
+package verifier
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+// batchSize is the number of registers inserted into the trie at once.
+const batchSize = 10_000
+
+// Verifier verifies that the registers of the index hash to the state
+// commitment of a height, by rebuilding the execution state trie from them.
+//
+// The whole trie is held in memory while it is being rebuilt, so verifying the
+// state of a large network requires as much memory as an execution node.
+type Verifier struct {
+	log  zerolog.Logger
+	lib2 archive.ReadLibrary2
+}
+
+// New creates a new verifier, which reads the registers from the given library.
+func New(log zerolog.Logger, lib2 archive.ReadLibrary2) *Verifier {
+
+	v := Verifier{
+		log:  log.With().Str("component", "state_verifier").Logger(),
+		lib2: lib2,
+	}
+
+	return &v
+}
+
+// Verify rebuilds the execution state trie from the values of all registers at
+// the given height, and checks that its root hash is the given commit.
+func (v *Verifier) Verify(height uint64, commit flow.StateCommitment) error {
+
+	root, err := v.RootHash(height)
+	if err != nil {
+		return fmt.Errorf("could not compute root hash: %w", err)
+	}
+
+	if flow.StateCommitment(root) != commit {
+		return fmt.Errorf("state commitment mismatch (height: %d, commit: %x, registers: %x)", height, commit[:], root[:])
+	}
+
+	v.log.Info().Uint64("height", height).Hex("commit", commit[:]).Msg("state commitment verified")
+
+	return nil
+}
+
+// RootHash rebuilds the execution state trie from the values of all registers
+// at the given height, and returns its root hash.
+func (v *Verifier) RootHash(height uint64) (ledger.RootHash, error) {
+
+	tree, err := v.Trie(height)
+	if err != nil {
+		return ledger.RootHash{}, err
+	}
+
+	return tree.RootHash(), nil
+}
+
+// Trie rebuilds the execution state trie from the values of all registers at
+// the given height.
+func (v *Verifier) Trie(height uint64) (*trie.MTrie, error) {
+
+	// Once registers have been pruned, the register state can only be rebuilt
+	// at the heights kept by the retention.
+	retention, err := v.lib2.GetRegisterRetention()
+	if err != nil {
+		return nil, fmt.Errorf("could not get register retention: %w", err)
+	}
+	if !retention.Retains(height) {
+		return nil, fmt.Errorf("registers at height have been pruned (height: %d, retention height: %d, retention interval: %d)",
+			height, retention.Height, retention.Interval)
+	}
+
+	tree := trie.NewEmptyMTrie()
+	paths := make([]ledger.Path, 0, batchSize)
+	payloads := make([]ledger.Payload, 0, batchSize)
+	total := 0
+
+	// The trie copies the paths and payloads it is given, and permutes the
+	// slices in place, so they can be reused for every batch.
+	insert := func() error {
+		updated, _, err := trie.NewTrieWithUpdatedRegisters(tree, paths, payloads, true)
+		if err != nil {
+			return fmt.Errorf("could not update trie: %w", err)
+		}
+		tree = updated
+		total += len(paths)
+		paths = paths[:0]
+		payloads = payloads[:0]
+
+		v.log.Debug().Uint64("height", height).Int("registers", total).Msg("registers inserted into trie")
+
+		return nil
+	}
+
+	err = v.lib2.IteratePayloads(height, func(entry flow.RegisterEntry) error {
+		key := state.RegisterIDToKey(entry.Key)
+		path, err := pathfinder.KeyToPath(key, complete.DefaultPathFinderVersion)
+		if err != nil {
+			return fmt.Errorf("could not get register path: %w", err)
+		}
+
+		paths = append(paths, path)
+		payloads = append(payloads, *ledger.NewPayload(key, entry.Value))
+		if len(paths) < batchSize {
+			return nil
+		}
+
+		return insert()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not iterate over registers: %w", err)
+	}
+
+	if len(paths) > 0 {
+		err = insert()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,129 @@
+package verifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/testing/helpers"
+	"github.com/onflow/flow-archive/testing/mocks"
+)
+
+func TestVerifier_RootHash(t *testing.T) {
+	t.Run("nominal case", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+		entries := helpers.RegisterEntries(100)
+		require.NoError(t, lib2.BatchSetPayload(1, entries))
+
+		// Overwritten and removed registers must hash to their latest value.
+		updated := flow.RegisterEntry{Key: entries[0].Key, Value: []byte("updated")}
+		removed := flow.RegisterEntry{Key: entries[1].Key, Value: []byte{}}
+		require.NoError(t, lib2.BatchSetPayload(2, flow.RegisterEntries{updated, removed}))
+
+		v := New(mocks.NoopLogger, lib2)
+
+		got, err := v.RootHash(1)
+		require.NoError(t, err)
+		assert.Equal(t, testRootHash(t, entries), got)
+
+		got, err = v.RootHash(2)
+		require.NoError(t, err)
+		want := append(flow.RegisterEntries{updated}, entries[2:]...)
+		assert.Equal(t, testRootHash(t, want), got)
+	})
+
+	t.Run("multiple batches", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+		entries := helpers.RegisterEntries(batchSize + 1)
+		require.NoError(t, lib2.BatchSetPayload(1, entries))
+
+		v := New(mocks.NoopLogger, lib2)
+
+		got, err := v.RootHash(1)
+		require.NoError(t, err)
+		assert.Equal(t, testRootHash(t, entries), got)
+	})
+
+	t.Run("handles pruned height", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+		require.NoError(t, lib2.BatchSetPayload(1, helpers.RegisterEntries(10)))
+		require.NoError(t, lib2.PruneRegisters(archive.RegisterRetention{Height: 5}, func(archive.PruneProgress) {}))
+
+		v := New(mocks.NoopLogger, lib2)
+
+		_, err := v.RootHash(1)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty index", func(t *testing.T) {
+		t.Parallel()
+
+		lib2 := helpers.Library2(t)
+
+		v := New(mocks.NoopLogger, lib2)
+
+		got, err := v.RootHash(1)
+		require.NoError(t, err)
+		assert.Equal(t, trie.NewEmptyMTrie().RootHash(), got)
+	})
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	lib2 := helpers.Library2(t)
+	entries := helpers.RegisterEntries(10)
+	require.NoError(t, lib2.BatchSetPayload(1, entries))
+
+	v := New(mocks.NoopLogger, lib2)
+	commit := flow.StateCommitment(testRootHash(t, entries))
+
+	t.Run("nominal case", func(t *testing.T) {
+		err := v.Verify(1, commit)
+		assert.NoError(t, err)
+	})
+
+	t.Run("handles commit mismatch", func(t *testing.T) {
+		err := v.Verify(1, mocks.GenericCommit(0))
+		assert.Error(t, err)
+	})
+
+	t.Run("handles missing registers", func(t *testing.T) {
+		err := v.Verify(0, commit)
+		assert.Error(t, err)
+	})
+}
+
+// testRootHash computes the root hash of a trie built from the given entries
+// in a single update.
+func testRootHash(t *testing.T, entries flow.RegisterEntries) ledger.RootHash {
+	t.Helper()
+
+	paths := make([]ledger.Path, 0, len(entries))
+	payloads := make([]ledger.Payload, 0, len(entries))
+	for _, entry := range entries {
+		key := state.RegisterIDToKey(entry.Key)
+		path, err := pathfinder.KeyToPath(key, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+		paths = append(paths, path)
+		payloads = append(payloads, *ledger.NewPayload(key, entry.Value))
+	}
+
+	tree, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+
+	return tree.RootHash()
+}
@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+	"github.com/onflow/flow-archive/service/storage2"
+)
+
+// Library2 returns an empty pebble-based index in a temporary directory, which
+// is closed once the test finishes.
+func Library2(t *testing.T) archive.Library2 {
+	t.Helper()
+
+	lib2, err := storage2.NewLibrary2(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, lib2.Close())
+	})
+
+	return lib2
+}
+
+// RegisterEntries returns the given number of register entries, spread over
+// the accounts of a few different owners.
+func RegisterEntries(count int) flow.RegisterEntries {
+	entries := make(flow.RegisterEntries, 0, count)
+	for i := 0; i < count; i++ {
+		entry := flow.RegisterEntry{
+			Key: flow.RegisterID{
+				Owner: string(flow.HexToAddress(fmt.Sprintf("%x", i%7+1)).Bytes()),
+				Key:   fmt.Sprintf("public/key%d", i),
+			},
+			Value: []byte(fmt.Sprintf("value%d", i)),
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
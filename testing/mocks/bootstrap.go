@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"testing"
+)
+
+type BootstrapProgress struct {
+	GetBootstrapProgressFunc func(height uint64) (uint64, error)
+	SetBootstrapProgressFunc func(height uint64, leaves uint64) error
+}
+
+func BaselineBootstrapProgress(t *testing.T) *BootstrapProgress {
+	t.Helper()
+
+	p := BootstrapProgress{
+		GetBootstrapProgressFunc: func(height uint64) (uint64, error) {
+			return 0, nil
+		},
+		SetBootstrapProgressFunc: func(height uint64, leaves uint64) error {
+			return nil
+		},
+	}
+
+	return &p
+}
+
+func (p *BootstrapProgress) GetBootstrapProgress(height uint64) (uint64, error) {
+	return p.GetBootstrapProgressFunc(height)
+}
+
+func (p *BootstrapProgress) SetBootstrapProgress(height uint64, leaves uint64) error {
+	return p.SetBootstrapProgressFunc(height, leaves)
+}
+
+type BootstrapMetrics struct {
+	ProgressFunc func(processed uint64, estimated uint64, rate float64)
+}
+
+func BaselineBootstrapMetrics(t *testing.T) *BootstrapMetrics {
+	t.Helper()
+
+	m := BootstrapMetrics{
+		ProgressFunc: func(processed uint64, estimated uint64, rate float64) {},
+	}
+
+	return &m
+}
+
+func (m *BootstrapMetrics) Progress(processed uint64, estimated uint64, rate float64) {
+	m.ProgressFunc(processed, estimated, rate)
+}
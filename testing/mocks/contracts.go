@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+type Contracts struct {
+	UpdatesFunc func(height uint64, events []flow.Event) ([]archive.ContractUpdate, error)
+}
+
+func BaselineContracts(t *testing.T) *Contracts {
+	t.Helper()
+
+	x := Contracts{
+		UpdatesFunc: func(height uint64, events []flow.Event) ([]archive.ContractUpdate, error) {
+			return GenericContractUpdates(4), nil
+		},
+	}
+
+	return &x
+}
+
+func (x *Contracts) Updates(height uint64, events []flow.Event) ([]archive.ContractUpdate, error) {
+	return x.UpdatesFunc(height, events)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/stdlib"
 	"github.com/onflow/cadence/runtime/tests/utils"
 	"github.com/onflow/flow-go/crypto"
 	chash "github.com/onflow/flow-go/crypto/hash"
@@ -48,6 +50,8 @@ var (
 
 	GenericBytes = []byte(`test`)
 
+	GenericContractCode = []byte(`pub contract Contract0 {}`)
+
 	GenericHeader = &flow.Header{
 		ChainID:   archive.FlowTestnet,
 		Height:    GenericHeight,
@@ -239,6 +243,18 @@ func GenericRegisterValue(index int) flow.RegisterValue {
 	return []byte(fmt.Sprintf("value-%d", random.Uint64()))
 }
 
+func GenericRegisterVersions(number int) []archive.RegisterVersion {
+	versions := make([]archive.RegisterVersion, 0, number)
+	for i := 0; i < number; i++ {
+		versions = append(versions, archive.RegisterVersion{
+			Height: GenericHeight + uint64(i),
+			Value:  GenericRegisterValue(i),
+		})
+	}
+
+	return versions
+}
+
 func GenericLedgerPayloads(number int) []*ledger.Payload {
 	var payloads []*ledger.Payload
 	for i := 0; i < number; i++ {
@@ -280,6 +296,225 @@ func GenericTransactionIDs(number int) []flow.Identifier {
 	return txIDs
 }
 
+func GenericAddressTransactions(number int) []archive.AddressTransaction {
+	txIDs := GenericTransactionIDs(number)
+
+	txs := make([]archive.AddressTransaction, 0, number)
+	for i, txID := range txIDs {
+		txs = append(txs, archive.AddressTransaction{
+			Height:        GenericHeight + uint64(i),
+			TransactionID: txID,
+			Roles:         archive.RoleAuthorizer,
+		})
+	}
+
+	return txs
+}
+
+func GenericTransfers(number int) []archive.Transfer {
+	txIDs := GenericTransactionIDs(number)
+
+	transfers := make([]archive.Transfer, 0, number)
+	for i, txID := range txIDs {
+		transfers = append(transfers, archive.Transfer{
+			Height:        GenericHeight + uint64(i),
+			TransactionID: txID,
+			EventIndex:    uint32(i),
+			Token:         archive.FlowSymbol,
+			From:          GenericAddress(i),
+			To:            GenericAddress(i + 1),
+			Amount:        uint64(i+1) * 100_000_000,
+		})
+	}
+
+	return transfers
+}
+
+func GenericBalanceChanges(number int) []archive.BalanceChange {
+	changes := make([]archive.BalanceChange, 0, number)
+	for i := 0; i < number; i++ {
+		changes = append(changes, archive.BalanceChange{
+			Height:    GenericHeight + uint64(i),
+			Deposited: uint64(i+1) * 100_000_000,
+			Withdrawn: uint64(i) * 50_000_000,
+		})
+	}
+
+	return changes
+}
+
+func GenericContracts(number int) []archive.Contract {
+	contracts := make([]archive.Contract, 0, number)
+	for i := 0; i < number; i++ {
+		contracts = append(contracts, archive.Contract{
+			Address: GenericAddress(0),
+			Name:    fmt.Sprintf("Contract%d", i),
+			Height:  GenericHeight + uint64(i),
+		})
+	}
+
+	return contracts
+}
+
+func GenericContractUpdates(number int) []archive.ContractUpdate {
+	txIDs := GenericTransactionIDs(number)
+
+	updates := make([]archive.ContractUpdate, 0, number)
+	for i, txID := range txIDs {
+		action := archive.ContractUpdated
+		if i == 0 {
+			action = archive.ContractAdded
+		}
+		updates = append(updates, archive.ContractUpdate{
+			Height:        GenericHeight + uint64(i),
+			TransactionID: txID,
+			EventIndex:    uint32(i),
+			Address:       GenericAddress(0),
+			Name:          "Contract0",
+			Action:        action,
+			CodeHash:      GenericBytes,
+		})
+	}
+
+	return updates
+}
+
+func GenericAccountKeyEvents(number int) []flow.Event {
+	publicKeyType := cadence.NewVariableSizedArrayType(cadence.UInt8Type{})
+
+	var events []flow.Event
+	for i, key := range GenericAccountKeys(number) {
+		publicKey := make([]cadence.Value, 0, len(key.PublicKey))
+		for _, b := range key.PublicKey {
+			publicKey = append(publicKey, cadence.UInt8(b))
+		}
+
+		// Keys alternate between two accounts, so that some accounts have
+		// several keys added within the same block.
+		payload := cadence.NewEvent(
+			[]cadence.Value{
+				cadence.NewAddress(GenericAddress(i % 2)),
+				cadence.NewArray(publicKey).WithType(publicKeyType),
+			},
+		).WithType(&cadence.EventType{
+			Location:            stdlib.FlowLocation{},
+			QualifiedIdentifier: strings.TrimPrefix(string(archive.EventAccountKeyAdded), "flow."),
+			Fields: []cadence.Field{
+				{
+					Identifier: "address",
+					Type:       cadence.AddressType{},
+				},
+				{
+					Identifier: "publicKey",
+					Type:       publicKeyType,
+				},
+			},
+		})
+
+		events = append(events, flow.Event{
+			TransactionID: GenericTransactionIDs(number)[i],
+			EventIndex:    uint32(i),
+			Type:          archive.EventAccountKeyAdded,
+			Payload:       json.MustEncode(payload),
+		})
+	}
+
+	return events
+}
+
+func GenericAccountKeys(number int) []archive.AccountKey {
+	// Ensure consistent deterministic results.
+	random := rand.New(rand.NewSource(7))
+
+	keys := make([]archive.AccountKey, 0, number)
+	for i := 0; i < number; i++ {
+		publicKey := make([]byte, 64)
+		_, _ = random.Read(publicKey)
+
+		keys = append(keys, archive.AccountKey{
+			Index:     uint32(i),
+			PublicKey: publicKey,
+			Weight:    1000,
+			SeqNumber: uint64(i),
+		})
+	}
+
+	return keys
+}
+
+func GenericAccountKeyHistory(number int) []archive.AccountKeys {
+	keys := GenericAccountKeys(number)
+
+	// Each change adds a key, except for the last one, which revokes the first.
+	history := make([]archive.AccountKeys, 0, number)
+	for i := 0; i < number; i++ {
+		current := make([]archive.AccountKey, i+1)
+		copy(current, keys[:i+1])
+		if i > 0 && i == number-1 {
+			current = current[:i]
+			current[0].Revoked = true
+		}
+		history = append(history, archive.AccountKeys{
+			Height:  GenericHeight + uint64(i),
+			Address: GenericAddress(0),
+			Keys:    current,
+		})
+	}
+
+	return history
+}
+
+func GenericContractEvents(number int) []flow.Event {
+	codeHashType := cadence.NewVariableSizedArrayType(cadence.UInt8Type{})
+
+	var events []flow.Event
+	for _, update := range GenericContractUpdates(number) {
+		eventType := archive.EventAccountContractUpdated
+		if update.Action == archive.ContractAdded {
+			eventType = archive.EventAccountContractAdded
+		}
+
+		codeHash := make([]cadence.Value, 0, len(update.CodeHash))
+		for _, b := range update.CodeHash {
+			codeHash = append(codeHash, cadence.UInt8(b))
+		}
+
+		payload := cadence.NewEvent(
+			[]cadence.Value{
+				cadence.NewAddress(update.Address),
+				cadence.NewArray(codeHash).WithType(codeHashType),
+				cadence.String(update.Name),
+			},
+		).WithType(&cadence.EventType{
+			Location:            stdlib.FlowLocation{},
+			QualifiedIdentifier: strings.TrimPrefix(string(eventType), "flow."),
+			Fields: []cadence.Field{
+				{
+					Identifier: "address",
+					Type:       cadence.AddressType{},
+				},
+				{
+					Identifier: "codeHash",
+					Type:       codeHashType,
+				},
+				{
+					Identifier: "contract",
+					Type:       cadence.StringType{},
+				},
+			},
+		})
+
+		events = append(events, flow.Event{
+			TransactionID: update.TransactionID,
+			EventIndex:    update.EventIndex,
+			Type:          eventType,
+			Payload:       json.MustEncode(payload),
+		})
+	}
+
+	return events
+}
+
 func GenericTransaction(index int) *flow.TransactionBody {
 	return GenericTransactions(index + 1)[index]
 }
@@ -6,11 +6,14 @@ import (
 
 	"github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
 )
 
 type Invoker struct {
-	AccountFunc func(ctx context.Context, height uint64, address flow.Address) (*flow.Account, error)
-	ScriptFunc  func(ctx context.Context, height uint64, script []byte, parameters [][]byte) ([]byte, error)
+	AccountFunc       func(ctx context.Context, height uint64, address flow.Address) (*flow.Account, error)
+	AccountKeysAtFunc func(ctx context.Context, height uint64, address flow.Address) ([]archive.AccountKey, error)
+	ScriptFunc        func(ctx context.Context, height uint64, script []byte, parameters [][]byte) ([]byte, error)
 }
 
 func BaselineInvoker(t *testing.T) *Invoker {
@@ -20,6 +23,9 @@ func BaselineInvoker(t *testing.T) *Invoker {
 		AccountFunc: func(ctx context.Context, height uint64, address flow.Address) (*flow.Account, error) {
 			return &GenericAccount, nil
 		},
+		AccountKeysAtFunc: func(ctx context.Context, height uint64, address flow.Address) ([]archive.AccountKey, error) {
+			return GenericAccountKeys(2), nil
+		},
 		ScriptFunc: func(ctx context.Context, height uint64, script []byte, parameters [][]byte) ([]byte, error) {
 			return json.MustEncode(GenericAmount(0)), nil
 		},
@@ -32,6 +38,10 @@ func (i *Invoker) Account(ctx context.Context, height uint64, address flow.Addre
 	return i.AccountFunc(ctx, height, address)
 }
 
+func (i *Invoker) AccountKeysAt(ctx context.Context, height uint64, address flow.Address) ([]archive.AccountKey, error) {
+	return i.AccountKeysAtFunc(ctx, height, address)
+}
+
 func (i *Invoker) Script(ctx context.Context, height uint64, script []byte, parameters [][]byte) ([]byte, error) {
 	return i.ScriptFunc(ctx, height, script, parameters)
 }
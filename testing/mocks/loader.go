@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+type BulkLoader struct {
+	NewRegisterLoaderFunc func(height uint64) (archive.RegisterLoader, error)
+}
+
+func BaselineBulkLoader(t *testing.T) *BulkLoader {
+	t.Helper()
+
+	b := BulkLoader{
+		NewRegisterLoaderFunc: func(height uint64) (archive.RegisterLoader, error) {
+			return BaselineRegisterLoader(t), nil
+		},
+	}
+
+	return &b
+}
+
+func (b *BulkLoader) NewRegisterLoader(height uint64) (archive.RegisterLoader, error) {
+	return b.NewRegisterLoaderFunc(height)
+}
+
+type RegisterLoader struct {
+	AddFunc    func(entries flow.RegisterEntries) error
+	FinishFunc func() error
+	CloseFunc  func() error
+}
+
+func BaselineRegisterLoader(t *testing.T) *RegisterLoader {
+	t.Helper()
+
+	l := RegisterLoader{
+		AddFunc: func(entries flow.RegisterEntries) error {
+			return nil
+		},
+		FinishFunc: func() error {
+			return nil
+		},
+		CloseFunc: func() error {
+			return nil
+		},
+	}
+
+	return &l
+}
+
+func (l *RegisterLoader) Add(entries flow.RegisterEntries) error {
+	return l.AddFunc(entries)
+}
+
+func (l *RegisterLoader) Finish() error {
+	return l.FinishFunc()
+}
+
+func (l *RegisterLoader) Close() error {
+	return l.CloseFunc()
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"testing"
+)
+
+type Notifier struct {
+	NotifyFunc func()
+	WaitFunc   func() <-chan struct{}
+}
+
+func BaselineNotifier(t *testing.T) *Notifier {
+	t.Helper()
+
+	n := Notifier{
+		NotifyFunc: func() {},
+		WaitFunc: func() <-chan struct{} {
+			return make(chan struct{})
+		},
+	}
+
+	return &n
+}
+
+func (n *Notifier) Notify() {
+	n.NotifyFunc()
+}
+
+func (n *Notifier) Wait() <-chan struct{} {
+	return n.WaitFunc()
+}
@@ -2,28 +2,49 @@ package mocks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
 )
 
 type Reader struct {
-	FirstFunc                func() (uint64, error)
-	LastFunc                 func() (uint64, error)
-	LatestRegisterHeightFunc func() (uint64, error)
-	HeightForBlockFunc       func(blockID flow.Identifier) (uint64, error)
-	CommitFunc               func(height uint64) (flow.StateCommitment, error)
-	HeaderFunc               func(height uint64) (*flow.Header, error)
-	EventsFunc               func(height uint64, types ...flow.EventType) ([]flow.Event, error)
-	ValuesFunc               func(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error)
-	CollectionFunc           func(collID flow.Identifier) (*flow.LightCollection, error)
-	CollectionsByHeightFunc  func(height uint64) ([]flow.Identifier, error)
-	GuaranteeFunc            func(collID flow.Identifier) (*flow.CollectionGuarantee, error)
-	TransactionFunc          func(txID flow.Identifier) (*flow.TransactionBody, error)
-	HeightForTransactionFunc func(txID flow.Identifier) (uint64, error)
-	TransactionsByHeightFunc func(height uint64) ([]flow.Identifier, error)
-	ResultFunc               func(txID flow.Identifier) (*flow.TransactionResult, error)
-	SealFunc                 func(sealID flow.Identifier) (*flow.Seal, error)
-	SealsByHeightFunc        func(height uint64) ([]flow.Identifier, error)
+	FirstFunc                 func() (uint64, error)
+	LastFunc                  func() (uint64, error)
+	LatestRegisterHeightFunc  func() (uint64, error)
+	RegisterRetentionFunc     func() (archive.RegisterRetention, error)
+	HeightForBlockFunc        func(blockID flow.Identifier) (uint64, error)
+	HeightForTimestampFunc    func(timestamp time.Time, mode archive.TimestampMode) (uint64, error)
+	HeightForCollectionFunc   func(collID flow.Identifier) (uint64, error)
+	HeightForSealFunc         func(sealID flow.Identifier) (uint64, error)
+	SealedAtHeightFunc        func(blockID flow.Identifier) (uint64, error)
+	CommitFunc                func(height uint64) (flow.StateCommitment, error)
+	HeaderFunc                func(height uint64) (*flow.Header, error)
+	EventsFunc                func(height uint64, types ...flow.EventType) ([]flow.Event, error)
+	EventHeightsFunc          func(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error)
+	EventsByContractFunc      func(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error)
+	ValuesFunc                func(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error)
+	RegisterHistoryFunc       func(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]archive.RegisterVersion, error)
+	CollectionFunc            func(collID flow.Identifier) (*flow.LightCollection, error)
+	CollectionsByHeightFunc   func(height uint64) ([]flow.Identifier, error)
+	GuaranteeFunc             func(collID flow.Identifier) (*flow.CollectionGuarantee, error)
+	TransactionFunc           func(txID flow.Identifier) (*flow.TransactionBody, error)
+	HeightForTransactionFunc  func(txID flow.Identifier) (uint64, error)
+	TransactionsByHeightFunc  func(height uint64) ([]flow.Identifier, error)
+	TransactionsByAddressFunc func(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error)
+	TransfersByAddressFunc    func(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error)
+	BalanceHistoryFunc        func(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error)
+	ContractsByAddressFunc    func(address flow.Address, height uint64) ([]archive.Contract, error)
+	ContractHistoryFunc       func(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error)
+	ContractCodeFunc          func(address flow.Address, name string, height uint64) ([]byte, error)
+	AccountKeysAtFunc         func(address flow.Address, height uint64) ([]archive.AccountKey, error)
+	AccountKeyHistoryFunc     func(address flow.Address) ([]archive.AccountKeys, error)
+	ResultFunc                func(txID flow.Identifier) (*flow.TransactionResult, error)
+	SealFunc                  func(sealID flow.Identifier) (*flow.Seal, error)
+	SealsByHeightFunc         func(height uint64) ([]flow.Identifier, error)
+	RegistersByHeightFunc     func(height uint64) (flow.RegisterIDs, error)
+	RegistersByOwnerFunc      func(height uint64, owner string, fn func(flow.RegisterEntry) error) error
 }
 
 func BaselineReader(t *testing.T) *Reader {
@@ -39,9 +60,24 @@ func BaselineReader(t *testing.T) *Reader {
 		LatestRegisterHeightFunc: func() (uint64, error) {
 			return GenericHeight, nil
 		},
+		RegisterRetentionFunc: func() (archive.RegisterRetention, error) {
+			return archive.RegisterRetention{}, nil
+		},
 		HeightForBlockFunc: func(blockID flow.Identifier) (uint64, error) {
 			return GenericHeight, nil
 		},
+		HeightForTimestampFunc: func(timestamp time.Time, mode archive.TimestampMode) (uint64, error) {
+			return GenericHeight, nil
+		},
+		HeightForCollectionFunc: func(collID flow.Identifier) (uint64, error) {
+			return GenericHeight, nil
+		},
+		HeightForSealFunc: func(sealID flow.Identifier) (uint64, error) {
+			return GenericHeight, nil
+		},
+		SealedAtHeightFunc: func(blockID flow.Identifier) (uint64, error) {
+			return GenericHeight, nil
+		},
 		CommitFunc: func(height uint64) (flow.StateCommitment, error) {
 			return GenericCommit(0), nil
 		},
@@ -51,9 +87,18 @@ func BaselineReader(t *testing.T) *Reader {
 		EventsFunc: func(height uint64, types ...flow.EventType) ([]flow.Event, error) {
 			return GenericEvents(4, GenericEventTypes(2)...), nil
 		},
+		EventHeightsFunc: func(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+			return []uint64{GenericHeight}, nil
+		},
+		EventsByContractFunc: func(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+			return []archive.BlockEvents{{Height: GenericHeight, Events: GenericEvents(4)}}, nil
+		},
 		ValuesFunc: func(height uint64, regs flow.RegisterIDs) ([]flow.RegisterValue, error) {
 			return GenericRegisterValues(6), nil
 		},
+		RegisterHistoryFunc: func(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]archive.RegisterVersion, error) {
+			return GenericRegisterVersions(4), nil
+		},
 		CollectionFunc: func(collID flow.Identifier) (*flow.LightCollection, error) {
 			return GenericCollection(0), nil
 		},
@@ -72,6 +117,30 @@ func BaselineReader(t *testing.T) *Reader {
 		TransactionsByHeightFunc: func(height uint64) ([]flow.Identifier, error) {
 			return GenericTransactionIDs(5), nil
 		},
+		TransactionsByAddressFunc: func(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+			return GenericAddressTransactions(4), nil
+		},
+		TransfersByAddressFunc: func(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+			return GenericTransfers(4), nil
+		},
+		BalanceHistoryFunc: func(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+			return GenericBalanceChanges(4), nil
+		},
+		ContractsByAddressFunc: func(address flow.Address, height uint64) ([]archive.Contract, error) {
+			return GenericContracts(4), nil
+		},
+		ContractHistoryFunc: func(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+			return GenericContractUpdates(4), nil
+		},
+		ContractCodeFunc: func(address flow.Address, name string, height uint64) ([]byte, error) {
+			return GenericContractCode, nil
+		},
+		AccountKeysAtFunc: func(address flow.Address, height uint64) ([]archive.AccountKey, error) {
+			return GenericAccountKeys(2), nil
+		},
+		AccountKeyHistoryFunc: func(address flow.Address) ([]archive.AccountKeys, error) {
+			return GenericAccountKeyHistory(4), nil
+		},
 		ResultFunc: func(txID flow.Identifier) (*flow.TransactionResult, error) {
 			return GenericResult(0), nil
 		},
@@ -81,6 +150,19 @@ func BaselineReader(t *testing.T) *Reader {
 		SealsByHeightFunc: func(height uint64) ([]flow.Identifier, error) {
 			return GenericSealIDs(5), nil
 		},
+		RegistersByHeightFunc: func(height uint64) (flow.RegisterIDs, error) {
+			return GenericRegisters(6), nil
+		},
+		RegistersByOwnerFunc: func(height uint64, owner string, fn func(flow.RegisterEntry) error) error {
+			values := GenericRegisterValues(6)
+			for i, reg := range GenericRegisters(6) {
+				err := fn(flow.RegisterEntry{Key: reg, Value: values[i]})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
 	}
 
 	return &r
@@ -98,10 +180,30 @@ func (r *Reader) LatestRegisterHeight() (uint64, error) {
 	return r.LatestRegisterHeightFunc()
 }
 
+func (r *Reader) RegisterRetention() (archive.RegisterRetention, error) {
+	return r.RegisterRetentionFunc()
+}
+
 func (r *Reader) HeightForBlock(blockID flow.Identifier) (uint64, error) {
 	return r.HeightForBlockFunc(blockID)
 }
 
+func (r *Reader) HeightForTimestamp(timestamp time.Time, mode archive.TimestampMode) (uint64, error) {
+	return r.HeightForTimestampFunc(timestamp, mode)
+}
+
+func (r *Reader) HeightForCollection(collID flow.Identifier) (uint64, error) {
+	return r.HeightForCollectionFunc(collID)
+}
+
+func (r *Reader) HeightForSeal(sealID flow.Identifier) (uint64, error) {
+	return r.HeightForSealFunc(sealID)
+}
+
+func (r *Reader) SealedAtHeight(blockID flow.Identifier) (uint64, error) {
+	return r.SealedAtHeightFunc(blockID)
+}
+
 func (r *Reader) Commit(height uint64) (flow.StateCommitment, error) {
 	return r.CommitFunc(height)
 }
@@ -118,6 +220,18 @@ func (r *Reader) Values(height uint64, regs flow.RegisterIDs) ([]flow.RegisterVa
 	return r.ValuesFunc(height, regs)
 }
 
+func (r *Reader) EventHeights(types []flow.EventType, startHeight uint64, endHeight uint64, limit int) ([]uint64, error) {
+	return r.EventHeightsFunc(types, startHeight, endHeight, limit)
+}
+
+func (r *Reader) EventsByContract(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.BlockEvents, error) {
+	return r.EventsByContractFunc(address, startHeight, endHeight, limit)
+}
+
+func (r *Reader) RegisterHistory(reg flow.RegisterID, startHeight uint64, endHeight uint64, limit int) ([]archive.RegisterVersion, error) {
+	return r.RegisterHistoryFunc(reg, startHeight, endHeight, limit)
+}
+
 func (r *Reader) Collection(collID flow.Identifier) (*flow.LightCollection, error) {
 	return r.CollectionFunc(collID)
 }
@@ -142,6 +256,38 @@ func (r *Reader) TransactionsByHeight(height uint64) ([]flow.Identifier, error)
 	return r.TransactionsByHeightFunc(height)
 }
 
+func (r *Reader) TransactionsByAddress(address flow.Address, startHeight uint64, endHeight uint64, roles archive.TransactionRole, limit int) ([]archive.AddressTransaction, error) {
+	return r.TransactionsByAddressFunc(address, startHeight, endHeight, roles, limit)
+}
+
+func (r *Reader) TransfersByAddress(address flow.Address, startHeight uint64, endHeight uint64, limit int) ([]archive.Transfer, error) {
+	return r.TransfersByAddressFunc(address, startHeight, endHeight, limit)
+}
+
+func (r *Reader) BalanceHistory(address flow.Address, token string, startHeight uint64, endHeight uint64, limit int) ([]archive.BalanceChange, error) {
+	return r.BalanceHistoryFunc(address, token, startHeight, endHeight, limit)
+}
+
+func (r *Reader) ContractsByAddress(address flow.Address, height uint64) ([]archive.Contract, error) {
+	return r.ContractsByAddressFunc(address, height)
+}
+
+func (r *Reader) ContractHistory(address flow.Address, name string, startHeight uint64, endHeight uint64, limit int) ([]archive.ContractUpdate, error) {
+	return r.ContractHistoryFunc(address, name, startHeight, endHeight, limit)
+}
+
+func (r *Reader) ContractCode(address flow.Address, name string, height uint64) ([]byte, error) {
+	return r.ContractCodeFunc(address, name, height)
+}
+
+func (r *Reader) AccountKeysAt(address flow.Address, height uint64) ([]archive.AccountKey, error) {
+	return r.AccountKeysAtFunc(address, height)
+}
+
+func (r *Reader) AccountKeyHistory(address flow.Address) ([]archive.AccountKeys, error) {
+	return r.AccountKeyHistoryFunc(address)
+}
+
 func (r *Reader) Result(txID flow.Identifier) (*flow.TransactionResult, error) {
 	return r.ResultFunc(txID)
 }
@@ -153,3 +299,11 @@ func (r *Reader) Seal(sealID flow.Identifier) (*flow.Seal, error) {
 func (r *Reader) SealsByHeight(height uint64) ([]flow.Identifier, error) {
 	return r.SealsByHeightFunc(height)
 }
+
+func (r *Reader) RegistersByHeight(height uint64) (flow.RegisterIDs, error) {
+	return r.RegistersByHeightFunc(height)
+}
+
+func (r *Reader) RegistersByOwner(height uint64, owner string, fn func(flow.RegisterEntry) error) error {
+	return r.RegistersByOwnerFunc(height, owner, fn)
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type Seed struct {
+	RegistersFunc func(height uint64, fn func(flow.RegisterEntry) error) error
+}
+
+func BaselineSeed(t *testing.T) *Seed {
+	t.Helper()
+
+	s := Seed{
+		RegistersFunc: func(height uint64, fn func(flow.RegisterEntry) error) error {
+			values := GenericRegisterValues(6)
+			for i, reg := range GenericRegisters(6) {
+				err := fn(flow.RegisterEntry{Key: reg, Value: values[i]})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return &s
+}
+
+func (s *Seed) Registers(height uint64, fn func(flow.RegisterEntry) error) error {
+	return s.RegistersFunc(height, fn)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
+)
+
+type Transfers struct {
+	TransfersFunc func(height uint64, events []flow.Event) ([]archive.Transfer, error)
+}
+
+func BaselineTransfers(t *testing.T) *Transfers {
+	t.Helper()
+
+	x := Transfers{
+		TransfersFunc: func(height uint64, events []flow.Event) ([]archive.Transfer, error) {
+			return GenericTransfers(4), nil
+		},
+	}
+
+	return &x
+}
+
+func (x *Transfers) Transfers(height uint64, events []flow.Event) ([]archive.Transfer, error) {
+	return x.TransfersFunc(height, events)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type Verifier struct {
+	VerifyFunc func(height uint64, commit flow.StateCommitment) error
+}
+
+func BaselineVerifier(t *testing.T) *Verifier {
+	t.Helper()
+
+	v := Verifier{
+		VerifyFunc: func(height uint64, commit flow.StateCommitment) error {
+			return nil
+		},
+	}
+
+	return &v
+}
+
+func (v *Verifier) Verify(height uint64, commit flow.StateCommitment) error {
+	return v.VerifyFunc(height, commit)
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-archive/models/archive"
 )
 
 type Writer struct {
@@ -23,6 +25,8 @@ type Writer struct {
 	ResultsFunc              func(results []*flow.TransactionResult) error
 	EventsFunc               func(height uint64, events []flow.Event) error
 	SealsFunc                func(height uint64, seals []*flow.Seal) error
+	TransfersFunc            func(height uint64, transfers []archive.Transfer) error
+	ContractUpdatesFunc      func(height uint64, updates []archive.ContractUpdate) error
 	CloseFunc                func() error
 }
 
@@ -72,6 +76,12 @@ func BaselineWriter(t *testing.T) *Writer {
 		SealsFunc: func(height uint64, seals []*flow.Seal) error {
 			return nil
 		},
+		TransfersFunc: func(height uint64, transfers []archive.Transfer) error {
+			return nil
+		},
+		ContractUpdatesFunc: func(height uint64, updates []archive.ContractUpdate) error {
+			return nil
+		},
 		CloseFunc: func() error {
 			return nil
 		},
@@ -136,6 +146,14 @@ func (w *Writer) Seals(height uint64, seals []*flow.Seal) error {
 	return w.SealsFunc(height, seals)
 }
 
+func (w *Writer) Transfers(height uint64, transfers []archive.Transfer) error {
+	return w.TransfersFunc(height, transfers)
+}
+
+func (w *Writer) ContractUpdates(height uint64, updates []archive.ContractUpdate) error {
+	return w.ContractUpdatesFunc(height, updates)
+}
+
 func (w *Writer) Close() error {
 	return w.CloseFunc()
 }
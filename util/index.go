@@ -50,5 +50,15 @@ func ValidateRegisterHeightIndexed(reader archive.Reader, height uint64) error {
 	if height > h || height < l {
 		return fmt.Errorf("the requested height (%d) is beyond the highest indexed height(%d) for registers", height, h)
 	}
+	// Once register data has been pruned, only the heights kept by the
+	// retention can still be read.
+	retention, err := reader.RegisterRetention()
+	if err != nil {
+		return fmt.Errorf("could not get register retention for Archive node: %w", err)
+	}
+	if !retention.Retains(height) {
+		return fmt.Errorf("the requested height (%d) has been pruned for registers (retained from height %d, every %d heights below)",
+			height, retention.Height, retention.Interval)
+	}
 	return nil
 }